@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type analyticsService struct {
+	caseService              CaseService
+	statusDurationRepository domain.StatusDurationRepository
+}
+
+//go:generate mockgen -source=analytics_service.go -destination=mock_application/mock_analytics_service.go -package=mock_application
+type AnalyticsService interface {
+	GetStatusDurations(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) (domain.StatusDurationReport, error)
+	GetCaseStatusDurations(ctx context.Context, caseID string) (domain.CaseStatusDurations, error)
+}
+
+func NewAnalyticsService(caseService CaseService, statusDurationRepository domain.StatusDurationRepository) AnalyticsService {
+	return &analyticsService{
+		caseService:              caseService,
+		statusDurationRepository: statusDurationRepository,
+	}
+}
+
+// GetStatusDurations summarizes how long the cases matching the filters,
+// ignoring their paging, stayed in each status and took to close, broken down
+// by contractor, queue or operator when a group is given.
+func (s *analyticsService) GetStatusDurations(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) (domain.StatusDurationReport, error) {
+	filters.PagingFilter = domain.PagingFilter{}
+
+	statuses, err := s.statusDurationRepository.SummarizeStatusDurations(ctx, filters, groupBy)
+	if err != nil {
+		return domain.StatusDurationReport{}, err
+	}
+
+	cycleTimes, err := s.statusDurationRepository.SummarizeCycleTimes(ctx, filters, groupBy)
+	if err != nil {
+		return domain.StatusDurationReport{}, err
+	}
+
+	return domain.StatusDurationReport{
+		GroupBy:    groupBy,
+		Statuses:   statuses,
+		CycleTimes: cycleTimes,
+	}, nil
+}
+
+// GetCaseStatusDurations replays the history of a case into the time it spent
+// in each status.
+func (s *analyticsService) GetCaseStatusDurations(ctx context.Context, caseID string) (domain.CaseStatusDurations, error) {
+	crmCase, err := s.caseService.GetCaseByID(ctx, caseID)
+	if err != nil {
+		return domain.CaseStatusDurations{}, err
+	}
+
+	history, err := s.caseService.GetCaseHistory(ctx, caseID)
+	if err != nil {
+		return domain.CaseStatusDurations{}, err
+	}
+
+	return domain.NewCaseStatusDurations(*crmCase, history, time.Now().UTC()), nil
+}
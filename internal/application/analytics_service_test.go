@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type analyticsServiceMocks struct {
+	caseService              *mock_application.MockCaseService
+	statusDurationRepository *mock_domain.MockStatusDurationRepository
+}
+
+func newAnalyticsServiceForTest(t *testing.T) (AnalyticsService, *analyticsServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &analyticsServiceMocks{
+		caseService:              mock_application.NewMockCaseService(ctrl),
+		statusDurationRepository: mock_domain.NewMockStatusDurationRepository(ctrl),
+	}
+
+	return NewAnalyticsService(mocks.caseService, mocks.statusDurationRepository), mocks
+}
+
+func TestAnalyticsService_GetStatusDurations(t *testing.T) {
+	service, mocks := newAnalyticsServiceForTest(t)
+
+	groupBy := domain.CASE_STATS_QUEUE
+	filters := domain.CaseFilters{ContractorID: []string{"contractor-1"}}
+	statuses := []domain.StatusDurationStats{{GroupKey: "queue-1", Status: domain.REPORT, DurationStats: domain.DurationStats{Cases: 4, P90Hours: 72}}}
+	cycleTimes := []domain.CycleTimeStats{{GroupKey: "queue-1", DurationStats: domain.DurationStats{Cases: 2, P50Hours: 120}}}
+
+	mocks.statusDurationRepository.EXPECT().SummarizeStatusDurations(gomock.Any(), filters, &groupBy).Return(statuses, nil)
+	mocks.statusDurationRepository.EXPECT().SummarizeCycleTimes(gomock.Any(), filters, &groupBy).Return(cycleTimes, nil)
+
+	report, err := service.GetStatusDurations(context.Background(), domain.CaseFilters{
+		ContractorID: []string{"contractor-1"},
+		PagingFilter: domain.PagingFilter{Limit: 10, SortBy: "created_at"},
+	}, &groupBy)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDurationReport{GroupBy: &groupBy, Statuses: statuses, CycleTimes: cycleTimes}, report)
+}
+
+func TestAnalyticsService_GetCaseStatusDurations(t *testing.T) {
+	service, mocks := newAnalyticsServiceForTest(t)
+
+	createdAt := time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	mocks.caseService.EXPECT().GetCaseByID(gomock.Any(), "case-1").Return(&domain.Case{CaseID: "case-1", Status: domain.CLOSED, CreatedAt: createdAt}, nil)
+	mocks.caseService.EXPECT().GetCaseHistory(gomock.Any(), "case-1").Return([]domain.CaseHistory{
+		{EventName: domain.CaseClosedEvent, OldValues: map[string]any{"status": "New"}, NewValues: map[string]any{"status": "Closed"}, CreatedAt: createdAt.Add(48 * time.Hour)},
+	}, nil)
+
+	durations, err := service.GetCaseStatusDurations(context.Background(), "case-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StatusDuration{{Status: domain.NEW, Hours: 48, Visits: 1, EnteredAt: createdAt}}, durations.Statuses)
+	require.NotNil(t, durations.CycleTimeHours)
+	assert.Equal(t, 48.0, *durations.CycleTimeHours)
+}
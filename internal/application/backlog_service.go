@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type backlogService struct {
+	caseRepository            domain.CaseRepository
+	backlogSnapshotRepository domain.BacklogSnapshotRepository
+	snapshotInterval          time.Duration
+	now                       func() time.Time
+}
+
+//go:generate mockgen -source=backlog_service.go -destination=mock_application/mock_backlog_service.go -package=mock_application
+type BacklogService interface {
+	GetAging(ctx context.Context, filters domain.CaseFilters, withCaseIDs bool) (domain.BacklogAging, error)
+	GetSnapshots(ctx context.Context, filters domain.BacklogSnapshotFilters) ([]domain.BacklogSnapshot, error)
+	TakeSnapshot(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+func NewBacklogService(
+	caseRepository domain.CaseRepository,
+	backlogSnapshotRepository domain.BacklogSnapshotRepository,
+	snapshotInterval time.Duration,
+) BacklogService {
+	return &backlogService{
+		caseRepository:            caseRepository,
+		backlogSnapshotRepository: backlogSnapshotRepository,
+		snapshotInterval:          snapshotInterval,
+		now:                       time.Now,
+	}
+}
+
+// GetAging buckets the open cases matching the filters, ignoring their paging,
+// by how many business days they have been open, counted by the database.
+// Status filters only narrow the backlog, cases in a final status are never
+// part of it.
+func (s *backlogService) GetAging(ctx context.Context, filters domain.CaseFilters, withCaseIDs bool) (domain.BacklogAging, error) {
+	statuses := make([]string, 0, len(domain.BacklogCaseStatuses))
+	for _, status := range domain.BacklogCaseStatuses {
+		if len(filters.Status) == 0 || slices.Contains(filters.Status, string(status)) {
+			statuses = append(statuses, string(status))
+		}
+	}
+
+	now := s.now()
+	if len(statuses) == 0 {
+		return domain.NewBacklogAging(nil, now, withCaseIDs), nil
+	}
+	filters.Status = statuses
+	filters.PagingFilter = domain.PagingFilter{}
+
+	counts, err := s.caseRepository.CountByAge(ctx, filters, domain.BacklogAgingBuckets, now, withCaseIDs)
+	if err != nil {
+		return domain.BacklogAging{}, err
+	}
+
+	return domain.NewBacklogAging(counts, now, withCaseIDs), nil
+}
+
+func (s *backlogService) GetSnapshots(ctx context.Context, filters domain.BacklogSnapshotFilters) ([]domain.BacklogSnapshot, error) {
+	return s.backlogSnapshotRepository.Search(ctx, filters)
+}
+
+// TakeSnapshot stores the aging of the whole backlog as the one of today,
+// unless today was already snapshotted.
+func (s *backlogService) TakeSnapshot(ctx context.Context) error {
+	taken, err := s.backlogSnapshotRepository.HasDay(ctx, domain.BacklogDay(s.now()))
+	if err != nil || taken {
+		return err
+	}
+
+	aging, err := s.GetAging(ctx, domain.CaseFilters{}, false)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := aging.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	return s.backlogSnapshotRepository.CreateBatch(ctx, snapshots)
+}
+
+// Run snapshots the backlog once a day, checking whether the day is due on
+// every interval until the context is canceled.
+func (s *backlogService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.TakeSnapshot(ctx); err != nil {
+			fmt.Printf("error taking backlog snapshot: %v\n", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
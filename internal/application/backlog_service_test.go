@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type backlogServiceMocks struct {
+	caseRepository            *mock_domain.MockCaseRepository
+	backlogSnapshotRepository *mock_domain.MockBacklogSnapshotRepository
+}
+
+func newBacklogServiceForTest(t *testing.T, now time.Time) (*backlogService, *backlogServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &backlogServiceMocks{
+		caseRepository:            mock_domain.NewMockCaseRepository(ctrl),
+		backlogSnapshotRepository: mock_domain.NewMockBacklogSnapshotRepository(ctrl),
+	}
+
+	service := &backlogService{
+		caseRepository:            mocks.caseRepository,
+		backlogSnapshotRepository: mocks.backlogSnapshotRepository,
+		snapshotInterval:          time.Hour,
+		now:                       func() time.Time { return now },
+	}
+
+	return service, mocks
+}
+
+func TestBacklogService_GetAging(t *testing.T) {
+	now := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("keeps only open statuses among the requested ones", func(t *testing.T) {
+		service, mocks := newBacklogServiceForTest(t, now)
+
+		mocks.caseRepository.EXPECT().CountByAge(gomock.Any(), gomock.Any(), domain.BacklogAgingBuckets, now, true).
+			DoAndReturn(func(_ context.Context, filters domain.CaseFilters, _ domain.AgeBuckets, _ time.Time, _ bool) ([]domain.CaseAgeCount, error) {
+				assert.Equal(t, []string{"New", "Report"}, filters.Status)
+				assert.Equal(t, []string{"queue-1"}, filters.QueueID)
+				assert.Zero(t, filters.Limit)
+				return []domain.CaseAgeCount{{QueueID: "queue-1", Status: domain.NEW, Bucket: 0, Cases: 1, CaseIDs: []string{"case-1"}}}, nil
+			})
+
+		aging, err := service.GetAging(context.Background(), domain.CaseFilters{
+			QueueID: []string{"queue-1"},
+			Status:  []string{"Closed", "New", "Report"},
+		}, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, aging.Total)
+		require.Len(t, aging.Groups, 1)
+		assert.Equal(t, []string{"case-1"}, aging.Groups[0].CaseIDs[domain.BACKLOG_UP_TO_2_DAYS])
+	})
+
+	t.Run("has no backlog for final statuses", func(t *testing.T) {
+		service, _ := newBacklogServiceForTest(t, now)
+
+		aging, err := service.GetAging(context.Background(), domain.CaseFilters{Status: []string{"Closed"}}, false)
+
+		require.NoError(t, err)
+		assert.Zero(t, aging.Total)
+		assert.Empty(t, aging.Groups)
+	})
+}
+
+func TestBacklogService_TakeSnapshot(t *testing.T) {
+	now := time.Date(2025, 3, 14, 23, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+	day := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	openCases := []domain.CaseAgeCount{{QueueID: "queue-1", Status: domain.ONGOING, Bucket: 3, Cases: 1}}
+
+	t.Run("stores the backlog of the day", func(t *testing.T) {
+		service, mocks := newBacklogServiceForTest(t, now)
+
+		mocks.caseRepository.EXPECT().CountByAge(gomock.Any(), gomock.Any(), domain.BacklogAgingBuckets, now, false).Return(openCases, nil)
+		mocks.backlogSnapshotRepository.EXPECT().HasDay(gomock.Any(), day).Return(false, nil)
+		mocks.backlogSnapshotRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, snapshots []domain.BacklogSnapshot) error {
+				require.Len(t, snapshots, 1)
+				assert.Equal(t, day, snapshots[0].SnapshotDate)
+				assert.Equal(t, 1, snapshots[0].Over10Days)
+				return nil
+			})
+
+		require.NoError(t, service.TakeSnapshot(context.Background()))
+	})
+
+	t.Run("stores an empty backlog as a zero row", func(t *testing.T) {
+		service, mocks := newBacklogServiceForTest(t, now)
+
+		mocks.caseRepository.EXPECT().CountByAge(gomock.Any(), gomock.Any(), domain.BacklogAgingBuckets, now, false).Return(nil, nil)
+		mocks.backlogSnapshotRepository.EXPECT().HasDay(gomock.Any(), day).Return(false, nil)
+		mocks.backlogSnapshotRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, snapshots []domain.BacklogSnapshot) error {
+				require.Len(t, snapshots, 1)
+				assert.Equal(t, day, snapshots[0].SnapshotDate)
+				assert.Zero(t, snapshots[0].Total)
+				return nil
+			})
+
+		require.NoError(t, service.TakeSnapshot(context.Background()))
+	})
+
+	t.Run("skips days already snapshotted", func(t *testing.T) {
+		service, mocks := newBacklogServiceForTest(t, now)
+
+		mocks.backlogSnapshotRepository.EXPECT().HasDay(gomock.Any(), day).Return(true, nil)
+
+		require.NoError(t, service.TakeSnapshot(context.Background()))
+	})
+}
@@ -5,169 +5,643 @@ import (
 	"fmt"
 	"io"
 	"slices"
-	"strings"
 
 	"github.com/icrxz/crm-api-core/internal/application/builder"
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
+// importProgressInterval is how many rows are built between progress updates.
+const importProgressInterval = 50
+
+// importRow keeps a spreadsheet row together with its line number so errors
+// can point operators back to it.
+type importRow struct {
+	number int
+	values []string
+}
+
 type batchCaseService struct {
-	customerService   CustomerService
-	productService    ProductService
-	contractorService ContractorService
-	caseRepository    domain.CaseRepository
+	customerService    CustomerService
+	productService     ProductService
+	contractorService  ContractorService
+	caseRepository     domain.CaseRepository
+	fraudService       FraudService
+	transactionManager domain.TransactionManager
 }
 
 //go:generate mockgen -source=batch_case_service.go -destination=mock_application/mock_batch_case_service.go -package=mock_application
 type BatchCaseService interface {
-	CreateBatch(ctx context.Context, file io.Reader, fileName, createdBy, company string) ([]string, error)
+	Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error)
+	Preview(ctx context.Context, job domain.ImportJob, file io.Reader) (domain.ImportPreview, error)
 }
 
-func NewBatchCaseService(customerService CustomerService, productService ProductService, contractorService ContractorService, caseRepository domain.CaseRepository) BatchCaseService {
+func NewBatchCaseService(customerService CustomerService, productService ProductService, contractorService ContractorService, caseRepository domain.CaseRepository, fraudService FraudService, transactionManager domain.TransactionManager) BatchCaseService {
 	return &batchCaseService{
-		customerService:   customerService,
-		productService:    productService,
-		contractorService: contractorService,
-		caseRepository:    caseRepository,
+		customerService:    customerService,
+		productService:     productService,
+		contractorService:  contractorService,
+		caseRepository:     caseRepository,
+		fraudService:       fraudService,
+		transactionManager: transactionManager,
 	}
 }
 
-func (s *batchCaseService) CreateBatch(ctx context.Context, file io.Reader, fileName, createdBy, companyName string) ([]string, error) {
-	fileNameSplit := strings.Split(fileName, ".")
-	fileExtension := fileNameSplit[len(fileNameSplit)-1]
+// Process runs a case import job, reading the company the spreadsheet belongs
+// to and how to read it from the job params.
+func (s *batchCaseService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	updateExisting := job.BoolParam(domain.ImportParamUpdateExisting)
+	return s.createBatch(ctx, file, job.FileName, spreadsheetOptionsOf(job), job.CreatedBy, job.Param(domain.ImportParamCompany), updateExisting, progress)
+}
 
-	var readFileFunc func(file io.Reader) ([][]string, error)
-	if slices.Contains([]string{"csv"}, fileExtension) {
-		readFileFunc = readCSV
-	} else if slices.Contains([]string{"xls", "xlsx"}, fileExtension) {
-		readFileFunc = readXLS
+// createBatch imports the spreadsheet. Rows matching a case already imported
+// for the contractor are refreshed when updateExisting is set and skipped
+// otherwise, so uploading the same file twice creates nothing new. Customers,
+// products and cases are written in a single transaction: rows that fail
+// validation are reported and left out, while a database error undoes the
+// whole import.
+func (s *batchCaseService) createBatch(ctx context.Context, file io.Reader, fileName string, options spreadsheetOptions, createdBy, companyName string, updateExisting bool, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	sheet, err := readSpreadsheet(fileName, file, options)
+	if err != nil {
+		fmt.Printf("error reading file: %v\n", err.Error())
+		return domain.ImportResult{}, err
 	}
 
-	if readFileFunc == nil {
-		return nil, domain.NewValidationError("file cannot be different from .csv, .xls", nil)
+	header := sheet.header()
+	caseBuilder, contractors, err := s.caseBuilderFor(ctx, companyName, getColumnHeadersIndex(header), createdBy)
+	if err != nil {
+		fmt.Printf("error getting company: %v\n", err.Error())
+		return domain.ImportResult{}, err
 	}
 
-	casesRows, err := readFileFunc(file)
+	validRows, rowErrors := validateRows(header, sheet.dataRows(), sheet.firstDataLine(), caseBuilder)
+	builtRows, buildErrors := buildRows(validRows, caseBuilder, contractors)
+	rowErrors = append(rowErrors, buildErrors...)
+
+	newRows, existingRows, repeatedRows, err := s.matchExistingCases(ctx, contractors, builtRows)
+	if err != nil {
+		fmt.Printf("error matching existing cases: %v\n", err.Error())
+		return domain.ImportResult{}, err
+	}
+
+	for _, row := range repeatedRows {
+		rowErrors = append(rowErrors, domain.ImportRowError{
+			Row:     row.number,
+			Value:   row.crmCase.ExternalReference,
+			Message: "external reference is repeated in the file",
+		})
+	}
+
+	totalRows := len(sheet.dataRows())
+	result := domain.ImportResult{
+		CreatedIDs: make([]string, 0),
+		UpdatedIDs: make([]string, 0),
+		SkippedIDs: make([]string, 0),
+	}
+
+	var cases []domain.Case
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var newErrors []domain.ImportRowError
+		var err error
+		cases, newErrors, err = s.buildCases(txCtx, header, newRows, caseBuilder, func(processedRows int) {
+			progress(processedRows, totalRows)
+		})
+		if err != nil {
+			fmt.Printf("error building cases: %v\n", err.Error())
+			return err
+		}
+		rowErrors = append(rowErrors, newErrors...)
+
+		if len(cases) > 0 {
+			result.CreatedIDs, err = s.caseRepository.CreateBatch(txCtx, cases)
+			if err != nil {
+				fmt.Printf("error creating cases: %v\n", err.Error())
+				return err
+			}
+		}
+
+		for i, row := range existingRows {
+			if i%importProgressInterval == 0 {
+				progress(len(newRows)+i, totalRows)
+			}
+
+			if !updateExisting {
+				result.SkippedIDs = append(result.SkippedIDs, row.existing.CaseID)
+				continue
+			}
+
+			importedProduct, err := caseBuilder.BuildProduct(row.values)
+			if err != nil {
+				rowErrors = append(rowErrors, domain.ImportRowError{Row: row.number, Message: err.Error()})
+				continue
+			}
+
+			updated, err := s.updateExistingCase(txCtx, row, *importedProduct)
+			if err != nil {
+				return err
+			}
+
+			if updated {
+				result.UpdatedIDs = append(result.UpdatedIDs, row.existing.CaseID)
+			} else {
+				result.SkippedIDs = append(result.SkippedIDs, row.existing.CaseID)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	s.assessCases(ctx, cases, result.CreatedIDs)
+
+	slices.SortStableFunc(rowErrors, func(a, b domain.ImportRowError) int {
+		return a.Row - b.Row
+	})
+	result.RowErrors = rowErrors
+
+	return result, nil
+}
+
+// Preview runs an import up to the point where it would write, reporting the
+// customers and cases the file would create and the rows that would be left
+// out. Nothing is persisted. The job is only read for its params, it does
+// not need to be enqueued.
+func (s *batchCaseService) Preview(ctx context.Context, job domain.ImportJob, file io.Reader) (domain.ImportPreview, error) {
+	sheet, err := readSpreadsheet(job.FileName, file, spreadsheetOptionsOf(job))
 	if err != nil {
 		fmt.Printf("error reading file: %v\n", err.Error())
-		return nil, err
+		return domain.ImportPreview{}, err
 	}
 
-	var caseBuilder domain.CaseBuilder
-	var columnsIndex map[string]int
-	var fileRows [][]string
+	header := sheet.header()
+	caseBuilder, contractors, err := s.caseBuilderFor(ctx, job.Param(domain.ImportParamCompany), getColumnHeadersIndex(header), job.CreatedBy)
+	if err != nil {
+		fmt.Printf("error getting company: %v\n", err.Error())
+		return domain.ImportPreview{}, err
+	}
+
+	validRows, rowErrors := validateRows(header, sheet.dataRows(), sheet.firstDataLine(), caseBuilder)
+	builtRows, buildErrors := buildRows(validRows, caseBuilder, contractors)
+	rowErrors = append(rowErrors, buildErrors...)
+
+	newRows, existingRows, repeatedRows, err := s.matchExistingCases(ctx, contractors, builtRows)
+	if err != nil {
+		fmt.Printf("error matching existing cases: %v\n", err.Error())
+		return domain.ImportPreview{}, err
+	}
+
+	preview := domain.ImportPreview{
+		NewCustomers:     []domain.PreviewCustomer{},
+		MatchedCustomers: []domain.PreviewCustomer{},
+		NewCases:         []domain.PreviewCase{},
+		Duplicates:       []domain.PreviewCase{},
+	}
+	for _, row := range sheet.dataRows() {
+		if len(row) > 1 {
+			preview.TotalRows++
+		}
+	}
+
+	customerDocIdx := caseBuilder.GetCostumerDocumentIdx()
+	customers := make(map[string]*domain.Customer)
+	customerErrors := make(map[string]error)
+	if customerDocIdx != -1 {
+		customers, customerErrors, err = s.previewCustomers(ctx, importRowsOf(newRows), customerDocIdx, caseBuilder.BuildCustomer, &preview)
+		if err != nil {
+			fmt.Printf("error getting customers: %v\n", err.Error())
+			return domain.ImportPreview{}, err
+		}
+	}
+
+	for _, row := range newRows {
+		customerDocument := ""
+		if customerDocIdx >= 0 {
+			customerDocument = row.values[customerDocIdx]
+			if customerErr, failed := customerErrors[customerDocument]; failed {
+				rowErrors = append(rowErrors, customerRowError(header, customerDocIdx, row.importRow, customerErr))
+				continue
+			}
+		}
+
+		if _, err := caseBuilder.BuildProduct(row.values); err != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Row: row.number, Message: err.Error()})
+			continue
+		}
+
+		previewCase := newPreviewCase(row, customerDocIdx)
+		if customer := customers[customerDocument]; customer != nil {
+			previewCase.CustomerID = customer.CustomerID
+		}
+		preview.NewCases = append(preview.NewCases, previewCase)
+	}
+
+	for _, row := range existingRows {
+		previewCase := newPreviewCase(row, customerDocIdx)
+		previewCase.ExistingCaseID = row.existing.CaseID
+		previewCase.DuplicateReason = domain.IMPORT_DUPLICATE_EXISTING
+		preview.Duplicates = append(preview.Duplicates, previewCase)
+	}
+
+	for _, row := range repeatedRows {
+		previewCase := newPreviewCase(row, customerDocIdx)
+		previewCase.DuplicateReason = domain.IMPORT_DUPLICATE_IN_FILE
+		preview.Duplicates = append(preview.Duplicates, previewCase)
+	}
+
+	slices.SortStableFunc(preview.Duplicates, func(a, b domain.PreviewCase) int {
+		return a.Row - b.Row
+	})
+	slices.SortStableFunc(rowErrors, func(a, b domain.ImportRowError) int {
+		return a.Row - b.Row
+	})
+	preview.RowErrors = rowErrors
+
+	return preview, nil
+}
+
+func newPreviewCase(row builtRow, customerDocIdx int) domain.PreviewCase {
+	previewCase := domain.PreviewCase{
+		Row:               row.number,
+		ContractorID:      row.crmCase.ContractorID,
+		ExternalReference: row.crmCase.ExternalReference,
+	}
+	if customerDocIdx >= 0 {
+		previewCase.CustomerDocument = row.values[customerDocIdx]
+	}
+
+	return previewCase
+}
+
+// previewCustomers resolves the customer of every row by document like
+// getCustomers does, but only builds the missing ones so they can be listed.
+func (s *batchCaseService) previewCustomers(ctx context.Context, rows []importRow, documentColumn int, buildCustomerFunc domain.BuildCustomerFuncType, preview *domain.ImportPreview) (map[string]*domain.Customer, map[string]error, error) {
+	customerDocuments := make([]string, 0)
+	customerRows := make(map[string][]int)
+	for _, row := range rows {
+		customerDocument := row.values[documentColumn]
+
+		if !slices.Contains(customerDocuments, customerDocument) {
+			customerDocuments = append(customerDocuments, customerDocument)
+		}
+		customerRows[customerDocument] = append(customerRows[customerDocument], row.number)
+	}
+
+	customerErrors := make(map[string]error)
+	if len(customerDocuments) == 0 {
+		return map[string]*domain.Customer{}, customerErrors, nil
+	}
+
+	customers, err := s.searchCustomerBatch(ctx, customerDocuments)
+	if err != nil {
+		fmt.Printf("error searching customers: %v\n", err.Error())
+		return nil, nil, err
+	}
+
+	for _, customerDoc := range customerDocuments {
+		if customer := customers[customerDoc]; customer != nil {
+			matchedCustomer := domain.NewPreviewCustomer(*customer)
+			matchedCustomer.Rows = customerRows[customerDoc]
+			preview.MatchedCustomers = append(preview.MatchedCustomers, matchedCustomer)
+			continue
+		}
+
+		customerIdx := slices.IndexFunc(rows, func(row importRow) bool {
+			return row.values[documentColumn] == customerDoc
+		})
+
+		newCustomer, err := buildCustomerFunc(rows[customerIdx].values)
+		if err != nil {
+			customerErrors[customerDoc] = err
+			continue
+		}
+
+		previewCustomer := domain.NewPreviewCustomer(*newCustomer)
+		previewCustomer.CustomerID = ""
+		previewCustomer.Rows = customerRows[customerDoc]
+		preview.NewCustomers = append(preview.NewCustomers, previewCustomer)
+	}
+
+	return customers, customerErrors, nil
+}
+
+// caseBuilderFor picks the spreadsheet layout of the company the file belongs
+// to: the import mapping stored for its contractor when there is one, or the
+// built-in builder otherwise. The contractors the cases may belong to are
+// returned along with it.
+func (s *batchCaseService) caseBuilderFor(ctx context.Context, companyName string, columnsIndex map[string]int, createdBy string) (domain.CaseBuilder, []domain.Contractor, error) {
+	caseBuilder := newCaseBuilder(companyName, columnsIndex, createdBy)
+
+	contractors, err := s.getCompany(ctx, caseBuilder.GetCompanyName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, contractor := range contractors {
+		if contractor.CompanyName != companyName {
+			continue
+		}
 
+		mapping, err := contractor.ImportMapping()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if mapping != nil {
+			return builder.NewMappedBuilder(*mapping, columnsIndex, createdBy, companyName), []domain.Contractor{contractor}, nil
+		}
+	}
+
+	return caseBuilder, contractors, nil
+}
+
+// newCaseBuilder picks the built-in layout of the company the file belongs
+// to. LuizaSeg and Cardif share a spreadsheet, the BASE column telling which
+// of the two contractors each row belongs to.
+func newCaseBuilder(companyName string, columnsIndex map[string]int, createdBy string) domain.CaseBuilder {
 	switch companyName {
 	case "Assurant":
-		columnsIndex = getColumnHeadersIndex(casesRows[0])
-		fileRows = casesRows[1:]
-		caseBuilder = builder.NewAssurantBuilder(columnsIndex, createdBy, companyName)
+		return builder.NewAssurantBuilder(columnsIndex, createdBy, companyName)
 	case "Cardif":
-		columnsIndex = getColumnHeadersIndex(casesRows[0])
-		fileRows = casesRows[1:]
-		caseBuilder = builder.NewLuizaSegBuilder(columnsIndex, createdBy, companyName)
+		return builder.NewLuizaSegBuilder(columnsIndex, createdBy, companyName)
 	case "Ezze Seguros":
-		columnsIndex = getColumnHeadersIndex(casesRows[0])
-		fileRows = casesRows[1:]
-		caseBuilder = builder.NewEzzeBuilder(columnsIndex, createdBy, companyName)
+		return builder.NewEzzeBuilder(columnsIndex, createdBy, companyName)
 	case "LuizaSeg":
-		columnsIndex = getColumnHeadersIndex(casesRows[0])
-		fileRows = casesRows[1:]
-		caseBuilder = builder.NewLuizaSegBuilder(columnsIndex, createdBy, companyName)
+		return builder.NewLuizaSegBuilder(columnsIndex, createdBy, companyName)
 	default:
-		columnsIndex = getColumnHeadersIndex(casesRows[0])
-		fileRows = casesRows[1:]
-		caseBuilder = builder.NewDefaultBuilder(columnsIndex, createdBy, companyName)
+		return builder.NewDefaultBuilder(columnsIndex, createdBy, companyName)
 	}
+}
 
-	cases, err := s.buildCases(ctx, fileRows, caseBuilder)
-	if err != nil {
-		fmt.Printf("error building cases: %v\n", err.Error())
-		return nil, err
+// validateRows skips blank lines and splits the remaining rows between the
+// ones ready to be built and the errors of the ones that are not. firstLine is
+// the line number of the first row in the file.
+func validateRows(header []string, fileRows [][]string, firstLine int, builder domain.CaseBuilder) ([]importRow, []domain.ImportRowError) {
+	rowErrors := make([]domain.ImportRowError, 0)
+	validRows := make([]importRow, 0, len(fileRows))
+	for i, row := range fileRows {
+		if len(row) <= 1 {
+			continue
+		}
+
+		rowNumber := firstLine + i
+		row = padRow(row, len(header)+1)
+
+		if errs := builder.ValidateRow(row); len(errs) > 0 {
+			rowErrors = append(rowErrors, withRowNumber(errs, rowNumber)...)
+			continue
+		}
+
+		validRows = append(validRows, importRow{number: rowNumber, values: row})
 	}
 
-	caseIDs, err := s.caseRepository.CreateBatch(ctx, cases)
-	if err != nil {
-		fmt.Printf("error creating cases: %v\n", err.Error())
-		return nil, err
+	return validRows, rowErrors
+}
+
+// assessCases scores the imported cases for fraud. Cases that lost an insert
+// conflict are left out. A failing assessment must not undo an import that
+// was already committed, so errors are only logged.
+func (s *batchCaseService) assessCases(ctx context.Context, cases []domain.Case, insertedIDs []string) {
+	for _, crmCase := range cases {
+		if !slices.Contains(insertedIDs, crmCase.CaseID) {
+			continue
+		}
+
+		if _, err := s.fraudService.Assess(ctx, crmCase); err != nil {
+			fmt.Printf("error assessing fraud for case %s: %v\n", crmCase.CaseID, err.Error())
+		}
+	}
+}
+
+// builtRow is a valid spreadsheet row already turned into a case. Customer and
+// product are only resolved once the row is known to create a new case.
+// existing is set when the row matches a case imported before.
+type builtRow struct {
+	importRow
+	crmCase  domain.Case
+	existing *domain.Case
+}
+
+// buildRows turns the valid rows into cases without touching customers or
+// products, so they can be matched against the cases already imported.
+func buildRows(rows []importRow, builder domain.CaseBuilder, contractors []domain.Contractor) ([]builtRow, []domain.ImportRowError) {
+	rowErrors := make([]domain.ImportRowError, 0)
+	builtRows := make([]builtRow, 0, len(rows))
+	for _, row := range rows {
+		newCrmCase, err := builder.BuildCase(row.values, contractors, "", -1)
+		if err != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Row: row.number, Message: err.Error()})
+			continue
+		}
+
+		builtRows = append(builtRows, builtRow{importRow: row, crmCase: *newCrmCase})
 	}
 
-	return caseIDs, nil
+	return builtRows, rowErrors
 }
 
-func (s *batchCaseService) buildCases(ctx context.Context, csvRows [][]string, builder domain.CaseBuilder) ([]domain.Case, error) {
-	crmCases := make([]domain.Case, 0, len(csvRows))
+func importRowsOf(rows []builtRow) []importRow {
+	importRows := make([]importRow, 0, len(rows))
+	for _, row := range rows {
+		importRows = append(importRows, row.importRow)
+	}
+
+	return importRows
+}
 
-	contractors, err := s.getCompany(ctx, builder.GetCompanyName())
+// matchExistingCases splits the rows by contractor and external reference
+// into the ones creating a case, the ones matching a case already in the
+// database and the ones repeating an earlier row of the same file. Rows
+// without an external reference cannot be matched and are always new.
+func (s *batchCaseService) matchExistingCases(ctx context.Context, contractors []domain.Contractor, rows []builtRow) ([]builtRow, []builtRow, []builtRow, error) {
+	contractorIDs := make([]string, 0, len(contractors))
+	for _, contractor := range contractors {
+		contractorIDs = append(contractorIDs, contractor.ContractorID)
+	}
+
+	externalReferences := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.crmCase.ExternalReference != "" && !slices.Contains(externalReferences, row.crmCase.ExternalReference) {
+			externalReferences = append(externalReferences, row.crmCase.ExternalReference)
+		}
+	}
+
+	existingCases, err := s.caseRepository.GetByExternalReferences(ctx, contractorIDs, externalReferences)
 	if err != nil {
-		fmt.Printf("error getting company: %v\n", err.Error())
-		return nil, err
+		return nil, nil, nil, err
+	}
+
+	existingByKey := make(map[string]domain.Case, len(existingCases))
+	for _, existingCase := range existingCases {
+		existingByKey[caseImportKey(existingCase)] = existingCase
+	}
+
+	newRows := make([]builtRow, 0, len(rows))
+	existingRows := make([]builtRow, 0)
+	repeatedRows := make([]builtRow, 0)
+	seenKeys := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		if row.crmCase.ExternalReference == "" {
+			newRows = append(newRows, row)
+			continue
+		}
+
+		key := caseImportKey(row.crmCase)
+		if _, seen := seenKeys[key]; seen {
+			repeatedRows = append(repeatedRows, row)
+			continue
+		}
+		seenKeys[key] = struct{}{}
+
+		if existingCase, found := existingByKey[key]; found {
+			row.existing = &existingCase
+			existingRows = append(existingRows, row)
+			continue
+		}
+
+		newRows = append(newRows, row)
 	}
 
+	return newRows, existingRows, repeatedRows, nil
+}
+
+func caseImportKey(crmCase domain.Case) string {
+	return crmCase.ContractorID + "|" + crmCase.ExternalReference
+}
+
+// updateExistingCase refreshes an already imported case with a re-uploaded
+// row, reporting whether the case or its product actually changed.
+func (s *batchCaseService) updateExistingCase(ctx context.Context, row builtRow, importedProduct domain.Product) (bool, error) {
+	existingCase := *row.existing
+	importedCase := row.crmCase
+	productChanged := false
+
+	var err error
+	if existingCase.ProductID == "" {
+		importedCase.ProductID, err = s.productService.CreateProduct(ctx, importedProduct)
+		if err != nil {
+			fmt.Printf("error creating product: %v\n", err.Error())
+			return false, err
+		}
+	} else {
+		product, err := s.productService.GetProductByID(ctx, existingCase.ProductID)
+		if err != nil {
+			fmt.Printf("error getting product: %v\n", err.Error())
+			return false, err
+		}
+
+		if productUpdate, changed := product.ImportUpdate(importedProduct); changed {
+			err = s.productService.UpdateProduct(ctx, existingCase.ProductID, productUpdate)
+			if err != nil {
+				fmt.Printf("error updating product: %v\n", err.Error())
+				return false, err
+			}
+			productChanged = true
+		}
+	}
+
+	if !existingCase.MergeImport(importedCase) {
+		return productChanged, nil
+	}
+
+	if err := s.caseRepository.Update(ctx, existingCase); err != nil {
+		fmt.Printf("error updating case: %v\n", err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+// buildCases resolves the customer and product of every new row, inserting
+// the new customers and products in bulk. Rows whose customer or product
+// cannot be built are reported back instead of aborting the import; failing
+// to save them is fatal.
+func (s *batchCaseService) buildCases(ctx context.Context, header []string, rows []builtRow, builder domain.CaseBuilder, progress func(processedRows int)) ([]domain.Case, []domain.ImportRowError, error) {
+	customerDocIdx := builder.GetCostumerDocumentIdx()
 	customers := make(map[string]*domain.Customer)
-	if builder.GetCostumerDocumentIdx() != -1 {
-		customers, err = s.getCustomers(ctx, csvRows, builder.GetCostumerDocumentIdx(), builder.BuildCustomer)
+	customerErrors := make(map[string]error)
+	if customerDocIdx != -1 {
+		var err error
+		customers, customerErrors, err = s.getCustomers(ctx, importRowsOf(rows), customerDocIdx, builder.BuildCustomer)
 		if err != nil {
 			fmt.Printf("error getting customers: %v\n", err.Error())
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	for _, row := range csvRows {
-		if len(row) <= 1 {
-			continue
+	rowErrors := make([]domain.ImportRowError, 0)
+	crmCases := make([]domain.Case, 0, len(rows))
+	products := make([]domain.Product, 0, len(rows))
+	for i, row := range rows {
+		if i%importProgressInterval == 0 {
+			progress(i)
 		}
 
-		customerID := ""
-		customerRegion := -1
-		customerDocIdx := builder.GetCostumerDocumentIdx()
+		newCrmCase := row.crmCase
 		if customerDocIdx >= 0 {
-			if len(row) > customerDocIdx {
-				if customer, hasCustomer := customers[row[customerDocIdx]]; hasCustomer {
-					customerID = customer.CustomerID
-					customerRegion = customer.GetRegion()
-				}
+			document := row.values[customerDocIdx]
+			if customerErr, failed := customerErrors[document]; failed {
+				rowErrors = append(rowErrors, customerRowError(header, customerDocIdx, row.importRow, customerErr))
+				continue
 			}
-		}
 
-		newCrmCase, err := builder.BuildCase(row, contractors, customerID, customerRegion)
-		if err != nil {
-			fmt.Printf("error building case: %v\n", err.Error())
-			return nil, err
+			if customer := customers[document]; customer != nil {
+				newCrmCase.CustomerID = customer.CustomerID
+				newCrmCase.Region = customer.GetRegion()
+			}
 		}
 
-		productID, err := s.createProduct(ctx, row, builder.BuildProduct)
+		newProduct, err := builder.BuildProduct(row.values)
 		if err != nil {
-			fmt.Printf("error creating product: %v\n", err.Error())
-			return nil, err
+			fmt.Printf("error building product: %v\n", err.Error())
+			rowErrors = append(rowErrors, domain.ImportRowError{Row: row.number, Message: err.Error()})
+			continue
 		}
-		newCrmCase.ProductID = productID
+		newCrmCase.ProductID = newProduct.ProductID
 
-		crmCases = append(crmCases, *newCrmCase)
+		products = append(products, *newProduct)
+		crmCases = append(crmCases, newCrmCase)
 	}
 
-	return crmCases, nil
+	if len(products) > 0 {
+		if _, err := s.productService.CreateBatch(ctx, products); err != nil {
+			fmt.Printf("error creating products: %v\n", err.Error())
+			return nil, nil, err
+		}
+	}
+
+	return crmCases, rowErrors, nil
 }
 
-func (s *batchCaseService) searchCustomerBatch(ctx context.Context, customerDocument []string) (map[string]*domain.Customer, error) {
-	filters := domain.CustomerFilters{Document: customerDocument, PagingFilter: domain.PagingFilter{Limit: 1000, Offset: 0}}
+func customerRowError(header []string, customerDocIdx int, row importRow, err error) domain.ImportRowError {
+	return domain.ImportRowError{
+		Row:     row.number,
+		Column:  columnName(header, customerDocIdx),
+		Value:   row.values[customerDocIdx],
+		Message: fmt.Sprintf("customer could not be created: %s", err.Error()),
+	}
+}
 
-	customers, err := s.customerService.Search(ctx, filters)
+// searchCustomerBatch maps every document of the file to its existing
+// customer, nil when there is none. Documents are matched by their digits, as
+// the spreadsheet and the stored customer may format them differently.
+func (s *batchCaseService) searchCustomerBatch(ctx context.Context, customerDocument []string) (map[string]*domain.Customer, error) {
+	customers, err := s.customerService.GetByDocuments(ctx, customerDocument)
 	if err != nil {
 		fmt.Printf("error searching customers: %v\n", err.Error())
 		return nil, err
 	}
 
-	customersMap := make(map[string]*domain.Customer)
-	for _, doc := range customerDocument {
-		customerIdx := slices.IndexFunc(customers.Result, func(c domain.Customer) bool {
-			return c.Document == doc
-		})
+	customersByDigits := make(map[string]*domain.Customer, len(customers))
+	for i := range customers {
+		customersByDigits[domain.DocumentDigits(customers[i].Document)] = &customers[i]
+	}
 
-		if customerIdx == -1 {
-			customersMap[doc] = nil
-		} else {
-			customersMap[doc] = &customers.Result[customerIdx]
+	customersMap := make(map[string]*domain.Customer, len(customerDocument))
+	for _, doc := range customerDocument {
+		customersMap[doc] = nil
+		if digits := domain.DocumentDigits(doc); digits != "" {
+			customersMap[doc] = customersByDigits[digits]
 		}
 	}
 
@@ -196,63 +670,59 @@ func (s *batchCaseService) getCompany(ctx context.Context, companyName []string)
 	return contractorsResult.Result, nil
 }
 
-func (s *batchCaseService) getCustomers(ctx context.Context, rows [][]string, documentColumn int, buildCustomerFunc domain.BuildCustomerFuncType) (map[string]*domain.Customer, error) {
+// getCustomers finds or creates the customer of every row, inserting the new
+// ones in bulk. Customers that cannot be built are returned by document so
+// their rows can be reported individually.
+func (s *batchCaseService) getCustomers(ctx context.Context, rows []importRow, documentColumn int, buildCustomerFunc domain.BuildCustomerFuncType) (map[string]*domain.Customer, map[string]error, error) {
 	customerDocuments := make([]string, 0)
 	for _, row := range rows {
-		customerDocument := row[documentColumn]
+		customerDocument := row.values[documentColumn]
 
 		if !slices.Contains(customerDocuments, customerDocument) {
 			customerDocuments = append(customerDocuments, customerDocument)
 		}
 	}
 
+	customerErrors := make(map[string]error)
+	if len(customerDocuments) == 0 {
+		return map[string]*domain.Customer{}, customerErrors, nil
+	}
+
 	customers, err := s.searchCustomerBatch(ctx, customerDocuments)
 	if err != nil {
 		fmt.Printf("error searching customers: %v\n", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	for customerDoc, customerVal := range customers {
-		if customerVal != nil {
+	newCustomers := make([]domain.Customer, 0)
+	for _, customerDoc := range customerDocuments {
+		if customers[customerDoc] != nil {
 			continue
 		}
 
-		customerIdx := slices.IndexFunc(rows, func(row []string) bool {
-			return row[documentColumn] == customerDoc
+		customerIdx := slices.IndexFunc(rows, func(row importRow) bool {
+			return row.values[documentColumn] == customerDoc
 		})
 
 		customerRow := rows[customerIdx]
 
-		newCustomer, err := buildCustomerFunc(customerRow)
+		newCustomer, err := buildCustomerFunc(customerRow.values)
 		if err != nil {
 			fmt.Printf("error building customer: %v\n", err.Error())
-			return nil, err
-		}
-
-		_, err = s.customerService.Create(ctx, *newCustomer)
-		if err != nil {
-			fmt.Printf("error creating customer: %v\n", err.Error())
-			return nil, err
+			customerErrors[customerDoc] = err
+			continue
 		}
 
-		customers[newCustomer.Document] = newCustomer
+		customers[customerDoc] = newCustomer
+		newCustomers = append(newCustomers, *newCustomer)
 	}
 
-	return customers, nil
-}
-
-func (s *batchCaseService) createProduct(ctx context.Context, row []string, buildProductFunc domain.BuildProductFuncType) (string, error) {
-	newProduct, err := buildProductFunc(row)
-	if err != nil {
-		fmt.Printf("error building product: %v\n", err.Error())
-		return "", err
-	}
-
-	productID, err := s.productService.CreateProduct(ctx, *newProduct)
-	if err != nil {
-		fmt.Printf("error creating product: %v\n", err.Error())
-		return "", err
+	if len(newCustomers) > 0 {
+		if _, err := s.customerService.CreateBatch(ctx, newCustomers); err != nil {
+			fmt.Printf("error creating customers: %v\n", err.Error())
+			return nil, nil, err
+		}
 	}
 
-	return productID, nil
+	return customers, customerErrors, nil
 }
@@ -0,0 +1,348 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type batchCaseServiceMocks struct {
+	customerService    *mock_application.MockCustomerService
+	productService     *mock_application.MockProductService
+	contractorService  *mock_application.MockContractorService
+	caseRepository     *mock_domain.MockCaseRepository
+	fraudService       *mock_application.MockFraudService
+	transactionManager *mock_domain.MockTransactionManager
+}
+
+func newBatchCaseServiceForTest(t *testing.T) (BatchCaseService, *batchCaseServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &batchCaseServiceMocks{
+		customerService:    mock_application.NewMockCustomerService(ctrl),
+		productService:     mock_application.NewMockProductService(ctrl),
+		contractorService:  mock_application.NewMockContractorService(ctrl),
+		caseRepository:     mock_domain.NewMockCaseRepository(ctrl),
+		fraudService:       mock_application.NewMockFraudService(ctrl),
+		transactionManager: mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewBatchCaseService(
+		mocks.customerService,
+		mocks.productService,
+		mocks.contractorService,
+		mocks.caseRepository,
+		mocks.fraudService,
+		mocks.transactionManager,
+	)
+
+	return service, mocks
+}
+
+func newCaseImportJobForTest(t *testing.T, companyName string, params map[string]string) domain.ImportJob {
+	t.Helper()
+
+	jobParams := map[string]string{domain.ImportParamCompany: companyName}
+	for param, value := range params {
+		jobParams[param] = value
+	}
+
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", jobParams, "operator-1")
+	require.NoError(t, err)
+
+	return job
+}
+
+const assurantHeader = "Número Sinistro;Defeito Reclamado;Valor Produto;Marca;Produto;Número de Série;Nome Cliente;CPF Cliente;Telefone Celular;E-mail;Endereço;Bairro;Cidade;Estado;CEP"
+
+func TestBatchCaseService_Process(t *testing.T) {
+	t.Run("imports the valid rows and reports the invalid ones", func(t *testing.T) {
+		service, mocks := newBatchCaseServiceForTest(t)
+
+		file := strings.Join([]string{
+			assurantHeader,
+			"S-1;Tela quebrada;1,299.90;Samsung;Galaxy;SN-1;Maria Silva;529.982.247-25;;;Rua A;Centro;Campinas;SP;13000-000",
+			"S-2;Não liga;800;LG;TV;SN-2;João Souza;529.982.247-26;;;Rua B;Centro;Recife;PE;50000-000",
+			";Sem som;300;Sony;Radio;SN-3;Ana Lima;529.982.247-25;;;Rua A;Centro;Campinas;ZZ;13000-000",
+		}, "\n")
+
+		mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{
+			Result: []domain.Contractor{{ContractorID: "contractor-1", CompanyName: "Assurant"}},
+			Paging: domain.Paging{Total: 1},
+		}, nil)
+		mocks.caseRepository.EXPECT().GetByExternalReferences(gomock.Any(), []string{"contractor-1"}, []string{"S-1"}).Return([]domain.Case{}, nil)
+		mocks.customerService.EXPECT().GetByDocuments(gomock.Any(), []string{"529.982.247-25"}).Return([]domain.Customer{}, nil)
+		var customers []domain.Customer
+		var products []domain.Product
+		mocks.customerService.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).DoAndReturn(
+			func(_ context.Context, newCustomers []domain.Customer) ([]string, error) {
+				customers = newCustomers
+				return []string{newCustomers[0].CustomerID}, nil
+			},
+		)
+		mocks.productService.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).DoAndReturn(
+			func(_ context.Context, newProducts []domain.Product) ([]string, error) {
+				products = newProducts
+				return []string{newProducts[0].ProductID}, nil
+			},
+		)
+		mocks.caseRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).DoAndReturn(
+			func(_ context.Context, cases []domain.Case) ([]string, error) {
+				assert.Equal(t, customers[0].CustomerID, cases[0].CustomerID)
+				assert.Equal(t, products[0].ProductID, cases[0].ProductID)
+				return []string{cases[0].CaseID}, nil
+			},
+		)
+		mocks.fraudService.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(&domain.FraudAssessment{}, nil)
+
+		job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", map[string]string{domain.ImportParamCompany: "Assurant"}, "operator-1")
+		require.NoError(t, err)
+
+		result, err := service.Process(context.Background(), job, strings.NewReader(file), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Len(t, result.CreatedIDs, 1)
+		require.Len(t, result.RowErrors, 3)
+		assert.Equal(t, domain.ImportRowError{Row: 3, Column: "CPF Cliente", Value: "529.982.247-26", Message: "invalid CPF/CNPJ"}, result.RowErrors[0])
+		assert.Equal(t, 4, result.RowErrors[1].Row)
+		assert.Equal(t, "Número Sinistro", result.RowErrors[1].Column)
+		assert.Equal(t, "Estado", result.RowErrors[2].Column)
+	})
+
+	t.Run("writes nothing when saving the rows fails", func(t *testing.T) {
+		service, mocks := newBatchCaseServiceForTest(t)
+
+		file := strings.Join([]string{
+			assurantHeader,
+			"S-1;Tela quebrada;1,299.90;Samsung;Galaxy;SN-1;Maria Silva;529.982.247-25;;;Rua A;Centro;Campinas;SP;13000-000",
+		}, "\n")
+
+		mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{
+			Result: []domain.Contractor{{ContractorID: "contractor-1", CompanyName: "Assurant"}},
+			Paging: domain.Paging{Total: 1},
+		}, nil)
+		mocks.caseRepository.EXPECT().GetByExternalReferences(gomock.Any(), []string{"contractor-1"}, []string{"S-1"}).Return([]domain.Case{}, nil)
+		mocks.customerService.EXPECT().GetByDocuments(gomock.Any(), gomock.Any()).Return([]domain.Customer{}, nil)
+		mocks.customerService.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).Return([]string{"customer-1"}, nil)
+		mocks.productService.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).Return(nil, assert.AnError)
+
+		job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", map[string]string{domain.ImportParamCompany: "Assurant"}, "operator-1")
+		require.NoError(t, err)
+
+		_, err = service.Process(context.Background(), job, strings.NewReader(file), func(int, int) {})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("fails the whole import when the company is unknown", func(t *testing.T) {
+		service, mocks := newBatchCaseServiceForTest(t)
+
+		mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{}, nil)
+
+		job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", map[string]string{domain.ImportParamCompany: "Assurant"}, "operator-1")
+		require.NoError(t, err)
+
+		_, err = service.Process(context.Background(), job, strings.NewReader(assurantHeader+"\nS-1"), func(int, int) {})
+
+		require.Error(t, err)
+	})
+}
+
+func TestBatchCaseService_Process_ExistingCases(t *testing.T) {
+	file := strings.Join([]string{
+		assurantHeader,
+		"S-1;Tela quebrada;1,299.90;Samsung;Galaxy;SN-1;Maria Silva;529.982.247-25;;;Rua A;Centro;Campinas;SP;13000-000",
+		"S-2;Não liga;800;LG;TV;SN-2;João Souza;111.444.777-35;;;Rua B;Centro;Recife;PE;50000-000",
+	}, "\n")
+
+	existingCases := []domain.Case{
+		{CaseID: "case-1", ContractorID: "contractor-1", ExternalReference: "S-1", Subject: "Tela quebrada", ProductID: "product-1"},
+		{CaseID: "case-2", ContractorID: "contractor-1", ExternalReference: "S-2", Subject: "Não carrega", ProductID: "product-2"},
+	}
+
+	expectCompany := func(mocks *batchCaseServiceMocks) {
+		mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{
+			Result: []domain.Contractor{{ContractorID: "contractor-1", CompanyName: "Assurant"}},
+			Paging: domain.Paging{Total: 1},
+		}, nil)
+		mocks.caseRepository.EXPECT().GetByExternalReferences(gomock.Any(), []string{"contractor-1"}, []string{"S-1", "S-2"}).Return(existingCases, nil)
+	}
+
+	t.Run("skips cases already imported without creating customers or products", func(t *testing.T) {
+		service, mocks := newBatchCaseServiceForTest(t)
+		expectCompany(mocks)
+
+		job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", map[string]string{domain.ImportParamCompany: "Assurant"}, "operator-1")
+		require.NoError(t, err)
+
+		result, err := service.Process(context.Background(), job, strings.NewReader(file), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.CreatedIDs)
+		assert.Empty(t, result.UpdatedIDs)
+		assert.Equal(t, []string{"case-1", "case-2"}, result.SkippedIDs)
+		assert.Empty(t, result.RowErrors)
+	})
+
+	t.Run("updates the cases whose row changed when asked to", func(t *testing.T) {
+		service, mocks := newBatchCaseServiceForTest(t)
+		expectCompany(mocks)
+
+		mocks.productService.EXPECT().GetProductByID(gomock.Any(), "product-1").Return(&domain.Product{
+			ProductID: "product-1", Value: 1299.90, Brand: "Samsung", Model: "Galaxy", SerialNumber: "SN-1",
+		}, nil)
+		mocks.productService.EXPECT().GetProductByID(gomock.Any(), "product-2").Return(&domain.Product{
+			ProductID: "product-2", Value: 800, Brand: "LG", Model: "TV", SerialNumber: "SN-2",
+		}, nil)
+		mocks.caseRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, crmCase domain.Case) error {
+				assert.Equal(t, "case-2", crmCase.CaseID)
+				assert.Equal(t, "Não liga", crmCase.Subject)
+				return nil
+			},
+		)
+
+		job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", map[string]string{
+			domain.ImportParamCompany:        "Assurant",
+			domain.ImportParamUpdateExisting: "true",
+		}, "operator-1")
+		require.NoError(t, err)
+
+		result, err := service.Process(context.Background(), job, strings.NewReader(file), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.CreatedIDs)
+		assert.Equal(t, []string{"case-2"}, result.UpdatedIDs)
+		assert.Equal(t, []string{"case-1"}, result.SkippedIDs)
+	})
+}
+
+func TestBatchCaseService_Preview(t *testing.T) {
+	t.Run("reports what would be created without writing", func(t *testing.T) {
+		service, mocks := newBatchCaseServiceForTest(t)
+
+		file := strings.Join([]string{
+			assurantHeader,
+			"S-1;Tela quebrada;1,299.90;Samsung;Galaxy;SN-1;Maria Silva;529.982.247-25;;;Rua A;Centro;Campinas;SP;13000-000",
+			"S-2;Não liga;800;LG;TV;SN-2;João Souza;111.444.777-35;;;Rua B;Centro;Recife;PE;50000-000",
+			"S-2;Não liga;800;LG;TV;SN-2;João Souza;111.444.777-35;;;Rua B;Centro;Recife;PE;50000-000",
+			"S-3;Sem som;300;Sony;Radio;SN-3;Maria Silva;529.982.247-25;;;Rua A;Centro;Campinas;SP;13000-000",
+			"S-4;Sem som;300;Sony;Radio;SN-4;Maria Silva;529.982.247-25;;;Rua A;Centro;Campinas;ZZ;13000-000",
+		}, "\n")
+
+		mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{
+			Result: []domain.Contractor{{ContractorID: "contractor-1", CompanyName: "Assurant"}},
+			Paging: domain.Paging{Total: 1},
+		}, nil)
+		mocks.caseRepository.EXPECT().GetByExternalReferences(gomock.Any(), []string{"contractor-1"}, []string{"S-1", "S-2", "S-3"}).Return([]domain.Case{
+			{CaseID: "case-1", ContractorID: "contractor-1", ExternalReference: "S-1"},
+		}, nil)
+		mocks.customerService.EXPECT().GetByDocuments(gomock.Any(), gomock.Any()).Return([]domain.Customer{
+			{CustomerID: "customer-1", FirstName: "Maria", LastName: "Silva", Document: "52998224725"},
+		}, nil)
+
+		preview, err := service.Preview(context.Background(), newCaseImportJobForTest(t, "Assurant", nil), strings.NewReader(file))
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, preview.TotalRows)
+		require.Len(t, preview.MatchedCustomers, 1)
+		assert.Equal(t, "customer-1", preview.MatchedCustomers[0].CustomerID)
+		assert.Equal(t, []int{5}, preview.MatchedCustomers[0].Rows)
+		require.Len(t, preview.NewCustomers, 1)
+		assert.Equal(t, "111.444.777-35", preview.NewCustomers[0].Document)
+		assert.Empty(t, preview.NewCustomers[0].CustomerID)
+		assert.Equal(t, []int{3}, preview.NewCustomers[0].Rows)
+		require.Len(t, preview.NewCases, 2)
+		assert.Equal(t, 3, preview.NewCases[0].Row)
+		assert.Equal(t, "customer-1", preview.NewCases[1].CustomerID)
+		require.Len(t, preview.Duplicates, 2)
+		assert.Equal(t, domain.IMPORT_DUPLICATE_EXISTING, preview.Duplicates[0].DuplicateReason)
+		assert.Equal(t, "case-1", preview.Duplicates[0].ExistingCaseID)
+		assert.Equal(t, domain.IMPORT_DUPLICATE_IN_FILE, preview.Duplicates[1].DuplicateReason)
+		assert.Equal(t, 4, preview.Duplicates[1].Row)
+		require.Len(t, preview.RowErrors, 1)
+		assert.Equal(t, 6, preview.RowErrors[0].Row)
+	})
+}
+
+func TestBatchCaseService_Preview_ImportMapping(t *testing.T) {
+	service, mocks := newBatchCaseServiceForTest(t)
+
+	mapping, err := domain.NewImportMapping([]domain.ImportFieldMapping{
+		{Field: domain.IMPORT_FIELD_EXTERNAL_REFERENCE, Column: "Protocolo", Required: true},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_DOCUMENT, Column: "CPF", Transform: domain.IMPORT_TRANSFORM_DOCUMENT, Required: true},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_NAME, Column: "Segurado"},
+	})
+	require.NoError(t, err)
+
+	contractor := domain.Contractor{ContractorID: "contractor-1", CompanyName: "Nova Seguros"}
+	contractor.SetImportMapping(&mapping, "operator-1")
+
+	mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{
+		Result: []domain.Contractor{contractor},
+		Paging: domain.Paging{Total: 1},
+	}, nil)
+	mocks.caseRepository.EXPECT().GetByExternalReferences(gomock.Any(), []string{"contractor-1"}, []string{"P-1"}).Return([]domain.Case{}, nil)
+	mocks.customerService.EXPECT().GetByDocuments(gomock.Any(), gomock.Any()).Return([]domain.Customer{}, nil)
+
+	file := "Protocolo;Segurado;CPF\nP-1;Maria Silva;529.982.247-25\n;João Souza;111.444.777-35"
+
+	preview, err := service.Preview(context.Background(), newCaseImportJobForTest(t, "Nova Seguros", nil), strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, preview.NewCases, 1)
+	assert.Equal(t, "P-1", preview.NewCases[0].ExternalReference)
+	require.Len(t, preview.NewCustomers, 1)
+	assert.Equal(t, "Maria Silva", preview.NewCustomers[0].Name)
+	require.Len(t, preview.RowErrors, 1)
+	assert.Equal(t, domain.ImportRowError{Row: 3, Column: "Protocolo", Message: "required value is empty"}, preview.RowErrors[0])
+}
+
+func TestBatchCaseService_Preview_TitleRows(t *testing.T) {
+	service, mocks := newBatchCaseServiceForTest(t)
+
+	mapping, err := domain.NewImportMapping([]domain.ImportFieldMapping{
+		{Field: domain.IMPORT_FIELD_EXTERNAL_REFERENCE, Column: "Protocolo", Required: true},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_DOCUMENT, Column: "CPF", Transform: domain.IMPORT_TRANSFORM_DOCUMENT, Required: true},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_NAME, Column: "Segurado"},
+	})
+	require.NoError(t, err)
+
+	contractor := domain.Contractor{ContractorID: "contractor-1", CompanyName: "Nova Seguros"}
+	contractor.SetImportMapping(&mapping, "operator-1")
+
+	mocks.contractorService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(domain.PagingResult[domain.Contractor]{
+		Result: []domain.Contractor{contractor},
+		Paging: domain.Paging{Total: 1},
+	}, nil)
+	mocks.caseRepository.EXPECT().GetByExternalReferences(gomock.Any(), []string{"contractor-1"}, []string{"P-1"}).Return([]domain.Case{}, nil)
+	mocks.customerService.EXPECT().GetByDocuments(gomock.Any(), gomock.Any()).Return([]domain.Customer{}, nil)
+
+	// a Windows-1252 export with a title row and commas as separator
+	file := "Relat\xf3rio de sinistros\n\nProtocolo,Segurado,CPF\nP-1,Jo\xe3o Souza,529.982.247-25\n,Ana Lima,111.444.777-35\n"
+
+	preview, err := service.Preview(context.Background(), newCaseImportJobForTest(t, "Nova Seguros", nil), strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, preview.TotalRows)
+	require.Len(t, preview.NewCases, 1)
+	assert.Equal(t, 4, preview.NewCases[0].Row)
+	require.Len(t, preview.NewCustomers, 1)
+	assert.Equal(t, "João Souza", preview.NewCustomers[0].Name)
+	require.Len(t, preview.RowErrors, 1)
+	assert.Equal(t, 5, preview.RowErrors[0].Row)
+}
@@ -0,0 +1,451 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/ptr"
+)
+
+// customerColumn is a field of the customer spreadsheet layout.
+type customerColumn string
+
+const (
+	customerColumnID              customerColumn = "customer_id"
+	customerColumnFirstName       customerColumn = "first_name"
+	customerColumnLastName        customerColumn = "last_name"
+	customerColumnCompanyName     customerColumn = "company_name"
+	customerColumnLegalName       customerColumn = "legal_name"
+	customerColumnDocument        customerColumn = "document"
+	customerColumnDocumentType    customerColumn = "document_type"
+	customerColumnType            customerColumn = "customer_type"
+	customerColumnShippingAddress customerColumn = "shipping_address"
+	customerColumnShippingCity    customerColumn = "shipping_city"
+	customerColumnShippingState   customerColumn = "shipping_state"
+	customerColumnShippingZipCode customerColumn = "shipping_zip_code"
+	customerColumnShippingCountry customerColumn = "shipping_country"
+	customerColumnBillingAddress  customerColumn = "billing_address"
+	customerColumnBillingCity     customerColumn = "billing_city"
+	customerColumnBillingState    customerColumn = "billing_state"
+	customerColumnBillingZipCode  customerColumn = "billing_zip_code"
+	customerColumnBillingCountry  customerColumn = "billing_country"
+	customerColumnPersonalPhone   customerColumn = "personal_phone"
+	customerColumnPersonalEmail   customerColumn = "personal_email"
+	customerColumnBusinessPhone   customerColumn = "business_phone"
+	customerColumnBusinessEmail   customerColumn = "business_email"
+	customerColumnActive          customerColumn = "active"
+	customerColumnCreatedAt       customerColumn = "created_at"
+	customerColumnUpdatedAt       customerColumn = "updated_at"
+)
+
+// customerLayout is the column order and header of customer exports.
+var customerLayout = []struct {
+	column customerColumn
+	header string
+}{
+	{customerColumnID, "ID"},
+	{customerColumnFirstName, "Nome"},
+	{customerColumnLastName, "Sobrenome"},
+	{customerColumnCompanyName, "Nome fantasia"},
+	{customerColumnLegalName, "Razão social"},
+	{customerColumnDocument, "Documento"},
+	{customerColumnDocumentType, "Tipo de documento"},
+	{customerColumnType, "Tipo"},
+	{customerColumnShippingAddress, "Endereço de entrega"},
+	{customerColumnShippingCity, "Cidade de entrega"},
+	{customerColumnShippingState, "Estado de entrega"},
+	{customerColumnShippingZipCode, "CEP de entrega"},
+	{customerColumnShippingCountry, "País de entrega"},
+	{customerColumnBillingAddress, "Endereço de cobrança"},
+	{customerColumnBillingCity, "Cidade de cobrança"},
+	{customerColumnBillingState, "Estado de cobrança"},
+	{customerColumnBillingZipCode, "CEP de cobrança"},
+	{customerColumnBillingCountry, "País de cobrança"},
+	{customerColumnPersonalPhone, "Telefone pessoal"},
+	{customerColumnPersonalEmail, "E-mail pessoal"},
+	{customerColumnBusinessPhone, "Telefone comercial"},
+	{customerColumnBusinessEmail, "E-mail comercial"},
+	{customerColumnActive, "Ativo"},
+	{customerColumnCreatedAt, "Criado em"},
+	{customerColumnUpdatedAt, "Atualizado em"},
+}
+
+// customerColumnAliases lists the header names each imported column is known
+// by, as compared by findImportColumns. The export headers come first, so an
+// export can be edited and uploaded back as is.
+var customerColumnAliases = map[customerColumn][]string{
+	customerColumnFirstName:       {"nome", "primeiro nome", "first_name"},
+	customerColumnLastName:        {"sobrenome", "last_name"},
+	customerColumnCompanyName:     {"nome fantasia", "empresa", "company_name"},
+	customerColumnLegalName:       {"razao social", "legal_name"},
+	customerColumnDocument:        {"documento", "cpf/cnpj", "cpf", "cnpj", "document"},
+	customerColumnShippingAddress: {"endereco de entrega", "endereco", "shipping_address"},
+	customerColumnShippingCity:    {"cidade de entrega", "cidade", "shipping_city"},
+	customerColumnShippingState:   {"estado de entrega", "estado", "uf", "shipping_state"},
+	customerColumnShippingZipCode: {"cep de entrega", "cep", "shipping_zip_code"},
+	customerColumnShippingCountry: {"pais de entrega", "pais", "shipping_country"},
+	customerColumnBillingAddress:  {"endereco de cobranca", "billing_address"},
+	customerColumnBillingCity:     {"cidade de cobranca", "billing_city"},
+	customerColumnBillingState:    {"estado de cobranca", "billing_state"},
+	customerColumnBillingZipCode:  {"cep de cobranca", "billing_zip_code"},
+	customerColumnBillingCountry:  {"pais de cobranca", "billing_country"},
+	customerColumnPersonalPhone:   {"telefone pessoal", "telefone", "celular", "personal_phone"},
+	customerColumnPersonalEmail:   {"e-mail pessoal", "email", "e-mail", "personal_email"},
+	customerColumnBusinessPhone:   {"telefone comercial", "business_phone"},
+	customerColumnBusinessEmail:   {"e-mail comercial", "business_email"},
+}
+
+// customerRow keeps a spreadsheet row together with the customer it becomes,
+// already merged with the stored one when the document is known.
+type customerRow struct {
+	importRow
+	customer domain.Customer
+}
+
+type batchCustomerService struct {
+	customerRepository domain.CustomerRepository
+	transactionManager domain.TransactionManager
+}
+
+//go:generate mockgen -source=batch_customer_service.go -destination=mock_application/mock_batch_customer_service.go -package=mock_application
+type BatchCustomerService interface {
+	Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error)
+	Export(ctx context.Context, filters domain.CustomerFilters, format domain.ExportFormat, w io.Writer) error
+}
+
+func NewBatchCustomerService(customerRepository domain.CustomerRepository, transactionManager domain.TransactionManager) BatchCustomerService {
+	return &batchCustomerService{
+		customerRepository: customerRepository,
+		transactionManager: transactionManager,
+	}
+}
+
+// Process runs a customer import job. Customers are matched by document:
+// known ones are updated with the values filled in the file and the others
+// created, all in a single transaction. Rows that fail validation are
+// reported and left out.
+func (s *batchCustomerService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	sheet, err := readSpreadsheet(job.FileName, file, spreadsheetOptionsOf(job))
+	if err != nil {
+		fmt.Printf("error reading file: %v\n", err.Error())
+		return domain.ImportResult{}, err
+	}
+
+	columns, err := findImportColumns(sheet.header(), customerColumnAliases, []customerColumn{customerColumnDocument})
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	rowErrors := make([]domain.ImportRowError, 0)
+	builtRows := make([]customerRow, 0, len(sheet.dataRows()))
+	documentRows := make(map[string]int)
+	totalRows := 0
+	for i, values := range sheet.dataRows() {
+		if len(values) <= 1 {
+			continue
+		}
+		totalRows++
+
+		row := importRow{number: sheet.firstDataLine() + i, values: values}
+		customer, errs := buildImportedCustomer(row, columns, job.CreatedBy)
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		if firstRow, repeated := documentRows[customer.Document]; repeated {
+			rowErrors = append(rowErrors, domain.ImportRowError{
+				Row:     row.number,
+				Column:  columns.name(customerColumnDocument),
+				Value:   customer.Document,
+				Message: fmt.Sprintf("document is repeated in the file, first seen on row %d", firstRow),
+			})
+			continue
+		}
+		documentRows[customer.Document] = row.number
+
+		builtRows = append(builtRows, customerRow{importRow: row, customer: customer})
+	}
+
+	existingCustomers, err := s.customerRepository.GetByDocuments(ctx, slices.Sorted(maps.Keys(documentRows)))
+	if err != nil {
+		fmt.Printf("error searching customers: %v\n", err.Error())
+		return domain.ImportResult{}, err
+	}
+
+	customersByDocument := make(map[string]domain.Customer, len(existingCustomers))
+	for _, customer := range existingCustomers {
+		customersByDocument[domain.DocumentDigits(customer.Document)] = customer
+	}
+
+	newCustomers := make([]domain.Customer, 0, len(builtRows))
+	existingRows := make([]customerRow, 0)
+	for _, row := range builtRows {
+		existing, found := customersByDocument[row.customer.Document]
+		if !found {
+			newCustomers = append(newCustomers, row.customer)
+			continue
+		}
+
+		existing.MergeUpdate(importedCustomerUpdate(row, columns, job.CreatedBy))
+		row.customer = existing
+		existingRows = append(existingRows, row)
+	}
+
+	result := domain.ImportResult{
+		CreatedIDs: make([]string, 0),
+		UpdatedIDs: make([]string, 0),
+		SkippedIDs: make([]string, 0),
+	}
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if len(newCustomers) > 0 {
+			createdIDs, err := s.customerRepository.CreateBatch(txCtx, newCustomers)
+			if err != nil {
+				fmt.Printf("error creating customers: %v\n", err.Error())
+				return err
+			}
+			result.CreatedIDs = createdIDs
+		}
+
+		for i, row := range existingRows {
+			if i%importProgressInterval == 0 {
+				progress(len(newCustomers)+i, totalRows)
+			}
+
+			if err := s.customerRepository.Update(txCtx, row.customer); err != nil {
+				fmt.Printf("error updating customer: %v\n", err.Error())
+				return err
+			}
+			result.UpdatedIDs = append(result.UpdatedIDs, row.customer.CustomerID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	slices.SortStableFunc(rowErrors, func(a, b domain.ImportRowError) int {
+		return a.Row - b.Row
+	})
+	result.RowErrors = rowErrors
+
+	return result, nil
+}
+
+// Export writes every customer matching the filters, ignoring their paging,
+// reading them a page at a time so large listings are not held in memory.
+func (s *batchCustomerService) Export(ctx context.Context, filters domain.CustomerFilters, format domain.ExportFormat, w io.Writer) error {
+	table, err := newTableWriter(format, w, "Clientes")
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, 0, len(customerLayout))
+	for _, layoutColumn := range customerLayout {
+		header = append(header, layoutColumn.header)
+	}
+
+	if err := table.WriteRow(header); err != nil {
+		return err
+	}
+
+	filters.Limit = exportPageSize
+	filters.Offset = 0
+	for {
+		customers, err := s.customerRepository.Search(ctx, filters)
+		if err != nil {
+			fmt.Printf("error searching customers: %v\n", err.Error())
+			return err
+		}
+
+		for _, customer := range customers.Result {
+			if err := table.WriteRow(customerExportRow(customer)); err != nil {
+				return err
+			}
+		}
+
+		filters.Offset += len(customers.Result)
+		if len(customers.Result) < filters.Limit || filters.Offset >= customers.Paging.Total {
+			break
+		}
+	}
+
+	return table.Close()
+}
+
+// buildImportedCustomer validates a spreadsheet row and turns it into a new
+// customer, reporting every invalid cell at once.
+func buildImportedCustomer(row importRow, columns importColumns[customerColumn], author string) (domain.Customer, []domain.ImportRowError) {
+	cell := func(column customerColumn) string {
+		return columns.cell(row.values, column)
+	}
+
+	rowErrors := make([]domain.ImportRowError, 0)
+	addError := func(column customerColumn, value, message string) {
+		rowErrors = append(rowErrors, domain.ImportRowError{
+			Row:     row.number,
+			Column:  columns.name(column),
+			Value:   value,
+			Message: message,
+		})
+	}
+
+	document := cell(customerColumnDocument)
+	if document == "" {
+		addError(customerColumnDocument, "", "required value is empty")
+	} else if !domain.IsValidDocument(document) {
+		addError(customerColumnDocument, document, "invalid CPF/CNPJ")
+	}
+
+	if cell(customerColumnFirstName) == "" && cell(customerColumnCompanyName) == "" && cell(customerColumnLegalName) == "" {
+		addError(customerColumnFirstName, "", "a name or company name is required")
+	}
+
+	states := make(map[customerColumn]string)
+	for _, column := range []customerColumn{customerColumnShippingState, customerColumnBillingState} {
+		state := cell(column)
+		if state == "" {
+			continue
+		}
+
+		stateName, found := domain.StateName(state)
+		if !found {
+			addError(column, state, "unknown state")
+		}
+		states[column] = stateName
+	}
+
+	if len(rowErrors) > 0 {
+		return domain.Customer{}, rowErrors
+	}
+
+	documentDigits := domain.DocumentDigits(document)
+	documentType := domain.CPF
+	if len(documentDigits) == 14 {
+		documentType = domain.CNPJ
+	}
+
+	countryOrDefault := func(column customerColumn) string {
+		if country := cell(column); country != "" {
+			return country
+		}
+
+		return "brazil"
+	}
+
+	customer, err := domain.NewCustomer(
+		cell(customerColumnFirstName),
+		cell(customerColumnLastName),
+		cell(customerColumnCompanyName),
+		cell(customerColumnLegalName),
+		documentDigits,
+		string(documentType),
+		author,
+		domain.Contact{PhoneNumber: cell(customerColumnPersonalPhone), Email: cell(customerColumnPersonalEmail)},
+		domain.Contact{PhoneNumber: cell(customerColumnBusinessPhone), Email: cell(customerColumnBusinessEmail)},
+		domain.Address{
+			Address: cell(customerColumnShippingAddress),
+			City:    cell(customerColumnShippingCity),
+			State:   states[customerColumnShippingState],
+			ZipCode: cell(customerColumnShippingZipCode),
+			Country: countryOrDefault(customerColumnShippingCountry),
+		},
+		domain.Address{
+			Address: cell(customerColumnBillingAddress),
+			City:    cell(customerColumnBillingCity),
+			State:   states[customerColumnBillingState],
+			ZipCode: cell(customerColumnBillingZipCode),
+			Country: countryOrDefault(customerColumnBillingCountry),
+		},
+	)
+	if err != nil {
+		return domain.Customer{}, []domain.ImportRowError{{Row: row.number, Message: err.Error()}}
+	}
+
+	return customer, nil
+}
+
+// importedCustomerUpdate holds the cells filled in a row matching a stored
+// customer. Empty cells keep what is stored, so a file carrying only a few
+// columns does not wipe the others out. States were validated when the row
+// was built.
+func importedCustomerUpdate(row customerRow, columns importColumns[customerColumn], author string) domain.UpdateCustomer {
+	cell := func(column customerColumn) *string {
+		return ptr.FromString(columns.cell(row.values, column))
+	}
+
+	return domain.UpdateCustomer{
+		FirstName:   cell(customerColumnFirstName),
+		LastName:    cell(customerColumnLastName),
+		CompanyName: cell(customerColumnCompanyName),
+		LegalName:   cell(customerColumnLegalName),
+		ShippingAddress: &domain.UpdateAddress{
+			Address: cell(customerColumnShippingAddress),
+			City:    cell(customerColumnShippingCity),
+			State:   ptr.FromString(row.customer.ShippingAddress.State),
+			ZipCode: cell(customerColumnShippingZipCode),
+			Country: cell(customerColumnShippingCountry),
+		},
+		BillingAddress: &domain.UpdateAddress{
+			Address: cell(customerColumnBillingAddress),
+			City:    cell(customerColumnBillingCity),
+			State:   ptr.FromString(row.customer.BillingAddress.State),
+			ZipCode: cell(customerColumnBillingZipCode),
+			Country: cell(customerColumnBillingCountry),
+		},
+		PersonalContact: &domain.UpdateContact{
+			PhoneNumber: cell(customerColumnPersonalPhone),
+			Email:       cell(customerColumnPersonalEmail),
+		},
+		BusinessContact: &domain.UpdateContact{
+			PhoneNumber: cell(customerColumnBusinessPhone),
+			Email:       cell(customerColumnBusinessEmail),
+		},
+		UpdatedBy: author,
+	}
+}
+
+func customerExportRow(customer domain.Customer) []string {
+	values := map[customerColumn]string{
+		customerColumnID:              customer.CustomerID,
+		customerColumnFirstName:       customer.FirstName,
+		customerColumnLastName:        customer.LastName,
+		customerColumnCompanyName:     customer.CompanyName,
+		customerColumnLegalName:       customer.LegalName,
+		customerColumnDocument:        customer.Document,
+		customerColumnDocumentType:    string(customer.DocumentType),
+		customerColumnType:            string(customer.Type),
+		customerColumnShippingAddress: customer.ShippingAddress.Address,
+		customerColumnShippingCity:    customer.ShippingAddress.City,
+		customerColumnShippingState:   customer.ShippingAddress.State,
+		customerColumnShippingZipCode: customer.ShippingAddress.ZipCode,
+		customerColumnShippingCountry: customer.ShippingAddress.Country,
+		customerColumnBillingAddress:  customer.BillingAddress.Address,
+		customerColumnBillingCity:     customer.BillingAddress.City,
+		customerColumnBillingState:    customer.BillingAddress.State,
+		customerColumnBillingZipCode:  customer.BillingAddress.ZipCode,
+		customerColumnBillingCountry:  customer.BillingAddress.Country,
+		customerColumnPersonalPhone:   customer.PersonalContact.PhoneNumber,
+		customerColumnPersonalEmail:   customer.PersonalContact.Email,
+		customerColumnBusinessPhone:   customer.BusinessContact.PhoneNumber,
+		customerColumnBusinessEmail:   customer.BusinessContact.Email,
+		customerColumnActive:          strconv.FormatBool(customer.Active),
+		customerColumnCreatedAt:       customer.CreatedAt.Format(time.RFC3339),
+		customerColumnUpdatedAt:       customer.UpdatedAt.Format(time.RFC3339),
+	}
+
+	row := make([]string, 0, len(customerLayout))
+	for _, layoutColumn := range customerLayout {
+		row = append(row, values[layoutColumn.column])
+	}
+
+	return row
+}
@@ -0,0 +1,191 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type batchCustomerServiceMocks struct {
+	customerRepository *mock_domain.MockCustomerRepository
+	transactionManager *mock_domain.MockTransactionManager
+}
+
+func newBatchCustomerServiceForTest(t *testing.T) (BatchCustomerService, *batchCustomerServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &batchCustomerServiceMocks{
+		customerRepository: mock_domain.NewMockCustomerRepository(ctrl),
+		transactionManager: mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	return NewBatchCustomerService(mocks.customerRepository, mocks.transactionManager), mocks
+}
+
+func TestBatchCustomerService_Process(t *testing.T) {
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_CUSTOMERS, "clientes.csv", nil, "operator-1")
+	require.NoError(t, err)
+
+	t.Run("creates new customers, updates known ones and reports invalid rows", func(t *testing.T) {
+		service, mocks := newBatchCustomerServiceForTest(t)
+
+		file := "Nome;Sobrenome;Razão social;CPF/CNPJ;Cidade;UF;Telefone\n" +
+			"Maria;Silva;;529.982.247-25;Campinas;SP;11 99999-0000\n" +
+			";;Loja do João LTDA;11.222.333/0001-81;;;\n" +
+			"Ana;Lima;;529.982.247-26;Natal;ZZ;\n" +
+			";;;111.444.777-35;;;\n"
+
+		existing := domain.Customer{
+			CustomerID:      "customer-1",
+			FirstName:       "Maria",
+			LastName:        "S.",
+			Document:        "529.982.247-25",
+			ShippingAddress: domain.Address{Address: "Rua A, 10", City: "Sumaré", State: "São Paulo"},
+			PersonalContact: domain.Contact{Email: "maria@example.com"},
+		}
+
+		mocks.customerRepository.EXPECT().GetByDocuments(gomock.Any(), []string{"11222333000181", "52998224725"}).
+			Return([]domain.Customer{existing}, nil)
+		mocks.customerRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, customers []domain.Customer) ([]string, error) {
+				require.Len(t, customers, 1)
+				assert.Equal(t, "Loja do João LTDA", customers[0].LegalName)
+				assert.Equal(t, domain.CNPJ, customers[0].DocumentType)
+				assert.Equal(t, domain.LEGAL, customers[0].Type)
+				return []string{customers[0].CustomerID}, nil
+			})
+		mocks.customerRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, customer domain.Customer) error {
+				assert.Equal(t, "customer-1", customer.CustomerID)
+				assert.Equal(t, "Silva", customer.LastName)
+				assert.Equal(t, domain.Address{Address: "Rua A, 10", City: "Campinas", State: "São Paulo"}, customer.ShippingAddress)
+				assert.Equal(t, domain.Contact{PhoneNumber: "11 99999-0000", Email: "maria@example.com"}, customer.PersonalContact)
+				assert.Equal(t, "operator-1", customer.UpdatedBy)
+				return nil
+			})
+
+		result, err := service.Process(context.Background(), job, strings.NewReader(file), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Len(t, result.CreatedIDs, 1)
+		assert.Equal(t, []string{"customer-1"}, result.UpdatedIDs)
+		assert.Equal(t, []domain.ImportRowError{
+			{Row: 4, Column: "CPF/CNPJ", Value: "529.982.247-26", Message: "invalid CPF/CNPJ"},
+			{Row: 4, Column: "UF", Value: "ZZ", Message: "unknown state"},
+			{Row: 5, Column: "Nome", Message: "a name or company name is required"},
+		}, result.RowErrors)
+	})
+
+	t.Run("creates customers repeated in the file only once", func(t *testing.T) {
+		service, mocks := newBatchCustomerServiceForTest(t)
+
+		file := "Nome;CPF/CNPJ\nMaria;529.982.247-25\nMaria S.;52998224725\n"
+
+		mocks.customerRepository.EXPECT().GetByDocuments(gomock.Any(), []string{"52998224725"}).Return([]domain.Customer{}, nil)
+		mocks.customerRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).Return([]string{"customer-1"}, nil)
+
+		result, err := service.Process(context.Background(), job, strings.NewReader(file), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"customer-1"}, result.CreatedIDs)
+		require.Len(t, result.RowErrors, 1)
+		assert.Equal(t, 3, result.RowErrors[0].Row)
+		assert.Equal(t, "document is repeated in the file, first seen on row 2", result.RowErrors[0].Message)
+	})
+
+	t.Run("fails when the document column is missing", func(t *testing.T) {
+		service, _ := newBatchCustomerServiceForTest(t)
+
+		_, err := service.Process(context.Background(), job, strings.NewReader("Nome;Cidade\nMaria;Campinas\n"), func(int, int) {})
+
+		var customErr *domain.CustomError
+		assert.ErrorAs(t, err, &customErr)
+	})
+}
+
+func TestBatchCustomerService_Export(t *testing.T) {
+	createdAt := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	customer := domain.Customer{
+		CustomerID:      "customer-1",
+		FirstName:       "Maria",
+		LastName:        "Silva",
+		Document:        "52998224725",
+		DocumentType:    domain.CPF,
+		Type:            domain.NATURAL,
+		ShippingAddress: domain.Address{Address: "Rua A, 10", City: "Campinas", State: "São Paulo", ZipCode: "13000-000", Country: "brazil"},
+		BillingAddress:  domain.Address{City: "Campinas"},
+		PersonalContact: domain.Contact{PhoneNumber: "11 99999-0000", Email: "maria@example.com"},
+		Active:          true,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+
+	t.Run("pages through every customer matching the filters", func(t *testing.T) {
+		service, mocks := newBatchCustomerServiceForTest(t)
+
+		page := make([]domain.Customer, exportPageSize)
+		for i := range page {
+			page[i] = customer
+		}
+
+		gomock.InOrder(
+			mocks.customerRepository.EXPECT().
+				Search(gomock.Any(), domain.CustomerFilters{CustomerType: []string{"Natural"}, PagingFilter: domain.PagingFilter{Limit: exportPageSize, Offset: 0}}).
+				Return(domain.PagingResult[domain.Customer]{Result: page, Paging: domain.Paging{Total: exportPageSize + 1}}, nil),
+			mocks.customerRepository.EXPECT().
+				Search(gomock.Any(), domain.CustomerFilters{CustomerType: []string{"Natural"}, PagingFilter: domain.PagingFilter{Limit: exportPageSize, Offset: exportPageSize}}).
+				Return(domain.PagingResult[domain.Customer]{Result: []domain.Customer{customer}, Paging: domain.Paging{Total: exportPageSize + 1}}, nil),
+		)
+
+		var buffer bytes.Buffer
+		filters := domain.CustomerFilters{CustomerType: []string{"Natural"}, PagingFilter: domain.PagingFilter{Limit: 10, Offset: 20}}
+		err := service.Export(context.Background(), filters, domain.EXPORT_CSV, &buffer)
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		assert.Len(t, lines, exportPageSize+2)
+		assert.Equal(t, "ID;Nome;Sobrenome;Nome fantasia;Razão social;Documento;Tipo de documento;Tipo;"+
+			"Endereço de entrega;Cidade de entrega;Estado de entrega;CEP de entrega;País de entrega;"+
+			"Endereço de cobrança;Cidade de cobrança;Estado de cobrança;CEP de cobrança;País de cobrança;"+
+			"Telefone pessoal;E-mail pessoal;Telefone comercial;E-mail comercial;Ativo;Criado em;Atualizado em", lines[0])
+		assert.Equal(t, "customer-1;Maria;Silva;;;52998224725;CPF;Natural;"+
+			"Rua A, 10;Campinas;São Paulo;13000-000;brazil;;Campinas;;;;"+
+			"11 99999-0000;maria@example.com;;;true;2025-01-31T12:00:00Z;2025-01-31T12:00:00Z", lines[1])
+	})
+
+	t.Run("writes a workbook that imports read back", func(t *testing.T) {
+		service, mocks := newBatchCustomerServiceForTest(t)
+
+		mocks.customerRepository.EXPECT().Search(gomock.Any(), gomock.Any()).
+			Return(domain.PagingResult[domain.Customer]{Result: []domain.Customer{customer}, Paging: domain.Paging{Total: 1}}, nil)
+
+		var buffer bytes.Buffer
+		err := service.Export(context.Background(), domain.CustomerFilters{}, domain.EXPORT_XLSX, &buffer)
+		require.NoError(t, err)
+
+		sheet, err := readSpreadsheet("clientes.xlsx", &buffer, spreadsheetOptions{})
+		require.NoError(t, err)
+
+		columns, err := findImportColumns(sheet.header(), customerColumnAliases, []customerColumn{customerColumnDocument})
+		require.NoError(t, err)
+		require.Len(t, sheet.dataRows(), 1)
+		assert.Equal(t, "52998224725", columns.cell(sheet.dataRows()[0], customerColumnDocument))
+		assert.Equal(t, "São Paulo", columns.cell(sheet.dataRows()[0], customerColumnShippingState))
+		assert.Equal(t, "Campinas", columns.cell(sheet.dataRows()[0], customerColumnBillingCity))
+	})
+}
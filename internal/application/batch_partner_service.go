@@ -0,0 +1,372 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// partnerColumn is a field read from a partner spreadsheet.
+type partnerColumn string
+
+const (
+	partnerColumnFirstName   partnerColumn = "first_name"
+	partnerColumnLastName    partnerColumn = "last_name"
+	partnerColumnCompanyName partnerColumn = "company_name"
+	partnerColumnLegalName   partnerColumn = "legal_name"
+	partnerColumnDocument    partnerColumn = "document"
+	partnerColumnType        partnerColumn = "partner_type"
+	partnerColumnPhone       partnerColumn = "phone"
+	partnerColumnEmail       partnerColumn = "email"
+	partnerColumnAddress     partnerColumn = "address"
+	partnerColumnCity        partnerColumn = "city"
+	partnerColumnState       partnerColumn = "state"
+	partnerColumnZipCode     partnerColumn = "zip_code"
+	partnerColumnDescription partnerColumn = "description"
+	partnerColumnPixKey      partnerColumn = "pix_key"
+	partnerColumnPixOption   partnerColumn = "pix_key_option"
+	partnerColumnPixOwner    partnerColumn = "pix_owner"
+)
+
+// partnerColumnAliases lists the header names each column is known by, as
+// compared by findImportColumns.
+var partnerColumnAliases = map[partnerColumn][]string{
+	partnerColumnFirstName:   {"nome", "primeiro nome", "first_name"},
+	partnerColumnLastName:    {"sobrenome", "last_name"},
+	partnerColumnCompanyName: {"nome fantasia", "empresa", "company_name"},
+	partnerColumnLegalName:   {"razao social", "legal_name"},
+	partnerColumnDocument:    {"documento", "cpf/cnpj", "cpf", "cnpj", "document"},
+	partnerColumnType:        {"tipo", "tipo de parceiro", "partner_type"},
+	partnerColumnPhone:       {"telefone", "celular", "phone"},
+	partnerColumnEmail:       {"email", "e-mail"},
+	partnerColumnAddress:     {"endereco", "address"},
+	partnerColumnCity:        {"cidade", "city"},
+	partnerColumnState:       {"estado", "uf", "state"},
+	partnerColumnZipCode:     {"cep", "zip_code"},
+	partnerColumnDescription: {"observacoes", "observacao", "description"},
+	partnerColumnPixKey:      {"chave pix", "pix", "payment_key"},
+	partnerColumnPixOption:   {"tipo chave pix", "tipo da chave pix", "payment_key_option"},
+	partnerColumnPixOwner:    {"titular pix", "titular da conta", "payment_owner"},
+}
+
+// requiredPartnerColumns must be in the header for a partner spreadsheet to be
+// read at all.
+var requiredPartnerColumns = []partnerColumn{partnerColumnFirstName, partnerColumnDocument, partnerColumnType}
+
+// partnerRow keeps a spreadsheet row together with the partner it becomes,
+// already merged with the stored one when the document is known.
+type partnerRow struct {
+	importRow
+	partner domain.Partner
+}
+
+// partnerImportPlan is what a partner import would write, worked out before
+// anything is.
+type partnerImportPlan struct {
+	totalRows    int
+	newRows      []partnerRow
+	existingRows []partnerRow
+	rowErrors    []domain.ImportRowError
+}
+
+type batchPartnerService struct {
+	partnerRepository  domain.PartnerRepository
+	transactionManager domain.TransactionManager
+}
+
+//go:generate mockgen -source=batch_partner_service.go -destination=mock_application/mock_batch_partner_service.go -package=mock_application
+type BatchPartnerService interface {
+	Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error)
+	Preview(ctx context.Context, job domain.ImportJob, file io.Reader) (domain.PartnerImportPreview, error)
+}
+
+func NewBatchPartnerService(partnerRepository domain.PartnerRepository, transactionManager domain.TransactionManager) BatchPartnerService {
+	return &batchPartnerService{
+		partnerRepository:  partnerRepository,
+		transactionManager: transactionManager,
+	}
+}
+
+// Process runs a partner import job. Partners are matched by document: known
+// ones are updated with the values filled in the file and the others created,
+// all in a single transaction. Rows that fail validation are reported and
+// left out.
+func (s *batchPartnerService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	plan, err := s.planImport(ctx, job, file)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	result := domain.ImportResult{
+		CreatedIDs: make([]string, 0),
+		UpdatedIDs: make([]string, 0),
+		SkippedIDs: make([]string, 0),
+		RowErrors:  plan.rowErrors,
+	}
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if len(plan.newRows) > 0 {
+			partners := make([]domain.Partner, 0, len(plan.newRows))
+			for _, row := range plan.newRows {
+				partners = append(partners, row.partner)
+			}
+
+			createdIDs, err := s.partnerRepository.CreateBatch(txCtx, partners)
+			if err != nil {
+				fmt.Printf("error creating partners: %v\n", err.Error())
+				return err
+			}
+			result.CreatedIDs = createdIDs
+		}
+
+		for i, row := range plan.existingRows {
+			if i%importProgressInterval == 0 {
+				progress(len(plan.newRows)+i, plan.totalRows)
+			}
+
+			if err := s.partnerRepository.Update(txCtx, row.partner); err != nil {
+				fmt.Printf("error updating partner: %v\n", err.Error())
+				return err
+			}
+			result.UpdatedIDs = append(result.UpdatedIDs, row.partner.PartnerID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	return result, nil
+}
+
+// Preview reports the partners a file would create and update and the rows
+// that would be left out, without writing anything.
+func (s *batchPartnerService) Preview(ctx context.Context, job domain.ImportJob, file io.Reader) (domain.PartnerImportPreview, error) {
+	plan, err := s.planImport(ctx, job, file)
+	if err != nil {
+		return domain.PartnerImportPreview{}, err
+	}
+
+	preview := domain.PartnerImportPreview{
+		TotalRows:       plan.totalRows,
+		NewPartners:     make([]domain.PreviewPartner, 0, len(plan.newRows)),
+		UpdatedPartners: make([]domain.PreviewPartner, 0, len(plan.existingRows)),
+		RowErrors:       plan.rowErrors,
+	}
+
+	for _, row := range plan.newRows {
+		newPartner := domain.NewPreviewPartner(row.partner, row.number)
+		newPartner.PartnerID = ""
+		preview.NewPartners = append(preview.NewPartners, newPartner)
+	}
+
+	for _, row := range plan.existingRows {
+		preview.UpdatedPartners = append(preview.UpdatedPartners, domain.NewPreviewPartner(row.partner, row.number))
+	}
+
+	return preview, nil
+}
+
+func (s *batchPartnerService) planImport(ctx context.Context, job domain.ImportJob, file io.Reader) (partnerImportPlan, error) {
+	sheet, err := readSpreadsheet(job.FileName, file, spreadsheetOptionsOf(job))
+	if err != nil {
+		fmt.Printf("error reading file: %v\n", err.Error())
+		return partnerImportPlan{}, err
+	}
+
+	header := sheet.header()
+	columns, err := findImportColumns(header, partnerColumnAliases, requiredPartnerColumns)
+	if err != nil {
+		return partnerImportPlan{}, err
+	}
+
+	plan := partnerImportPlan{rowErrors: make([]domain.ImportRowError, 0)}
+	builtRows := make([]partnerRow, 0, len(sheet.dataRows()))
+	documentRows := make(map[string]int)
+	for i, values := range sheet.dataRows() {
+		if len(values) <= 1 {
+			continue
+		}
+		plan.totalRows++
+
+		row := importRow{number: sheet.firstDataLine() + i, values: values}
+		partner, rowErrors := buildImportedPartner(row, columns, job.CreatedBy)
+		if len(rowErrors) > 0 {
+			plan.rowErrors = append(plan.rowErrors, rowErrors...)
+			continue
+		}
+
+		if firstRow, repeated := documentRows[partner.Document]; repeated {
+			plan.rowErrors = append(plan.rowErrors, domain.ImportRowError{
+				Row:     row.number,
+				Column:  columns.name(partnerColumnDocument),
+				Value:   partner.Document,
+				Message: fmt.Sprintf("document is repeated in the file, first seen on row %d", firstRow),
+			})
+			continue
+		}
+		documentRows[partner.Document] = row.number
+
+		builtRows = append(builtRows, partnerRow{importRow: row, partner: partner})
+	}
+
+	existingPartners, err := s.partnerRepository.GetByDocuments(ctx, slices.Sorted(maps.Keys(documentRows)))
+	if err != nil {
+		fmt.Printf("error searching partners: %v\n", err.Error())
+		return partnerImportPlan{}, err
+	}
+
+	partnersByDocument := make(map[string]domain.Partner, len(existingPartners))
+	for _, partner := range existingPartners {
+		partnersByDocument[domain.DocumentDigits(partner.Document)] = partner
+	}
+
+	for _, row := range builtRows {
+		existing, found := partnersByDocument[row.partner.Document]
+		if !found {
+			plan.newRows = append(plan.newRows, row)
+			continue
+		}
+
+		row.partner = mergeImportedPartner(existing, row.partner)
+		plan.existingRows = append(plan.existingRows, row)
+	}
+
+	slices.SortStableFunc(plan.rowErrors, func(a, b domain.ImportRowError) int {
+		return a.Row - b.Row
+	})
+
+	return plan, nil
+}
+
+// buildImportedPartner validates a spreadsheet row and turns it into a new
+// partner, reporting every invalid cell at once.
+func buildImportedPartner(row importRow, columns importColumns[partnerColumn], author string) (domain.Partner, []domain.ImportRowError) {
+	cell := func(column partnerColumn) string {
+		return columns.cell(row.values, column)
+	}
+
+	rowErrors := make([]domain.ImportRowError, 0)
+	addError := func(column partnerColumn, value, message string) {
+		rowErrors = append(rowErrors, domain.ImportRowError{
+			Row:     row.number,
+			Column:  columns.name(column),
+			Value:   value,
+			Message: message,
+		})
+	}
+
+	for _, column := range requiredPartnerColumns {
+		if cell(column) == "" {
+			addError(column, "", "required value is empty")
+		}
+	}
+
+	document := cell(partnerColumnDocument)
+	if document != "" && !domain.IsValidDocument(document) {
+		addError(partnerColumnDocument, document, "invalid CPF/CNPJ")
+	}
+
+	state := cell(partnerColumnState)
+	if state != "" {
+		stateName, found := domain.StateName(state)
+		if !found {
+			addError(partnerColumnState, state, "unknown state")
+		}
+		state = stateName
+	}
+
+	var billing domain.Billing
+	if pixKey := cell(partnerColumnPixKey); pixKey != "" {
+		var err error
+		billing, err = domain.NewPixBilling(pixKey, cell(partnerColumnPixOption), cell(partnerColumnPixOwner))
+		if err != nil {
+			addError(partnerColumnPixKey, pixKey, "invalid pix key")
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return domain.Partner{}, rowErrors
+	}
+
+	phone := cell(partnerColumnPhone)
+	if phone != "" && !strings.HasPrefix(phone, "+") {
+		phone = "+55 " + phone
+	}
+
+	documentDigits := domain.DocumentDigits(document)
+	documentType := domain.CPF
+	if len(documentDigits) == 14 {
+		documentType = domain.CNPJ
+	}
+
+	partner, err := domain.NewPartner(
+		cell(partnerColumnFirstName),
+		cell(partnerColumnLastName),
+		cell(partnerColumnCompanyName),
+		cell(partnerColumnLegalName),
+		documentDigits,
+		string(documentType),
+		author,
+		domain.Contact{PhoneNumber: phone, Email: cell(partnerColumnEmail)},
+		domain.Contact{},
+		domain.Address{
+			Address: cell(partnerColumnAddress),
+			City:    cell(partnerColumnCity),
+			State:   state,
+			ZipCode: cell(partnerColumnZipCode),
+			Country: "brazil",
+		},
+		domain.Address{},
+		cell(partnerColumnDescription),
+		cell(partnerColumnType),
+		billing,
+	)
+	if err != nil {
+		return domain.Partner{}, []domain.ImportRowError{{Row: row.number, Message: err.Error()}}
+	}
+
+	return partner, nil
+}
+
+// mergeImportedPartner updates a stored partner with the values filled in the
+// spreadsheet. Empty cells keep what is stored, so a file carrying only a few
+// columns does not wipe the others out.
+func mergeImportedPartner(existing, imported domain.Partner) domain.Partner {
+	merged := existing
+	merged.UpdatedBy = imported.UpdatedBy
+	merged.UpdatedAt = time.Now().UTC()
+
+	setIfFilled(&merged.FirstName, imported.FirstName)
+	setIfFilled(&merged.LastName, imported.LastName)
+	setIfFilled(&merged.CompanyName, imported.CompanyName)
+	setIfFilled(&merged.LegalName, imported.LegalName)
+	setIfFilled(&merged.PartnerType, imported.PartnerType)
+	setIfFilled(&merged.Description, imported.Description)
+	setIfFilled(&merged.PersonalContact.PhoneNumber, imported.PersonalContact.PhoneNumber)
+	setIfFilled(&merged.PersonalContact.Email, imported.PersonalContact.Email)
+	setIfFilled(&merged.ShippingAddress.Address, imported.ShippingAddress.Address)
+	setIfFilled(&merged.ShippingAddress.City, imported.ShippingAddress.City)
+	setIfFilled(&merged.ShippingAddress.State, imported.ShippingAddress.State)
+	setIfFilled(&merged.ShippingAddress.ZipCode, imported.ShippingAddress.ZipCode)
+	setIfFilled(&merged.ShippingAddress.Country, imported.ShippingAddress.Country)
+
+	if imported.Billing.Key != "" {
+		merged.Billing = imported.Billing
+	}
+
+	return merged
+}
+
+func setIfFilled(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type batchPartnerServiceMocks struct {
+	partnerRepository  *mock_domain.MockPartnerRepository
+	transactionManager *mock_domain.MockTransactionManager
+}
+
+func newBatchPartnerServiceForTest(t *testing.T) (BatchPartnerService, *batchPartnerServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &batchPartnerServiceMocks{
+		partnerRepository:  mock_domain.NewMockPartnerRepository(ctrl),
+		transactionManager: mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	return NewBatchPartnerService(mocks.partnerRepository, mocks.transactionManager), mocks
+}
+
+func newPartnerImportJobForTest(t *testing.T) domain.ImportJob {
+	t.Helper()
+
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_PARTNERS, "partners.csv", nil, "operator-1")
+	require.NoError(t, err)
+
+	return job
+}
+
+const partnerFileForTest = "Nome;Sobrenome;Documento;Tipo;Telefone;Cidade;UF;Observações;Chave PIX;Titular PIX\n" +
+	"Maria;Silva;529.982.247-25;Técnico;11 99999-0000;Campinas;SP;;maria@example.com;\n" +
+	"João;Souza;111.444.777-35;Técnico;;Recife;PE;Atende fins de semana;;\n" +
+	"Ana;Lima;529.982.247-26;Técnico;;Natal;ZZ;;chave;\n" +
+	"Pedro;Alves;529.982.247-25;;;;;;;\n"
+
+func TestBatchPartnerService_Process(t *testing.T) {
+	t.Run("creates new partners, updates known ones and reports invalid rows", func(t *testing.T) {
+		service, mocks := newBatchPartnerServiceForTest(t)
+
+		existing := domain.Partner{
+			PartnerID:       "partner-2",
+			FirstName:       "João",
+			LastName:        "Souza",
+			Document:        "111.444.777-35",
+			PartnerType:     "Técnico",
+			PersonalContact: domain.Contact{PhoneNumber: "+55 81 98888-0000"},
+			ShippingAddress: domain.Address{City: "Olinda", State: "Pernambuco"},
+		}
+
+		mocks.partnerRepository.EXPECT().GetByDocuments(gomock.Any(), []string{"11144477735", "52998224725"}).
+			Return([]domain.Partner{existing}, nil)
+		mocks.partnerRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, partners []domain.Partner) ([]string, error) {
+				require.Len(t, partners, 1)
+				assert.Equal(t, "52998224725", partners[0].Document)
+				assert.Equal(t, domain.CPF, partners[0].DocumentType)
+				assert.Equal(t, "+55 11 99999-0000", partners[0].PersonalContact.PhoneNumber)
+				assert.Equal(t, "São Paulo", partners[0].ShippingAddress.State)
+				assert.Equal(t, domain.Billing{Key: "maria@example.com", Option: "EMAIL", Type: domain.PIX, IsSameFromOwner: true}, partners[0].Billing)
+				return []string{partners[0].PartnerID}, nil
+			})
+		mocks.partnerRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, partner domain.Partner) error {
+				assert.Equal(t, "partner-2", partner.PartnerID)
+				assert.Equal(t, "Recife", partner.ShippingAddress.City)
+				assert.Equal(t, "+55 81 98888-0000", partner.PersonalContact.PhoneNumber)
+				assert.Equal(t, "Atende fins de semana", partner.Description)
+				assert.Equal(t, "operator-1", partner.UpdatedBy)
+				return nil
+			})
+
+		result, err := service.Process(context.Background(), newPartnerImportJobForTest(t), strings.NewReader(partnerFileForTest), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Len(t, result.CreatedIDs, 1)
+		assert.Equal(t, []string{"partner-2"}, result.UpdatedIDs)
+		assert.Equal(t, []domain.ImportRowError{
+			{Row: 4, Column: "Documento", Value: "529.982.247-26", Message: "invalid CPF/CNPJ"},
+			{Row: 4, Column: "UF", Value: "ZZ", Message: "unknown state"},
+			{Row: 4, Column: "Chave PIX", Value: "chave", Message: "invalid pix key"},
+			{Row: 5, Column: "Tipo", Message: "required value is empty"},
+		}, result.RowErrors)
+	})
+
+	t.Run("reports documents repeated in the file", func(t *testing.T) {
+		service, mocks := newBatchPartnerServiceForTest(t)
+
+		file := "Nome;Documento;Tipo\nMaria;529.982.247-25;Técnico\nMaria S.;52998224725;Técnico\n"
+
+		mocks.partnerRepository.EXPECT().GetByDocuments(gomock.Any(), []string{"52998224725"}).Return([]domain.Partner{}, nil)
+		mocks.partnerRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).Return([]string{"partner-1"}, nil)
+
+		result, err := service.Process(context.Background(), newPartnerImportJobForTest(t), strings.NewReader(file), func(int, int) {})
+
+		require.NoError(t, err)
+		require.Len(t, result.RowErrors, 1)
+		assert.Equal(t, 3, result.RowErrors[0].Row)
+		assert.Equal(t, "document is repeated in the file, first seen on row 2", result.RowErrors[0].Message)
+	})
+
+	t.Run("fails when a required column is missing", func(t *testing.T) {
+		service, _ := newBatchPartnerServiceForTest(t)
+
+		_, err := service.Process(context.Background(), newPartnerImportJobForTest(t), strings.NewReader("Nome;Cidade\nMaria;Campinas\n"), func(int, int) {})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+
+	t.Run("writes nothing when saving fails", func(t *testing.T) {
+		service, mocks := newBatchPartnerServiceForTest(t)
+
+		mocks.partnerRepository.EXPECT().GetByDocuments(gomock.Any(), gomock.Any()).Return([]domain.Partner{}, nil)
+		mocks.partnerRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+
+		_, err := service.Process(context.Background(), newPartnerImportJobForTest(t), strings.NewReader(partnerFileForTest), func(int, int) {})
+
+		assert.EqualError(t, err, "connection reset")
+	})
+}
+
+func TestBatchPartnerService_Preview(t *testing.T) {
+	service, mocks := newBatchPartnerServiceForTest(t)
+
+	mocks.partnerRepository.EXPECT().GetByDocuments(gomock.Any(), gomock.Any()).
+		Return([]domain.Partner{{PartnerID: "partner-2", FirstName: "João", Document: "11144477735"}}, nil)
+
+	preview, err := service.Preview(context.Background(), newPartnerImportJobForTest(t), strings.NewReader(partnerFileForTest))
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, preview.TotalRows)
+	assert.Equal(t, []domain.PreviewPartner{{Row: 2, Document: "52998224725", Name: "Maria Silva"}}, preview.NewPartners)
+	assert.Equal(t, []domain.PreviewPartner{{Row: 3, PartnerID: "partner-2", Document: "11144477735", Name: "João Souza"}}, preview.UpdatedPartners)
+	assert.Len(t, preview.RowErrors, 4)
+}
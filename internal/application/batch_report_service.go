@@ -0,0 +1,243 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// reports are generated a few at a time, each one downloads its template
+	// and photos and may render a PDF
+	maxConcurrentReports = 4
+	reportBatchPageSize  = 100
+
+	reportBatchManifestName = "manifesto.csv"
+	reportBatchContentType  = "application/zip"
+)
+
+type batchReportService struct {
+	caseService       CaseService
+	caseActionService CaseActionService
+	attachmentBucket  domain.AttachmentBucket
+}
+
+//go:generate mockgen -source=batch_report_service.go -destination=mock_application/mock_batch_report_service.go -package=mock_application
+type BatchReportService interface {
+	Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error)
+	Download(ctx context.Context, job domain.ImportJob) (io.ReadCloser, int64, string, error)
+}
+
+func NewBatchReportService(
+	caseService CaseService,
+	caseActionService CaseActionService,
+	attachmentBucket domain.AttachmentBucket,
+) BatchReportService {
+	return &batchReportService{
+		caseService:       caseService,
+		caseActionService: caseActionService,
+		attachmentBucket:  attachmentBucket,
+	}
+}
+
+// reportBatchEntry is the outcome of the report of one case of a batch.
+type reportBatchEntry struct {
+	crmCase  domain.Case
+	fileName string
+	err      error
+}
+
+// Process generates the report of every case the batch selects and stores
+// them in a ZIP along with a manifest of the ones generated and the ones that
+// failed. A failed report does not stop the others, it is listed as a row
+// error of the job.
+func (s *batchReportService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	batch, err := domain.ParseReportBatch(content)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	cases, err := s.batchCases(ctx, batch.Filters)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+	progress(0, len(cases))
+
+	// the ZIP is spooled to disk, batches of PDFs with photos do not fit in
+	// the worker's memory
+	archive, err := os.CreateTemp("", "report-batch-*.zip")
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	archiveWriter := zip.NewWriter(archive)
+
+	var mu sync.Mutex
+	entries := make([]reportBatchEntry, len(cases))
+	fileNames := make(map[string]bool, len(cases))
+	processed := 0
+
+	var wg errgroup.Group
+	wg.SetLimit(maxConcurrentReports)
+	for i, crmCase := range cases {
+		wg.Go(func() error {
+			report, fileName, err := s.caseActionService.GenerateReport(ctx, crmCase.CaseID, batch.Format, job.CreatedBy)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			processed++
+			defer progress(processed, len(cases))
+
+			entries[i] = reportBatchEntry{crmCase: crmCase, err: err}
+			if err != nil {
+				return nil
+			}
+
+			fileName = fmt.Sprintf("%s.%s", fileName, batch.Format)
+			if fileNames[fileName] {
+				fileName = fmt.Sprintf("%s-%s.%s", strings.TrimSuffix(fileName, "."+string(batch.Format)), crmCase.CaseID, batch.Format)
+			}
+			fileNames[fileName] = true
+			entries[i].fileName = fileName
+
+			return writeZipFile(archiveWriter, fileName, report)
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	manifest, result, err := reportBatchManifest(entries)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	if err := writeZipFile(archiveWriter, reportBatchManifestName, manifest); err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	if err := archiveWriter.Close(); err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	if err := s.attachmentBucket.UploadStream(ctx, domain.ReportBatchKey(job.JobID), archive, reportBatchContentType); err != nil {
+		return domain.ImportResult{}, fmt.Errorf("failed to store report batch: %w", err)
+	}
+
+	return result, nil
+}
+
+// Download opens the ZIP of a finished report batch to be streamed, along
+// with its size and the name it is downloaded as. The caller closes it.
+func (s *batchReportService) Download(ctx context.Context, job domain.ImportJob) (io.ReadCloser, int64, string, error) {
+	if job.Type != domain.IMPORT_JOB_REPORTS {
+		return nil, 0, "", domain.NewNotFoundError("no report batch found with this id", map[string]any{"job_id": job.JobID})
+	}
+
+	if !job.IsFinished() || job.Status == domain.IMPORT_JOB_FAILED {
+		return nil, 0, "", domain.NewConflictError("report batch has no file to download", map[string]any{"job_id": job.JobID, "status": job.Status})
+	}
+
+	content, size, err := s.attachmentBucket.DownloadStream(ctx, domain.ReportBatchKey(job.JobID))
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to download report batch %s: %w", job.JobID, err)
+	}
+
+	return content, size, fmt.Sprintf("relatorios_%s.zip", job.CreatedAt.Format("2006_01_02_15_04")), nil
+}
+
+// batchCases pages through the cases the filters select, refusing batches
+// larger than domain.MaxReportBatchCases.
+func (s *batchReportService) batchCases(ctx context.Context, filters domain.CaseFilters) ([]domain.Case, error) {
+	filters.PagingFilter = domain.PagingFilter{
+		Limit:     reportBatchPageSize,
+		SortBy:    "created_at",
+		SortOrder: "ASC",
+	}
+
+	var cases []domain.Case
+	for {
+		page, err := s.caseService.SearchCases(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		if page.Paging.Total > domain.MaxReportBatchCases {
+			return nil, domain.NewValidationError(
+				fmt.Sprintf("report batch selects %d cases, at most %d are generated at once", page.Paging.Total, domain.MaxReportBatchCases),
+				map[string]any{"total": page.Paging.Total},
+			)
+		}
+
+		cases = append(cases, page.Result...)
+		if len(page.Result) < filters.Limit || len(cases) >= page.Paging.Total {
+			return cases, nil
+		}
+		filters.Offset += filters.Limit
+	}
+}
+
+// reportBatchManifest lists every case of the batch with its file or the
+// reason it has none.
+func reportBatchManifest(entries []reportBatchEntry) ([]byte, domain.ImportResult, error) {
+	result := domain.ImportResult{
+		CreatedIDs: []string{},
+		UpdatedIDs: []string{},
+		SkippedIDs: []string{},
+		RowErrors:  []domain.ImportRowError{},
+	}
+
+	rows := [][]string{{"Caso", "Sinistro", "Status", "Arquivo", "Erro"}}
+	for i, entry := range entries {
+		if entry.err != nil {
+			rows = append(rows, []string{entry.crmCase.CaseID, entry.crmCase.ExternalReference, "Falha", "", entry.err.Error()})
+			result.RowErrors = append(result.RowErrors, domain.ImportRowError{
+				Row:     i + 1,
+				Column:  "case_id",
+				Value:   entry.crmCase.CaseID,
+				Message: entry.err.Error(),
+			})
+			continue
+		}
+
+		rows = append(rows, []string{entry.crmCase.CaseID, entry.crmCase.ExternalReference, "Gerado", entry.fileName, ""})
+		result.CreatedIDs = append(result.CreatedIDs, entry.crmCase.CaseID)
+	}
+
+	manifest, err := writeCSV(rows)
+	if err != nil {
+		return nil, domain.ImportResult{}, err
+	}
+
+	return manifest, result, nil
+}
+
+func writeZipFile(archive *zip.Writer, name string, content []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(content)
+	return err
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type batchReportServiceMocks struct {
+	caseService       *mock_application.MockCaseService
+	caseActionService *mock_application.MockCaseActionService
+	attachmentBucket  *mock_domain.MockAttachmentBucket
+}
+
+func newBatchReportServiceForTest(t *testing.T) (BatchReportService, *batchReportServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &batchReportServiceMocks{
+		caseService:       mock_application.NewMockCaseService(ctrl),
+		caseActionService: mock_application.NewMockCaseActionService(ctrl),
+		attachmentBucket:  mock_domain.NewMockAttachmentBucket(ctrl),
+	}
+
+	return NewBatchReportService(mocks.caseService, mocks.caseActionService, mocks.attachmentBucket), mocks
+}
+
+func newReportBatchJob(t *testing.T, filters domain.CaseFilters) (domain.ImportJob, []byte) {
+	t.Helper()
+
+	job, content, err := domain.NewReportBatchJob(domain.ReportBatch{Filters: filters, Format: domain.REPORT_PDF}, "operator-1")
+	require.NoError(t, err)
+
+	return job, content
+}
+
+func readZipFiles(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	files := make(map[string]string, len(archive.File))
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		fileContent, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		files[file.Name] = string(fileContent)
+	}
+
+	return files
+}
+
+func TestBatchReportService_Process(t *testing.T) {
+	cases := []domain.Case{
+		{CaseID: "case-1", ExternalReference: "SIN-1"},
+		{CaseID: "case-2", ExternalReference: "SIN-2"},
+		{CaseID: "case-3", ExternalReference: "SIN-3"},
+	}
+
+	t.Run("zips the reports with a manifest of the failures", func(t *testing.T) {
+		service, mocks := newBatchReportServiceForTest(t)
+		job, content := newReportBatchJob(t, domain.CaseFilters{CaseID: []string{"case-1", "case-2", "case-3"}})
+
+		mocks.caseService.EXPECT().SearchCases(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, filters domain.CaseFilters) (domain.PagingResult[domain.Case], error) {
+				assert.Equal(t, []string{"case-1", "case-2", "case-3"}, filters.CaseID)
+				return domain.PagingResult[domain.Case]{Result: cases, Paging: domain.Paging{Total: 3}}, nil
+			})
+		mocks.caseActionService.EXPECT().GenerateReport(gomock.Any(), "case-1", domain.REPORT_PDF, "operator-1").Return([]byte("pdf 1"), "Assurant-SIN-1", nil)
+		mocks.caseActionService.EXPECT().GenerateReport(gomock.Any(), "case-2", domain.REPORT_PDF, "operator-1").
+			Return(nil, "", domain.NewValidationError("case is not in status REPORT", nil))
+		mocks.caseActionService.EXPECT().GenerateReport(gomock.Any(), "case-3", domain.REPORT_PDF, "operator-1").Return([]byte("pdf 3"), "Assurant-SIN-1", nil)
+
+		var archive []byte
+		mocks.attachmentBucket.EXPECT().UploadStream(gomock.Any(), domain.ReportBatchKey(job.JobID), gomock.Any(), "application/zip").
+			DoAndReturn(func(_ context.Context, _ string, content io.ReadSeeker, _ string) error {
+				var err error
+				archive, err = io.ReadAll(content)
+				return err
+			})
+
+		var lastProgress []int
+		result, err := service.Process(context.Background(), job, bytes.NewReader(content), func(processed, total int) {
+			lastProgress = []int{processed, total}
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 3}, lastProgress)
+		assert.ElementsMatch(t, []string{"case-1", "case-3"}, result.CreatedIDs)
+		require.Len(t, result.RowErrors, 1)
+		assert.Equal(t, domain.ImportRowError{Row: 2, Column: "case_id", Value: "case-2", Message: "Validation error - Message: case is not in status REPORT"}, result.RowErrors[0])
+
+		files := readZipFiles(t, archive)
+		assert.Len(t, files, 3)
+		assert.Contains(t, files, "Assurant-SIN-1.pdf")
+		assert.Contains(t, files, reportBatchManifestName)
+		assert.Contains(t, files[reportBatchManifestName], "Caso;Sinistro;Status;Arquivo;Erro\n")
+		assert.Contains(t, files[reportBatchManifestName], "case-2;SIN-2;Falha;;Validation error - Message: case is not in status REPORT\n")
+	})
+
+	t.Run("refuses batches past the limit", func(t *testing.T) {
+		service, mocks := newBatchReportServiceForTest(t)
+		job, content := newReportBatchJob(t, domain.CaseFilters{Status: []string{"REPORT"}})
+
+		mocks.caseService.EXPECT().SearchCases(gomock.Any(), gomock.Any()).
+			Return(domain.PagingResult[domain.Case]{Result: cases, Paging: domain.Paging{Total: domain.MaxReportBatchCases + 1}}, nil)
+
+		_, err := service.Process(context.Background(), job, bytes.NewReader(content), func(int, int) {})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+}
+
+func TestBatchReportService_Download(t *testing.T) {
+	job, _ := newReportBatchJob(t, domain.CaseFilters{Status: []string{"REPORT"}})
+
+	t.Run("reads the zip of finished batches", func(t *testing.T) {
+		service, mocks := newBatchReportServiceForTest(t)
+		finished := job
+		finished.Complete(domain.ImportResult{})
+
+		mocks.attachmentBucket.EXPECT().DownloadStream(gomock.Any(), domain.ReportBatchKey(job.JobID)).
+			Return(io.NopCloser(strings.NewReader("zip")), int64(3), nil)
+
+		content, size, fileName, err := service.Download(context.Background(), finished)
+
+		require.NoError(t, err)
+		defer content.Close()
+		read, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, "zip", string(read))
+		assert.Equal(t, int64(3), size)
+		assert.Contains(t, fileName, "relatorios_")
+	})
+
+	t.Run("waits for the batch to finish", func(t *testing.T) {
+		service, _ := newBatchReportServiceForTest(t)
+
+		_, _, _, err := service.Download(context.Background(), job)
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusConflict, customErr.StatusCode())
+	})
+}
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -59,8 +60,7 @@ func (b *assurantBuilder) BuildProduct(row []string) (*domain.Product, error) {
 
 	productValueStr := row[b.columnsIndex["Valor Produto"]]
 	if productValueStr != "" {
-		productValueParsed := strings.ReplaceAll(productValueStr, ",", "")
-		productValue, err = strconv.ParseFloat(productValueParsed, 64)
+		productValue, err = parseAssurantValue(productValueStr)
 		if err != nil {
 			return nil, err
 		}
@@ -113,3 +113,37 @@ func (b *assurantBuilder) BuildCustomer(row []string) (*domain.Customer, error)
 
 	return &newCustomer, nil
 }
+
+func (b *assurantBuilder) ValidateRow(row []string) []domain.ImportRowError {
+	return validateRow(row, b.columnsIndex, 0, []columnRule{
+		{column: "Número Sinistro", required: true},
+		{column: "Defeito Reclamado"},
+		{column: "Valor Produto", validate: func(value string) error {
+			_, err := parseAssurantValue(value)
+			return err
+		}},
+		{column: "Marca"},
+		{column: "Produto"},
+		{column: "Número de Série"},
+		{column: "Nome Cliente", required: true},
+		{column: "CPF Cliente", required: true, validate: validateDocument},
+		{column: "Telefone Celular"},
+		{column: "E-mail"},
+		{column: "Endereço"},
+		{column: "Bairro"},
+		{column: "Cidade"},
+		{column: "Estado", required: true, validate: validateStateAcronym},
+		{column: "CEP"},
+	})
+}
+
+// parseAssurantValue reads the product value, which Assurant exports with
+// comma thousand separators.
+func parseAssurantValue(value string) (float64, error) {
+	productValue, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return 0, errors.New("value is not a number")
+	}
+
+	return productValue, nil
+}
@@ -102,3 +102,18 @@ func (b *defaultBuilder) BuildCustomer(row []string) (*domain.Customer, error) {
 
 	return &newCustomer, nil
 }
+
+func (b *defaultBuilder) ValidateRow(row []string) []domain.ImportRowError {
+	return validateRow(row, b.columnsIndex, 0, []columnRule{
+		{column: "Sinistro", required: true},
+		{column: "Descrição"},
+		{column: "Valor", validate: validateDecimal},
+		{column: "Marca"},
+		{column: "Modelo"},
+		{column: "Nome", required: true},
+		{column: "Sobrenome"},
+		{column: "Documento", required: true, validate: validateDocument},
+		{column: "Cidade"},
+		{column: "Estado", required: true, validate: validateStateName},
+	})
+}
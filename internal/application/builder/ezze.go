@@ -100,3 +100,17 @@ func (b *ezzeBuilder) BuildCustomer(row []string) (*domain.Customer, error) {
 
 	return &newCustomer, nil
 }
+
+func (b *ezzeBuilder) ValidateRow(row []string) []domain.ImportRowError {
+	return validateRow(row, b.columnsIndex, 0, []columnRule{
+		{column: "Ticket", required: true},
+		{column: "Operação"},
+		{column: "Bem Segurado"},
+		{column: "Nome Segurado", required: true},
+		{column: "CPF/CNPJ Segurado", required: true, validate: validateDocument},
+		{column: "Celular"},
+		{column: "E-mail"},
+		{column: "Cidade"},
+		{column: "Estado", required: true, validate: validateStateAcronym},
+	})
+}
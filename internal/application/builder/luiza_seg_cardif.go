@@ -112,3 +112,16 @@ func (b *luizaSegBuilder) BuildCustomer(row []string) (*domain.Customer, error)
 
 	return &newCustomer, nil
 }
+
+// ValidateRow reads each value one cell to the right of its header, matching
+// how the LuizaSeg spreadsheet is laid out.
+func (b *luizaSegBuilder) ValidateRow(row []string) []domain.ImportRowError {
+	return validateRow(row, b.columnsIndex, 1, []columnRule{
+		{column: "BASE"},
+		{column: "SINISTRO", required: true},
+		{column: "MARCA"},
+		{column: "PRODUTO"},
+		{column: "CIDADE"},
+		{column: "UF", required: true, validate: validateStateAcronym},
+	})
+}
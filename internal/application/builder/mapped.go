@@ -0,0 +1,198 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// mappedBuilder reads spreadsheets through a contractor import mapping
+// instead of a hand-written layout.
+type mappedBuilder struct {
+	mapping      domain.ImportMapping
+	columnsIndex map[string]int
+	author       string
+	companyName  string
+}
+
+func NewMappedBuilder(mapping domain.ImportMapping, columnsIndex map[string]int, author, companyName string) domain.CaseBuilder {
+	return &mappedBuilder{
+		mapping:      mapping,
+		columnsIndex: columnsIndex,
+		author:       author,
+		companyName:  companyName,
+	}
+}
+
+func (b *mappedBuilder) GetCompanyName() []string {
+	return []string{b.companyName}
+}
+
+func (b *mappedBuilder) GetCostumerDocumentIdx() int {
+	field, found := b.mapping.Field(domain.IMPORT_FIELD_CUSTOMER_DOCUMENT)
+	if !found {
+		return -1
+	}
+
+	columnIdx, found := b.columnsIndex[field.Column]
+	if !found {
+		return -1
+	}
+
+	return columnIdx + field.Offset
+}
+
+func (b *mappedBuilder) BuildCase(row []string, contractors []domain.Contractor, customerID string, customerRegion int) (*domain.Case, error) {
+	values, err := b.values(row)
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate := time.Now().Add(7 * 24 * time.Hour)
+	if values[domain.IMPORT_FIELD_DUE_DATE] != "" {
+		dueDate, err = time.Parse(time.DateOnly, values[domain.IMPORT_FIELD_DUE_DATE])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newCrmCase, err := domain.NewCase(
+		contractors[0].ContractorID,
+		customerID,
+		"csv",
+		"insurance",
+		values[domain.IMPORT_FIELD_SUBJECT],
+		dueDate,
+		b.author,
+		values[domain.IMPORT_FIELD_EXTERNAL_REFERENCE],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	newCrmCase.Region = customerRegion
+
+	return &newCrmCase, nil
+}
+
+func (b *mappedBuilder) BuildProduct(row []string) (*domain.Product, error) {
+	values, err := b.values(row)
+	if err != nil {
+		return nil, err
+	}
+
+	productValue := float64(0)
+	if values[domain.IMPORT_FIELD_PRODUCT_VALUE] != "" {
+		productValue, err = domain.ParseCurrency(values[domain.IMPORT_FIELD_PRODUCT_VALUE])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newProduct, err := domain.NewProduct(
+		values[domain.IMPORT_FIELD_PRODUCT_NAME],
+		values[domain.IMPORT_FIELD_PRODUCT_DESCRIPTION],
+		productValue,
+		values[domain.IMPORT_FIELD_PRODUCT_BRAND],
+		values[domain.IMPORT_FIELD_PRODUCT_MODEL],
+		values[domain.IMPORT_FIELD_PRODUCT_SERIAL_NUMBER],
+		b.author,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newProduct, nil
+}
+
+func (b *mappedBuilder) BuildCustomer(row []string) (*domain.Customer, error) {
+	values, err := b.values(row)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName := values[domain.IMPORT_FIELD_CUSTOMER_FIRST_NAME]
+	lastName := values[domain.IMPORT_FIELD_CUSTOMER_LAST_NAME]
+	if fullName := values[domain.IMPORT_FIELD_CUSTOMER_NAME]; fullName != "" && firstName == "" {
+		firstName, lastName, _ = strings.Cut(fullName, " ")
+	}
+
+	address := values[domain.IMPORT_FIELD_CUSTOMER_ADDRESS]
+	if district := values[domain.IMPORT_FIELD_CUSTOMER_DISTRICT]; district != "" {
+		address = fmt.Sprintf("%s - %s", address, district)
+	}
+
+	document := values[domain.IMPORT_FIELD_CUSTOMER_DOCUMENT]
+	documentType := domain.CPF
+	if len(domain.DocumentDigits(document)) == 14 {
+		documentType = domain.CNPJ
+	}
+
+	newCustomer, err := domain.NewCustomer(
+		firstName,
+		lastName,
+		values[domain.IMPORT_FIELD_CUSTOMER_COMPANY_NAME],
+		"",
+		document,
+		string(documentType),
+		b.author,
+		domain.Contact{
+			PhoneNumber: values[domain.IMPORT_FIELD_CUSTOMER_PHONE],
+			Email:       values[domain.IMPORT_FIELD_CUSTOMER_EMAIL],
+		},
+		domain.Contact{},
+		domain.Address{
+			Address: address,
+			City:    values[domain.IMPORT_FIELD_CUSTOMER_CITY],
+			State:   values[domain.IMPORT_FIELD_CUSTOMER_STATE],
+			Country: "brazil",
+			ZipCode: values[domain.IMPORT_FIELD_CUSTOMER_ZIP_CODE],
+		},
+		domain.Address{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newCustomer, nil
+}
+
+// ValidateRow checks every mapped column, running its transform so values the
+// builder would reject are reported up front.
+func (b *mappedBuilder) ValidateRow(row []string) []domain.ImportRowError {
+	rowErrors := make([]domain.ImportRowError, 0)
+	for _, field := range b.mapping.Fields {
+		rowErrors = append(rowErrors, validateRow(row, b.columnsIndex, field.Offset, []columnRule{{
+			column:   field.Column,
+			required: field.Required && field.Default == "",
+			validate: func(value string) error {
+				_, err := field.Apply(value)
+				return err
+			},
+		}})...)
+	}
+
+	return rowErrors
+}
+
+// values reads and transforms every mapped field of the row.
+func (b *mappedBuilder) values(row []string) (map[domain.ImportField]string, error) {
+	values := make(map[domain.ImportField]string, len(b.mapping.Fields))
+	for _, field := range b.mapping.Fields {
+		cell := ""
+		if columnIdx, found := b.columnsIndex[field.Column]; found && columnIdx+field.Offset < len(row) {
+			cell = row[columnIdx+field.Offset]
+		}
+
+		value, err := field.Apply(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Column, err)
+		}
+
+		values[field.Field] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappedBuilder(t *testing.T) {
+	mapping, err := domain.NewImportMapping([]domain.ImportFieldMapping{
+		{Field: domain.IMPORT_FIELD_EXTERNAL_REFERENCE, Column: "Protocolo", Required: true},
+		{Field: domain.IMPORT_FIELD_SUBJECT, Column: "Reclamação"},
+		{Field: domain.IMPORT_FIELD_DUE_DATE, Column: "Prazo", Transform: domain.IMPORT_TRANSFORM_DATE},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_NAME, Column: "Segurado"},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_DOCUMENT, Column: "CPF", Transform: domain.IMPORT_TRANSFORM_DOCUMENT, Required: true},
+		{Field: domain.IMPORT_FIELD_CUSTOMER_STATE, Column: "UF", Transform: domain.IMPORT_TRANSFORM_STATE_ACRONYM},
+		{Field: domain.IMPORT_FIELD_PRODUCT_VALUE, Column: "Valor", Transform: domain.IMPORT_TRANSFORM_CURRENCY},
+	})
+	require.NoError(t, err)
+
+	columnsIndex := map[string]int{"Protocolo": 0, "Reclamação": 1, "Prazo": 2, "Segurado": 3, "CPF": 4, "UF": 5, "Valor": 6}
+	builder := NewMappedBuilder(mapping, columnsIndex, "operator-1", "Nova Seguros")
+	row := []string{"P-1", "Não liga", "31/01/2025", "Maria da Silva", "529.982.247-25", "SP", "R$ 1.299,90"}
+	contractors := []domain.Contractor{{ContractorID: "contractor-1"}}
+
+	t.Run("builds the case, customer and product from the mapping", func(t *testing.T) {
+		assert.Equal(t, 4, builder.GetCostumerDocumentIdx())
+
+		crmCase, err := builder.BuildCase(row, contractors, "customer-1", 3)
+		require.NoError(t, err)
+		assert.Equal(t, "P-1", crmCase.ExternalReference)
+		assert.Equal(t, "Não liga", crmCase.Subject)
+		assert.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), crmCase.DueDate)
+		assert.Equal(t, "contractor-1", crmCase.ContractorID)
+
+		customer, err := builder.BuildCustomer(row)
+		require.NoError(t, err)
+		assert.Equal(t, "Maria", customer.FirstName)
+		assert.Equal(t, "da Silva", customer.LastName)
+		assert.Equal(t, "São Paulo", customer.ShippingAddress.State)
+
+		product, err := builder.BuildProduct(row)
+		require.NoError(t, err)
+		assert.Equal(t, 1299.9, product.Value)
+	})
+
+	t.Run("validates the mapped columns", func(t *testing.T) {
+		invalidRow := []string{"", "Não liga", "31-01-2025", "Maria da Silva", "529.982.247-26", "SP", "R$ 1.299,90"}
+
+		rowErrors := builder.ValidateRow(invalidRow)
+
+		require.Len(t, rowErrors, 3)
+		assert.Equal(t, "Protocolo", rowErrors[0].Column)
+		assert.Equal(t, "Prazo", rowErrors[1].Column)
+		assert.Equal(t, domain.ImportRowError{Column: "CPF", Value: "529.982.247-26", Message: "invalid CPF/CNPJ"}, rowErrors[2])
+	})
+}
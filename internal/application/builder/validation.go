@@ -0,0 +1,87 @@
+package builder
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// columnRule declares how a spreadsheet column read by a builder is checked
+// before the row is turned into a case.
+type columnRule struct {
+	column   string
+	required bool
+	validate func(value string) error
+}
+
+// validateRow checks every rule against the row. offset shifts the cell read
+// for a column, for layouts whose data is not aligned with the header.
+func validateRow(row []string, columnsIndex map[string]int, offset int, rules []columnRule) []domain.ImportRowError {
+	rowErrors := make([]domain.ImportRowError, 0)
+
+	for _, rule := range rules {
+		columnIdx, found := columnsIndex[rule.column]
+		if !found {
+			rowErrors = append(rowErrors, domain.ImportRowError{Column: rule.column, Message: "column is missing from the header"})
+			continue
+		}
+
+		value := ""
+		if columnIdx+offset < len(row) {
+			value = strings.TrimSpace(row[columnIdx+offset])
+		}
+
+		if value == "" {
+			if rule.required {
+				rowErrors = append(rowErrors, domain.ImportRowError{Column: rule.column, Message: "required value is empty"})
+			}
+			continue
+		}
+
+		if rule.validate == nil {
+			continue
+		}
+
+		if err := rule.validate(value); err != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Column: rule.column, Value: value, Message: err.Error()})
+		}
+	}
+
+	return rowErrors
+}
+
+func validateDocument(value string) error {
+	if !domain.IsValidDocument(value) {
+		return errors.New("invalid CPF/CNPJ")
+	}
+
+	return nil
+}
+
+func validateStateAcronym(value string) error {
+	if _, found := domain.AcronymForState[value]; !found {
+		return errors.New("unknown state acronym")
+	}
+
+	return nil
+}
+
+func validateStateName(value string) error {
+	for _, stateName := range domain.AcronymForState {
+		if stateName == value {
+			return nil
+		}
+	}
+
+	return errors.New("unknown state name")
+}
+
+func validateDecimal(value string) error {
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return errors.New("value is not a number")
+	}
+
+	return nil
+}
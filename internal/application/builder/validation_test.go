@@ -0,0 +1,58 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssurantBuilder_ValidateRow(t *testing.T) {
+	header := []string{
+		"Número Sinistro", "Defeito Reclamado", "Valor Produto", "Marca", "Produto", "Número de Série",
+		"Nome Cliente", "CPF Cliente", "Telefone Celular", "E-mail", "Endereço", "Bairro", "Cidade", "Estado", "CEP",
+	}
+	columnsIndex := make(map[string]int)
+	for i, column := range header {
+		columnsIndex[column] = i
+	}
+	builder := NewAssurantBuilder(columnsIndex, "operator-1", "Assurant")
+
+	t.Run("accepts a complete row", func(t *testing.T) {
+		row := []string{"S-1", "Tela quebrada", "1,299.90", "Samsung", "Galaxy", "SN-1", "Maria Silva", "529.982.247-25", "", "", "Rua A", "Centro", "Campinas", "SP", "13000-000"}
+
+		assert.Empty(t, builder.ValidateRow(row))
+	})
+
+	t.Run("collects every invalid column of the row", func(t *testing.T) {
+		row := []string{"", "Tela quebrada", "mil reais", "Samsung", "Galaxy", "SN-1", "Maria Silva", "529.982.247-26", "", "", "Rua A", "Centro", "Campinas", "XX", "13000-000"}
+
+		rowErrors := builder.ValidateRow(row)
+
+		require.Len(t, rowErrors, 4)
+		assert.Equal(t, domain.ImportRowError{Column: "Número Sinistro", Message: "required value is empty"}, rowErrors[0])
+		assert.Equal(t, "Valor Produto", rowErrors[1].Column)
+		assert.Equal(t, "CPF Cliente", rowErrors[2].Column)
+		assert.Equal(t, domain.ImportRowError{Column: "Estado", Value: "XX", Message: "unknown state acronym"}, rowErrors[3])
+	})
+
+	t.Run("reports columns missing from the header", func(t *testing.T) {
+		builder := NewAssurantBuilder(map[string]int{"Número Sinistro": 0}, "operator-1", "Assurant")
+
+		rowErrors := builder.ValidateRow([]string{"S-1"})
+
+		assert.Contains(t, rowErrors, domain.ImportRowError{Column: "CPF Cliente", Message: "column is missing from the header"})
+	})
+}
+
+func TestLuizaSegBuilder_ValidateRow(t *testing.T) {
+	t.Run("reads values one cell to the right of the header", func(t *testing.T) {
+		columnsIndex := map[string]int{"BASE": 0, "SINISTRO": 1, "MARCA": 2, "PRODUTO": 3, "CIDADE": 4, "UF": 5}
+		builder := NewLuizaSegBuilder(columnsIndex, "operator-1", "LuizaSeg")
+
+		rowErrors := builder.ValidateRow([]string{"", "Garantias", "S-1", "LG", "TV", "Recife", "PE"})
+
+		assert.Empty(t, rowErrors)
+	})
+}
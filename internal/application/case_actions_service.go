@@ -17,6 +17,7 @@ type caseActionService struct {
 	attachmentService     AttachmentService
 	transactionService    TransactionService
 	reportService         ReportService
+	quoteService          QuoteService
 }
 
 //go:generate mockgen -source=case_actions_service.go -destination=mock_application/mock_case_actions_service.go -package=mock_application
@@ -24,7 +25,7 @@ type CaseActionService interface {
 	ChangeOwner(ctx context.Context, caseID string, newOwner domain.ChangeOwner) error
 	ChangeStatus(ctx context.Context, caseID string, newStatus domain.ChangeStatus) error
 	ChangePartner(ctx context.Context, caseID string, newPartner domain.ChangePartner) error
-	GenerateReport(ctx context.Context, caseID string) ([]byte, string, error)
+	GenerateReport(ctx context.Context, caseID string, format domain.ReportFormat, author string) ([]byte, string, error)
 	ResetCaseStatus(ctx context.Context, caseID, author string) error
 }
 
@@ -36,6 +37,7 @@ func NewCaseActionService(
 	reportService ReportService,
 	attachmentService AttachmentService,
 	transactionService TransactionService,
+	quoteService QuoteService,
 ) CaseActionService {
 	return &caseActionService{
 		caseRepository:        caseRepository,
@@ -45,6 +47,7 @@ func NewCaseActionService(
 		reportService:         reportService,
 		attachmentService:     attachmentService,
 		transactionService:    transactionService,
+		quoteService:          quoteService,
 	}
 }
 
@@ -92,6 +95,10 @@ func (c *caseActionService) ChangeStatus(ctx context.Context, caseID string, new
 		return err
 	}
 
+	if err := c.ensureCanStart(ctx, caseID, newStatus.Status); err != nil {
+		return err
+	}
+
 	caseUpdate := domain.CaseUpdate{
 		Status:    &newStatus.Status,
 		Type:      newStatus.Type,
@@ -129,6 +136,10 @@ func (c *caseActionService) ChangePartner(ctx context.Context, caseID string, ne
 		return err
 	}
 
+	if err := c.ensureCanStart(ctx, caseID, newPartner.Status); err != nil {
+		return err
+	}
+
 	caseUpdate := domain.CaseUpdate{
 		PartnerID:  &newPartner.PartnerID,
 		Status:     &newPartner.Status,
@@ -149,7 +160,17 @@ func (c *caseActionService) ChangePartner(ctx context.Context, caseID string, ne
 	})
 }
 
-func (c *caseActionService) GenerateReport(ctx context.Context, caseID string) ([]byte, string, error) {
+// ensureCanStart blocks the move to ONGOING while the case quote is still
+// waiting for the contractor's approval.
+func (c *caseActionService) ensureCanStart(ctx context.Context, caseID string, status domain.CaseStatus) error {
+	if status != domain.ONGOING {
+		return nil
+	}
+
+	return c.quoteService.EnsureApproved(ctx, caseID)
+}
+
+func (c *caseActionService) GenerateReport(ctx context.Context, caseID string, format domain.ReportFormat, author string) ([]byte, string, error) {
 	if caseID == "" {
 		return nil, "", domain.NewValidationError("case_id is required", nil)
 	}
@@ -163,7 +184,7 @@ func (c *caseActionService) GenerateReport(ctx context.Context, caseID string) (
 		return nil, "", domain.NewValidationError("case is not in status REPORT", map[string]any{"status": crmCase.Status})
 	}
 
-	return c.reportService.GenerateReport(ctx, *crmCase)
+	return c.reportService.GenerateReport(ctx, *crmCase, format, author)
 }
 
 func (c *caseActionService) createChangeStatusComment(ctx context.Context, caseID string, newStatus domain.ChangeStatus) error {
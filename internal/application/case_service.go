@@ -21,6 +21,7 @@ type caseService struct {
 	partnerService        PartnerService
 	contractorService     ContractorService
 	queueService          QueueService
+	fraudService          FraudService
 }
 
 //go:generate mockgen -source=case_service.go -destination=mock_application/mock_case_service.go -package=mock_application
@@ -32,6 +33,7 @@ type CaseService interface {
 	GetCaseFullByID(ctx context.Context, caseID string) (*domain.CaseFull, error)
 	SearchCasesFull(ctx context.Context, filters domain.CaseFilters) (domain.PagingResult[domain.CaseFull], error)
 	GetCaseHistory(ctx context.Context, caseID string) ([]domain.CaseHistory, error)
+	GetCaseStats(ctx context.Context, filters domain.CaseFilters, groupBy []domain.CaseStatsDimension) (domain.CaseStats, error)
 }
 
 func NewCaseService(
@@ -46,6 +48,7 @@ func NewCaseService(
 	partnerService PartnerService,
 	contractorService ContractorService,
 	queueService QueueService,
+	fraudService FraudService,
 ) CaseService {
 	return &caseService{
 		customerService:       customerService,
@@ -59,6 +62,7 @@ func NewCaseService(
 		partnerService:        partnerService,
 		contractorService:     contractorService,
 		queueService:          queueService,
+		fraudService:          fraudService,
 	}
 }
 
@@ -94,7 +98,13 @@ func (c *caseService) CreateCase(ctx context.Context, newCase domain.CreateCase)
 			return err
 		}
 
-		return c.caseHistoryRepository.Create(txCtx, history)
+		if err := c.caseHistoryRepository.Create(txCtx, history); err != nil {
+			return err
+		}
+
+		crmCase.CaseID = caseID
+		_, err = c.fraudService.Assess(txCtx, crmCase)
+		return err
 	})
 	if err != nil {
 		return "", err
@@ -119,6 +129,31 @@ func (c *caseService) SearchCasesFull(ctx context.Context, filters domain.CaseFi
 	return c.caseRepository.SearchFull(ctx, filters)
 }
 
+// GetCaseStats counts the cases matching the filters, ignoring their paging,
+// grouped by the given dimensions.
+func (c *caseService) GetCaseStats(ctx context.Context, filters domain.CaseFilters, groupBy []domain.CaseStatsDimension) (domain.CaseStats, error) {
+	if len(groupBy) == 0 {
+		return domain.CaseStats{}, domain.NewValidationError("case stats must be grouped by at least one field", nil)
+	}
+
+	filters.PagingFilter = domain.PagingFilter{}
+
+	groups, err := c.caseRepository.CountGrouped(ctx, filters, groupBy)
+	if err != nil {
+		return domain.CaseStats{}, err
+	}
+
+	stats := domain.CaseStats{
+		GroupBy: groupBy,
+		Groups:  groups,
+	}
+	for _, group := range groups {
+		stats.Total += group.Cases
+	}
+
+	return stats, nil
+}
+
 func (c *caseService) assignOwnerToNewCase(ctx context.Context, crmCase *domain.Case) error {
 	regionStringified := strconv.Itoa(crmCase.Region)
 
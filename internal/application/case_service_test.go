@@ -23,6 +23,7 @@ type caseServiceMocks struct {
 	partnerService        *mock_application.MockPartnerService
 	contractorService     *mock_application.MockContractorService
 	queueService          *mock_application.MockQueueService
+	fraudService          *mock_application.MockFraudService
 }
 
 func newCaseServiceForTest(t *testing.T) (CaseService, *caseServiceMocks) {
@@ -41,6 +42,7 @@ func newCaseServiceForTest(t *testing.T) (CaseService, *caseServiceMocks) {
 		partnerService:        mock_application.NewMockPartnerService(ctrl),
 		contractorService:     mock_application.NewMockContractorService(ctrl),
 		queueService:          mock_application.NewMockQueueService(ctrl),
+		fraudService:          mock_application.NewMockFraudService(ctrl),
 	}
 
 	service := NewCaseService(
@@ -55,6 +57,7 @@ func newCaseServiceForTest(t *testing.T) (CaseService, *caseServiceMocks) {
 		mocks.partnerService,
 		mocks.contractorService,
 		mocks.queueService,
+		mocks.fraudService,
 	)
 
 	return service, mocks
@@ -229,3 +232,35 @@ func TestCaseService_SearchCasesFull(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, result)
 }
+
+func TestCaseService_GetCaseStats(t *testing.T) {
+	t.Run("totals the groups and ignores paging", func(t *testing.T) {
+		service, mocks := newCaseServiceForTest(t)
+
+		groupBy := []domain.CaseStatsDimension{domain.CASE_STATS_STATUS}
+		groups := []domain.CaseStatsGroup{
+			{Keys: map[domain.CaseStatsDimension]string{domain.CASE_STATS_STATUS: "New"}, Cases: 3},
+			{Keys: map[domain.CaseStatsDimension]string{domain.CASE_STATS_STATUS: "Closed"}, Cases: 2},
+		}
+
+		mocks.caseRepository.EXPECT().CountGrouped(gomock.Any(), domain.CaseFilters{QueueID: []string{"queue-1"}}, groupBy).Return(groups, nil)
+
+		stats, err := service.GetCaseStats(context.Background(), domain.CaseFilters{
+			QueueID:      []string{"queue-1"},
+			PagingFilter: domain.PagingFilter{Limit: 10, SortBy: "created_at"},
+		}, groupBy)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, stats.Total)
+		assert.Equal(t, groups, stats.Groups)
+		assert.Equal(t, groupBy, stats.GroupBy)
+	})
+
+	t.Run("needs a group", func(t *testing.T) {
+		service, _ := newCaseServiceForTest(t)
+
+		_, err := service.GetCaseStats(context.Background(), domain.CaseFilters{}, nil)
+
+		assert.Error(t, err)
+	})
+}
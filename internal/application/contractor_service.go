@@ -17,6 +17,8 @@ type ContractorService interface {
 	Update(ctx context.Context, contractorID string, contractor domain.UpdateContractor) error
 	Delete(ctx context.Context, contractorID string) error
 	Search(ctx context.Context, filters domain.ContractorFilters) (domain.PagingResult[domain.Contractor], error)
+	GetImportMapping(ctx context.Context, contractorID string) (*domain.ImportMapping, error)
+	UpdateImportMapping(ctx context.Context, contractorID string, mapping *domain.ImportMapping, author string) error
 }
 
 func NewContractorService(contractorRepository domain.ContractorRepository) ContractorService {
@@ -63,3 +65,38 @@ func (s *contractorService) Update(ctx context.Context, contractorID string, upd
 
 	return s.contractorRepository.Update(ctx, *contractor)
 }
+
+func (s *contractorService) GetImportMapping(ctx context.Context, contractorID string) (*domain.ImportMapping, error) {
+	contractor, err := s.GetByID(ctx, contractorID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := contractor.ImportMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping == nil {
+		return nil, domain.NewNotFoundError("contractor has no import mapping", map[string]any{"contractor_id": contractorID})
+	}
+
+	return mapping, nil
+}
+
+// UpdateImportMapping replaces the spreadsheet mapping of the contractor. A
+// nil mapping removes it, so its files go back to the built-in layout.
+func (s *contractorService) UpdateImportMapping(ctx context.Context, contractorID string, mapping *domain.ImportMapping, author string) error {
+	if author == "" {
+		return domain.NewValidationError("author cannot be empty", nil)
+	}
+
+	contractor, err := s.GetByID(ctx, contractorID)
+	if err != nil {
+		return err
+	}
+
+	contractor.SetImportMapping(mapping, author)
+
+	return s.contractorRepository.Update(ctx, *contractor)
+}
@@ -13,10 +13,12 @@ type customerService struct {
 //go:generate mockgen -source=customer_service.go -destination=mock_application/mock_customer_service.go -package=mock_application
 type CustomerService interface {
 	Create(ctx context.Context, customer domain.Customer) (string, error)
+	CreateBatch(ctx context.Context, customers []domain.Customer) ([]string, error)
 	GetByID(ctx context.Context, customerID string) (*domain.Customer, error)
 	Update(ctx context.Context, customerID string, updatedCustomer domain.UpdateCustomer) error
 	Delete(ctx context.Context, customerID string) error
 	Search(ctx context.Context, filters domain.CustomerFilters) (domain.PagingResult[domain.Customer], error)
+	GetByDocuments(ctx context.Context, documents []string) ([]domain.Customer, error)
 }
 
 func NewCustomerService(customerRepository domain.CustomerRepository) CustomerService {
@@ -29,6 +31,10 @@ func (s *customerService) Create(ctx context.Context, customer domain.Customer)
 	return s.customerRepository.Create(ctx, customer)
 }
 
+func (s *customerService) CreateBatch(ctx context.Context, customers []domain.Customer) ([]string, error) {
+	return s.customerRepository.CreateBatch(ctx, customers)
+}
+
 func (s *customerService) Delete(ctx context.Context, customerID string) error {
 	if customerID == "" {
 		return domain.NewValidationError("customerID cannot be empty", nil)
@@ -63,3 +69,9 @@ func (s *customerService) Update(ctx context.Context, customerID string, updated
 
 	return s.customerRepository.Update(ctx, *customer)
 }
+
+// GetByDocuments finds the customers of the documents, however they are
+// formatted, looking them up in chunks.
+func (s *customerService) GetByDocuments(ctx context.Context, documents []string) ([]domain.Customer, error) {
+	return s.customerRepository.GetByDocuments(ctx, documents)
+}
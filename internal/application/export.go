@@ -0,0 +1,43 @@
+package application
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/xlsx"
+)
+
+// exportPageSize is how many records exports read per query.
+const exportPageSize = 1000
+
+// tableWriter streams the rows of an export in one of the export formats.
+// Close must be called once every row is written.
+type tableWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+func newTableWriter(format domain.ExportFormat, w io.Writer, sheetName string) (tableWriter, error) {
+	if format == domain.EXPORT_CSV {
+		fileCSV := csv.NewWriter(w)
+		fileCSV.Comma = ';' // same separator as the import error reports
+
+		return &csvTableWriter{writer: fileCSV}, nil
+	}
+
+	return xlsx.NewWriter(w, sheetName)
+}
+
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvTableWriter) WriteRow(values []string) error {
+	return c.writer.Write(values)
+}
+
+func (c *csvTableWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type fraudService struct {
+	fraudRepository       domain.FraudRepository
+	customerService       CustomerService
+	productService        ProductService
+	caseHistoryRepository domain.CaseHistoryRepository
+	transactionManager    domain.TransactionManager
+	rules                 domain.FraudRules
+}
+
+//go:generate mockgen -source=fraud_service.go -destination=mock_application/mock_fraud_service.go -package=mock_application
+type FraudService interface {
+	Assess(ctx context.Context, crmCase domain.Case) (*domain.FraudAssessment, error)
+	GetByCaseID(ctx context.Context, caseID string) (*domain.FraudAssessment, error)
+	Review(ctx context.Context, caseID string, review domain.FraudReview) (*domain.FraudAssessment, error)
+}
+
+func NewFraudService(
+	fraudRepository domain.FraudRepository,
+	customerService CustomerService,
+	productService ProductService,
+	caseHistoryRepository domain.CaseHistoryRepository,
+	transactionManager domain.TransactionManager,
+) FraudService {
+	return &fraudService{
+		fraudRepository:       fraudRepository,
+		customerService:       customerService,
+		productService:        productService,
+		caseHistoryRepository: caseHistoryRepository,
+		transactionManager:    transactionManager,
+		rules:                 domain.DefaultFraudRules(),
+	}
+}
+
+// Assess scores a freshly created case against the fraud heuristics and
+// stores the result. It must run after the case and its product exist so the
+// repeat lookups can exclude the case itself.
+func (s *fraudService) Assess(ctx context.Context, crmCase domain.Case) (*domain.FraudAssessment, error) {
+	if crmCase.CaseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	signals, err := s.collectSignals(ctx, crmCase)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment := s.rules.Evaluate(crmCase.CaseID, signals)
+
+	if err := s.fraudRepository.Save(ctx, assessment); err != nil {
+		return nil, err
+	}
+
+	if assessment.Flagged {
+		err = s.recordHistory(ctx, crmCase.CaseID, domain.CaseFraudFlaggedEvent, crmCase.CreatedBy, map[string]any{}, assessment.Snapshot())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &assessment, nil
+}
+
+func (s *fraudService) GetByCaseID(ctx context.Context, caseID string) (*domain.FraudAssessment, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	return s.fraudRepository.GetByCaseID(ctx, caseID)
+}
+
+func (s *fraudService) Review(ctx context.Context, caseID string, review domain.FraudReview) (*domain.FraudAssessment, error) {
+	assessment, err := s.GetByCaseID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValues := assessment.Snapshot()
+	if err := assessment.Review(review); err != nil {
+		return nil, err
+	}
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.fraudRepository.Save(txCtx, *assessment); err != nil {
+			return err
+		}
+
+		newValues := assessment.Snapshot()
+		newValues["review_notes"] = assessment.ReviewNotes
+
+		return s.recordHistory(txCtx, caseID, domain.CaseFraudReviewedEvent, review.ReviewedBy, oldValues, newValues)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assessment, nil
+}
+
+func (s *fraudService) collectSignals(ctx context.Context, crmCase domain.Case) (domain.FraudSignals, error) {
+	signals := domain.FraudSignals{ContractorID: crmCase.ContractorID}
+
+	if crmCase.CustomerID != "" {
+		since := time.Now().UTC().Add(-s.rules.Window)
+		recentCases, err := s.fraudRepository.CountRecentCasesByCustomer(ctx, crmCase.CustomerID, since, crmCase.CaseID)
+		if err != nil {
+			return signals, err
+		}
+		signals.RecentCustomerCases = recentCases
+
+		customer, err := s.customerService.GetByID(ctx, crmCase.CustomerID)
+		if err != nil {
+			return signals, err
+		}
+
+		sharedContact, err := s.fraudRepository.CountCustomersSharingContact(ctx, *customer)
+		if err != nil {
+			return signals, err
+		}
+		signals.SharedContactCustomers = sharedContact
+	}
+
+	if crmCase.ProductID != "" {
+		product, err := s.productService.GetProductByID(ctx, crmCase.ProductID)
+		if err != nil {
+			return signals, err
+		}
+		signals.ProductValue = product.Value
+
+		serialContractors, err := s.fraudRepository.GetContractorsBySerialNumber(ctx, product.SerialNumber, crmCase.CaseID)
+		if err != nil {
+			return signals, err
+		}
+		signals.SerialContractors = serialContractors
+	}
+
+	return signals, nil
+}
+
+func (s *fraudService) recordHistory(ctx context.Context, caseID, eventName, author string, oldValues, newValues map[string]any) error {
+	history, err := domain.NewCaseHistory(caseID, eventName, author, oldValues, newValues)
+	if err != nil {
+		return err
+	}
+
+	return s.caseHistoryRepository.Create(ctx, history)
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fraudServiceMocks struct {
+	fraudRepository       *mock_domain.MockFraudRepository
+	customerService       *mock_application.MockCustomerService
+	productService        *mock_application.MockProductService
+	caseHistoryRepository *mock_domain.MockCaseHistoryRepository
+	transactionManager    *mock_domain.MockTransactionManager
+}
+
+func newFraudServiceForTest(t *testing.T) (FraudService, *fraudServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &fraudServiceMocks{
+		fraudRepository:       mock_domain.NewMockFraudRepository(ctrl),
+		customerService:       mock_application.NewMockCustomerService(ctrl),
+		productService:        mock_application.NewMockProductService(ctrl),
+		caseHistoryRepository: mock_domain.NewMockCaseHistoryRepository(ctrl),
+		transactionManager:    mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewFraudService(
+		mocks.fraudRepository,
+		mocks.customerService,
+		mocks.productService,
+		mocks.caseHistoryRepository,
+		mocks.transactionManager,
+	)
+
+	return service, mocks
+}
+
+func TestFraudService_Assess(t *testing.T) {
+	crmCase := domain.Case{
+		CaseID:       "case-1",
+		ContractorID: "contractor-1",
+		CustomerID:   "customer-1",
+		ProductID:    "product-1",
+		CreatedBy:    "operator-1",
+	}
+
+	expectSignals := func(mocks *fraudServiceMocks, recentCases int, serialContractors []string) {
+		mocks.fraudRepository.EXPECT().CountRecentCasesByCustomer(gomock.Any(), "customer-1", gomock.Any(), "case-1").Return(recentCases, nil)
+		mocks.customerService.EXPECT().GetByID(gomock.Any(), "customer-1").Return(&domain.Customer{CustomerID: "customer-1"}, nil)
+		mocks.fraudRepository.EXPECT().CountCustomersSharingContact(gomock.Any(), gomock.Any()).Return(0, nil)
+		mocks.productService.EXPECT().GetProductByID(gomock.Any(), "product-1").
+			Return(&domain.Product{ProductID: "product-1", SerialNumber: "SN-1", Value: 1200}, nil)
+		mocks.fraudRepository.EXPECT().GetContractorsBySerialNumber(gomock.Any(), "SN-1", "case-1").Return(serialContractors, nil)
+	}
+
+	t.Run("flags the case and records it in the timeline", func(t *testing.T) {
+		service, mocks := newFraudServiceForTest(t)
+
+		expectSignals(mocks, 4, []string{"contractor-2"})
+		mocks.fraudRepository.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, assessment domain.FraudAssessment) error {
+				assert.True(t, assessment.Flagged)
+				assert.Contains(t, assessment.Reasons, domain.FRAUD_SERIAL_OTHER_INSURER)
+				return nil
+			},
+		)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.CaseFraudFlaggedEvent, history.EventName)
+				assert.Equal(t, "operator-1", history.AuthorID)
+				return nil
+			},
+		)
+
+		assessment, err := service.Assess(context.Background(), crmCase)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.FRAUD_REVIEW_PENDING, assessment.ReviewStatus)
+	})
+
+	t.Run("stores a clean assessment without touching the timeline", func(t *testing.T) {
+		service, mocks := newFraudServiceForTest(t)
+
+		expectSignals(mocks, 0, nil)
+		mocks.fraudRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		assessment, err := service.Assess(context.Background(), crmCase)
+
+		require.NoError(t, err)
+		assert.False(t, assessment.Flagged)
+	})
+}
+
+func TestFraudService_Review(t *testing.T) {
+	t.Run("records the review in the timeline", func(t *testing.T) {
+		service, mocks := newFraudServiceForTest(t)
+
+		assessment := domain.DefaultFraudRules().Evaluate("case-1", domain.FraudSignals{RecentCustomerCases: 3, SharedContactCustomers: 1})
+		mocks.fraudRepository.EXPECT().GetByCaseID(gomock.Any(), "case-1").Return(&assessment, nil)
+		mocks.fraudRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.CaseFraudReviewedEvent, history.EventName)
+				assert.Equal(t, domain.FRAUD_REVIEW_PENDING, history.OldValues["review_status"])
+				assert.Equal(t, domain.FRAUD_REVIEW_CONFIRMED, history.NewValues["review_status"])
+				return nil
+			},
+		)
+
+		reviewed, err := service.Review(context.Background(), "case-1", domain.FraudReview{
+			Status:     domain.FRAUD_REVIEW_CONFIRMED,
+			ReviewedBy: "analyst-1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "analyst-1", reviewed.ReviewedBy)
+	})
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// importColumns locates the columns of a fixed spreadsheet layout, such as the
+// partner and customer ones, by their header names.
+type importColumns[C comparable] struct {
+	header []string
+	index  map[C]int
+}
+
+// findImportColumns matches the header against the names each column is known
+// by. Names are compared without accents, case or surrounding spaces, so
+// "Observações" matches "observacoes". It fails when a required column is
+// missing, naming it by its first alias.
+func findImportColumns[C comparable](header []string, aliases map[C][]string, required []C) (importColumns[C], error) {
+	columns := importColumns[C]{header: header, index: make(map[C]int)}
+	for i, name := range header {
+		normalized := normalizeHeader(name)
+		for column, names := range aliases {
+			if _, found := columns.index[column]; !found && slices.Contains(names, normalized) {
+				columns.index[column] = i
+			}
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, column := range required {
+		if _, found := columns.index[column]; !found {
+			missing = append(missing, aliases[column][0])
+		}
+	}
+
+	if len(missing) > 0 {
+		return importColumns[C]{}, domain.NewValidationError("spreadsheet is missing required columns", map[string]any{"columns": missing})
+	}
+
+	return columns, nil
+}
+
+// cell returns the trimmed value of the column in the row, empty when the
+// column is not in the file.
+func (c importColumns[C]) cell(row []string, column C) string {
+	columnIdx, found := c.index[column]
+	if !found || columnIdx >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[columnIdx])
+}
+
+// name returns the header of the column as written in the file.
+func (c importColumns[C]) name(column C) string {
+	columnIdx, found := c.index[column]
+	if !found {
+		return ""
+	}
+
+	return columnName(c.header, columnIdx)
+}
+
+// normalizeHeader lowercases a header name and strips its accents.
+func normalizeHeader(name string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(stripAccents, name)
+	if err != nil {
+		normalized = name
+	}
+
+	return strings.ToLower(strings.TrimSpace(normalized))
+}
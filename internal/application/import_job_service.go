@@ -0,0 +1,309 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// ImportProcessor runs the import of a single job type and returns what was
+// created along with the rows it had to leave out.
+type ImportProcessor interface {
+	Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error)
+}
+
+type importJobService struct {
+	importJobRepository domain.ImportJobRepository
+	transactionManager  domain.TransactionManager
+	processors          map[domain.ImportJobType]ImportProcessor
+	pollInterval        time.Duration
+	staleAfter          time.Duration
+}
+
+//go:generate mockgen -source=import_job_service.go -destination=mock_application/mock_import_job_service.go -package=mock_application
+type ImportJobService interface {
+	Enqueue(ctx context.Context, job domain.ImportJob, file io.Reader) (*domain.ImportJob, error)
+	GetByID(ctx context.Context, jobID string) (*domain.ImportJob, error)
+	GetErrorReport(ctx context.Context, jobID string) ([]byte, string, error)
+	ProcessNext(ctx context.Context) (bool, error)
+	Run(ctx context.Context)
+}
+
+func NewImportJobService(
+	importJobRepository domain.ImportJobRepository,
+	transactionManager domain.TransactionManager,
+	pollInterval time.Duration,
+	staleAfter time.Duration,
+	processors map[domain.ImportJobType]ImportProcessor,
+) ImportJobService {
+	return &importJobService{
+		importJobRepository: importJobRepository,
+		transactionManager:  transactionManager,
+		processors:          processors,
+		pollInterval:        pollInterval,
+		staleAfter:          staleAfter,
+	}
+}
+
+func (s *importJobService) Enqueue(ctx context.Context, job domain.ImportJob, file io.Reader) (*domain.ImportJob, error) {
+	if _, found := s.processors[job.Type]; !found {
+		return nil, domain.NewValidationError("unsupported import job type", map[string]any{"type": job.Type})
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) == 0 {
+		return nil, domain.NewValidationError("import file cannot be empty", map[string]any{"file_name": job.FileName})
+	}
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.importJobRepository.Create(txCtx, job); err != nil {
+			return err
+		}
+
+		return s.importJobRepository.CreateFile(txCtx, domain.ImportFile{
+			JobID:    job.JobID,
+			FileName: job.FileName,
+			Content:  content,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (s *importJobService) GetByID(ctx context.Context, jobID string) (*domain.ImportJob, error) {
+	if jobID == "" {
+		return nil, domain.NewValidationError("jobID cannot be empty", nil)
+	}
+
+	return s.importJobRepository.GetByID(ctx, jobID)
+}
+
+// GetErrorReport rebuilds the rows that were left out of an import from its
+// source file, each followed by its line number and errors, so operators can
+// fix them and upload the file again. Imports drop those two columns.
+// Report batches have no spreadsheet to rebuild, their failures are listed in
+// the manifest of the batch ZIP.
+func (s *importJobService) GetErrorReport(ctx context.Context, jobID string) ([]byte, string, error) {
+	job, err := s.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if job.Type == domain.IMPORT_JOB_REPORTS {
+		return nil, "", domain.NewValidationError(
+			"report batches have no error report, their failures are listed in the manifest of the batch file",
+			map[string]any{"job_id": jobID, "batch_file": fmt.Sprintf("/reports/batch/%s/file", jobID)},
+		)
+	}
+
+	if len(job.RowErrors) == 0 {
+		return nil, "", domain.NewNotFoundError("import job has no row errors", map[string]any{"job_id": jobID})
+	}
+
+	file, err := s.importJobRepository.GetFile(ctx, jobID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sheet, err := readSpreadsheet(file.FileName, bytes.NewReader(file.Content), spreadsheetOptionsOf(*job))
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows := sheet.rows
+	header := sheet.header()
+	messagesByRow := make(map[int][]string)
+	for _, rowError := range job.RowErrors {
+		messagesByRow[rowError.Row] = append(messagesByRow[rowError.Row], formatImportRowError(rowError))
+	}
+
+	rowNumbers := slices.Sorted(maps.Keys(messagesByRow))
+
+	// some layouts carry more cells than header names, keep them all so the
+	// file can be uploaded again as is
+	width := len(header)
+	for _, rowNumber := range rowNumbers {
+		if rowNumber >= 1 && rowNumber <= len(rows) {
+			width = max(width, len(rows[rowNumber-1]))
+		}
+	}
+
+	reportRows := make([][]string, 0, len(rowNumbers)+1)
+	reportRows = append(reportRows, append(padRow(slices.Clone(header), width), errorReportColumns...))
+	for _, rowNumber := range rowNumbers {
+		values := make([]string, width)
+		if rowNumber >= 1 && rowNumber <= len(rows) {
+			copy(values, rows[rowNumber-1])
+		}
+
+		reportRows = append(reportRows, append(values, strconv.Itoa(rowNumber), strings.Join(messagesByRow[rowNumber], " | ")))
+	}
+
+	report, err := writeCSV(reportRows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fileName := strings.TrimSuffix(file.FileName, filepath.Ext(file.FileName)) + "_erros.csv"
+
+	return report, fileName, nil
+}
+
+// ProcessNext claims the oldest pending job, or one whose worker stopped
+// sending heartbeats, and runs it. It reports whether a job was found.
+func (s *importJobService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.importJobRepository.ClaimNext(ctx, uuid.NewString(), time.Now().UTC().Add(-s.staleAfter))
+	if err != nil {
+		var customErr *domain.CustomError
+		if errors.As(err, &customErr) && customErr.IsNotFound() {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if job.Attempts >= domain.MaxImportJobAttempts {
+		job.Fail(fmt.Errorf("import interrupted after %d attempts", job.Attempts))
+		return true, s.importJobRepository.Update(ctx, *job)
+	}
+
+	job.Start()
+	if err := s.importJobRepository.Update(ctx, *job); err != nil {
+		return true, err
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		s.heartbeat(runCtx, *job, cancel)
+	}()
+
+	result, err := s.process(runCtx, job)
+	cancel(nil)
+	<-heartbeatDone
+
+	if cause := context.Cause(runCtx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return true, cause
+	}
+
+	if err != nil {
+		job.Fail(err)
+	} else {
+		job.Complete(result)
+	}
+
+	return true, s.importJobRepository.Update(ctx, *job)
+}
+
+// heartbeat keeps the job leased while it runs, outside of any transaction
+// the import holds. When another worker took the job over the import is
+// canceled, rolling back what it has not committed yet.
+func (s *importJobService) heartbeat(ctx context.Context, job domain.ImportJob, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(s.staleAfter / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := s.importJobRepository.Heartbeat(ctx, job.JobID, job.LeaseID)
+		var customErr *domain.CustomError
+		if errors.As(err, &customErr) && customErr.StatusCode() == http.StatusConflict {
+			cancel(err)
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			fmt.Printf("error sending import job heartbeat: %v\n", err.Error())
+		}
+	}
+}
+
+// Run polls for import jobs until the context is canceled.
+func (s *importJobService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		found, err := s.ProcessNext(ctx)
+		if err != nil {
+			fmt.Printf("error processing import job: %v\n", err.Error())
+		}
+
+		if found && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *importJobService) process(ctx context.Context, job *domain.ImportJob) (domain.ImportResult, error) {
+	processor, found := s.processors[job.Type]
+	if !found {
+		return domain.ImportResult{}, domain.NewValidationError("unsupported import job type", map[string]any{"type": job.Type})
+	}
+
+	file, err := s.importJobRepository.GetFile(ctx, job.JobID)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	progress := func(processedRows, totalRows int) {
+		job.Progress(processedRows, totalRows)
+		if err := s.importJobRepository.Update(ctx, *job); err != nil {
+			fmt.Printf("error updating import job progress: %v\n", err.Error())
+		}
+	}
+
+	return s.runProcessor(ctx, processor, *job, file.Content, progress)
+}
+
+// runProcessor keeps a panicking import from taking the worker down with it.
+func (s *importJobService) runProcessor(ctx context.Context, processor ImportProcessor, job domain.ImportJob, content []byte, progress domain.ImportProgressFunc) (result domain.ImportResult, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("import job panicked: %v", recovered)
+		}
+	}()
+
+	return processor.Process(ctx, job, bytes.NewReader(content), progress)
+}
+
+func formatImportRowError(rowError domain.ImportRowError) string {
+	message := rowError.Message
+	if rowError.Value != "" {
+		message = fmt.Sprintf("%s (%s)", message, rowError.Value)
+	}
+
+	if rowError.Column != "" {
+		message = fmt.Sprintf("%s: %s", rowError.Column, message)
+	}
+
+	return message
+}
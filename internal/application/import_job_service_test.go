@@ -0,0 +1,298 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type importJobServiceMocks struct {
+	importJobRepository *mock_domain.MockImportJobRepository
+	transactionManager  *mock_domain.MockTransactionManager
+	processor           *mock_application.MockImportProcessor
+}
+
+func newImportJobServiceForTest(t *testing.T) (ImportJobService, *importJobServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &importJobServiceMocks{
+		importJobRepository: mock_domain.NewMockImportJobRepository(ctrl),
+		transactionManager:  mock_domain.NewMockTransactionManager(ctrl),
+		processor:           mock_application.NewMockImportProcessor(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewImportJobService(
+		mocks.importJobRepository,
+		mocks.transactionManager,
+		time.Second,
+		10*time.Minute,
+		map[domain.ImportJobType]ImportProcessor{domain.IMPORT_JOB_CASES: mocks.processor},
+	)
+
+	return service, mocks
+}
+
+func newImportJobForTest(t *testing.T) domain.ImportJob {
+	t.Helper()
+
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, "cases.csv", map[string]string{domain.ImportParamCompany: "Assurant"}, "operator-1")
+	require.NoError(t, err)
+
+	return job
+}
+
+func TestImportJobService_Enqueue(t *testing.T) {
+	t.Run("persists the job together with its source file", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job := newImportJobForTest(t)
+
+		mocks.importJobRepository.EXPECT().Create(gomock.Any(), job).Return(nil)
+		mocks.importJobRepository.EXPECT().CreateFile(gomock.Any(), domain.ImportFile{
+			JobID:    job.JobID,
+			FileName: "cases.csv",
+			Content:  []byte("header\nrow"),
+		}).Return(nil)
+
+		enqueued, err := service.Enqueue(context.Background(), job, strings.NewReader("header\nrow"))
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.IMPORT_JOB_PENDING, enqueued.Status)
+	})
+
+	t.Run("returns validation error for an empty file", func(t *testing.T) {
+		service, _ := newImportJobServiceForTest(t)
+
+		_, err := service.Enqueue(context.Background(), newImportJobForTest(t), strings.NewReader(""))
+
+		require.Error(t, err)
+	})
+}
+
+func TestImportJobService_ProcessNext(t *testing.T) {
+	t.Run("reports no job when the queue is empty", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+
+		mocks.importJobRepository.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewNotFoundError("no import job waiting to run", nil))
+
+		found, err := service.ProcessNext(context.Background())
+
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("runs the processor and stores the created ids", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job := newImportJobForTest(t)
+
+		var updates []domain.ImportJob
+		mocks.importJobRepository.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(&job, nil)
+		mocks.importJobRepository.EXPECT().GetFile(gomock.Any(), job.JobID).
+			Return(&domain.ImportFile{JobID: job.JobID, Content: []byte("header\nrow")}, nil)
+		mocks.importJobRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, job domain.ImportJob) error {
+				updates = append(updates, job)
+				return nil
+			},
+		).Times(3)
+		mocks.processor.EXPECT().Process(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+				assert.Equal(t, "Assurant", job.Param(domain.ImportParamCompany))
+				progress(0, 1)
+				return domain.ImportResult{CreatedIDs: []string{"case-1"}}, nil
+			},
+		)
+
+		found, err := service.ProcessNext(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		require.Len(t, updates, 3)
+		assert.Equal(t, domain.IMPORT_JOB_PROCESSING, updates[0].Status)
+		assert.Equal(t, 1, updates[1].TotalRows)
+		assert.Equal(t, domain.IMPORT_JOB_COMPLETED, updates[2].Status)
+		assert.Equal(t, []string{"case-1"}, updates[2].CreatedIDs)
+	})
+
+	t.Run("marks the job as failed when the processor fails", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job := newImportJobForTest(t)
+
+		mocks.importJobRepository.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(&job, nil)
+		mocks.importJobRepository.EXPECT().GetFile(gomock.Any(), job.JobID).
+			Return(&domain.ImportFile{JobID: job.JobID, Content: []byte("header")}, nil)
+		mocks.processor.EXPECT().Process(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(domain.ImportResult{}, errors.New("company not found"))
+		mocks.importJobRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mocks.importJobRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, job domain.ImportJob) error {
+				assert.Equal(t, domain.IMPORT_JOB_FAILED, job.Status)
+				assert.Equal(t, []string{"company not found"}, job.Errors)
+				return nil
+			},
+		)
+
+		found, err := service.ProcessNext(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("stops the import when another worker took the job over", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		importJobRepository := mock_domain.NewMockImportJobRepository(ctrl)
+		processor := mock_application.NewMockImportProcessor(ctrl)
+		service := NewImportJobService(
+			importJobRepository,
+			mock_domain.NewMockTransactionManager(ctrl),
+			time.Second,
+			30*time.Millisecond,
+			map[domain.ImportJobType]ImportProcessor{domain.IMPORT_JOB_CASES: processor},
+		)
+		job := newImportJobForTest(t)
+		job.LeaseID = "lease-1"
+
+		importJobRepository.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(&job, nil)
+		importJobRepository.EXPECT().GetFile(gomock.Any(), job.JobID).
+			Return(&domain.ImportFile{JobID: job.JobID, Content: []byte("header")}, nil)
+		importJobRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		importJobRepository.EXPECT().Heartbeat(gomock.Any(), job.JobID, "lease-1").
+			Return(domain.NewConflictError("import job is no longer leased to this worker", nil))
+		processor.EXPECT().Process(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ domain.ImportJob, _ io.Reader, _ domain.ImportProgressFunc) (domain.ImportResult, error) {
+				<-ctx.Done()
+				return domain.ImportResult{}, ctx.Err()
+			},
+		)
+
+		found, err := service.ProcessNext(context.Background())
+
+		assert.True(t, found)
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusConflict, customErr.StatusCode())
+	})
+
+	t.Run("gives up on a job interrupted too many times", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job := newImportJobForTest(t)
+		job.Attempts = domain.MaxImportJobAttempts
+
+		mocks.importJobRepository.EXPECT().ClaimNext(gomock.Any(), gomock.Any(), gomock.Any()).Return(&job, nil)
+		mocks.importJobRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, job domain.ImportJob) error {
+				assert.Equal(t, domain.IMPORT_JOB_FAILED, job.Status)
+				return nil
+			},
+		)
+
+		found, err := service.ProcessNext(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, found)
+	})
+}
+
+func TestImportJobService_GetErrorReport(t *testing.T) {
+	t.Run("lists the failed rows with their errors", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job := newImportJobForTest(t)
+		job.Complete(domain.ImportResult{RowErrors: []domain.ImportRowError{
+			{Row: 3, Column: "Estado", Value: "ZZ", Message: "unknown state acronym"},
+			{Row: 3, Column: "Sinistro", Message: "required value is empty"},
+		}})
+
+		mocks.importJobRepository.EXPECT().GetByID(gomock.Any(), job.JobID).Return(&job, nil)
+		mocks.importJobRepository.EXPECT().GetFile(gomock.Any(), job.JobID).Return(&domain.ImportFile{
+			JobID:    job.JobID,
+			FileName: "cases.csv",
+			Content:  []byte("Sinistro;Estado\nS-1;SP\n;ZZ\n"),
+		}, nil)
+
+		report, fileName, err := service.GetErrorReport(context.Background(), job.JobID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "cases_erros.csv", fileName)
+		assert.Equal(t, "Sinistro;Estado;Linha;Erros\n;ZZ;3;Estado: unknown state acronym (ZZ) | Sinistro: required value is empty\n", string(report))
+	})
+
+	t.Run("imports the fixed report as the original file", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		customerService, customerMocks := newBatchCustomerServiceForTest(t)
+		job, err := domain.NewImportJob(domain.IMPORT_JOB_CUSTOMERS, "clientes.csv", nil, "operator-1")
+		require.NoError(t, err)
+		job.Complete(domain.ImportResult{RowErrors: []domain.ImportRowError{
+			{Row: 2, Column: "UF", Value: "ZZ", Message: "unknown state"},
+		}})
+
+		mocks.importJobRepository.EXPECT().GetByID(gomock.Any(), job.JobID).Return(&job, nil)
+		mocks.importJobRepository.EXPECT().GetFile(gomock.Any(), job.JobID).Return(&domain.ImportFile{
+			JobID:    job.JobID,
+			FileName: "clientes.csv",
+			Content:  []byte("Nome;CPF/CNPJ;UF\nMaria;529.982.247-25;ZZ\n"),
+		}, nil)
+
+		report, fileName, err := service.GetErrorReport(context.Background(), job.JobID)
+		require.NoError(t, err)
+
+		fixed := strings.Replace(string(report), ";ZZ;", ";SP;", 1)
+		reupload, err := domain.NewImportJob(domain.IMPORT_JOB_CUSTOMERS, fileName, nil, "operator-1")
+		require.NoError(t, err)
+
+		customerMocks.customerRepository.EXPECT().GetByDocuments(gomock.Any(), []string{"52998224725"}).Return([]domain.Customer{}, nil)
+		customerMocks.customerRepository.EXPECT().CreateBatch(gomock.Any(), gomock.Len(1)).Return([]string{"customer-1"}, nil)
+
+		result, err := customerService.Process(context.Background(), reupload, strings.NewReader(fixed), func(int, int) {})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"customer-1"}, result.CreatedIDs)
+		assert.Empty(t, result.RowErrors)
+	})
+
+	t.Run("points report batches to the manifest of their file", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job, _, err := domain.NewReportBatchJob(domain.ReportBatch{Filters: domain.CaseFilters{CaseID: []string{"case-1"}}}, "operator-1")
+		require.NoError(t, err)
+		job.Complete(domain.ImportResult{RowErrors: []domain.ImportRowError{
+			{Row: 1, Column: "case_id", Value: "case-1", Message: "case has no report"},
+		}})
+
+		mocks.importJobRepository.EXPECT().GetByID(gomock.Any(), job.JobID).Return(&job, nil)
+
+		_, _, err = service.GetErrorReport(context.Background(), job.JobID)
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+		assert.Contains(t, customErr.Error(), "manifest")
+	})
+
+	t.Run("returns not found when no row failed", func(t *testing.T) {
+		service, mocks := newImportJobServiceForTest(t)
+		job := newImportJobForTest(t)
+
+		mocks.importJobRepository.EXPECT().GetByID(gomock.Any(), job.JobID).Return(&job, nil)
+
+		_, _, err := service.GetErrorReport(context.Background(), job.JobID)
+
+		require.Error(t, err)
+	})
+}
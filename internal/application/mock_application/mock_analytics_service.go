@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: analytics_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=analytics_service.go -destination=mock_application/mock_analytics_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsService is a mock of AnalyticsService interface.
+type MockAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsServiceMockRecorder is the mock recorder for MockAnalyticsService.
+type MockAnalyticsServiceMockRecorder struct {
+	mock *MockAnalyticsService
+}
+
+// NewMockAnalyticsService creates a new mock instance.
+func NewMockAnalyticsService(ctrl *gomock.Controller) *MockAnalyticsService {
+	mock := &MockAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsService) EXPECT() *MockAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCaseStatusDurations mocks base method.
+func (m *MockAnalyticsService) GetCaseStatusDurations(ctx context.Context, caseID string) (domain.CaseStatusDurations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaseStatusDurations", ctx, caseID)
+	ret0, _ := ret[0].(domain.CaseStatusDurations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaseStatusDurations indicates an expected call of GetCaseStatusDurations.
+func (mr *MockAnalyticsServiceMockRecorder) GetCaseStatusDurations(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaseStatusDurations", reflect.TypeOf((*MockAnalyticsService)(nil).GetCaseStatusDurations), ctx, caseID)
+}
+
+// GetStatusDurations mocks base method.
+func (m *MockAnalyticsService) GetStatusDurations(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) (domain.StatusDurationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusDurations", ctx, filters, groupBy)
+	ret0, _ := ret[0].(domain.StatusDurationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusDurations indicates an expected call of GetStatusDurations.
+func (mr *MockAnalyticsServiceMockRecorder) GetStatusDurations(ctx, filters, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusDurations", reflect.TypeOf((*MockAnalyticsService)(nil).GetStatusDurations), ctx, filters, groupBy)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backlog_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=backlog_service.go -destination=mock_application/mock_backlog_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBacklogService is a mock of BacklogService interface.
+type MockBacklogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBacklogServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBacklogServiceMockRecorder is the mock recorder for MockBacklogService.
+type MockBacklogServiceMockRecorder struct {
+	mock *MockBacklogService
+}
+
+// NewMockBacklogService creates a new mock instance.
+func NewMockBacklogService(ctrl *gomock.Controller) *MockBacklogService {
+	mock := &MockBacklogService{ctrl: ctrl}
+	mock.recorder = &MockBacklogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBacklogService) EXPECT() *MockBacklogServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAging mocks base method.
+func (m *MockBacklogService) GetAging(ctx context.Context, filters domain.CaseFilters, withCaseIDs bool) (domain.BacklogAging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAging", ctx, filters, withCaseIDs)
+	ret0, _ := ret[0].(domain.BacklogAging)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAging indicates an expected call of GetAging.
+func (mr *MockBacklogServiceMockRecorder) GetAging(ctx, filters, withCaseIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAging", reflect.TypeOf((*MockBacklogService)(nil).GetAging), ctx, filters, withCaseIDs)
+}
+
+// GetSnapshots mocks base method.
+func (m *MockBacklogService) GetSnapshots(ctx context.Context, filters domain.BacklogSnapshotFilters) ([]domain.BacklogSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshots", ctx, filters)
+	ret0, _ := ret[0].([]domain.BacklogSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshots indicates an expected call of GetSnapshots.
+func (mr *MockBacklogServiceMockRecorder) GetSnapshots(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshots", reflect.TypeOf((*MockBacklogService)(nil).GetSnapshots), ctx, filters)
+}
+
+// Run mocks base method.
+func (m *MockBacklogService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockBacklogServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockBacklogService)(nil).Run), ctx)
+}
+
+// TakeSnapshot mocks base method.
+func (m *MockBacklogService) TakeSnapshot(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSnapshot", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeSnapshot indicates an expected call of TakeSnapshot.
+func (mr *MockBacklogServiceMockRecorder) TakeSnapshot(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSnapshot", reflect.TypeOf((*MockBacklogService)(nil).TakeSnapshot), ctx)
+}
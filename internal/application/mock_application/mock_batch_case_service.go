@@ -14,6 +14,7 @@ import (
 	io "io"
 	reflect "reflect"
 
+	domain "github.com/icrxz/crm-api-core/internal/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,17 +42,32 @@ func (m *MockBatchCaseService) EXPECT() *MockBatchCaseServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateBatch mocks base method.
-func (m *MockBatchCaseService) CreateBatch(ctx context.Context, file io.Reader, fileName, createdBy, company string) ([]string, error) {
+// Preview mocks base method.
+func (m *MockBatchCaseService) Preview(ctx context.Context, job domain.ImportJob, file io.Reader) (domain.ImportPreview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBatch", ctx, file, fileName, createdBy, company)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "Preview", ctx, job, file)
+	ret0, _ := ret[0].(domain.ImportPreview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateBatch indicates an expected call of CreateBatch.
-func (mr *MockBatchCaseServiceMockRecorder) CreateBatch(ctx, file, fileName, createdBy, company any) *gomock.Call {
+// Preview indicates an expected call of Preview.
+func (mr *MockBatchCaseServiceMockRecorder) Preview(ctx, job, file any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockBatchCaseService)(nil).CreateBatch), ctx, file, fileName, createdBy, company)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockBatchCaseService)(nil).Preview), ctx, job, file)
+}
+
+// Process mocks base method.
+func (m *MockBatchCaseService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, job, file, progress)
+	ret0, _ := ret[0].(domain.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockBatchCaseServiceMockRecorder) Process(ctx, job, file, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockBatchCaseService)(nil).Process), ctx, job, file, progress)
 }
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: batch_customer_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=batch_customer_service.go -destination=mock_application/mock_batch_customer_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBatchCustomerService is a mock of BatchCustomerService interface.
+type MockBatchCustomerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchCustomerServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchCustomerServiceMockRecorder is the mock recorder for MockBatchCustomerService.
+type MockBatchCustomerServiceMockRecorder struct {
+	mock *MockBatchCustomerService
+}
+
+// NewMockBatchCustomerService creates a new mock instance.
+func NewMockBatchCustomerService(ctrl *gomock.Controller) *MockBatchCustomerService {
+	mock := &MockBatchCustomerService{ctrl: ctrl}
+	mock.recorder = &MockBatchCustomerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchCustomerService) EXPECT() *MockBatchCustomerServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockBatchCustomerService) Export(ctx context.Context, filters domain.CustomerFilters, format domain.ExportFormat, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filters, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBatchCustomerServiceMockRecorder) Export(ctx, filters, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBatchCustomerService)(nil).Export), ctx, filters, format, w)
+}
+
+// Process mocks base method.
+func (m *MockBatchCustomerService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, job, file, progress)
+	ret0, _ := ret[0].(domain.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockBatchCustomerServiceMockRecorder) Process(ctx, job, file, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockBatchCustomerService)(nil).Process), ctx, job, file, progress)
+}
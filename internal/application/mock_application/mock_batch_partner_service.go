@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: batch_partner_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=batch_partner_service.go -destination=mock_application/mock_batch_partner_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBatchPartnerService is a mock of BatchPartnerService interface.
+type MockBatchPartnerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchPartnerServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchPartnerServiceMockRecorder is the mock recorder for MockBatchPartnerService.
+type MockBatchPartnerServiceMockRecorder struct {
+	mock *MockBatchPartnerService
+}
+
+// NewMockBatchPartnerService creates a new mock instance.
+func NewMockBatchPartnerService(ctrl *gomock.Controller) *MockBatchPartnerService {
+	mock := &MockBatchPartnerService{ctrl: ctrl}
+	mock.recorder = &MockBatchPartnerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchPartnerService) EXPECT() *MockBatchPartnerServiceMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockBatchPartnerService) Preview(ctx context.Context, job domain.ImportJob, file io.Reader) (domain.PartnerImportPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", ctx, job, file)
+	ret0, _ := ret[0].(domain.PartnerImportPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockBatchPartnerServiceMockRecorder) Preview(ctx, job, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockBatchPartnerService)(nil).Preview), ctx, job, file)
+}
+
+// Process mocks base method.
+func (m *MockBatchPartnerService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, job, file, progress)
+	ret0, _ := ret[0].(domain.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockBatchPartnerServiceMockRecorder) Process(ctx, job, file, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockBatchPartnerService)(nil).Process), ctx, job, file, progress)
+}
@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: batch_report_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=batch_report_service.go -destination=mock_application/mock_batch_report_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBatchReportService is a mock of BatchReportService interface.
+type MockBatchReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchReportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchReportServiceMockRecorder is the mock recorder for MockBatchReportService.
+type MockBatchReportServiceMockRecorder struct {
+	mock *MockBatchReportService
+}
+
+// NewMockBatchReportService creates a new mock instance.
+func NewMockBatchReportService(ctrl *gomock.Controller) *MockBatchReportService {
+	mock := &MockBatchReportService{ctrl: ctrl}
+	mock.recorder = &MockBatchReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchReportService) EXPECT() *MockBatchReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Download mocks base method.
+func (m *MockBatchReportService) Download(ctx context.Context, job domain.ImportJob) (io.ReadCloser, int64, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", ctx, job)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockBatchReportServiceMockRecorder) Download(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockBatchReportService)(nil).Download), ctx, job)
+}
+
+// Process mocks base method.
+func (m *MockBatchReportService) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, job, file, progress)
+	ret0, _ := ret[0].(domain.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockBatchReportServiceMockRecorder) Process(ctx, job, file, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockBatchReportService)(nil).Process), ctx, job, file, progress)
+}
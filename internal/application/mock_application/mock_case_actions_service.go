@@ -84,9 +84,9 @@ func (mr *MockCaseActionServiceMockRecorder) ChangeStatus(ctx, caseID, newStatus
 }
 
 // GenerateReport mocks base method.
-func (m *MockCaseActionService) GenerateReport(ctx context.Context, caseID string) ([]byte, string, error) {
+func (m *MockCaseActionService) GenerateReport(ctx context.Context, caseID string, format domain.ReportFormat, author string) ([]byte, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateReport", ctx, caseID)
+	ret := m.ctrl.Call(m, "GenerateReport", ctx, caseID, format, author)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -94,9 +94,9 @@ func (m *MockCaseActionService) GenerateReport(ctx context.Context, caseID strin
 }
 
 // GenerateReport indicates an expected call of GenerateReport.
-func (mr *MockCaseActionServiceMockRecorder) GenerateReport(ctx, caseID any) *gomock.Call {
+func (mr *MockCaseActionServiceMockRecorder) GenerateReport(ctx, caseID, format, author any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateReport", reflect.TypeOf((*MockCaseActionService)(nil).GenerateReport), ctx, caseID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateReport", reflect.TypeOf((*MockCaseActionService)(nil).GenerateReport), ctx, caseID, format, author)
 }
 
 // ResetCaseStatus mocks base method.
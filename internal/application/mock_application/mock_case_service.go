@@ -101,6 +101,21 @@ func (mr *MockCaseServiceMockRecorder) GetCaseHistory(ctx, caseID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaseHistory", reflect.TypeOf((*MockCaseService)(nil).GetCaseHistory), ctx, caseID)
 }
 
+// GetCaseStats mocks base method.
+func (m *MockCaseService) GetCaseStats(ctx context.Context, filters domain.CaseFilters, groupBy []domain.CaseStatsDimension) (domain.CaseStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaseStats", ctx, filters, groupBy)
+	ret0, _ := ret[0].(domain.CaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaseStats indicates an expected call of GetCaseStats.
+func (mr *MockCaseServiceMockRecorder) GetCaseStats(ctx, filters, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaseStats", reflect.TypeOf((*MockCaseService)(nil).GetCaseStats), ctx, filters, groupBy)
+}
+
 // SearchCases mocks base method.
 func (m *MockCaseService) SearchCases(ctx context.Context, filters domain.CaseFilters) (domain.PagingResult[domain.Case], error) {
 	m.ctrl.T.Helper()
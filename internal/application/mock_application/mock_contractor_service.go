@@ -85,6 +85,21 @@ func (mr *MockContractorServiceMockRecorder) GetByID(ctx, contractorID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockContractorService)(nil).GetByID), ctx, contractorID)
 }
 
+// GetImportMapping mocks base method.
+func (m *MockContractorService) GetImportMapping(ctx context.Context, contractorID string) (*domain.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportMapping", ctx, contractorID)
+	ret0, _ := ret[0].(*domain.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportMapping indicates an expected call of GetImportMapping.
+func (mr *MockContractorServiceMockRecorder) GetImportMapping(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportMapping", reflect.TypeOf((*MockContractorService)(nil).GetImportMapping), ctx, contractorID)
+}
+
 // Search mocks base method.
 func (m *MockContractorService) Search(ctx context.Context, filters domain.ContractorFilters) (domain.PagingResult[domain.Contractor], error) {
 	m.ctrl.T.Helper()
@@ -113,3 +128,17 @@ func (mr *MockContractorServiceMockRecorder) Update(ctx, contractorID, contracto
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockContractorService)(nil).Update), ctx, contractorID, contractor)
 }
+
+// UpdateImportMapping mocks base method.
+func (m *MockContractorService) UpdateImportMapping(ctx context.Context, contractorID string, mapping *domain.ImportMapping, author string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImportMapping", ctx, contractorID, mapping, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImportMapping indicates an expected call of UpdateImportMapping.
+func (mr *MockContractorServiceMockRecorder) UpdateImportMapping(ctx, contractorID, mapping, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImportMapping", reflect.TypeOf((*MockContractorService)(nil).UpdateImportMapping), ctx, contractorID, mapping, author)
+}
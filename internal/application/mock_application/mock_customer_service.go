@@ -56,6 +56,21 @@ func (mr *MockCustomerServiceMockRecorder) Create(ctx, customer any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerService)(nil).Create), ctx, customer)
 }
 
+// CreateBatch mocks base method.
+func (m *MockCustomerService) CreateBatch(ctx context.Context, customers []domain.Customer) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, customers)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockCustomerServiceMockRecorder) CreateBatch(ctx, customers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockCustomerService)(nil).CreateBatch), ctx, customers)
+}
+
 // Delete mocks base method.
 func (m *MockCustomerService) Delete(ctx context.Context, customerID string) error {
 	m.ctrl.T.Helper()
@@ -70,6 +85,21 @@ func (mr *MockCustomerServiceMockRecorder) Delete(ctx, customerID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerService)(nil).Delete), ctx, customerID)
 }
 
+// GetByDocuments mocks base method.
+func (m *MockCustomerService) GetByDocuments(ctx context.Context, documents []string) ([]domain.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDocuments", ctx, documents)
+	ret0, _ := ret[0].([]domain.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDocuments indicates an expected call of GetByDocuments.
+func (mr *MockCustomerServiceMockRecorder) GetByDocuments(ctx, documents any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDocuments", reflect.TypeOf((*MockCustomerService)(nil).GetByDocuments), ctx, documents)
+}
+
 // GetByID mocks base method.
 func (m *MockCustomerService) GetByID(ctx context.Context, customerID string) (*domain.Customer, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fraud_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fraud_service.go -destination=mock_application/mock_fraud_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFraudService is a mock of FraudService interface.
+type MockFraudService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudServiceMockRecorder is the mock recorder for MockFraudService.
+type MockFraudServiceMockRecorder struct {
+	mock *MockFraudService
+}
+
+// NewMockFraudService creates a new mock instance.
+func NewMockFraudService(ctrl *gomock.Controller) *MockFraudService {
+	mock := &MockFraudService{ctrl: ctrl}
+	mock.recorder = &MockFraudServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudService) EXPECT() *MockFraudServiceMockRecorder {
+	return m.recorder
+}
+
+// Assess mocks base method.
+func (m *MockFraudService) Assess(ctx context.Context, crmCase domain.Case) (*domain.FraudAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assess", ctx, crmCase)
+	ret0, _ := ret[0].(*domain.FraudAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assess indicates an expected call of Assess.
+func (mr *MockFraudServiceMockRecorder) Assess(ctx, crmCase any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assess", reflect.TypeOf((*MockFraudService)(nil).Assess), ctx, crmCase)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockFraudService) GetByCaseID(ctx context.Context, caseID string) (*domain.FraudAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].(*domain.FraudAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockFraudServiceMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockFraudService)(nil).GetByCaseID), ctx, caseID)
+}
+
+// Review mocks base method.
+func (m *MockFraudService) Review(ctx context.Context, caseID string, review domain.FraudReview) (*domain.FraudAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, caseID, review)
+	ret0, _ := ret[0].(*domain.FraudAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockFraudServiceMockRecorder) Review(ctx, caseID, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockFraudService)(nil).Review), ctx, caseID, review)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import_job_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=import_job_service.go -destination=mock_application/mock_import_job_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportProcessor is a mock of ImportProcessor interface.
+type MockImportProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportProcessorMockRecorder
+	isgomock struct{}
+}
+
+// MockImportProcessorMockRecorder is the mock recorder for MockImportProcessor.
+type MockImportProcessorMockRecorder struct {
+	mock *MockImportProcessor
+}
+
+// NewMockImportProcessor creates a new mock instance.
+func NewMockImportProcessor(ctrl *gomock.Controller) *MockImportProcessor {
+	mock := &MockImportProcessor{ctrl: ctrl}
+	mock.recorder = &MockImportProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportProcessor) EXPECT() *MockImportProcessorMockRecorder {
+	return m.recorder
+}
+
+// Process mocks base method.
+func (m *MockImportProcessor) Process(ctx context.Context, job domain.ImportJob, file io.Reader, progress domain.ImportProgressFunc) (domain.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, job, file, progress)
+	ret0, _ := ret[0].(domain.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockImportProcessorMockRecorder) Process(ctx, job, file, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockImportProcessor)(nil).Process), ctx, job, file, progress)
+}
+
+// MockImportJobService is a mock of ImportJobService interface.
+type MockImportJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockImportJobServiceMockRecorder is the mock recorder for MockImportJobService.
+type MockImportJobServiceMockRecorder struct {
+	mock *MockImportJobService
+}
+
+// NewMockImportJobService creates a new mock instance.
+func NewMockImportJobService(ctrl *gomock.Controller) *MockImportJobService {
+	mock := &MockImportJobService{ctrl: ctrl}
+	mock.recorder = &MockImportJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobService) EXPECT() *MockImportJobServiceMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockImportJobService) Enqueue(ctx context.Context, job domain.ImportJob, file io.Reader) (*domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, job, file)
+	ret0, _ := ret[0].(*domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockImportJobServiceMockRecorder) Enqueue(ctx, job, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockImportJobService)(nil).Enqueue), ctx, job, file)
+}
+
+// GetByID mocks base method.
+func (m *MockImportJobService) GetByID(ctx context.Context, jobID string) (*domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, jobID)
+	ret0, _ := ret[0].(*domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImportJobServiceMockRecorder) GetByID(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImportJobService)(nil).GetByID), ctx, jobID)
+}
+
+// GetErrorReport mocks base method.
+func (m *MockImportJobService) GetErrorReport(ctx context.Context, jobID string) ([]byte, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErrorReport", ctx, jobID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetErrorReport indicates an expected call of GetErrorReport.
+func (mr *MockImportJobServiceMockRecorder) GetErrorReport(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorReport", reflect.TypeOf((*MockImportJobService)(nil).GetErrorReport), ctx, jobID)
+}
+
+// ProcessNext mocks base method.
+func (m *MockImportJobService) ProcessNext(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessNext", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessNext indicates an expected call of ProcessNext.
+func (mr *MockImportJobServiceMockRecorder) ProcessNext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessNext", reflect.TypeOf((*MockImportJobService)(nil).ProcessNext), ctx)
+}
+
+// Run mocks base method.
+func (m *MockImportJobService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockImportJobServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockImportJobService)(nil).Run), ctx)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: operational_report_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=operational_report_service.go -destination=mock_application/mock_operational_report_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationalReportService is a mock of OperationalReportService interface.
+type MockOperationalReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationalReportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationalReportServiceMockRecorder is the mock recorder for MockOperationalReportService.
+type MockOperationalReportServiceMockRecorder struct {
+	mock *MockOperationalReportService
+}
+
+// NewMockOperationalReportService creates a new mock instance.
+func NewMockOperationalReportService(ctrl *gomock.Controller) *MockOperationalReportService {
+	mock := &MockOperationalReportService{ctrl: ctrl}
+	mock.recorder = &MockOperationalReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationalReportService) EXPECT() *MockOperationalReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockOperationalReportService) Export(ctx context.Context, name domain.OperationalReportName, filters domain.OperationalReportFilters, format domain.ExportFormat, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, name, filters, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOperationalReportServiceMockRecorder) Export(ctx, name, filters, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOperationalReportService)(nil).Export), ctx, name, filters, format, w)
+}
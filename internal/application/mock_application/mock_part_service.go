@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: part_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=part_service.go -destination=mock_application/mock_part_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPartService is a mock of PartService interface.
+type MockPartService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPartServiceMockRecorder is the mock recorder for MockPartService.
+type MockPartServiceMockRecorder struct {
+	mock *MockPartService
+}
+
+// NewMockPartService creates a new mock instance.
+func NewMockPartService(ctrl *gomock.Controller) *MockPartService {
+	mock := &MockPartService{ctrl: ctrl}
+	mock.recorder = &MockPartServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartService) EXPECT() *MockPartServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPartService) Create(ctx context.Context, part domain.Part) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, part)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPartServiceMockRecorder) Create(ctx, part any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPartService)(nil).Create), ctx, part)
+}
+
+// GetByID mocks base method.
+func (m *MockPartService) GetByID(ctx context.Context, partID string) (*domain.Part, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, partID)
+	ret0, _ := ret[0].(*domain.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPartServiceMockRecorder) GetByID(ctx, partID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPartService)(nil).GetByID), ctx, partID)
+}
+
+// GetCaseParts mocks base method.
+func (m *MockPartService) GetCaseParts(ctx context.Context, caseID string) ([]domain.CasePartUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaseParts", ctx, caseID)
+	ret0, _ := ret[0].([]domain.CasePartUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaseParts indicates an expected call of GetCaseParts.
+func (mr *MockPartServiceMockRecorder) GetCaseParts(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaseParts", reflect.TypeOf((*MockPartService)(nil).GetCaseParts), ctx, caseID)
+}
+
+// MoveCasePart mocks base method.
+func (m *MockPartService) MoveCasePart(ctx context.Context, movement domain.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCasePart", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCasePart indicates an expected call of MoveCasePart.
+func (mr *MockPartServiceMockRecorder) MoveCasePart(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCasePart", reflect.TypeOf((*MockPartService)(nil).MoveCasePart), ctx, movement)
+}
+
+// Restock mocks base method.
+func (m *MockPartService) Restock(ctx context.Context, partID string, quantity int, author string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restock", ctx, partID, quantity, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restock indicates an expected call of Restock.
+func (mr *MockPartServiceMockRecorder) Restock(ctx, partID, quantity, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restock", reflect.TypeOf((*MockPartService)(nil).Restock), ctx, partID, quantity, author)
+}
+
+// Search mocks base method.
+func (m *MockPartService) Search(ctx context.Context, filters domain.PartFilters) (domain.PagingResult[domain.Part], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filters)
+	ret0, _ := ret[0].(domain.PagingResult[domain.Part])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPartServiceMockRecorder) Search(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPartService)(nil).Search), ctx, filters)
+}
+
+// Update mocks base method.
+func (m *MockPartService) Update(ctx context.Context, partID string, update domain.UpdatePart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, partID, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPartServiceMockRecorder) Update(ctx, partID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPartService)(nil).Update), ctx, partID, update)
+}
@@ -11,7 +11,6 @@ package mock_application
 
 import (
 	context "context"
-	io "io"
 	reflect "reflect"
 
 	domain "github.com/icrxz/crm-api-core/internal/domain"
@@ -57,21 +56,6 @@ func (mr *MockPartnerServiceMockRecorder) Create(ctx, partner any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPartnerService)(nil).Create), ctx, partner)
 }
 
-// CreateBatch mocks base method.
-func (m *MockPartnerService) CreateBatch(ctx context.Context, file io.Reader, createdBy string) ([]string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBatch", ctx, file, createdBy)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// CreateBatch indicates an expected call of CreateBatch.
-func (mr *MockPartnerServiceMockRecorder) CreateBatch(ctx, file, createdBy any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockPartnerService)(nil).CreateBatch), ctx, file, createdBy)
-}
-
 // Delete mocks base method.
 func (m *MockPartnerService) Delete(ctx context.Context, partnerID string) error {
 	m.ctrl.T.Helper()
@@ -41,6 +41,21 @@ func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateBatch mocks base method.
+func (m *MockProductService) CreateBatch(ctx context.Context, products []domain.Product) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, products)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockProductServiceMockRecorder) CreateBatch(ctx, products any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockProductService)(nil).CreateBatch), ctx, products)
+}
+
 // CreateProduct mocks base method.
 func (m *MockProductService) CreateProduct(ctx context.Context, product domain.Product) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quote_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=quote_service.go -destination=mock_application/mock_quote_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteService is a mock of QuoteService interface.
+type MockQuoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteServiceMockRecorder is the mock recorder for MockQuoteService.
+type MockQuoteServiceMockRecorder struct {
+	mock *MockQuoteService
+}
+
+// NewMockQuoteService creates a new mock instance.
+func NewMockQuoteService(ctrl *gomock.Controller) *MockQuoteService {
+	mock := &MockQuoteService{ctrl: ctrl}
+	mock.recorder = &MockQuoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteService) EXPECT() *MockQuoteServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockQuoteService) Approve(ctx context.Context, quoteID string, review domain.ReviewQuote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, quoteID, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockQuoteServiceMockRecorder) Approve(ctx, quoteID, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockQuoteService)(nil).Approve), ctx, quoteID, review)
+}
+
+// Create mocks base method.
+func (m *MockQuoteService) Create(ctx context.Context, quote domain.Quote) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, quote)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuoteServiceMockRecorder) Create(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuoteService)(nil).Create), ctx, quote)
+}
+
+// EnsureApproved mocks base method.
+func (m *MockQuoteService) EnsureApproved(ctx context.Context, caseID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureApproved", ctx, caseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureApproved indicates an expected call of EnsureApproved.
+func (mr *MockQuoteServiceMockRecorder) EnsureApproved(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureApproved", reflect.TypeOf((*MockQuoteService)(nil).EnsureApproved), ctx, caseID)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockQuoteService) GetByCaseID(ctx context.Context, caseID string) ([]domain.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].([]domain.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockQuoteServiceMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockQuoteService)(nil).GetByCaseID), ctx, caseID)
+}
+
+// GetByID mocks base method.
+func (m *MockQuoteService) GetByID(ctx context.Context, quoteID string) (*domain.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, quoteID)
+	ret0, _ := ret[0].(*domain.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockQuoteServiceMockRecorder) GetByID(ctx, quoteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockQuoteService)(nil).GetByID), ctx, quoteID)
+}
+
+// Reject mocks base method.
+func (m *MockQuoteService) Reject(ctx context.Context, quoteID string, review domain.ReviewQuote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, quoteID, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockQuoteServiceMockRecorder) Reject(ctx, quoteID, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockQuoteService)(nil).Reject), ctx, quoteID, review)
+}
@@ -41,10 +41,26 @@ func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
 	return m.recorder
 }
 
+// DownloadCaseReport mocks base method.
+func (m *MockReportService) DownloadCaseReport(ctx context.Context, caseID, caseReportID string) ([]byte, *domain.CaseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadCaseReport", ctx, caseID, caseReportID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*domain.CaseReport)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadCaseReport indicates an expected call of DownloadCaseReport.
+func (mr *MockReportServiceMockRecorder) DownloadCaseReport(ctx, caseID, caseReportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCaseReport", reflect.TypeOf((*MockReportService)(nil).DownloadCaseReport), ctx, caseID, caseReportID)
+}
+
 // GenerateReport mocks base method.
-func (m *MockReportService) GenerateReport(ctx context.Context, crmCase domain.Case) ([]byte, string, error) {
+func (m *MockReportService) GenerateReport(ctx context.Context, crmCase domain.Case, format domain.ReportFormat, author string) ([]byte, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateReport", ctx, crmCase)
+	ret := m.ctrl.Call(m, "GenerateReport", ctx, crmCase, format, author)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -52,7 +68,22 @@ func (m *MockReportService) GenerateReport(ctx context.Context, crmCase domain.C
 }
 
 // GenerateReport indicates an expected call of GenerateReport.
-func (mr *MockReportServiceMockRecorder) GenerateReport(ctx, crmCase any) *gomock.Call {
+func (mr *MockReportServiceMockRecorder) GenerateReport(ctx, crmCase, format, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateReport", reflect.TypeOf((*MockReportService)(nil).GenerateReport), ctx, crmCase, format, author)
+}
+
+// GetCaseReports mocks base method.
+func (m *MockReportService) GetCaseReports(ctx context.Context, caseID string) ([]domain.CaseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaseReports", ctx, caseID)
+	ret0, _ := ret[0].([]domain.CaseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaseReports indicates an expected call of GetCaseReports.
+func (mr *MockReportServiceMockRecorder) GetCaseReports(ctx, caseID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateReport", reflect.TypeOf((*MockReportService)(nil).GenerateReport), ctx, crmCase)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaseReports", reflect.TypeOf((*MockReportService)(nil).GetCaseReports), ctx, caseID)
 }
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_template_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=report_template_service.go -destination=mock_application/mock_report_template_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	io "io"
+	fs "io/fs"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportTemplateService is a mock of ReportTemplateService interface.
+type MockReportTemplateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportTemplateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReportTemplateServiceMockRecorder is the mock recorder for MockReportTemplateService.
+type MockReportTemplateServiceMockRecorder struct {
+	mock *MockReportTemplateService
+}
+
+// NewMockReportTemplateService creates a new mock instance.
+func NewMockReportTemplateService(ctrl *gomock.Controller) *MockReportTemplateService {
+	mock := &MockReportTemplateService{ctrl: ctrl}
+	mock.recorder = &MockReportTemplateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportTemplateService) EXPECT() *MockReportTemplateServiceMockRecorder {
+	return m.recorder
+}
+
+// Activate mocks base method.
+func (m *MockReportTemplateService) Activate(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", ctx, contractorID, reportID, author)
+	ret0, _ := ret[0].(*domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockReportTemplateServiceMockRecorder) Activate(ctx, contractorID, reportID, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockReportTemplateService)(nil).Activate), ctx, contractorID, reportID, author)
+}
+
+// Download mocks base method.
+func (m *MockReportTemplateService) Download(ctx context.Context, contractorID, reportID string) ([]byte, *domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", ctx, contractorID, reportID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*domain.Report)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockReportTemplateServiceMockRecorder) Download(ctx, contractorID, reportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockReportTemplateService)(nil).Download), ctx, contractorID, reportID)
+}
+
+// GetActive mocks base method.
+func (m *MockReportTemplateService) GetActive(ctx context.Context, contractorID string) (*domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, contractorID)
+	ret0, _ := ret[0].(*domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockReportTemplateServiceMockRecorder) GetActive(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockReportTemplateService)(nil).GetActive), ctx, contractorID)
+}
+
+// GetByContractor mocks base method.
+func (m *MockReportTemplateService) GetByContractor(ctx context.Context, contractorID string) ([]domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByContractor", ctx, contractorID)
+	ret0, _ := ret[0].([]domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByContractor indicates an expected call of GetByContractor.
+func (mr *MockReportTemplateServiceMockRecorder) GetByContractor(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByContractor", reflect.TypeOf((*MockReportTemplateService)(nil).GetByContractor), ctx, contractorID)
+}
+
+// ImportShipped mocks base method.
+func (m *MockReportTemplateService) ImportShipped(ctx context.Context, templates fs.FS) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportShipped", ctx, templates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportShipped indicates an expected call of ImportShipped.
+func (mr *MockReportTemplateServiceMockRecorder) ImportShipped(ctx, templates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportShipped", reflect.TypeOf((*MockReportTemplateService)(nil).ImportShipped), ctx, templates)
+}
+
+// Retire mocks base method.
+func (m *MockReportTemplateService) Retire(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retire", ctx, contractorID, reportID, author)
+	ret0, _ := ret[0].(*domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retire indicates an expected call of Retire.
+func (mr *MockReportTemplateServiceMockRecorder) Retire(ctx, contractorID, reportID, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retire", reflect.TypeOf((*MockReportTemplateService)(nil).Retire), ctx, contractorID, reportID, author)
+}
+
+// Upload mocks base method.
+func (m *MockReportTemplateService) Upload(ctx context.Context, report domain.Report, file io.Reader) (*domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, report, file)
+	ret0, _ := ret[0].(*domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockReportTemplateServiceMockRecorder) Upload(ctx, report, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockReportTemplateService)(nil).Upload), ctx, report, file)
+}
+
+// Validate mocks base method.
+func (m *MockReportTemplateService) Validate(ctx context.Context, contractorID, reportID string) (*domain.ReportValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, contractorID, reportID)
+	ret0, _ := ret[0].(*domain.ReportValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockReportTemplateServiceMockRecorder) Validate(ctx, contractorID, reportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockReportTemplateService)(nil).Validate), ctx, contractorID, reportID)
+}
+
+// ValidateFile mocks base method.
+func (m *MockReportTemplateService) ValidateFile(ctx context.Context, contractorID, fileName string, file io.Reader) (*domain.ReportValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateFile", ctx, contractorID, fileName, file)
+	ret0, _ := ret[0].(*domain.ReportValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateFile indicates an expected call of ValidateFile.
+func (mr *MockReportTemplateServiceMockRecorder) ValidateFile(ctx, contractorID, fileName, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateFile", reflect.TypeOf((*MockReportTemplateService)(nil).ValidateFile), ctx, contractorID, fileName, file)
+}
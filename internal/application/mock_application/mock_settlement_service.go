@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settlement_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=settlement_service.go -destination=mock_application/mock_settlement_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSettlementService is a mock of SettlementService interface.
+type MockSettlementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSettlementServiceMockRecorder is the mock recorder for MockSettlementService.
+type MockSettlementServiceMockRecorder struct {
+	mock *MockSettlementService
+}
+
+// NewMockSettlementService creates a new mock instance.
+func NewMockSettlementService(ctrl *gomock.Controller) *MockSettlementService {
+	mock := &MockSettlementService{ctrl: ctrl}
+	mock.recorder = &MockSettlementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementService) EXPECT() *MockSettlementServiceMockRecorder {
+	return m.recorder
+}
+
+// Decide mocks base method.
+func (m *MockSettlementService) Decide(ctx context.Context, caseID string, input domain.DecideSettlement) (*domain.SettlementDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, caseID, input)
+	ret0, _ := ret[0].(*domain.SettlementDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockSettlementServiceMockRecorder) Decide(ctx, caseID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockSettlementService)(nil).Decide), ctx, caseID, input)
+}
+
+// GetDecision mocks base method.
+func (m *MockSettlementService) GetDecision(ctx context.Context, caseID string) (*domain.SettlementDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecision", ctx, caseID)
+	ret0, _ := ret[0].(*domain.SettlementDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecision indicates an expected call of GetDecision.
+func (mr *MockSettlementServiceMockRecorder) GetDecision(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecision", reflect.TypeOf((*MockSettlementService)(nil).GetDecision), ctx, caseID)
+}
+
+// GetPolicy mocks base method.
+func (m *MockSettlementService) GetPolicy(ctx context.Context, contractorID string) (*domain.SettlementPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, contractorID)
+	ret0, _ := ret[0].(*domain.SettlementPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockSettlementServiceMockRecorder) GetPolicy(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockSettlementService)(nil).GetPolicy), ctx, contractorID)
+}
+
+// SavePolicy mocks base method.
+func (m *MockSettlementService) SavePolicy(ctx context.Context, policy domain.SettlementPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePolicy indicates an expected call of SavePolicy.
+func (mr *MockSettlementServiceMockRecorder) SavePolicy(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePolicy", reflect.TypeOf((*MockSettlementService)(nil).SavePolicy), ctx, policy)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shipment_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=shipment_service.go -destination=mock_application/mock_shipment_service.go -package=mock_application
+//
+
+// Package mock_application is a generated GoMock package.
+package mock_application
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShipmentService is a mock of ShipmentService interface.
+type MockShipmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockShipmentServiceMockRecorder is the mock recorder for MockShipmentService.
+type MockShipmentServiceMockRecorder struct {
+	mock *MockShipmentService
+}
+
+// NewMockShipmentService creates a new mock instance.
+func NewMockShipmentService(ctrl *gomock.Controller) *MockShipmentService {
+	mock := &MockShipmentService{ctrl: ctrl}
+	mock.recorder = &MockShipmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentService) EXPECT() *MockShipmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentService) Create(ctx context.Context, input domain.CreateShipment) (*domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentServiceMockRecorder) Create(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentService)(nil).Create), ctx, input)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockShipmentService) GetByCaseID(ctx context.Context, caseID string) ([]domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].([]domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockShipmentServiceMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockShipmentService)(nil).GetByCaseID), ctx, caseID)
+}
+
+// GetByID mocks base method.
+func (m *MockShipmentService) GetByID(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, shipmentID)
+	ret0, _ := ret[0].(*domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockShipmentServiceMockRecorder) GetByID(ctx, shipmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockShipmentService)(nil).GetByID), ctx, shipmentID)
+}
+
+// Refresh mocks base method.
+func (m *MockShipmentService) Refresh(ctx context.Context, shipmentID, author string) (*domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, shipmentID, author)
+	ret0, _ := ret[0].(*domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockShipmentServiceMockRecorder) Refresh(ctx, shipmentID, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockShipmentService)(nil).Refresh), ctx, shipmentID, author)
+}
+
+// RegisterEvent mocks base method.
+func (m *MockShipmentService) RegisterEvent(ctx context.Context, shipmentID string, event domain.ShipmentEvent, author string) (*domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterEvent", ctx, shipmentID, event, author)
+	ret0, _ := ret[0].(*domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterEvent indicates an expected call of RegisterEvent.
+func (mr *MockShipmentServiceMockRecorder) RegisterEvent(ctx, shipmentID, event, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEvent", reflect.TypeOf((*MockShipmentService)(nil).RegisterEvent), ctx, shipmentID, event, author)
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const operationalMonthLayout = "01/2006"
+
+type operationalReportService struct {
+	operationalReportRepository domain.OperationalReportRepository
+}
+
+//go:generate mockgen -source=operational_report_service.go -destination=mock_application/mock_operational_report_service.go -package=mock_application
+type OperationalReportService interface {
+	Export(ctx context.Context, name domain.OperationalReportName, filters domain.OperationalReportFilters, format domain.ExportFormat, w io.Writer) error
+}
+
+func NewOperationalReportService(operationalReportRepository domain.OperationalReportRepository) OperationalReportService {
+	return &operationalReportService{
+		operationalReportRepository: operationalReportRepository,
+	}
+}
+
+// operationalSheet is an operational report laid out as a spreadsheet.
+type operationalSheet struct {
+	name   string
+	header []string
+	rows   [][]string
+}
+
+// Export writes the operational report of the given name as a spreadsheet.
+// The report is fully queried before anything is written, so a failing query
+// can still be answered with an error.
+func (s *operationalReportService) Export(ctx context.Context, name domain.OperationalReportName, filters domain.OperationalReportFilters, format domain.ExportFormat, w io.Writer) error {
+	sheet, err := s.buildSheet(ctx, name, filters)
+	if err != nil {
+		return err
+	}
+
+	table, err := newTableWriter(format, w, sheet.name)
+	if err != nil {
+		return err
+	}
+
+	if err := table.WriteRow(sheet.header); err != nil {
+		return err
+	}
+
+	for _, row := range sheet.rows {
+		if err := table.WriteRow(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Close()
+}
+
+func (s *operationalReportService) buildSheet(ctx context.Context, name domain.OperationalReportName, filters domain.OperationalReportFilters) (operationalSheet, error) {
+	switch name {
+	case domain.OPERATIONAL_CASES_BY_STATUS:
+		counts, err := s.operationalReportRepository.CountCasesByStatus(ctx, filters)
+		if err != nil {
+			return operationalSheet{}, err
+		}
+
+		sheet := operationalSheet{
+			name:   "Casos por status",
+			header: []string{"Cliente", "Região", "Operador", "Status", "Casos"},
+			rows:   make([][]string, 0, len(counts)),
+		}
+		for _, count := range counts {
+			sheet.rows = append(sheet.rows, []string{
+				count.ContractorName,
+				strconv.Itoa(count.Region),
+				count.OwnerName,
+				string(count.Status),
+				strconv.Itoa(count.Cases),
+			})
+		}
+
+		return sheet, nil
+
+	case domain.OPERATIONAL_CASE_AGING:
+		counts, err := s.operationalReportRepository.CountOpenCasesByAge(ctx, filters)
+		if err != nil {
+			return operationalSheet{}, err
+		}
+
+		sheet := operationalSheet{
+			name:   "Envelhecimento",
+			header: []string{"Cliente", "Região", "Status", "Até 7 dias", "8 a 15 dias", "16 a 30 dias", "31 a 60 dias", "Mais de 60 dias", "Total"},
+			rows:   make([][]string, 0, len(counts)),
+		}
+		for _, count := range counts {
+			total := count.UpTo7Days + count.UpTo15Days + count.UpTo30Days + count.UpTo60Days + count.Over60Days
+			sheet.rows = append(sheet.rows, []string{
+				count.ContractorName,
+				strconv.Itoa(count.Region),
+				string(count.Status),
+				strconv.Itoa(count.UpTo7Days),
+				strconv.Itoa(count.UpTo15Days),
+				strconv.Itoa(count.UpTo30Days),
+				strconv.Itoa(count.UpTo60Days),
+				strconv.Itoa(count.Over60Days),
+				strconv.Itoa(total),
+			})
+		}
+
+		return sheet, nil
+
+	case domain.OPERATIONAL_CLOSED_PER_MONTH:
+		counts, err := s.operationalReportRepository.CountClosedCasesByMonth(ctx, filters)
+		if err != nil {
+			return operationalSheet{}, err
+		}
+
+		sheet := operationalSheet{
+			name:   "Fechados por mês",
+			header: []string{"Mês", "Cliente", "Região", "Operador", "Casos fechados", "Média de dias em aberto"},
+			rows:   make([][]string, 0, len(counts)),
+		}
+		for _, count := range counts {
+			sheet.rows = append(sheet.rows, []string{
+				count.Month.Format(operationalMonthLayout),
+				count.ContractorName,
+				strconv.Itoa(count.Region),
+				count.OwnerName,
+				strconv.Itoa(count.Cases),
+				strconv.FormatFloat(count.AverageOpenDays, 'f', 1, 64),
+			})
+		}
+
+		return sheet, nil
+
+	case domain.OPERATIONAL_PARTNER_PAYOUTS:
+		payouts, err := s.operationalReportRepository.SumPartnerPayouts(ctx, filters)
+		if err != nil {
+			return operationalSheet{}, err
+		}
+
+		sheet := operationalSheet{
+			name:   "Pagamentos a técnicos",
+			header: []string{"Técnico", "Documento", "Chave de pagamento", "Casos", "Transações", "Total"},
+			rows:   make([][]string, 0, len(payouts)),
+		}
+		for _, payout := range payouts {
+			sheet.rows = append(sheet.rows, []string{
+				payout.PartnerName,
+				payout.Document,
+				payout.PaymentKey,
+				strconv.Itoa(payout.Cases),
+				strconv.Itoa(payout.Transactions),
+				strconv.FormatFloat(payout.Total, 'f', 2, 64),
+			})
+		}
+
+		return sheet, nil
+
+	default:
+		_, err := domain.ParseOperationalReportName(string(name))
+		return operationalSheet{}, err
+	}
+}
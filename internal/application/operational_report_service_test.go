@@ -0,0 +1,105 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newOperationalReportServiceForTest(t *testing.T) (OperationalReportService, *mock_domain.MockOperationalReportRepository) {
+	t.Helper()
+
+	operationalReportRepository := mock_domain.NewMockOperationalReportRepository(gomock.NewController(t))
+
+	return NewOperationalReportService(operationalReportRepository), operationalReportRepository
+}
+
+func TestOperationalReportService_Export(t *testing.T) {
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filters := domain.OperationalReportFilters{ContractorID: []string{"contractor-1"}, StartDate: &startDate}
+
+	t.Run("sums the aging buckets of each row", func(t *testing.T) {
+		service, operationalReportRepository := newOperationalReportServiceForTest(t)
+
+		operationalReportRepository.EXPECT().CountOpenCasesByAge(gomock.Any(), filters).Return([]domain.CaseAgingCount{
+			{ContractorName: "Assurant", Region: 11, Status: domain.CaseStatus("Report"), UpTo7Days: 3, UpTo15Days: 2, UpTo30Days: 1, Over60Days: 4},
+		}, nil)
+
+		var buffer bytes.Buffer
+		err := service.Export(context.Background(), domain.OPERATIONAL_CASE_AGING, filters, domain.EXPORT_CSV, &buffer)
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "Cliente;Região;Status;Até 7 dias;8 a 15 dias;16 a 30 dias;31 a 60 dias;Mais de 60 dias;Total", lines[0])
+		assert.Equal(t, "Assurant;11;Report;3;2;1;0;4;10", lines[1])
+	})
+
+	t.Run("formats months and payouts", func(t *testing.T) {
+		service, operationalReportRepository := newOperationalReportServiceForTest(t)
+
+		operationalReportRepository.EXPECT().CountClosedCasesByMonth(gomock.Any(), filters).Return([]domain.ClosedCasesCount{
+			{Month: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), ContractorName: "Assurant", Region: 11, OwnerName: "Ana Souza", Cases: 7, AverageOpenDays: 12.345},
+		}, nil)
+		operationalReportRepository.EXPECT().SumPartnerPayouts(gomock.Any(), filters).Return([]domain.PartnerPayout{
+			{PartnerID: "partner-1", PartnerName: "João", Document: "52998224725", PaymentKey: "joao@example.com", Cases: 2, Transactions: 3, Total: 450.5},
+		}, nil)
+
+		var closed bytes.Buffer
+		require.NoError(t, service.Export(context.Background(), domain.OPERATIONAL_CLOSED_PER_MONTH, filters, domain.EXPORT_CSV, &closed))
+		assert.Contains(t, closed.String(), "03/2025;Assurant;11;Ana Souza;7;12.3\n")
+
+		var payouts bytes.Buffer
+		require.NoError(t, service.Export(context.Background(), domain.OPERATIONAL_PARTNER_PAYOUTS, filters, domain.EXPORT_CSV, &payouts))
+		assert.Contains(t, payouts.String(), "João;52998224725;joao@example.com;2;3;450.50\n")
+	})
+
+	t.Run("writes a workbook", func(t *testing.T) {
+		service, operationalReportRepository := newOperationalReportServiceForTest(t)
+
+		operationalReportRepository.EXPECT().CountCasesByStatus(gomock.Any(), filters).Return([]domain.CaseStatusCount{
+			{ContractorName: "Assurant", Region: 11, OwnerName: "Ana Souza", Status: domain.CaseStatus("Report"), Cases: 5},
+		}, nil)
+
+		var buffer bytes.Buffer
+		err := service.Export(context.Background(), domain.OPERATIONAL_CASES_BY_STATUS, filters, domain.EXPORT_XLSX, &buffer)
+		require.NoError(t, err)
+
+		sheet, err := readSpreadsheet("casos.xlsx", &buffer, spreadsheetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Cliente", "Região", "Operador", "Status", "Casos"}, sheet.header())
+		require.Len(t, sheet.dataRows(), 1)
+		assert.Equal(t, []string{"Assurant", "11", "Ana Souza", "Report", "5"}, sheet.dataRows()[0])
+	})
+
+	t.Run("writes nothing when the query fails", func(t *testing.T) {
+		service, operationalReportRepository := newOperationalReportServiceForTest(t)
+
+		operationalReportRepository.EXPECT().SumPartnerPayouts(gomock.Any(), filters).Return(nil, assert.AnError)
+
+		var buffer bytes.Buffer
+		err := service.Export(context.Background(), domain.OPERATIONAL_PARTNER_PAYOUTS, filters, domain.EXPORT_XLSX, &buffer)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Zero(t, buffer.Len())
+	})
+
+	t.Run("rejects unknown reports", func(t *testing.T) {
+		service, _ := newOperationalReportServiceForTest(t)
+
+		err := service.Export(context.Background(), domain.OperationalReportName("revenue"), filters, domain.EXPORT_CSV, &bytes.Buffer{})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusNotFound, customErr.StatusCode())
+	})
+}
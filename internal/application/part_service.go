@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"slices"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type partService struct {
+	partRepository        domain.PartRepository
+	caseRepository        domain.CaseRepository
+	transactionRepository domain.TransactionRepository
+	transactionManager    domain.TransactionManager
+}
+
+//go:generate mockgen -source=part_service.go -destination=mock_application/mock_part_service.go -package=mock_application
+type PartService interface {
+	Create(ctx context.Context, part domain.Part) (string, error)
+	GetByID(ctx context.Context, partID string) (*domain.Part, error)
+	Update(ctx context.Context, partID string, update domain.UpdatePart) error
+	Search(ctx context.Context, filters domain.PartFilters) (domain.PagingResult[domain.Part], error)
+	Restock(ctx context.Context, partID string, quantity int, author string) error
+	MoveCasePart(ctx context.Context, movement domain.StockMovement) error
+	GetCaseParts(ctx context.Context, caseID string) ([]domain.CasePartUsage, error)
+}
+
+func NewPartService(
+	partRepository domain.PartRepository,
+	caseRepository domain.CaseRepository,
+	transactionRepository domain.TransactionRepository,
+	transactionManager domain.TransactionManager,
+) PartService {
+	return &partService{
+		partRepository:        partRepository,
+		caseRepository:        caseRepository,
+		transactionRepository: transactionRepository,
+		transactionManager:    transactionManager,
+	}
+}
+
+func (s *partService) Create(ctx context.Context, part domain.Part) (string, error) {
+	return s.partRepository.Create(ctx, part)
+}
+
+func (s *partService) GetByID(ctx context.Context, partID string) (*domain.Part, error) {
+	if partID == "" {
+		return nil, domain.NewValidationError("partID cannot be empty", nil)
+	}
+
+	return s.partRepository.GetByID(ctx, partID)
+}
+
+func (s *partService) Update(ctx context.Context, partID string, update domain.UpdatePart) error {
+	part, err := s.GetByID(ctx, partID)
+	if err != nil {
+		return err
+	}
+
+	part.MergeUpdate(update)
+
+	return s.partRepository.Update(ctx, *part)
+}
+
+func (s *partService) Search(ctx context.Context, filters domain.PartFilters) (domain.PagingResult[domain.Part], error) {
+	return s.partRepository.Search(ctx, filters)
+}
+
+func (s *partService) Restock(ctx context.Context, partID string, quantity int, author string) error {
+	movement, err := domain.NewStockMovement(partID, "", "", domain.STOCK_RESTOCK, quantity, 0, author)
+	if err != nil {
+		return err
+	}
+
+	return s.applyMovement(ctx, movement)
+}
+
+func (s *partService) MoveCasePart(ctx context.Context, movement domain.StockMovement) error {
+	crmCase, err := s.caseRepository.GetByID(ctx, movement.CaseID)
+	if err != nil {
+		return err
+	}
+
+	if movement.Type == domain.STOCK_RESERVE &&
+		slices.Contains([]domain.CaseStatus{domain.CLOSED, domain.CANCELED, domain.REJECTED}, crmCase.Status) {
+		return domain.NewValidationError("cannot reserve parts for a finished case", map[string]any{"status": crmCase.Status})
+	}
+	movement.ProductID = crmCase.ProductID
+
+	return s.applyMovement(ctx, movement)
+}
+
+// applyMovement locks the part row so concurrent movements cannot oversell
+// the stock or consume the same reservation twice.
+func (s *partService) applyMovement(ctx context.Context, movement domain.StockMovement) error {
+	return s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		part, err := s.partRepository.LockByID(txCtx, movement.PartID)
+		if err != nil {
+			return err
+		}
+
+		caseReserved := 0
+		if movement.CaseID != "" {
+			caseMovements, err := s.partRepository.GetMovementsByCaseID(txCtx, movement.CaseID)
+			if err != nil {
+				return err
+			}
+			caseReserved = domain.OutstandingReservation(caseMovements, movement.PartID)
+		}
+
+		movement.UnitCost = part.UnitCost
+		if err := part.Apply(movement, caseReserved); err != nil {
+			return err
+		}
+
+		if movement.Type == domain.STOCK_CONSUME {
+			transaction, err := movement.ToTransaction(part.Name)
+			if err != nil {
+				return err
+			}
+
+			transactionID, err := s.transactionRepository.CreateTransaction(txCtx, transaction)
+			if err != nil {
+				return err
+			}
+			movement.TransactionID = transactionID
+		}
+
+		if err := s.partRepository.Update(txCtx, *part); err != nil {
+			return err
+		}
+
+		return s.partRepository.CreateMovement(txCtx, movement)
+	})
+}
+
+func (s *partService) GetCaseParts(ctx context.Context, caseID string) ([]domain.CasePartUsage, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	movements, err := s.partRepository.GetMovementsByCaseID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(movements) == 0 {
+		return []domain.CasePartUsage{}, nil
+	}
+
+	partIDs := make([]string, 0)
+	for _, movement := range movements {
+		if !slices.Contains(partIDs, movement.PartID) {
+			partIDs = append(partIDs, movement.PartID)
+		}
+	}
+
+	foundParts, err := s.partRepository.Search(ctx, domain.PartFilters{
+		PartID:       partIDs,
+		PagingFilter: domain.PagingFilter{Limit: len(partIDs)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make(map[string]domain.Part, len(foundParts.Result))
+	for _, part := range foundParts.Result {
+		parts[part.PartID] = part
+	}
+
+	return domain.SummarizeCaseParts(movements, parts), nil
+}
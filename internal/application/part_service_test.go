@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type partServiceMocks struct {
+	partRepository        *mock_domain.MockPartRepository
+	caseRepository        *mock_domain.MockCaseRepository
+	transactionRepository *mock_domain.MockTransactionRepository
+	transactionManager    *mock_domain.MockTransactionManager
+}
+
+func newPartServiceForTest(t *testing.T) (PartService, *partServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &partServiceMocks{
+		partRepository:        mock_domain.NewMockPartRepository(ctrl),
+		caseRepository:        mock_domain.NewMockCaseRepository(ctrl),
+		transactionRepository: mock_domain.NewMockTransactionRepository(ctrl),
+		transactionManager:    mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewPartService(
+		mocks.partRepository,
+		mocks.caseRepository,
+		mocks.transactionRepository,
+		mocks.transactionManager,
+	)
+
+	return service, mocks
+}
+
+func TestPartService_MoveCasePart(t *testing.T) {
+	t.Run("consuming reserved parts creates an outgoing transaction", func(t *testing.T) {
+		service, mocks := newPartServiceForTest(t)
+		movement, err := domain.NewStockMovement("part-1", "case-1", "", domain.STOCK_CONSUME, 2, 0, "operator-1")
+		require.NoError(t, err)
+
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+			Return(&domain.Case{CaseID: "case-1", ProductID: "product-1", Status: domain.ONGOING}, nil)
+		mocks.partRepository.EXPECT().LockByID(gomock.Any(), "part-1").
+			Return(&domain.Part{PartID: "part-1", Name: "display", UnitCost: 100, StockQuantity: 4, ReservedQuantity: 2, Active: true}, nil)
+		mocks.partRepository.EXPECT().GetMovementsByCaseID(gomock.Any(), "case-1").
+			Return([]domain.StockMovement{{PartID: "part-1", Type: domain.STOCK_RESERVE, Quantity: 2}}, nil)
+		mocks.transactionRepository.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, transaction domain.Transaction) (string, error) {
+				assert.Equal(t, domain.OUTGOING, transaction.Type)
+				assert.InDelta(t, 200, transaction.Value, 0.001)
+				return "transaction-1", nil
+			},
+		)
+		mocks.partRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, part domain.Part) error {
+				assert.Equal(t, 2, part.StockQuantity)
+				assert.Equal(t, 0, part.ReservedQuantity)
+				return nil
+			},
+		)
+		mocks.partRepository.EXPECT().CreateMovement(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, created domain.StockMovement) error {
+				assert.Equal(t, "transaction-1", created.TransactionID)
+				assert.Equal(t, "product-1", created.ProductID)
+				return nil
+			},
+		)
+
+		require.NoError(t, service.MoveCasePart(context.Background(), movement))
+	})
+
+	t.Run("refuses to consume parts that were not reserved for the case", func(t *testing.T) {
+		service, mocks := newPartServiceForTest(t)
+		movement, err := domain.NewStockMovement("part-1", "case-1", "", domain.STOCK_CONSUME, 1, 0, "operator-1")
+		require.NoError(t, err)
+
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+			Return(&domain.Case{CaseID: "case-1", Status: domain.ONGOING}, nil)
+		mocks.partRepository.EXPECT().LockByID(gomock.Any(), "part-1").
+			Return(&domain.Part{PartID: "part-1", StockQuantity: 4, ReservedQuantity: 2, Active: true}, nil)
+		mocks.partRepository.EXPECT().GetMovementsByCaseID(gomock.Any(), "case-1").Return(nil, nil)
+
+		require.Error(t, service.MoveCasePart(context.Background(), movement))
+	})
+
+	t.Run("refuses to reserve parts for a closed case", func(t *testing.T) {
+		service, mocks := newPartServiceForTest(t)
+		movement, err := domain.NewStockMovement("part-1", "case-1", "", domain.STOCK_RESERVE, 1, 0, "operator-1")
+		require.NoError(t, err)
+
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+			Return(&domain.Case{CaseID: "case-1", Status: domain.CLOSED}, nil)
+
+		require.Error(t, service.MoveCasePart(context.Background(), movement))
+	})
+}
@@ -2,8 +2,6 @@ package application
 
 import (
 	"context"
-	"io"
-	"strings"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
@@ -19,7 +17,6 @@ type PartnerService interface {
 	Update(ctx context.Context, partnerID string, editPartner domain.EditPartner) error
 	Delete(ctx context.Context, partnerID string) error
 	Search(ctx context.Context, filters domain.PartnerFilters) (domain.PagingResult[domain.Partner], error)
-	CreateBatch(ctx context.Context, file io.Reader, createdBy string) ([]string, error)
 }
 
 func NewPartnerService(partnerRepository domain.PartnerRepository) PartnerService {
@@ -66,76 +63,3 @@ func (s *partnerService) Delete(ctx context.Context, partnerID string) error {
 func (s *partnerService) Search(ctx context.Context, filters domain.PartnerFilters) (domain.PagingResult[domain.Partner], error) {
 	return s.partnerRepository.Search(ctx, filters)
 }
-
-func (s *partnerService) CreateBatch(ctx context.Context, file io.Reader, createdBy string) ([]string, error) {
-	partnersRows, err := readCSV(file)
-	if err != nil {
-		return nil, err
-	}
-
-	columnsIndex := getColumnHeadersIndex(partnersRows[0])
-	partners, err := s.buildPartner(partnersRows[1:], columnsIndex, createdBy)
-	if err != nil {
-		return nil, err
-	}
-
-	return s.partnerRepository.CreateBatch(ctx, partners)
-}
-
-func (s *partnerService) buildPartner(csvRows [][]string, columnsIndex map[string]int, author string) ([]domain.Partner, error) {
-	partners := make([]domain.Partner, 0, len(csvRows))
-
-	for _, row := range csvRows {
-		phone := row[columnsIndex["Telefone"]]
-		if strings.TrimSpace(phone) != "" {
-			phone = "+55 " + phone
-		}
-
-		personalContact := domain.Contact{
-			PhoneNumber: phone,
-		}
-
-		shippingAddress := domain.Address{
-			City:    row[columnsIndex["Cidade"]],
-			State:   row[columnsIndex["Estado"]],
-			Country: "brazil",
-		}
-
-		document := row[columnsIndex["Documento"]]
-		document = strings.ReplaceAll(document, ".", "")
-		document = strings.ReplaceAll(document, "-", "")
-		document = strings.ReplaceAll(document, "/", "")
-
-		documentType := ""
-		if len(document) == 11 {
-			documentType = "CPF"
-		} else if len(document) == 14 {
-			documentType = "CNPJ"
-		}
-
-		description := row[columnsIndex["Observacoes"]]
-
-		newPartner, err := domain.NewPartner(
-			row[columnsIndex["Nome"]],
-			row[columnsIndex["Sobrenome"]],
-			"",
-			"",
-			document,
-			documentType,
-			author,
-			personalContact,
-			domain.Contact{},
-			shippingAddress,
-			domain.Address{},
-			description,
-			row[columnsIndex["Tipo"]],
-			domain.Billing{},
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		partners = append(partners, newPartner)
-	}
-	return partners, nil
-}
@@ -13,6 +13,7 @@ type productService struct {
 //go:generate mockgen -source=product_service.go -destination=mock_application/mock_product_service.go -package=mock_application
 type ProductService interface {
 	CreateProduct(ctx context.Context, product domain.Product) (string, error)
+	CreateBatch(ctx context.Context, products []domain.Product) ([]string, error)
 	GetProductByID(ctx context.Context, productID string) (*domain.Product, error)
 	UpdateProduct(ctx context.Context, productID string, updateProduct domain.UpdateProduct) error
 }
@@ -27,6 +28,10 @@ func (s *productService) CreateProduct(ctx context.Context, product domain.Produ
 	return s.productRepository.CreateProduct(ctx, product)
 }
 
+func (s *productService) CreateBatch(ctx context.Context, products []domain.Product) ([]string, error) {
+	return s.productRepository.CreateBatch(ctx, products)
+}
+
 func (s *productService) GetProductByID(ctx context.Context, productID string) (*domain.Product, error) {
 	if productID == "" {
 		return nil, domain.NewValidationError("productID is required", nil)
@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"slices"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type quoteService struct {
+	quoteRepository       domain.QuoteRepository
+	caseRepository        domain.CaseRepository
+	caseHistoryRepository domain.CaseHistoryRepository
+	transactionRepository domain.TransactionRepository
+	userRepository        domain.UserRepository
+	transactionManager    domain.TransactionManager
+}
+
+//go:generate mockgen -source=quote_service.go -destination=mock_application/mock_quote_service.go -package=mock_application
+type QuoteService interface {
+	Create(ctx context.Context, quote domain.Quote) (string, error)
+	GetByID(ctx context.Context, quoteID string) (*domain.Quote, error)
+	GetByCaseID(ctx context.Context, caseID string) ([]domain.Quote, error)
+	Approve(ctx context.Context, quoteID string, review domain.ReviewQuote) error
+	Reject(ctx context.Context, quoteID string, review domain.ReviewQuote) error
+	EnsureApproved(ctx context.Context, caseID string) error
+}
+
+func NewQuoteService(
+	quoteRepository domain.QuoteRepository,
+	caseRepository domain.CaseRepository,
+	caseHistoryRepository domain.CaseHistoryRepository,
+	transactionRepository domain.TransactionRepository,
+	userRepository domain.UserRepository,
+	transactionManager domain.TransactionManager,
+) QuoteService {
+	return &quoteService{
+		quoteRepository:       quoteRepository,
+		caseRepository:        caseRepository,
+		caseHistoryRepository: caseHistoryRepository,
+		transactionRepository: transactionRepository,
+		userRepository:        userRepository,
+		transactionManager:    transactionManager,
+	}
+}
+
+func (s *quoteService) Create(ctx context.Context, quote domain.Quote) (string, error) {
+	crmCase, err := s.caseRepository.GetByID(ctx, quote.CaseID)
+	if err != nil {
+		return "", err
+	}
+
+	if slices.Contains([]domain.CaseStatus{domain.CLOSED, domain.CANCELED, domain.REJECTED}, crmCase.Status) {
+		return "", domain.NewValidationError("cannot submit a quote for a finished case", map[string]any{"status": crmCase.Status})
+	}
+
+	var quoteID string
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		createdID, err := s.quoteRepository.Create(txCtx, quote)
+		if err != nil {
+			return err
+		}
+		quoteID = createdID
+
+		return s.recordHistory(txCtx, quote.CaseID, domain.QuoteSubmittedEvent, quote.CreatedBy, map[string]any{}, quote.Snapshot())
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return quoteID, nil
+}
+
+func (s *quoteService) GetByID(ctx context.Context, quoteID string) (*domain.Quote, error) {
+	if quoteID == "" {
+		return nil, domain.NewValidationError("quoteID cannot be empty", nil)
+	}
+
+	return s.quoteRepository.GetByID(ctx, quoteID)
+}
+
+func (s *quoteService) GetByCaseID(ctx context.Context, caseID string) ([]domain.Quote, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	return s.quoteRepository.GetByCaseID(ctx, caseID)
+}
+
+func (s *quoteService) Approve(ctx context.Context, quoteID string, review domain.ReviewQuote) error {
+	quote, err := s.GetByID(ctx, quoteID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkReviewer(ctx, *quote, review.ReviewedBy); err != nil {
+		return err
+	}
+
+	oldValues := quote.Snapshot()
+	if err := quote.Approve(review.ReviewedBy); err != nil {
+		return err
+	}
+
+	transactions, err := quote.ToTransactions(review.ReviewedBy)
+	if err != nil {
+		return err
+	}
+
+	return s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.quoteRepository.Update(txCtx, *quote); err != nil {
+			return err
+		}
+
+		if _, err := s.transactionRepository.CreateTransactionBatch(txCtx, transactions); err != nil {
+			return err
+		}
+
+		return s.recordHistory(txCtx, quote.CaseID, domain.QuoteApprovedEvent, review.ReviewedBy, oldValues, quote.Snapshot())
+	})
+}
+
+func (s *quoteService) Reject(ctx context.Context, quoteID string, review domain.ReviewQuote) error {
+	quote, err := s.GetByID(ctx, quoteID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkReviewer(ctx, *quote, review.ReviewedBy); err != nil {
+		return err
+	}
+
+	oldValues := quote.Snapshot()
+	if err := quote.Reject(review.Reason, review.ReviewedBy); err != nil {
+		return err
+	}
+
+	newValues := quote.Snapshot()
+	newValues["rejection_reason"] = quote.RejectionReason
+
+	return s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.quoteRepository.Update(txCtx, *quote); err != nil {
+			return err
+		}
+
+		return s.recordHistory(txCtx, quote.CaseID, domain.QuoteRejectedEvent, review.ReviewedBy, oldValues, newValues)
+	})
+}
+
+func (s *quoteService) EnsureApproved(ctx context.Context, caseID string) error {
+	quotes, err := s.GetByCaseID(ctx, caseID)
+	if err != nil {
+		return err
+	}
+
+	return domain.EnsureQuoteApproved(quotes)
+}
+
+// checkReviewer refuses reviews from users that cannot decide on the quotes
+// of the case, see domain.CanReviewQuote.
+func (s *quoteService) checkReviewer(ctx context.Context, quote domain.Quote, reviewerID string) error {
+	if reviewerID == "" {
+		return domain.NewUnauthorizedError("quote reviewer is not authenticated")
+	}
+
+	reviewer, err := s.userRepository.GetByID(ctx, reviewerID)
+	if err != nil {
+		return err
+	}
+
+	crmCase, err := s.caseRepository.GetByID(ctx, quote.CaseID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.CanReviewQuote(*reviewer, *crmCase) {
+		return domain.NewUnauthorizedError("user cannot review the quotes of this case")
+	}
+
+	return nil
+}
+
+func (s *quoteService) recordHistory(ctx context.Context, caseID, eventName, author string, oldValues, newValues map[string]any) error {
+	history, err := domain.NewCaseHistory(caseID, eventName, author, oldValues, newValues)
+	if err != nil {
+		return err
+	}
+
+	return s.caseHistoryRepository.Create(ctx, history)
+}
@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type quoteServiceMocks struct {
+	quoteRepository       *mock_domain.MockQuoteRepository
+	caseRepository        *mock_domain.MockCaseRepository
+	caseHistoryRepository *mock_domain.MockCaseHistoryRepository
+	transactionRepository *mock_domain.MockTransactionRepository
+	userRepository        *mock_domain.MockUserRepository
+	transactionManager    *mock_domain.MockTransactionManager
+}
+
+func newQuoteServiceForTest(t *testing.T) (QuoteService, *quoteServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &quoteServiceMocks{
+		quoteRepository:       mock_domain.NewMockQuoteRepository(ctrl),
+		caseRepository:        mock_domain.NewMockCaseRepository(ctrl),
+		caseHistoryRepository: mock_domain.NewMockCaseHistoryRepository(ctrl),
+		transactionRepository: mock_domain.NewMockTransactionRepository(ctrl),
+		userRepository:        mock_domain.NewMockUserRepository(ctrl),
+		transactionManager:    mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewQuoteService(
+		mocks.quoteRepository,
+		mocks.caseRepository,
+		mocks.caseHistoryRepository,
+		mocks.transactionRepository,
+		mocks.userRepository,
+		mocks.transactionManager,
+	)
+
+	return service, mocks
+}
+
+func newPendingQuote(t *testing.T) domain.Quote {
+	t.Helper()
+
+	quote, err := domain.NewQuote("case-1", domain.QUOTE_FROM_PARTNER, "", []domain.QuoteItem{
+		{Type: domain.QUOTE_ITEM_PARTS, Description: "display", Quantity: 1, UnitValue: 350},
+	}, "partner-1")
+	require.NoError(t, err)
+
+	return quote
+}
+
+func TestQuoteService_Create(t *testing.T) {
+	t.Run("refuses quotes for closed cases", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+			Return(&domain.Case{CaseID: "case-1", Status: domain.CLOSED}, nil)
+
+		_, err := service.Create(context.Background(), newPendingQuote(t))
+
+		require.Error(t, err)
+	})
+
+	t.Run("persists the quote and records the submission in history", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+		quote := newPendingQuote(t)
+
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+			Return(&domain.Case{CaseID: "case-1", Status: domain.WAITING_PARTNER}, nil)
+		mocks.quoteRepository.EXPECT().Create(gomock.Any(), quote).Return(quote.QuoteID, nil)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.QuoteSubmittedEvent, history.EventName)
+				assert.Equal(t, "case-1", history.CaseID)
+				return nil
+			},
+		)
+
+		quoteID, err := service.Create(context.Background(), quote)
+
+		require.NoError(t, err)
+		assert.Equal(t, quote.QuoteID, quoteID)
+	})
+}
+
+func TestQuoteService_Approve(t *testing.T) {
+	owner := domain.User{UserID: "operator-1", Role: domain.OPERATOR, Active: true}
+	crmCase := domain.Case{CaseID: "case-1", OwnerID: "operator-1"}
+
+	t.Run("approves the quote and creates pending transactions", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+		quote := newPendingQuote(t)
+
+		mocks.quoteRepository.EXPECT().GetByID(gomock.Any(), quote.QuoteID).Return(&quote, nil)
+		mocks.userRepository.EXPECT().GetByID(gomock.Any(), "operator-1").Return(&owner, nil)
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").Return(&crmCase, nil)
+		mocks.quoteRepository.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, updated domain.Quote) error {
+				assert.Equal(t, domain.QUOTE_APPROVED, updated.Status)
+				assert.Equal(t, "operator-1", updated.ReviewedBy)
+				return nil
+			},
+		)
+		mocks.transactionRepository.EXPECT().CreateTransactionBatch(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, transactions []domain.Transaction) ([]string, error) {
+				require.Len(t, transactions, 1)
+				assert.Equal(t, domain.TRANSACTION_PENDING, transactions[0].Status)
+				assert.InDelta(t, 350, transactions[0].Value, 0.001)
+				return []string{transactions[0].TransactionID}, nil
+			},
+		)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := service.Approve(context.Background(), quote.QuoteID, domain.ReviewQuote{ReviewedBy: "operator-1"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("books the repair cost once when two reviewers approve at once", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+		firstRead, secondRead := newPendingQuote(t), newPendingQuote(t)
+		secondRead.QuoteID = firstRead.QuoteID
+
+		gomock.InOrder(
+			mocks.quoteRepository.EXPECT().GetByID(gomock.Any(), firstRead.QuoteID).Return(&firstRead, nil),
+			mocks.quoteRepository.EXPECT().GetByID(gomock.Any(), firstRead.QuoteID).Return(&secondRead, nil),
+		)
+		mocks.userRepository.EXPECT().GetByID(gomock.Any(), "operator-1").Return(&owner, nil).Times(2)
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").Return(&crmCase, nil).Times(2)
+		gomock.InOrder(
+			mocks.quoteRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+			mocks.quoteRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+				Return(domain.NewConflictError("quote was already reviewed", nil)),
+		)
+		mocks.transactionRepository.EXPECT().CreateTransactionBatch(gomock.Any(), gomock.Any()).Return([]string{"transaction-1"}, nil).Times(1)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		firstErr := service.Approve(context.Background(), firstRead.QuoteID, domain.ReviewQuote{ReviewedBy: "operator-1"})
+		secondErr := service.Approve(context.Background(), firstRead.QuoteID, domain.ReviewQuote{ReviewedBy: "operator-1"})
+
+		require.NoError(t, firstErr)
+		var customErr *domain.CustomError
+		require.ErrorAs(t, secondErr, &customErr)
+		assert.Equal(t, http.StatusConflict, customErr.StatusCode())
+	})
+
+	t.Run("refuses reviewers that do not own the case", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+		quote := newPendingQuote(t)
+
+		mocks.quoteRepository.EXPECT().GetByID(gomock.Any(), quote.QuoteID).Return(&quote, nil)
+		mocks.userRepository.EXPECT().GetByID(gomock.Any(), "operator-2").
+			Return(&domain.User{UserID: "operator-2", Role: domain.OPERATOR, Active: true}, nil)
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").Return(&crmCase, nil)
+
+		err := service.Approve(context.Background(), quote.QuoteID, domain.ReviewQuote{ReviewedBy: "operator-2"})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusUnauthorized, customErr.StatusCode())
+	})
+
+	t.Run("refuses reviews without an authenticated user", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+		quote := newPendingQuote(t)
+
+		mocks.quoteRepository.EXPECT().GetByID(gomock.Any(), quote.QuoteID).Return(&quote, nil)
+
+		err := service.Approve(context.Background(), quote.QuoteID, domain.ReviewQuote{})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusUnauthorized, customErr.StatusCode())
+	})
+
+	t.Run("returns conflict when the quote was already reviewed", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+		quote := newPendingQuote(t)
+		require.NoError(t, quote.Reject("too expensive", "operator-1"))
+
+		mocks.quoteRepository.EXPECT().GetByID(gomock.Any(), quote.QuoteID).Return(&quote, nil)
+		mocks.userRepository.EXPECT().GetByID(gomock.Any(), "admin-1").
+			Return(&domain.User{UserID: "admin-1", Role: domain.ADMIN, Active: true}, nil)
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").Return(&crmCase, nil)
+
+		err := service.Approve(context.Background(), quote.QuoteID, domain.ReviewQuote{ReviewedBy: "admin-1"})
+
+		require.Error(t, err)
+	})
+}
+
+func TestQuoteService_EnsureApproved(t *testing.T) {
+	t.Run("blocks when the latest quote is pending", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+
+		mocks.quoteRepository.EXPECT().GetByCaseID(gomock.Any(), "case-1").Return([]domain.Quote{newPendingQuote(t)}, nil)
+
+		err := service.EnsureApproved(context.Background(), "case-1")
+
+		require.Error(t, err)
+	})
+
+	t.Run("allows cases without quotes", func(t *testing.T) {
+		service, mocks := newQuoteServiceForTest(t)
+
+		mocks.quoteRepository.EXPECT().GetByCaseID(gomock.Any(), "case-1").Return(nil, nil)
+
+		err := service.EnsureApproved(context.Background(), "case-1")
+
+		require.NoError(t, err)
+	})
+}
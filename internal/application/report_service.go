@@ -1,20 +1,23 @@
 package application
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"image"
-	"image/jpeg"
-	_ "image/png"
 	"io"
-	"os"
+	"path"
+	"regexp"
+	"strings"
 	"time"
 
-	_ "golang.org/x/image/webp"
-
-	"github.com/google/uuid"
 	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/docxpdf"
+	"github.com/icrxz/crm-api-core/pkg/docxtemplate"
+	"github.com/icrxz/crm-api-core/pkg/photo"
 	"github.com/nguyenthenguyen/docx"
 	"golang.org/x/sync/errgroup"
 )
@@ -22,14 +25,12 @@ import (
 const (
 	dateReportLayout = "02/Jan/2006"
 	timestampLayout  = "02_01_2006_15_04_05_0000"
-)
 
-var contractorsTemplates = map[string]string{
-	"LuizaSeg":     "luizaseg_template.docx",
-	"Assurant":     "assurant_template.docx",
-	"Cardif":       "cardif_template.docx",
-	"Ezze Seguros": "ezze_template.docx",
-}
+	// photos are shrunk to what prints sharp on a page, phone cameras take
+	// them several times larger
+	reportPhotoMaxSide = 1600
+	maxPhotoDownloads  = 4
+)
 
 type ContentWithAttachment struct {
 	Content    string
@@ -37,53 +38,95 @@ type ContentWithAttachment struct {
 }
 
 type reportService struct {
-	reportFolder      string
-	caseService       CaseService
-	productService    ProductService
-	customerService   CustomerService
-	commentService    CommentService
-	partnerService    PartnerService
-	contractorService ContractorService
-	attachmentBucket  domain.AttachmentBucket
+	caseService           CaseService
+	productService        ProductService
+	customerService       CustomerService
+	commentService        CommentService
+	partnerService        PartnerService
+	contractorService     ContractorService
+	settlementService     SettlementService
+	transactionService    TransactionService
+	reportTemplateService ReportTemplateService
+	attachmentBucket      domain.AttachmentBucket
+	caseReportRepository  domain.CaseReportRepository
+	caseHistoryRepository domain.CaseHistoryRepository
+	transactionManager    domain.TransactionManager
 }
 
 //go:generate mockgen -source=report_service.go -destination=mock_application/mock_report_service.go -package=mock_application
 type ReportService interface {
-	GenerateReport(ctx context.Context, crmCase domain.Case) ([]byte, string, error)
+	GenerateReport(ctx context.Context, crmCase domain.Case, format domain.ReportFormat, author string) ([]byte, string, error)
+	GetCaseReports(ctx context.Context, caseID string) ([]domain.CaseReport, error)
+	DownloadCaseReport(ctx context.Context, caseID, caseReportID string) ([]byte, *domain.CaseReport, error)
+}
+
+type reportReplacement struct {
+	placeholder string
+	value       string
+}
+
+var settlementReportLabels = map[domain.SettlementType]string{
+	domain.SETTLEMENT_REPAIR:         "Reparo",
+	domain.SETTLEMENT_REPLACEMENT:    "Troca",
+	domain.SETTLEMENT_CASH_INDEMNITY: "Indenização em dinheiro",
 }
 
 type ReportData struct {
-	CrmCase    domain.Case
-	Customer   domain.Customer
-	Product    domain.Product
-	Partner    domain.Partner
-	Contractor domain.Contractor
-	Comments   []domain.Comment
+	CrmCase      domain.Case
+	Customer     domain.Customer
+	Product      domain.Product
+	Partner      domain.Partner
+	Contractor   domain.Contractor
+	Comments     []domain.Comment
+	Transactions []domain.Transaction
+	History      []domain.CaseHistory
+	Settlement   *domain.SettlementDecision
+	Photos       []ReportPhoto
+}
+
+// ReportPhoto is a picture attached to the resolution of a case, ready to be
+// printed in its report.
+type ReportPhoto struct {
+	Attachment domain.Attachment
+	Image      docxtemplate.Image
 }
 
 func NewReportService(
-	reportFolder string,
 	caseService CaseService,
 	productService ProductService,
 	customerService CustomerService,
 	commentService CommentService,
 	partnerService PartnerService,
 	contractorService ContractorService,
+	settlementService SettlementService,
+	transactionService TransactionService,
+	reportTemplateService ReportTemplateService,
 	attachmentBucket domain.AttachmentBucket,
+	caseReportRepository domain.CaseReportRepository,
+	caseHistoryRepository domain.CaseHistoryRepository,
+	transactionManager domain.TransactionManager,
 ) ReportService {
 	return &reportService{
-		reportFolder:      reportFolder,
-		caseService:       caseService,
-		productService:    productService,
-		customerService:   customerService,
-		commentService:    commentService,
-		partnerService:    partnerService,
-		contractorService: contractorService,
-		attachmentBucket:  attachmentBucket,
+		caseService:           caseService,
+		productService:        productService,
+		customerService:       customerService,
+		commentService:        commentService,
+		partnerService:        partnerService,
+		contractorService:     contractorService,
+		settlementService:     settlementService,
+		transactionService:    transactionService,
+		reportTemplateService: reportTemplateService,
+		attachmentBucket:      attachmentBucket,
+		caseReportRepository:  caseReportRepository,
+		caseHistoryRepository: caseHistoryRepository,
+		transactionManager:    transactionManager,
 	}
 }
 
-func (s *reportService) GenerateReport(ctx context.Context, crmCase domain.Case) ([]byte, string, error) {
+// GenerateReport fills the contractor's active template for the case. PDF
+// reports are the filled docx rendered, so both formats hold the same fields
+// and images. Every report generated is archived in the case.
+func (s *reportService) GenerateReport(ctx context.Context, crmCase domain.Case, format domain.ReportFormat, author string) ([]byte, string, error) {
 	var memoryDoc bytes.Buffer
 
 	reportData, err := s.getReportData(ctx, crmCase)
@@ -91,17 +134,104 @@ func (s *reportService) GenerateReport(ctx context.Context, crmCase domain.Case)
 		return nil, "", err
 	}
 
-	filename, hasTemplate := contractorsTemplates[reportData.Contractor.CompanyName]
-	if !hasTemplate {
-		return nil, "", fmt.Errorf("no template found for contractor %s", reportData.Contractor.CompanyName)
+	// templates are linked to the contractor id, so renaming it keeps its reports
+	template, err := s.reportTemplateService.GetActive(ctx, crmCase.ContractorID)
+	if err != nil {
+		return nil, "", err
 	}
 
-	err = s.readReportTemplate(ctx, *reportData, filename, &memoryDoc)
+	err = s.readReportTemplate(ctx, *reportData, *template, &memoryDoc)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return memoryDoc.Bytes(), fmt.Sprintf("%s-%s-%s", reportData.Contractor.CompanyName, crmCase.ExternalReference, time.Now().Format(timestampLayout)), nil
+	report := memoryDoc.Bytes()
+	if format == domain.REPORT_PDF {
+		var pdfDoc bytes.Buffer
+		if err := docxpdf.Convert(report, &pdfDoc); err != nil {
+			return nil, "", fmt.Errorf("failed to render report as pdf: %w", err)
+		}
+		report = pdfDoc.Bytes()
+	}
+
+	fileName := fmt.Sprintf("%s-%s-%s", reportData.Contractor.CompanyName, crmCase.ExternalReference, time.Now().Format(timestampLayout))
+	if err := s.archiveReport(ctx, crmCase.CaseID, *template, format, fileName, report, author); err != nil {
+		return nil, "", err
+	}
+
+	return report, fileName, nil
+}
+
+// archiveReport stores the report in the bucket and attaches it to a
+// GeneratedReport comment of the case, recording the template it was filled
+// from and its hash.
+func (s *reportService) archiveReport(ctx context.Context, caseID string, template domain.Report, format domain.ReportFormat, fileName string, report []byte, author string) error {
+	caseReport, err := domain.NewCaseReport(caseID, template, format, fileName, report, author)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := caseReport.Attachment()
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentBucket.Upload(ctx, caseReport.Key, report, format.ContentType()); err != nil {
+		return fmt.Errorf("failed to archive report %s: %w", caseReport.Key, err)
+	}
+
+	content := fmt.Sprintf("Relatório gerado com o modelo %s versão %d. SHA-256: %s", template.ReportName, template.Version, caseReport.ContentHash)
+	comment, err := domain.NewComment(caseID, content, author, domain.COMMENT_GENERATED_REPORT, []domain.Attachment{attachment})
+	if err != nil {
+		return err
+	}
+
+	return s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		commentID, err := s.commentService.Create(txCtx, comment)
+		if err != nil {
+			return err
+		}
+		caseReport.CommentID = commentID
+		caseReport.AttachmentID = attachment.AttachmentID
+
+		if err := s.caseReportRepository.Create(txCtx, caseReport); err != nil {
+			return err
+		}
+
+		history, err := domain.NewCaseHistory(caseID, domain.CaseReportGeneratedEvent, author, nil, caseReport.Snapshot())
+		if err != nil {
+			return err
+		}
+
+		return s.caseHistoryRepository.Create(txCtx, history)
+	})
+}
+
+func (s *reportService) GetCaseReports(ctx context.Context, caseID string) ([]domain.CaseReport, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	return s.caseReportRepository.GetByCaseID(ctx, caseID)
+}
+
+// DownloadCaseReport reads an archived report, exactly as it was generated.
+func (s *reportService) DownloadCaseReport(ctx context.Context, caseID, caseReportID string) ([]byte, *domain.CaseReport, error) {
+	caseReport, err := s.caseReportRepository.GetByID(ctx, caseReportID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if caseReport.CaseID != caseID {
+		return nil, nil, domain.NewNotFoundError("no case report found for this case", map[string]any{"case_id": caseID, "case_report_id": caseReportID})
+	}
+
+	content, err := s.attachmentBucket.Download(ctx, caseReport.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download report %s: %w", caseReport.Key, err)
+	}
+
+	return content, caseReport, nil
 }
 
 func (s *reportService) getReportData(ctx context.Context, crmCase domain.Case) (*ReportData, error) {
@@ -155,18 +285,60 @@ func (s *reportService) getReportData(ctx context.Context, crmCase domain.Case)
 		return nil
 	})
 
+	wg.Go(func() error {
+		transactions, err := s.transactionService.SearchTransactions(newCtx, domain.TransactionFilters{CaseIDs: []string{crmCase.CaseID}})
+		if err != nil {
+			return err
+		}
+		reportData.Transactions = transactions
+		return nil
+	})
+
+	wg.Go(func() error {
+		history, err := s.caseService.GetCaseHistory(newCtx, crmCase.CaseID)
+		if err != nil {
+			return err
+		}
+		reportData.History = history
+		return nil
+	})
+
+	wg.Go(func() error {
+		settlement, err := s.settlementService.GetDecision(newCtx, crmCase.CaseID)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		reportData.Settlement = settlement
+		return nil
+	})
+
 	if err := wg.Wait(); err != nil {
 		return nil, err
 	}
 
+	photos, err := s.reportPhotos(ctx, reportData.Comments)
+	if err != nil {
+		return nil, err
+	}
+	reportData.Photos = photos
+
 	return reportData, nil
 }
 
-func (s *reportService) readReportTemplate(ctx context.Context, reportData ReportData, filename string, memDoc io.Writer) error {
-	filePath := fmt.Sprintf("%s/%s", s.reportFolder, filename)
-	file, err := docx.ReadDocxFile(filePath)
+func (s *reportService) readReportTemplate(ctx context.Context, reportData ReportData, template domain.Report, memDoc io.Writer) error {
+	content, err := s.attachmentBucket.Download(ctx, template.ReportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to download report template %s: %w", template.ReportTemplate, err)
+	}
+
+	content, hasGallery, err := fillReportTemplate(content, reportData, template)
+	if err != nil {
+		return err
+	}
+
+	file, err := docx.ReadDocxFromMemory(bytes.NewReader(content), int64(len(content)))
 	if err != nil {
-		return fmt.Errorf("failed to read report template %s: %w", filename, err)
+		return fmt.Errorf("failed to read report template %s: %w", template.ReportTemplate, err)
 	}
 
 	docEdit := file.Editable()
@@ -176,134 +348,302 @@ func (s *reportService) readReportTemplate(ctx context.Context, reportData Repor
 		return err
 	}
 
-	resolution, resolutionAttachments, attachmentKeys, err := s.extractResolutionFromComments(ctx, reportData.Comments)
-	if err != nil {
+	if err = docEdit.Replace("$resolution", fmt.Sprintf("%s\r\n", reportResolution(reportData.Comments)), -1); err != nil {
 		return err
 	}
 
-	if err = docEdit.Replace("$resolution", fmt.Sprintf("%s\r\n", resolution), -1); err != nil {
+	var report bytes.Buffer
+	if err := docEdit.Write(&report); err != nil {
+		return fmt.Errorf("failed to write report document: %w", err)
+	}
+
+	if hasGallery {
+		_, err = memDoc.Write(report.Bytes())
 		return err
 	}
 
-	isAssurant := reportData.Contractor.CompanyName == "Assurant"
-	return s.replaceImages(docEdit, memDoc, resolutionAttachments, attachmentKeys, isAssurant)
+	filled, err := fillImageSlots(report.Bytes(), reportData.Photos)
+	if err != nil {
+		return err
+	}
+
+	_, err = memDoc.Write(filled)
+	return err
 }
 
-func (s *reportService) replaceReportFields(docEdit *docx.Docx, reportData ReportData) error {
-	replacements := []struct {
-		placeholder string
-		value       string
-	}{
-		{"$claim", reportData.CrmCase.ExternalReference},
-		{"$actual_date", time.Now().Format(dateReportLayout)},
-		{"$client", fmt.Sprintf("%s %s", reportData.Customer.FirstName, reportData.Customer.LastName)},
-		{"$brand", reportData.Product.Brand},
-		{"$summary", reportData.CrmCase.Subject},
-		{"$partner", fmt.Sprintf("%s %s", reportData.Partner.FirstName, reportData.Partner.LastName)},
-		{"$target_date", reportData.CrmCase.TargetDate.Format(dateReportLayout)},
-		{"$document", ParseDocument(reportData.Customer.Document)},
-		{"$address", reportData.Customer.ShippingAddress.Address},
-		{"$zip_code", reportData.Customer.ShippingAddress.ZipCode},
-		{"$product", reportData.Product.Name},
-		{"$serial_number", reportData.Product.SerialNumber},
-		{"$model", reportData.Product.Model},
+// fillReportTemplate renders the {{placeholders}} of the template, telling
+// whether it declares where photos go. The $placeholders of older templates
+// are replaced afterwards.
+func fillReportTemplate(content []byte, reportData ReportData, template domain.Report) ([]byte, bool, error) {
+	parsedTemplate, err := docxtemplate.Parse(content, reportTemplateFuncs)
+	if err != nil {
+		return nil, false, fmt.Errorf("report template %s version %d has invalid placeholders: %w", template.ReportID, template.Version, err)
 	}
 
-	for _, r := range replacements {
-		if err := docEdit.Replace(r.placeholder, r.value, -1); err != nil {
-			return fmt.Errorf("failed to replace field %s: %w", r.placeholder, err)
-		}
+	var filled bytes.Buffer
+	if err := parsedTemplate.Execute(&filled, newReportTemplateData(reportData, time.Now())); err != nil {
+		return nil, false, fmt.Errorf("failed to fill report template %s version %d: %w", template.ReportID, template.Version, err)
 	}
 
-	return nil
+	return filled.Bytes(), parsedTemplate.Uses("photos"), nil
 }
 
-func (s *reportService) extractResolutionFromComments(ctx context.Context, comments []domain.Comment) (string, [][]byte, []string, error) {
-	var resolution string
-	resolutionAttachments := make([][]byte, 0)
-	attachmentKeys := make([]string, 0)
-
+// reportPhotos downloads the pictures attached to the resolution of the case,
+// upright and shrunk to be printed. Attachments that are not pictures, such
+// as invoices, are left out.
+func (s *reportService) reportPhotos(ctx context.Context, comments []domain.Comment) ([]ReportPhoto, error) {
+	var attachments []domain.Attachment
 	for _, comment := range comments {
-		switch comment.CommentType {
-		case domain.COMMENT_RESOLUTION:
-			files, err := s.downloadFiles(ctx, comment.Attachments)
+		if comment.CommentType == domain.COMMENT_RESOLUTION {
+			attachments = comment.Attachments
+		}
+	}
+
+	photos := make([]*ReportPhoto, len(attachments))
+	wg, newCtx := errgroup.WithContext(ctx)
+	wg.SetLimit(maxPhotoDownloads)
+	for i, attachment := range attachments {
+		wg.Go(func() error {
+			content, err := s.attachmentBucket.Download(newCtx, attachment.Key)
 			if err != nil {
-				return "", nil, nil, err
+				return fmt.Errorf("failed to download attachment %s: %w", attachment.Key, err)
 			}
-			resolutionAttachments = files
-			for _, a := range comment.Attachments {
-				attachmentKeys = append(attachmentKeys, a.Key)
+
+			data, size, err := photo.Normalize(content, reportPhotoMaxSide)
+			if errors.Is(err, image.ErrFormat) {
+				return nil
 			}
-		case domain.COMMENT_REPORT:
-			resolution = comment.Content
-		}
+			if err != nil {
+				return fmt.Errorf("failed to decode attachment %s: %w", attachment.Key, err)
+			}
+
+			photos[i] = &ReportPhoto{
+				Attachment: attachment,
+				Image:      fitReportImage(docxtemplate.Image{Data: data, Width: float64(size.X), Height: float64(size.Y)}, reportImageWidth, reportImageHeight),
+			}
+			return nil
+		})
 	}
 
-	return resolution, resolutionAttachments, attachmentKeys, nil
-}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
 
-func (s *reportService) downloadFiles(ctx context.Context, files []domain.Attachment) ([][]byte, error) {
-	downloadedFiles := make([][]byte, 0)
-	for _, attachment := range files {
-		file, err := s.attachmentBucket.Download(ctx, attachment.Key)
-		if err != nil {
-			return nil, fmt.Errorf("failed to download attachment %s: %w", attachment.Key, err)
+	reportPhotos := make([]ReportPhoto, 0, len(photos))
+	for _, reportPhoto := range photos {
+		if reportPhoto != nil {
+			reportPhotos = append(reportPhotos, *reportPhoto)
 		}
-		downloadedFiles = append(downloadedFiles, file)
 	}
 
-	return downloadedFiles, nil
+	return reportPhotos, nil
 }
 
-func (s *reportService) replaceImages(doc *docx.Docx, memDoc io.Writer, attachments [][]byte, keys []string, isAssurant bool) error {
-	attachmentNames := make([]string, 0, len(attachments))
+// fixedImageAltText marks, as the alt text of a picture, the template
+// pictures that are part of the layout and must not be taken by photos.
+const fixedImageAltText = "fixed"
 
-	docImagesLength := doc.ImagesLen()
+var (
+	drawingPattern       = regexp.MustCompile(`(?s)<w:drawing>.*?</w:drawing>`)
+	docPropertiesPattern = regexp.MustCompile(`<wp:docPr\b[^>]*>`)
+	altTextPattern       = regexp.MustCompile(`\b(?:descr|title)="([^"]*)"`)
+	blipPattern          = regexp.MustCompile(`<a:blip\b[^>]*\br:embed="([^"]*)"`)
+)
 
-	for idx := range docImagesLength {
-		if idx >= docImagesLength-1 && isAssurant {
-			break
+type documentRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// fillImageSlots puts the photos in place of the template's own pictures,
+// word/media/image1.png first, for templates without a {{photos}} gallery.
+// Pictures whose alt text is "fixed" are skipped and slots past the last
+// photo keep their picture.
+func fillImageSlots(report []byte, photos []ReportPhoto) ([]byte, error) {
+	if len(photos) == 0 {
+		return report, nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(report), int64(len(report)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report document: %w", err)
+	}
+
+	fixed, err := fixedTemplateImages(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report pictures: %w", err)
+	}
+
+	slots := make(map[string][]byte, len(photos))
+	for i, next := 1, 0; next < len(photos) && i <= len(archive.File); i++ {
+		name := fmt.Sprintf("word/media/image%d.png", i)
+		if fixed[name] {
+			continue
 		}
 
-		if idx >= len(attachments) {
-			break
+		slots[name] = photos[next].Image.Data
+		next++
+	}
+
+	var filled bytes.Buffer
+	writer := zip.NewWriter(&filled)
+	for _, file := range archive.File {
+		slot, ok := slots[file.Name]
+		if !ok {
+			if err := writer.Copy(file); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
-		key := keys[idx]
-		img, _, err := image.Decode(bytes.NewReader(attachments[idx]))
+		fileWriter, err := writer.Create(file.Name)
 		if err != nil {
-			return fmt.Errorf("failed to decode attachment %s: %w", key, err)
+			return nil, err
+		}
+		if _, err := fileWriter.Write(slot); err != nil {
+			return nil, err
 		}
+	}
 
-		fileName := fmt.Sprintf("./resources/img_%s.png", uuid.NewString())
-		out, err := os.Create(fileName)
-		if err != nil {
-			return fmt.Errorf("failed to create temp file for attachment %s: %w", key, err)
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return filled.Bytes(), nil
+}
+
+// fixedTemplateImages lists the archive files of the pictures of the document
+// body whose alt text marks them as fixed.
+func fixedTemplateImages(archive *zip.Reader) (map[string]bool, error) {
+	document, err := readArchiveFile(archive, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	// each picture's properties come right before the reference to its file,
+	// pictures can be nested in text boxes so drawings are not matched whole
+	embeds := make(map[string]bool)
+	docProperties := docPropertiesPattern.FindAllStringIndex(document, -1)
+	for i, bounds := range docProperties {
+		end := len(document)
+		if i+1 < len(docProperties) {
+			end = docProperties[i+1][0]
 		}
-		attachmentNames = append(attachmentNames, fileName)
 
-		if err = jpeg.Encode(out, img, nil); err != nil {
-			return fmt.Errorf("failed to encode attachment %s as jpeg: %w", key, err)
+		blip := blipPattern.FindStringSubmatch(document[bounds[1]:end])
+		if blip == nil {
+			continue
 		}
 
-		if err = out.Close(); err != nil {
-			return fmt.Errorf("failed to close temp file for attachment %s: %w", key, err)
+		for _, altText := range altTextPattern.FindAllStringSubmatch(document[bounds[0]:bounds[1]], -1) {
+			if strings.EqualFold(strings.TrimSpace(altText[1]), fixedImageAltText) {
+				embeds[blip[1]] = true
+			}
 		}
+	}
 
-		if err = doc.ReplaceImage(fmt.Sprintf("word/media/image%d.png", idx+1), fileName); err != nil {
-			return fmt.Errorf("failed to replace image slot %d with attachment %s: %w", idx+1, key, err)
+	fixed := make(map[string]bool, len(embeds))
+	if len(embeds) == 0 {
+		return fixed, nil
+	}
+
+	rels, err := readArchiveFile(archive, "word/_rels/document.xml.rels")
+	if err != nil {
+		return nil, err
+	}
+
+	var relationships documentRelationships
+	if err := xml.Unmarshal([]byte(rels), &relationships); err != nil {
+		return nil, err
+	}
+
+	for _, relationship := range relationships.Relationships {
+		if !embeds[relationship.ID] {
+			continue
+		}
+
+		if target, ok := strings.CutPrefix(relationship.Target, "/"); ok {
+			fixed[target] = true
+		} else {
+			fixed[path.Join("word", relationship.Target)] = true
 		}
 	}
 
-	if err := doc.Write(memDoc); err != nil {
-		return fmt.Errorf("failed to write report document: %w", err)
+	return fixed, nil
+}
+
+func readArchiveFile(archive *zip.Reader, name string) (string, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// reportResolution is the text of the last report comment of the case.
+func reportResolution(comments []domain.Comment) string {
+	var resolution string
+	for _, comment := range comments {
+		if comment.CommentType == domain.COMMENT_REPORT {
+			resolution = comment.Content
+		}
 	}
 
-	for _, attachmentName := range attachmentNames {
-		if err := os.Remove(attachmentName); err != nil {
-			return fmt.Errorf("failed to remove temp file %s: %w", attachmentName, err)
+	return resolution
+}
+
+func (s *reportService) replaceReportFields(docEdit *docx.Docx, reportData ReportData) error {
+	replacements := []reportReplacement{
+		{"$claim", reportData.CrmCase.ExternalReference},
+		{"$actual_date", time.Now().Format(dateReportLayout)},
+		{"$client", fmt.Sprintf("%s %s", reportData.Customer.FirstName, reportData.Customer.LastName)},
+		{"$brand", reportData.Product.Brand},
+		{"$summary", reportData.CrmCase.Subject},
+		{"$partner", fmt.Sprintf("%s %s", reportData.Partner.FirstName, reportData.Partner.LastName)},
+		{"$target_date", reportData.CrmCase.TargetDate.Format(dateReportLayout)},
+		{"$document", ParseDocument(reportData.Customer.Document)},
+		{"$address", reportData.Customer.ShippingAddress.Address},
+		{"$zip_code", reportData.Customer.ShippingAddress.ZipCode},
+		{"$product", reportData.Product.Name},
+		{"$serial_number", reportData.Product.SerialNumber},
+		{"$model", reportData.Product.Model},
+	}
+	replacements = append(replacements, settlementReplacements(reportData.Settlement)...)
+
+	for _, r := range replacements {
+		if err := docEdit.Replace(r.placeholder, r.value, -1); err != nil {
+			return fmt.Errorf("failed to replace field %s: %w", r.placeholder, err)
 		}
 	}
 
 	return nil
 }
+
+// settlementReplacements always returns every settlement placeholder so
+// templates never print them raw when the case has no decision yet.
+func settlementReplacements(settlement *domain.SettlementDecision) []reportReplacement {
+	if settlement == nil {
+		return []reportReplacement{
+			{"$settlement", ""},
+			{"$repair_cost", ""},
+			{"$insured_value", ""},
+			{"$loss_ratio", ""},
+		}
+	}
+
+	return []reportReplacement{
+		{"$settlement", settlementReportLabels[settlement.Type]},
+		{"$repair_cost", formatReportCurrency(settlement.RepairCost)},
+		{"$insured_value", formatReportCurrency(settlement.ProductValue)},
+		{"$loss_ratio", formatReportPercent(settlement.LossRatio)},
+	}
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type reportServiceMocks struct {
+	commentService        *mock_application.MockCommentService
+	attachmentBucket      *mock_domain.MockAttachmentBucket
+	caseReportRepository  *mock_domain.MockCaseReportRepository
+	caseHistoryRepository *mock_domain.MockCaseHistoryRepository
+	transactionManager    *mock_domain.MockTransactionManager
+}
+
+func newReportServiceForTest(t *testing.T) (*reportService, *reportServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &reportServiceMocks{
+		commentService:        mock_application.NewMockCommentService(ctrl),
+		attachmentBucket:      mock_domain.NewMockAttachmentBucket(ctrl),
+		caseReportRepository:  mock_domain.NewMockCaseReportRepository(ctrl),
+		caseHistoryRepository: mock_domain.NewMockCaseHistoryRepository(ctrl),
+		transactionManager:    mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := &reportService{
+		commentService:        mocks.commentService,
+		attachmentBucket:      mocks.attachmentBucket,
+		caseReportRepository:  mocks.caseReportRepository,
+		caseHistoryRepository: mocks.caseHistoryRepository,
+		transactionManager:    mocks.transactionManager,
+	}
+
+	return service, mocks
+}
+
+func TestReportService_ArchiveReport(t *testing.T) {
+	template := domain.Report{ReportID: "report-1", ReportName: "Laudo", Version: 2}
+
+	t.Run("attaches the report to a comment and records it", func(t *testing.T) {
+		service, mocks := newReportServiceForTest(t)
+
+		var archivedKey string
+		mocks.attachmentBucket.EXPECT().Upload(gomock.Any(), gomock.Any(), []byte("report"), domain.REPORT_PDF.ContentType()).
+			DoAndReturn(func(_ context.Context, key string, _ []byte, _ string) error {
+				archivedKey = key
+				return nil
+			})
+		mocks.commentService.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, comment domain.Comment) (string, error) {
+				assert.Equal(t, domain.COMMENT_GENERATED_REPORT, comment.CommentType)
+				assert.Equal(t, "operator-1", comment.CreatedBy)
+				assert.Contains(t, comment.Content, "modelo Laudo versão 2")
+				require.Len(t, comment.Attachments, 1)
+				assert.Equal(t, archivedKey, comment.Attachments[0].Key)
+				assert.Equal(t, "Assurant-SIN-1.pdf", comment.Attachments[0].FileName)
+				return "comment-1", nil
+			})
+		mocks.caseReportRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, caseReport domain.CaseReport) error {
+				assert.Equal(t, "comment-1", caseReport.CommentID)
+				assert.NotEmpty(t, caseReport.AttachmentID)
+				assert.Equal(t, 2, caseReport.TemplateVersion)
+				assert.Equal(t, archivedKey, caseReport.Key)
+				return nil
+			})
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.CaseReportGeneratedEvent, history.EventName)
+				assert.Equal(t, 2, history.NewValues["template_version"])
+				return nil
+			})
+
+		err := service.archiveReport(context.Background(), "case-1", template, domain.REPORT_PDF, "Assurant-SIN-1", []byte("report"), "operator-1")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps nothing when the upload fails", func(t *testing.T) {
+		service, mocks := newReportServiceForTest(t)
+
+		mocks.attachmentBucket.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		err := service.archiveReport(context.Background(), "case-1", template, domain.REPORT_DOCX, "Assurant-SIN-1", []byte("report"), "operator-1")
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestReportService_DownloadCaseReport(t *testing.T) {
+	caseReport := &domain.CaseReport{CaseReportID: "case-report-1", CaseID: "case-1", Key: "case-reports/case-1/case-report-1.pdf"}
+
+	t.Run("reads the archived file", func(t *testing.T) {
+		service, mocks := newReportServiceForTest(t)
+
+		mocks.caseReportRepository.EXPECT().GetByID(gomock.Any(), "case-report-1").Return(caseReport, nil)
+		mocks.attachmentBucket.EXPECT().Download(gomock.Any(), caseReport.Key).Return([]byte("report"), nil)
+
+		content, found, err := service.DownloadCaseReport(context.Background(), "case-1", "case-report-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("report"), content)
+		assert.Equal(t, caseReport, found)
+	})
+
+	t.Run("does not serve reports of other cases", func(t *testing.T) {
+		service, mocks := newReportServiceForTest(t)
+
+		mocks.caseReportRepository.EXPECT().GetByID(gomock.Any(), "case-report-1").Return(caseReport, nil)
+
+		_, _, err := service.DownloadCaseReport(context.Background(), "case-2", "case-report-1")
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusNotFound, customErr.StatusCode())
+	})
+}
+
+func TestFillReportTemplate_FailsAsInternalError(t *testing.T) {
+	template := domain.Report{ReportID: "report-1", Version: 2}
+	content := newTestDocx(t, "{{#each comments as comment}}{{comment.content}}")
+
+	_, _, err := fillReportTemplate(content, ReportData{}, template)
+
+	var customErr *domain.CustomError
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &customErr), "a broken active template is a server error, not a bad request")
+}
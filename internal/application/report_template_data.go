@@ -0,0 +1,361 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/docxtemplate"
+)
+
+const (
+	dateTemplateLayout     = "02/01/2006"
+	dateTimeTemplateLayout = "02/01/2006 15:04"
+
+	// reportImageWidth and reportImageHeight bound, in centimetres, photos
+	// printed without a size, leaving room on an A4 page for a caption
+	reportImageWidth  = 15.0
+	reportImageHeight = 20.0
+)
+
+var reportMonths = [...]string{
+	"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+}
+
+// reportTemplateFuncs are the helpers report templates format values with, as
+// in {{brl settlement.repair_cost}} or {{date case.target_date}}.
+var reportTemplateFuncs = docxtemplate.Funcs{
+	"brl": func(args ...any) (any, error) {
+		value, err := templateNumberArg("brl", args)
+		if err != nil || value == nil {
+			return "", err
+		}
+		return formatReportCurrency(*value), nil
+	},
+	"percent": func(args ...any) (any, error) {
+		value, err := templateNumberArg("percent", args)
+		if err != nil || value == nil {
+			return "", err
+		}
+		return formatReportPercent(*value), nil
+	},
+	"date": func(args ...any) (any, error) {
+		return formatTemplateTime("date", args, func(t time.Time) string { return t.Format(dateTemplateLayout) })
+	},
+	"datetime": func(args ...any) (any, error) {
+		return formatTemplateTime("datetime", args, func(t time.Time) string { return t.Format(dateTimeTemplateLayout) })
+	},
+	"longdate": func(args ...any) (any, error) {
+		return formatTemplateTime("longdate", args, func(t time.Time) string {
+			return fmt.Sprintf("%d de %s de %d", t.Day(), reportMonths[t.Month()-1], t.Year())
+		})
+	},
+	"document": func(args ...any) (any, error) {
+		value, err := templateStringArg("document", args)
+		return ParseDocument(value), err
+	},
+	"image": func(args ...any) (any, error) {
+		if len(args) < 1 || len(args) > 3 {
+			return nil, fmt.Errorf("image takes 1 to 3 arguments, got %d", len(args))
+		}
+
+		img, ok := args[0].(docxtemplate.Image)
+		if !ok && args[0] != nil {
+			return nil, errors.New("image prints photos")
+		}
+
+		bounds := []float64{reportImageWidth, reportImageHeight}
+		for i, arg := range args[1:] {
+			size, err := templateNumberArg("image", []any{arg})
+			if err != nil || size == nil || *size <= 0 {
+				return nil, errors.New("image sizes are centimetres")
+			}
+			bounds[i] = *size
+		}
+
+		// a width alone keeps the proportions of the photo
+		if len(args) == 2 {
+			bounds[1] = math.MaxFloat64
+		}
+
+		return fitReportImage(img, bounds[0], bounds[1]), nil
+	},
+	"upper": func(args ...any) (any, error) {
+		value, err := templateStringArg("upper", args)
+		return strings.ToUpper(value), err
+	},
+	"lower": func(args ...any) (any, error) {
+		value, err := templateStringArg("lower", args)
+		return strings.ToLower(value), err
+	},
+}
+
+// fitReportImage scales the image to fit width by height, keeping its
+// proportions.
+func fitReportImage(img docxtemplate.Image, width, height float64) docxtemplate.Image {
+	if img.Width <= 0 || img.Height <= 0 {
+		return img
+	}
+
+	scale := min(width/img.Width, height/img.Height)
+	img.Width, img.Height = img.Width*scale, img.Height*scale
+
+	return img
+}
+
+func templateNumberArg(helper string, args []any) (*float64, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%s takes 1 argument, got %d", helper, len(args))
+	}
+
+	switch value := args[0].(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return &value, nil
+	case int:
+		number := float64(value)
+		return &number, nil
+	default:
+		return nil, fmt.Errorf("%s formats numbers", helper)
+	}
+}
+
+func templateStringArg(helper string, args []any) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%s takes 1 argument, got %d", helper, len(args))
+	}
+
+	switch value := args[0].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	default:
+		return "", fmt.Errorf("%s formats text", helper)
+	}
+}
+
+func formatTemplateTime(helper string, args []any, layout func(time.Time) string) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%s takes 1 argument, got %d", helper, len(args))
+	}
+
+	switch value := args[0].(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		if value.IsZero() {
+			return "", nil
+		}
+		return layout(value), nil
+	default:
+		return nil, fmt.Errorf("%s formats dates", helper)
+	}
+}
+
+// formatReportCurrency prints an amount the way Brazilian documents do,
+// R$ 1.234,56.
+func formatReportCurrency(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+
+	cents := int64(math.Round(math.Abs(value) * 100))
+	units := fmt.Sprint(cents / 100)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "." + units[i:]
+	}
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, units, cents%100)
+}
+
+func formatReportPercent(ratio float64) string {
+	return strings.Replace(fmt.Sprintf("%.1f%%", ratio*100), ".", ",", 1)
+}
+
+// newReportTemplateData builds the fields report templates are filled with.
+// Names are the snake_case of the domain ones; dates are printed through the
+// date helpers and nullable ones are empty when unset.
+func newReportTemplateData(reportData ReportData, now time.Time) map[string]any {
+	crmCase := reportData.CrmCase
+
+	comments := make([]map[string]any, 0, len(reportData.Comments))
+	resolution := ""
+	for _, comment := range reportData.Comments {
+		// earlier reports are not part of the case story reports tell
+		if comment.CommentType == domain.COMMENT_GENERATED_REPORT {
+			continue
+		}
+
+		comments = append(comments, map[string]any{
+			"content":     comment.Content,
+			"type":        string(comment.CommentType),
+			"attachments": len(comment.Attachments),
+			"created_by":  comment.CreatedBy,
+			"created_at":  comment.CreatedAt,
+		})
+		if comment.CommentType == domain.COMMENT_REPORT {
+			resolution = comment.Content
+		}
+	}
+
+	transactions := make([]map[string]any, 0, len(reportData.Transactions))
+	for _, transaction := range reportData.Transactions {
+		transactions = append(transactions, map[string]any{
+			"type":        string(transaction.Type),
+			"status":      string(transaction.Status),
+			"value":       transaction.Value,
+			"description": transaction.Description,
+			"created_by":  transaction.CreatedBy,
+			"created_at":  transaction.CreatedAt,
+		})
+	}
+
+	photos := make([]map[string]any, 0, len(reportData.Photos))
+	for _, reportPhoto := range reportData.Photos {
+		photos = append(photos, map[string]any{
+			"image":      reportPhoto.Image,
+			"caption":    strings.TrimSuffix(reportPhoto.Attachment.FileName, filepath.Ext(reportPhoto.Attachment.FileName)),
+			"file_name":  reportPhoto.Attachment.FileName,
+			"created_at": reportPhoto.Attachment.CreatedAt,
+		})
+	}
+
+	history := make([]map[string]any, 0, len(reportData.History))
+	for _, event := range reportData.History {
+		history = append(history, map[string]any{
+			"event":      event.EventName,
+			"author":     event.AuthorID,
+			"created_at": event.CreatedAt,
+		})
+	}
+
+	return map[string]any{
+		"today": now,
+		"case": map[string]any{
+			"id":                 crmCase.CaseID,
+			"claim":              crmCase.ExternalReference,
+			"subject":            crmCase.Subject,
+			"type":               crmCase.Type,
+			"status":             string(crmCase.Status),
+			"priority":           string(crmCase.Priority),
+			"origin_channel":     crmCase.OriginChannel,
+			"region":             crmCase.Region,
+			"created_at":         crmCase.CreatedAt,
+			"due_date":           crmCase.DueDate,
+			"target_date":        optionalTemplateTime(crmCase.TargetDate),
+			"closed_at":          optionalTemplateTime(crmCase.ClosedAt),
+			"external_reference": crmCase.ExternalReference,
+		},
+		"customer": map[string]any{
+			"name":             strings.TrimSpace(reportData.Customer.FirstName + " " + reportData.Customer.LastName),
+			"first_name":       reportData.Customer.FirstName,
+			"last_name":        reportData.Customer.LastName,
+			"company_name":     reportData.Customer.CompanyName,
+			"legal_name":       reportData.Customer.LegalName,
+			"document":         reportData.Customer.Document,
+			"document_type":    string(reportData.Customer.DocumentType),
+			"shipping_address": addressTemplateData(reportData.Customer.ShippingAddress),
+			"billing_address":  addressTemplateData(reportData.Customer.BillingAddress),
+			"personal_contact": contactTemplateData(reportData.Customer.PersonalContact),
+			"business_contact": contactTemplateData(reportData.Customer.BusinessContact),
+		},
+		"partner": map[string]any{
+			"name":             strings.TrimSpace(reportData.Partner.FirstName + " " + reportData.Partner.LastName),
+			"first_name":       reportData.Partner.FirstName,
+			"last_name":        reportData.Partner.LastName,
+			"company_name":     reportData.Partner.CompanyName,
+			"legal_name":       reportData.Partner.LegalName,
+			"document":         reportData.Partner.Document,
+			"document_type":    string(reportData.Partner.DocumentType),
+			"description":      reportData.Partner.Description,
+			"shipping_address": addressTemplateData(reportData.Partner.ShippingAddress),
+			"personal_contact": contactTemplateData(reportData.Partner.PersonalContact),
+			"business_contact": contactTemplateData(reportData.Partner.BusinessContact),
+		},
+		"product": map[string]any{
+			"name":          reportData.Product.Name,
+			"description":   reportData.Product.Description,
+			"brand":         reportData.Product.Brand,
+			"model":         reportData.Product.Model,
+			"serial_number": reportData.Product.SerialNumber,
+			"value":         reportData.Product.Value,
+		},
+		"contractor": map[string]any{
+			"company_name":     reportData.Contractor.CompanyName,
+			"legal_name":       reportData.Contractor.LegalName,
+			"document":         reportData.Contractor.Document,
+			"document_type":    string(reportData.Contractor.DocumentType),
+			"business_contact": contactTemplateData(reportData.Contractor.BusinessContact),
+		},
+		"settlement":   settlementTemplateData(reportData.Settlement),
+		"resolution":   resolution,
+		"comments":     comments,
+		"transactions": transactions,
+		"history":      history,
+		"photos":       photos,
+	}
+}
+
+// reportTemplateSchema holds every field templates can use, with an item in
+// each list, to check templates against.
+func reportTemplateSchema() map[string]any {
+	return newReportTemplateData(ReportData{
+		Comments:     []domain.Comment{{}},
+		Transactions: []domain.Transaction{{}},
+		History:      []domain.CaseHistory{{}},
+		Settlement:   &domain.SettlementDecision{},
+		Photos:       []ReportPhoto{{}},
+	}, time.Time{})
+}
+
+func settlementTemplateData(settlement *domain.SettlementDecision) any {
+	if settlement == nil {
+		return nil
+	}
+
+	return map[string]any{
+		"type":          string(settlement.Type),
+		"label":         settlementReportLabels[settlement.Type],
+		"repair_cost":   settlement.RepairCost,
+		"insured_value": settlement.ProductValue,
+		"loss_ratio":    settlement.LossRatio,
+		"threshold":     settlement.Threshold,
+		"overridden":    settlement.Overridden,
+		"reason":        settlement.Reason,
+		"decided_by":    settlement.DecidedBy,
+		"decided_at":    settlement.DecidedAt,
+	}
+}
+
+func addressTemplateData(address domain.Address) map[string]any {
+	return map[string]any{
+		"address":  address.Address,
+		"city":     address.City,
+		"state":    address.State,
+		"zip_code": address.ZipCode,
+		"country":  address.Country,
+	}
+}
+
+func contactTemplateData(contact domain.Contact) map[string]any {
+	return map[string]any{
+		"phone_number": contact.PhoneNumber,
+		"email":        contact.Email,
+	}
+}
+
+func optionalTemplateTime(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
@@ -0,0 +1,236 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/docxtemplate"
+	"github.com/icrxz/crm-api-core/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportTemplateFuncs(t *testing.T) {
+	decidedAt := time.Date(2026, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		helper   string
+		arg      any
+		expected any
+	}{
+		"brl with thousands":       {"brl", 1234567.891, "R$ 1.234.567,89"},
+		"brl of negative amounts":  {"brl", -15.5, "-R$ 15,50"},
+		"brl of empty values":      {"brl", nil, ""},
+		"percent of ratios":        {"percent", 0.725, "72,5%"},
+		"date":                     {"date", decidedAt, "05/03/2026"},
+		"date of empty values":     {"date", nil, ""},
+		"datetime":                 {"datetime", decidedAt, "05/03/2026 14:30"},
+		"longdate":                 {"longdate", decidedAt, "5 de março de 2026"},
+		"document with cpf":        {"document", "12345678901", "123.456.789-01"},
+		"document with cnpj":       {"document", "12345678000190", "12.345.678/0001-90"},
+		"upper keeps the accents":  {"upper", "indenização", "INDENIZAÇÃO"},
+		"lower of empty values":    {"lower", nil, ""},
+		"zero dates print nothing": {"date", time.Time{}, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			value, err := reportTemplateFuncs[tc.helper](tc.arg)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+
+	t.Run("rejects values of other types", func(t *testing.T) {
+		_, err := reportTemplateFuncs["brl"]("12")
+		assert.EqualError(t, err, "brl formats numbers")
+
+		_, err = reportTemplateFuncs["date"](12.0)
+		assert.EqualError(t, err, "date formats dates")
+
+		_, err = reportTemplateFuncs["upper"]("a", "b")
+		assert.EqualError(t, err, "upper takes 1 argument, got 2")
+	})
+}
+
+func TestNewReportTemplateData(t *testing.T) {
+	template, err := docxtemplate.Parse(newTestDocx(t,
+		"{{case.claim}} - {{customer.name}} ({{document customer.document}})",
+		"{{#each transactions as transaction}}{{@number}}: {{brl transaction.value}};{{/each}}",
+		"{{#if settlement}}{{settlement.label}} {{percent settlement.loss_ratio}}{{else}}sem acordo{{/if}}",
+		"{{resolution}} {{date case.target_date}}",
+	), reportTemplateFuncs)
+	require.NoError(t, err)
+
+	assert.Empty(t, template.Check(reportTemplateSchema()))
+
+	var filled bytes.Buffer
+	require.NoError(t, template.Execute(&filled, newReportTemplateData(ReportData{
+		CrmCase:  domain.Case{ExternalReference: "SIN-1"},
+		Customer: domain.Customer{FirstName: "Ana", LastName: "Souza", Document: "12345678901"},
+		Comments: []domain.Comment{
+			{CommentType: domain.COMMENT, Content: "Visita agendada"},
+			{CommentType: domain.COMMENT_REPORT, Content: "Placa substituída"},
+		},
+		Transactions: []domain.Transaction{{Value: 150}, {Value: 1200.5}},
+		Settlement:   &domain.SettlementDecision{Type: domain.SETTLEMENT_REPAIR, LossRatio: 0.4},
+	}, time.Now())))
+
+	document := readDocxText(t, filled.Bytes())
+	assert.Contains(t, document, "SIN-1 - Ana Souza (123.456.789-01)")
+	assert.Contains(t, document, "1: R$ 150,00;2: R$ 1.200,50;")
+	assert.Contains(t, document, "Reparo 40,0%")
+	assert.Contains(t, document, "Placa substituída </w:t>")
+}
+
+func TestReportTemplateFuncs_Image(t *testing.T) {
+	photo := docxtemplate.Image{Data: []byte("jpeg"), Width: 1600, Height: 1200}
+
+	for name, tc := range map[string]struct {
+		args     []any
+		expected docxtemplate.Image
+	}{
+		"fits the page":        {[]any{photo}, docxtemplate.Image{Data: photo.Data, Width: 15, Height: 11.25}},
+		"takes a width":        {[]any{photo, 8}, docxtemplate.Image{Data: photo.Data, Width: 8, Height: 6}},
+		"fits width by height": {[]any{photo, 8.0, 3}, docxtemplate.Image{Data: photo.Data, Width: 4, Height: 3}},
+		"of missing photos":    {[]any{nil}, docxtemplate.Image{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			value, err := reportTemplateFuncs["image"](tc.args...)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+
+	t.Run("rejects other values", func(t *testing.T) {
+		_, err := reportTemplateFuncs["image"]("photo.jpg")
+		assert.EqualError(t, err, "image prints photos")
+
+		_, err = reportTemplateFuncs["image"](photo, "8cm")
+		assert.EqualError(t, err, "image sizes are centimetres")
+	})
+}
+
+func TestNewReportTemplateData_Photos(t *testing.T) {
+	template, err := docxtemplate.Parse(newTestDocx(t,
+		"{{#each photos as photo}}{{photo.caption}}: {{image photo.image 8}}{{/each}}",
+	), reportTemplateFuncs)
+	require.NoError(t, err)
+
+	assert.Empty(t, template.Check(reportTemplateSchema()))
+	assert.True(t, template.Uses("photos"))
+
+	var filled bytes.Buffer
+	require.NoError(t, template.Execute(&filled, newReportTemplateData(ReportData{
+		Photos: []ReportPhoto{
+			{Attachment: domain.Attachment{FileName: "fachada.jpg"}, Image: docxtemplate.Image{Data: []byte("jpeg"), Width: 16, Height: 12}},
+		},
+	}, time.Now())))
+
+	document := readDocxText(t, filled.Bytes())
+	assert.Contains(t, document, "fachada: </w:t><w:drawing>")
+	assert.Contains(t, document, `<wp:extent cx="2880000" cy="2160000"/>`)
+}
+
+func TestFillImageSlots(t *testing.T) {
+	var report bytes.Buffer
+	archive := zip.NewWriter(&report)
+	for _, name := range []string{"word/document.xml", "word/media/image1.png", "word/media/image2.png"} {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	filled, err := fillImageSlots(report.Bytes(), []ReportPhoto{{Image: docxtemplate.Image{Data: []byte("photo")}}})
+	require.NoError(t, err)
+
+	files, err := zip.NewReader(bytes.NewReader(filled), int64(len(filled)))
+	require.NoError(t, err)
+	for name, expected := range map[string]string{
+		"word/document.xml":     "word/document.xml",
+		"word/media/image1.png": "photo",
+		"word/media/image2.png": "word/media/image2.png",
+	} {
+		file, err := files.Open(name)
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(content), name)
+	}
+}
+
+func TestFillImageSlots_KeepsFixedPictures(t *testing.T) {
+	template, err := fs.ReadFile(resources.Reports, "reports/assurant_template.docx")
+	require.NoError(t, err)
+
+	photos := make([]ReportPhoto, 10)
+	for i := range photos {
+		photos[i] = ReportPhoto{Image: docxtemplate.Image{Data: []byte(fmt.Sprintf("photo %d", i+1))}}
+	}
+
+	filled, err := fillImageSlots(template, photos)
+	require.NoError(t, err)
+
+	original, err := zip.NewReader(bytes.NewReader(template), int64(len(template)))
+	require.NoError(t, err)
+	files, err := zip.NewReader(bytes.NewReader(filled), int64(len(filled)))
+	require.NoError(t, err)
+
+	for i := 1; i <= 7; i++ {
+		name := fmt.Sprintf("word/media/image%d.png", i)
+		assert.Equal(t, fmt.Sprintf("photo %d", i), string(readArchiveEntry(t, files, name)), name)
+	}
+	assert.Equal(t, readArchiveEntry(t, original, "word/media/image8.png"), readArchiveEntry(t, files, "word/media/image8.png"))
+}
+
+func TestReportTemplateSchema_ReportsUnknownPlaceholders(t *testing.T) {
+	template, err := docxtemplate.Parse(newTestDocx(t,
+		"{{customer.nickname}} {{brl customer.name}} {{currency product.value}}",
+		"{{#each comments as comment}}{{comment.author}}{{/each}}",
+	), reportTemplateFuncs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"customer.nickname",
+		"brl customer.name",
+		"currency product.value",
+		"comment.author",
+	}, template.Check(reportTemplateSchema()))
+}
+
+func readDocxText(t *testing.T, docx []byte) string {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	require.NoError(t, err)
+
+	file, err := archive.Open("word/document.xml")
+	require.NoError(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func readArchiveEntry(t *testing.T, archive *zip.Reader, name string) []byte {
+	t.Helper()
+
+	file, err := archive.Open(name)
+	require.NoError(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	return content
+}
@@ -0,0 +1,351 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/docxtemplate"
+	"github.com/nguyenthenguyen/docx"
+)
+
+// maxReportTemplateSize bounds uploaded templates, which are read whole into
+// memory every time a report is generated.
+const maxReportTemplateSize = 20 << 20
+
+// shippedReportTemplates maps the company name of the contractors whose
+// templates ship with the service to the file holding them.
+var shippedReportTemplates = map[string]string{
+	"LuizaSeg":     "luizaseg_template.docx",
+	"Assurant":     "assurant_template.docx",
+	"Cardif":       "cardif_template.docx",
+	"Ezze Seguros": "ezze_template.docx",
+}
+
+const shippedReportTemplateAuthor = "system"
+
+type reportTemplateService struct {
+	reportRepository   domain.ReportRepository
+	contractorService  ContractorService
+	attachmentBucket   domain.AttachmentBucket
+	transactionManager domain.TransactionManager
+}
+
+//go:generate mockgen -source=report_template_service.go -destination=mock_application/mock_report_template_service.go -package=mock_application
+type ReportTemplateService interface {
+	Upload(ctx context.Context, report domain.Report, file io.Reader) (*domain.Report, error)
+	GetByContractor(ctx context.Context, contractorID string) ([]domain.Report, error)
+	GetActive(ctx context.Context, contractorID string) (*domain.Report, error)
+	Download(ctx context.Context, contractorID, reportID string) ([]byte, *domain.Report, error)
+	Activate(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error)
+	Retire(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error)
+	Validate(ctx context.Context, contractorID, reportID string) (*domain.ReportValidation, error)
+	ValidateFile(ctx context.Context, contractorID, fileName string, file io.Reader) (*domain.ReportValidation, error)
+	ImportShipped(ctx context.Context, templates fs.FS) error
+}
+
+func NewReportTemplateService(
+	reportRepository domain.ReportRepository,
+	contractorService ContractorService,
+	attachmentBucket domain.AttachmentBucket,
+	transactionManager domain.TransactionManager,
+) ReportTemplateService {
+	return &reportTemplateService{
+		reportRepository:   reportRepository,
+		contractorService:  contractorService,
+		attachmentBucket:   attachmentBucket,
+		transactionManager: transactionManager,
+	}
+}
+
+// Upload stores the docx in the attachment bucket and registers it as a draft
+// holding the contractor's next version. It must be activated to be used.
+func (s *reportTemplateService) Upload(ctx context.Context, report domain.Report, file io.Reader) (*domain.Report, error) {
+	content, _, err := readReportTemplate(report.FileName, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.contractorService.GetByID(ctx, report.ContractorID); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachmentBucket.Upload(ctx, report.ReportTemplate, content, domain.REPORT_DOCX.ContentType()); err != nil {
+		return nil, err
+	}
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		version, err := s.reportRepository.Create(txCtx, report)
+		if err != nil {
+			return err
+		}
+
+		report.Version = version
+		return nil
+	})
+	if err != nil {
+		if deleteErr := s.attachmentBucket.Delete(ctx, report.ReportTemplate); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (s *reportTemplateService) GetByContractor(ctx context.Context, contractorID string) ([]domain.Report, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID cannot be empty", nil)
+	}
+
+	return s.reportRepository.GetByContractor(ctx, contractorID)
+}
+
+func (s *reportTemplateService) GetActive(ctx context.Context, contractorID string) (*domain.Report, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID cannot be empty", nil)
+	}
+
+	return s.reportRepository.GetActiveByContractor(ctx, contractorID)
+}
+
+func (s *reportTemplateService) Download(ctx context.Context, contractorID, reportID string) ([]byte, *domain.Report, error) {
+	report, err := s.getContractorReport(ctx, contractorID, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.attachmentBucket.Download(ctx, report.ReportTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return content, report, nil
+}
+
+// Activate makes the version the one used by new reports, retiring the
+// version that was active until then.
+func (s *reportTemplateService) Activate(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error) {
+	var report *domain.Report
+	err := s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		report, err = s.getContractorReport(txCtx, contractorID, reportID)
+		if err != nil {
+			return err
+		}
+
+		if err := report.Activate(author); err != nil {
+			return err
+		}
+
+		current, err := s.reportRepository.GetActiveByContractor(txCtx, contractorID)
+		if ignoreNotFound(err) != nil {
+			return err
+		}
+
+		if current != nil {
+			if err := current.Retire(author); err != nil {
+				return err
+			}
+
+			if err := s.reportRepository.Update(txCtx, *current); err != nil {
+				return err
+			}
+		}
+
+		return s.reportRepository.Update(txCtx, *report)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Retire takes the version out of use. Retiring the active version leaves the
+// contractor without reports until another one is activated.
+func (s *reportTemplateService) Retire(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error) {
+	report, err := s.getContractorReport(ctx, contractorID, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := report.Retire(author); err != nil {
+		return nil, err
+	}
+
+	if err := s.reportRepository.Update(ctx, *report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Validate checks the placeholders of a version against the fields reports
+// are filled with, so typos are found before the version is activated.
+func (s *reportTemplateService) Validate(ctx context.Context, contractorID, reportID string) (*domain.ReportValidation, error) {
+	content, report, err := s.Download(ctx, contractorID, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := docxtemplate.Parse(content, reportTemplateFuncs)
+	if err != nil {
+		return nil, domain.NewUnprocessableError("report template has invalid placeholders", map[string]any{"report_id": reportID, "error": err.Error()})
+	}
+
+	return &domain.ReportValidation{
+		Report:              *report,
+		UnknownPlaceholders: template.Check(reportTemplateSchema()),
+	}, nil
+}
+
+// ValidateFile checks a docx the way Validate checks a stored version, before
+// it is uploaded, so nothing is stored for a template that needs fixing.
+func (s *reportTemplateService) ValidateFile(ctx context.Context, contractorID, fileName string, file io.Reader) (*domain.ReportValidation, error) {
+	if _, err := s.contractorService.GetByID(ctx, contractorID); err != nil {
+		return nil, err
+	}
+
+	_, template, err := readReportTemplate(fileName, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ReportValidation{
+		Report:              domain.Report{ContractorID: contractorID, FileName: fileName},
+		UnknownPlaceholders: template.Check(reportTemplateSchema()),
+	}, nil
+}
+
+// ImportShipped uploads and activates the shipped template of every contractor
+// that has no template version yet. Contractors that already have versions are
+// left alone, so running it on every startup is safe.
+func (s *reportTemplateService) ImportShipped(ctx context.Context, templates fs.FS) error {
+	var errs []error
+	for companyName, fileName := range shippedReportTemplates {
+		contractors, err := s.contractorsOfCompany(ctx, companyName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, contractor := range contractors {
+			if err := s.importShippedTemplate(ctx, templates, contractor.ContractorID, fileName); err != nil {
+				errs = append(errs, fmt.Errorf("importing %s for contractor %s: %w", fileName, contractor.ContractorID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// contractorsOfCompany pages through every contractor with the company name.
+func (s *reportTemplateService) contractorsOfCompany(ctx context.Context, companyName string) ([]domain.Contractor, error) {
+	filters := domain.ContractorFilters{
+		CompanyName:  []string{companyName},
+		PagingFilter: domain.PagingFilter{Limit: exportPageSize},
+	}
+
+	var contractors []domain.Contractor
+	for {
+		page, err := s.contractorService.Search(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		contractors = append(contractors, page.Result...)
+		filters.Offset += len(page.Result)
+		if len(page.Result) < filters.Limit || filters.Offset >= page.Paging.Total {
+			return contractors, nil
+		}
+	}
+}
+
+func (s *reportTemplateService) importShippedTemplate(ctx context.Context, templates fs.FS, contractorID, fileName string) error {
+	versions, err := s.reportRepository.GetByContractor(ctx, contractorID)
+	if err != nil {
+		return err
+	}
+
+	if len(versions) > 0 {
+		return nil
+	}
+
+	file, err := templates.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := domain.NewReport(contractorID, "", fileName, shippedReportTemplateAuthor)
+	if err != nil {
+		return err
+	}
+
+	uploaded, err := s.Upload(ctx, report, file)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Activate(ctx, contractorID, uploaded.ReportID, shippedReportTemplateAuthor)
+	return err
+}
+
+// readReportTemplate reads an uploaded docx whole and parses its placeholders.
+// Files that are not docx documents are refused as bad requests, templates
+// whose placeholders cannot be parsed as unprocessable.
+func readReportTemplate(fileName string, file io.Reader) ([]byte, *docxtemplate.Template, error) {
+	if !strings.EqualFold(filepath.Ext(fileName), ".docx") {
+		return nil, nil, domain.NewValidationError("report template must be a docx file", map[string]any{"file_name": fileName})
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file, maxReportTemplateSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(content) == 0 {
+		return nil, nil, domain.NewValidationError("report template cannot be empty", map[string]any{"file_name": fileName})
+	}
+
+	if len(content) > maxReportTemplateSize {
+		return nil, nil, domain.NewValidationError("report template is too large", map[string]any{"file_name": fileName, "max_size": maxReportTemplateSize})
+	}
+
+	document, err := docx.ReadDocxFromMemory(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, domain.NewValidationError("report template is not a valid docx file", map[string]any{"file_name": fileName, "error": err.Error()})
+	}
+	_ = document.Close()
+
+	template, err := docxtemplate.Parse(content, reportTemplateFuncs)
+	if err != nil {
+		return nil, nil, domain.NewUnprocessableError("report template has invalid placeholders", map[string]any{"file_name": fileName, "error": err.Error()})
+	}
+
+	return content, template, nil
+}
+
+func (s *reportTemplateService) getContractorReport(ctx context.Context, contractorID, reportID string) (*domain.Report, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID cannot be empty", nil)
+	}
+
+	report, err := s.reportRepository.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.ContractorID != contractorID {
+		return nil, domain.NewNotFoundError("no report template found with this id for this contractor", map[string]any{"report_id": reportID, "contractor_id": contractorID})
+	}
+
+	return report, nil
+}
@@ -0,0 +1,403 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/icrxz/crm-api-core/pkg/docxtemplate"
+	"github.com/icrxz/crm-api-core/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type reportTemplateServiceMocks struct {
+	reportRepository   *mock_domain.MockReportRepository
+	contractorService  *mock_application.MockContractorService
+	attachmentBucket   *mock_domain.MockAttachmentBucket
+	transactionManager *mock_domain.MockTransactionManager
+}
+
+func newReportTemplateServiceForTest(t *testing.T) (ReportTemplateService, *reportTemplateServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &reportTemplateServiceMocks{
+		reportRepository:   mock_domain.NewMockReportRepository(ctrl),
+		contractorService:  mock_application.NewMockContractorService(ctrl),
+		attachmentBucket:   mock_domain.NewMockAttachmentBucket(ctrl),
+		transactionManager: mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	return NewReportTemplateService(mocks.reportRepository, mocks.contractorService, mocks.attachmentBucket, mocks.transactionManager), mocks
+}
+
+// newTestDocx builds the smallest docx the template reader accepts, holding
+// the given paragraphs.
+func newTestDocx(t *testing.T, paragraphs ...string) []byte {
+	t.Helper()
+
+	var body strings.Builder
+	for _, paragraph := range paragraphs {
+		body.WriteString("<w:p><w:r><w:t>" + paragraph + "</w:t></w:r></w:p>")
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	parts := map[string]string{
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			body.String() + `</w:body></w:document>`,
+		"word/_rels/document.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"></Relationships>`,
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"></Types>`,
+	}
+	for name, content := range parts {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return buffer.Bytes()
+}
+
+func TestReportTemplateService_Upload(t *testing.T) {
+	t.Run("stores the file and registers a draft with the next version", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		report, err := domain.NewReport("contractor-1", "", "cardif.docx", "operator-1")
+		require.NoError(t, err)
+		content := newTestDocx(t, "Sinistro $claim")
+
+		mocks.contractorService.EXPECT().GetByID(gomock.Any(), "contractor-1").Return(&domain.Contractor{ContractorID: "contractor-1"}, nil)
+		mocks.attachmentBucket.EXPECT().Upload(gomock.Any(), report.ReportTemplate, content, domain.REPORT_DOCX.ContentType()).Return(nil)
+		mocks.reportRepository.EXPECT().Create(gomock.Any(), report).Return(3, nil)
+
+		uploaded, err := service.Upload(context.Background(), report, bytes.NewReader(content))
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, uploaded.Version)
+		assert.Equal(t, domain.REPORT_DRAFT, uploaded.Status)
+		assert.Equal(t, "cardif.docx", uploaded.ReportName)
+		assert.True(t, strings.HasPrefix(uploaded.ReportTemplate, "report-templates/contractor-1/"))
+	})
+
+	t.Run("deletes the stored file when the version cannot be registered", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		report, err := domain.NewReport("contractor-1", "", "cardif.docx", "operator-1")
+		require.NoError(t, err)
+		content := newTestDocx(t, "Sinistro $claim")
+		createErr := errors.New("duplicate key value violates unique constraint")
+
+		mocks.contractorService.EXPECT().GetByID(gomock.Any(), "contractor-1").Return(&domain.Contractor{ContractorID: "contractor-1"}, nil)
+		mocks.attachmentBucket.EXPECT().Upload(gomock.Any(), report.ReportTemplate, content, domain.REPORT_DOCX.ContentType()).Return(nil)
+		mocks.reportRepository.EXPECT().Create(gomock.Any(), report).Return(0, createErr)
+		mocks.attachmentBucket.EXPECT().Delete(gomock.Any(), report.ReportTemplate).Return(nil)
+
+		_, err = service.Upload(context.Background(), report, bytes.NewReader(content))
+
+		assert.ErrorIs(t, err, createErr)
+	})
+
+	t.Run("rejects files that are not docx documents", func(t *testing.T) {
+		service, _ := newReportTemplateServiceForTest(t)
+
+		for fileName, content := range map[string][]byte{
+			"cardif.pdf":  newTestDocx(t),
+			"cardif.docx": []byte("not a zip"),
+			"empty.docx":  nil,
+		} {
+			report, err := domain.NewReport("contractor-1", "", fileName, "operator-1")
+			require.NoError(t, err)
+
+			_, err = service.Upload(context.Background(), report, bytes.NewReader(content))
+
+			var customErr *domain.CustomError
+			require.ErrorAs(t, err, &customErr, fileName)
+			assert.Equal(t, http.StatusBadRequest, customErr.StatusCode(), fileName)
+		}
+	})
+
+	t.Run("refuses templates whose placeholders cannot be parsed", func(t *testing.T) {
+		service, _ := newReportTemplateServiceForTest(t)
+
+		report, err := domain.NewReport("contractor-1", "", "loops.docx", "operator-1")
+		require.NoError(t, err)
+
+		_, err = service.Upload(context.Background(), report, bytes.NewReader(newTestDocx(t, "{{#each comments as comment}}{{comment.content}}")))
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, customErr.StatusCode())
+	})
+}
+
+func TestReportTemplateService_Activate(t *testing.T) {
+	t.Run("retires the version that was active", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		draft := domain.Report{ReportID: "report-2", ContractorID: "contractor-1", Version: 2, Status: domain.REPORT_DRAFT}
+		active := domain.Report{ReportID: "report-1", ContractorID: "contractor-1", Version: 1, Status: domain.REPORT_ACTIVE}
+
+		mocks.reportRepository.EXPECT().GetByID(gomock.Any(), "report-2").Return(&draft, nil)
+		mocks.reportRepository.EXPECT().GetActiveByContractor(gomock.Any(), "contractor-1").Return(&active, nil)
+		gomock.InOrder(
+			mocks.reportRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, report domain.Report) error {
+					assert.Equal(t, "report-1", report.ReportID)
+					assert.Equal(t, domain.REPORT_RETIRED, report.Status)
+					return nil
+				}),
+			mocks.reportRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, report domain.Report) error {
+					assert.Equal(t, "report-2", report.ReportID)
+					assert.Equal(t, domain.REPORT_ACTIVE, report.Status)
+					assert.Equal(t, "operator-1", report.UpdatedBy)
+					return nil
+				}),
+		)
+
+		activated, err := service.Activate(context.Background(), "contractor-1", "report-2", "operator-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.REPORT_ACTIVE, activated.Status)
+	})
+
+	t.Run("activates the first version of a contractor", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		draft := domain.Report{ReportID: "report-1", ContractorID: "contractor-1", Version: 1, Status: domain.REPORT_DRAFT}
+
+		mocks.reportRepository.EXPECT().GetByID(gomock.Any(), "report-1").Return(&draft, nil)
+		mocks.reportRepository.EXPECT().GetActiveByContractor(gomock.Any(), "contractor-1").
+			Return(nil, domain.NewNotFoundError("no active report template found for this contractor", nil))
+		mocks.reportRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		activated, err := service.Activate(context.Background(), "contractor-1", "report-1", "operator-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.REPORT_ACTIVE, activated.Status)
+	})
+
+	t.Run("does not find versions of other contractors", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		mocks.reportRepository.EXPECT().GetByID(gomock.Any(), "report-1").
+			Return(&domain.Report{ReportID: "report-1", ContractorID: "contractor-2", Status: domain.REPORT_DRAFT}, nil)
+
+		_, err := service.Activate(context.Background(), "contractor-1", "report-1", "operator-1")
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusNotFound, customErr.StatusCode())
+	})
+}
+
+func TestReportTemplateService_Validate(t *testing.T) {
+	report := domain.Report{ReportID: "report-1", ContractorID: "contractor-1", ReportTemplate: "report-templates/contractor-1/report-1.docx", Version: 2}
+
+	t.Run("lists the placeholders reports cannot be filled with", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		content := newTestDocx(t, "{{case.claim}} {{customer.nickname}}", "$claim")
+
+		mocks.reportRepository.EXPECT().GetByID(gomock.Any(), "report-1").Return(&report, nil)
+		mocks.attachmentBucket.EXPECT().Download(gomock.Any(), report.ReportTemplate).Return(content, nil)
+
+		validation, err := service.Validate(context.Background(), "contractor-1", "report-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, report, validation.Report)
+		assert.Equal(t, []string{"customer.nickname"}, validation.UnknownPlaceholders)
+	})
+
+	t.Run("reports unparsable placeholders as unprocessable", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		content := newTestDocx(t, "{{#each comments as comment}}{{comment.content}}")
+
+		mocks.reportRepository.EXPECT().GetByID(gomock.Any(), "report-1").Return(&report, nil)
+		mocks.attachmentBucket.EXPECT().Download(gomock.Any(), report.ReportTemplate).Return(content, nil)
+
+		_, err := service.Validate(context.Background(), "contractor-1", "report-1")
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, customErr.StatusCode())
+	})
+}
+
+func TestReportTemplateService_ValidateFile(t *testing.T) {
+	t.Run("checks the placeholders without storing the file", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		content := newTestDocx(t, "{{case.claim}} {{customer.nickname}}")
+
+		mocks.contractorService.EXPECT().GetByID(gomock.Any(), "contractor-1").Return(&domain.Contractor{ContractorID: "contractor-1"}, nil)
+
+		validation, err := service.ValidateFile(context.Background(), "contractor-1", "cardif.docx", bytes.NewReader(content))
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.Report{ContractorID: "contractor-1", FileName: "cardif.docx"}, validation.Report)
+		assert.Equal(t, []string{"customer.nickname"}, validation.UnknownPlaceholders)
+	})
+
+	t.Run("reports unparsable placeholders as unprocessable", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		content := newTestDocx(t, "{{#each comments as comment}}{{comment.content}}")
+
+		mocks.contractorService.EXPECT().GetByID(gomock.Any(), "contractor-1").Return(&domain.Contractor{ContractorID: "contractor-1"}, nil)
+
+		_, err := service.ValidateFile(context.Background(), "contractor-1", "loops.docx", bytes.NewReader(content))
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, customErr.StatusCode())
+	})
+
+	t.Run("refuses files that are not docx documents", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		mocks.contractorService.EXPECT().GetByID(gomock.Any(), "contractor-1").Return(&domain.Contractor{ContractorID: "contractor-1"}, nil)
+
+		_, err := service.ValidateFile(context.Background(), "contractor-1", "cardif.pdf", bytes.NewReader(newTestDocx(t)))
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+}
+
+func TestReportTemplateService_ImportShipped(t *testing.T) {
+	t.Run("uploads and activates the template of contractors without versions", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		content := newTestDocx(t, "Sinistro {{case.claim}}")
+		templates := fstest.MapFS{"cardif_template.docx": {Data: content}}
+
+		for companyName := range shippedReportTemplates {
+			page := domain.PagingResult[domain.Contractor]{}
+			if companyName == "Cardif" {
+				page = domain.PagingResult[domain.Contractor]{Result: []domain.Contractor{{ContractorID: "contractor-1"}}, Paging: domain.Paging{Total: 1}}
+			}
+			mocks.contractorService.EXPECT().Search(gomock.Any(), shippedTemplateContractorFilters(companyName, 0)).Return(page, nil)
+		}
+		mocks.reportRepository.EXPECT().GetByContractor(gomock.Any(), "contractor-1").Return(nil, nil)
+		mocks.contractorService.EXPECT().GetByID(gomock.Any(), "contractor-1").Return(&domain.Contractor{ContractorID: "contractor-1"}, nil)
+		mocks.attachmentBucket.EXPECT().Upload(gomock.Any(), gomock.Any(), content, domain.REPORT_DOCX.ContentType()).Return(nil)
+
+		var created domain.Report
+		mocks.reportRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, report domain.Report) (int, error) {
+				created = report
+				return 1, nil
+			})
+		mocks.reportRepository.EXPECT().GetByID(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string) (*domain.Report, error) {
+				return &created, nil
+			})
+		mocks.reportRepository.EXPECT().GetActiveByContractor(gomock.Any(), "contractor-1").
+			Return(nil, domain.NewNotFoundError("no active report template found for this contractor", nil))
+		mocks.reportRepository.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, report domain.Report) error {
+				assert.Equal(t, "cardif_template.docx", report.FileName)
+				assert.Equal(t, domain.REPORT_ACTIVE, report.Status)
+				assert.Equal(t, shippedReportTemplateAuthor, report.UpdatedBy)
+				return nil
+			})
+
+		err := service.ImportShipped(context.Background(), templates)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("leaves contractors that already have versions alone", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		for companyName := range shippedReportTemplates {
+			mocks.contractorService.EXPECT().Search(gomock.Any(), shippedTemplateContractorFilters(companyName, 0)).
+				Return(domain.PagingResult[domain.Contractor]{Result: []domain.Contractor{{ContractorID: "contractor-1"}}, Paging: domain.Paging{Total: 1}}, nil)
+		}
+		mocks.reportRepository.EXPECT().GetByContractor(gomock.Any(), "contractor-1").
+			Return([]domain.Report{{ReportID: "report-1", ContractorID: "contractor-1"}}, nil).
+			Times(len(shippedReportTemplates))
+
+		err := service.ImportShipped(context.Background(), fstest.MapFS{})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("pages through every contractor of the company", func(t *testing.T) {
+		service, mocks := newReportTemplateServiceForTest(t)
+
+		firstPage := make([]domain.Contractor, exportPageSize)
+		for i := range firstPage {
+			firstPage[i] = domain.Contractor{ContractorID: fmt.Sprintf("contractor-%d", i)}
+		}
+		total := domain.Paging{Total: exportPageSize + 1}
+
+		for companyName := range shippedReportTemplates {
+			if companyName != "Cardif" {
+				mocks.contractorService.EXPECT().Search(gomock.Any(), shippedTemplateContractorFilters(companyName, 0)).
+					Return(domain.PagingResult[domain.Contractor]{}, nil)
+			}
+		}
+		gomock.InOrder(
+			mocks.contractorService.EXPECT().Search(gomock.Any(), shippedTemplateContractorFilters("Cardif", 0)).
+				Return(domain.PagingResult[domain.Contractor]{Result: firstPage, Paging: total}, nil),
+			mocks.contractorService.EXPECT().Search(gomock.Any(), shippedTemplateContractorFilters("Cardif", exportPageSize)).
+				Return(domain.PagingResult[domain.Contractor]{Result: []domain.Contractor{{ContractorID: "contractor-last"}}, Paging: total}, nil),
+		)
+		mocks.reportRepository.EXPECT().GetByContractor(gomock.Any(), "contractor-last").
+			Return([]domain.Report{{ReportID: "report-1"}}, nil)
+		mocks.reportRepository.EXPECT().GetByContractor(gomock.Any(), gomock.Any()).
+			Return([]domain.Report{{ReportID: "report-1"}}, nil).
+			Times(exportPageSize)
+
+		err := service.ImportShipped(context.Background(), fstest.MapFS{})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("ships a valid file for every contractor", func(t *testing.T) {
+		templates, err := fs.Sub(resources.Reports, "reports")
+		require.NoError(t, err)
+
+		for companyName, fileName := range shippedReportTemplates {
+			content, err := fs.ReadFile(templates, fileName)
+			require.NoError(t, err, companyName)
+
+			_, err = docxtemplate.Parse(content, reportTemplateFuncs)
+			assert.NoError(t, err, companyName)
+		}
+	})
+}
+
+func shippedTemplateContractorFilters(companyName string, offset int) domain.ContractorFilters {
+	return domain.ContractorFilters{
+		CompanyName:  []string{companyName},
+		PagingFilter: domain.PagingFilter{Limit: exportPageSize, Offset: offset},
+	}
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"slices"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type settlementService struct {
+	settlementRepository  domain.SettlementRepository
+	caseRepository        domain.CaseRepository
+	productService        ProductService
+	caseHistoryRepository domain.CaseHistoryRepository
+	quoteService          QuoteService
+	transactionManager    domain.TransactionManager
+}
+
+//go:generate mockgen -source=settlement_service.go -destination=mock_application/mock_settlement_service.go -package=mock_application
+type SettlementService interface {
+	GetPolicy(ctx context.Context, contractorID string) (*domain.SettlementPolicy, error)
+	SavePolicy(ctx context.Context, policy domain.SettlementPolicy) error
+	Decide(ctx context.Context, caseID string, input domain.DecideSettlement) (*domain.SettlementDecision, error)
+	GetDecision(ctx context.Context, caseID string) (*domain.SettlementDecision, error)
+}
+
+func NewSettlementService(
+	settlementRepository domain.SettlementRepository,
+	caseRepository domain.CaseRepository,
+	productService ProductService,
+	caseHistoryRepository domain.CaseHistoryRepository,
+	quoteService QuoteService,
+	transactionManager domain.TransactionManager,
+) SettlementService {
+	return &settlementService{
+		settlementRepository:  settlementRepository,
+		caseRepository:        caseRepository,
+		productService:        productService,
+		caseHistoryRepository: caseHistoryRepository,
+		quoteService:          quoteService,
+		transactionManager:    transactionManager,
+	}
+}
+
+func (s *settlementService) GetPolicy(ctx context.Context, contractorID string) (*domain.SettlementPolicy, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID cannot be empty", nil)
+	}
+
+	policy, err := s.settlementRepository.GetPolicy(ctx, contractorID)
+	if err != nil {
+		if ignoreNotFound(err) == nil {
+			defaultPolicy := domain.DefaultSettlementPolicy(contractorID)
+			return &defaultPolicy, nil
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (s *settlementService) SavePolicy(ctx context.Context, policy domain.SettlementPolicy) error {
+	return s.settlementRepository.SavePolicy(ctx, policy)
+}
+
+func (s *settlementService) Decide(ctx context.Context, caseID string, input domain.DecideSettlement) (*domain.SettlementDecision, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	crmCase, err := s.caseRepository.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains([]domain.CaseStatus{domain.CLOSED, domain.CANCELED, domain.REJECTED}, crmCase.Status) {
+		return nil, domain.NewValidationError("cannot decide the settlement of a finished case", map[string]any{"status": crmCase.Status})
+	}
+
+	product, err := s.productService.GetProductByID(ctx, crmCase.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := s.quoteService.GetByCaseID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvedQuote := domain.LatestApprovedQuote(quotes)
+	if approvedQuote == nil && input.Override == nil {
+		return nil, domain.NewValidationError("an approved quote is required to evaluate the repair cost", map[string]any{"case_id": caseID})
+	}
+
+	var repairCost float64
+	if approvedQuote != nil {
+		repairCost = approvedQuote.Total()
+	}
+
+	policy, err := s.GetPolicy(ctx, crmCase.ContractorID)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := policy.Decide(caseID, product.Value, repairCost, input)
+	if err != nil {
+		return nil, err
+	}
+
+	previousValues := map[string]any{}
+	previousDecision, err := s.settlementRepository.GetDecision(ctx, caseID)
+	if ignoreNotFound(err) != nil {
+		return nil, err
+	}
+	if previousDecision != nil {
+		previousValues = previousDecision.Snapshot()
+	}
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.settlementRepository.SaveDecision(txCtx, decision); err != nil {
+			return err
+		}
+
+		history, err := domain.NewCaseHistory(caseID, domain.CaseSettlementDecidedEvent, input.DecidedBy, previousValues, decision.Snapshot())
+		if err != nil {
+			return err
+		}
+
+		return s.caseHistoryRepository.Create(txCtx, history)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &decision, nil
+}
+
+func (s *settlementService) GetDecision(ctx context.Context, caseID string) (*domain.SettlementDecision, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	return s.settlementRepository.GetDecision(ctx, caseID)
+}
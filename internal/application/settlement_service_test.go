@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type settlementServiceMocks struct {
+	settlementRepository  *mock_domain.MockSettlementRepository
+	caseRepository        *mock_domain.MockCaseRepository
+	productService        *mock_application.MockProductService
+	caseHistoryRepository *mock_domain.MockCaseHistoryRepository
+	quoteService          *mock_application.MockQuoteService
+	transactionManager    *mock_domain.MockTransactionManager
+}
+
+func newSettlementServiceForTest(t *testing.T) (SettlementService, *settlementServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &settlementServiceMocks{
+		settlementRepository:  mock_domain.NewMockSettlementRepository(ctrl),
+		caseRepository:        mock_domain.NewMockCaseRepository(ctrl),
+		productService:        mock_application.NewMockProductService(ctrl),
+		caseHistoryRepository: mock_domain.NewMockCaseHistoryRepository(ctrl),
+		quoteService:          mock_application.NewMockQuoteService(ctrl),
+		transactionManager:    mock_domain.NewMockTransactionManager(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewSettlementService(
+		mocks.settlementRepository,
+		mocks.caseRepository,
+		mocks.productService,
+		mocks.caseHistoryRepository,
+		mocks.quoteService,
+		mocks.transactionManager,
+	)
+
+	return service, mocks
+}
+
+func expectSettlementCase(mocks *settlementServiceMocks, productValue float64, quotes []domain.Quote) {
+	mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+		Return(&domain.Case{CaseID: "case-1", ContractorID: "contractor-1", ProductID: "product-1", Status: domain.ONGOING}, nil)
+	mocks.productService.EXPECT().GetProductByID(gomock.Any(), "product-1").
+		Return(&domain.Product{ProductID: "product-1", Value: productValue}, nil)
+	mocks.quoteService.EXPECT().GetByCaseID(gomock.Any(), "case-1").Return(quotes, nil)
+}
+
+func TestSettlementService_Decide(t *testing.T) {
+	approvedQuote := domain.Quote{
+		QuoteID:   "quote-1",
+		Status:    domain.QUOTE_APPROVED,
+		Items:     []domain.QuoteItem{{Type: domain.QUOTE_ITEM_PARTS, Quantity: 1, UnitValue: 900}},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	t.Run("declares total loss using the contractor policy and records history", func(t *testing.T) {
+		service, mocks := newSettlementServiceForTest(t)
+		expectSettlementCase(mocks, 1000, []domain.Quote{approvedQuote})
+
+		mocks.settlementRepository.EXPECT().GetPolicy(gomock.Any(), "contractor-1").
+			Return(&domain.SettlementPolicy{ContractorID: "contractor-1", TotalLossThreshold: 0.8, TotalLossSettlement: domain.SETTLEMENT_CASH_INDEMNITY}, nil)
+		mocks.settlementRepository.EXPECT().GetDecision(gomock.Any(), "case-1").
+			Return(nil, domain.NewNotFoundError("not found", nil))
+		mocks.settlementRepository.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).Return(nil)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.CaseSettlementDecidedEvent, history.EventName)
+				assert.Equal(t, domain.SETTLEMENT_CASH_INDEMNITY, history.NewValues["settlement_type"])
+				return nil
+			},
+		)
+
+		decision, err := service.Decide(context.Background(), "case-1", domain.DecideSettlement{DecidedBy: "operator-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SETTLEMENT_CASH_INDEMNITY, decision.Type)
+		assert.InDelta(t, 900, decision.RepairCost, 0.001)
+	})
+
+	t.Run("falls back to the default policy", func(t *testing.T) {
+		service, mocks := newSettlementServiceForTest(t)
+		expectSettlementCase(mocks, 1000, []domain.Quote{approvedQuote})
+
+		mocks.settlementRepository.EXPECT().GetPolicy(gomock.Any(), "contractor-1").
+			Return(nil, domain.NewNotFoundError("not found", nil))
+		mocks.settlementRepository.EXPECT().GetDecision(gomock.Any(), "case-1").
+			Return(nil, domain.NewNotFoundError("not found", nil))
+		mocks.settlementRepository.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).Return(nil)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		decision, err := service.Decide(context.Background(), "case-1", domain.DecideSettlement{DecidedBy: "operator-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SETTLEMENT_REPLACEMENT, decision.Type)
+		assert.InDelta(t, domain.DefaultTotalLossThreshold, decision.Threshold, 0.0001)
+	})
+
+	t.Run("requires an approved quote unless overridden", func(t *testing.T) {
+		service, mocks := newSettlementServiceForTest(t)
+		expectSettlementCase(mocks, 1000, nil)
+
+		_, err := service.Decide(context.Background(), "case-1", domain.DecideSettlement{DecidedBy: "operator-1"})
+
+		require.Error(t, err)
+	})
+}
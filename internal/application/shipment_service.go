@@ -0,0 +1,225 @@
+package application
+
+import (
+	"context"
+	"slices"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type shipmentService struct {
+	shipmentRepository    domain.ShipmentRepository
+	caseRepository        domain.CaseRepository
+	caseHistoryRepository domain.CaseHistoryRepository
+	customerService       CustomerService
+	partnerService        PartnerService
+	transactionManager    domain.TransactionManager
+	carriers              map[string]domain.Carrier
+}
+
+//go:generate mockgen -source=shipment_service.go -destination=mock_application/mock_shipment_service.go -package=mock_application
+type ShipmentService interface {
+	Create(ctx context.Context, input domain.CreateShipment) (*domain.Shipment, error)
+	GetByID(ctx context.Context, shipmentID string) (*domain.Shipment, error)
+	GetByCaseID(ctx context.Context, caseID string) ([]domain.Shipment, error)
+	Refresh(ctx context.Context, shipmentID string, author string) (*domain.Shipment, error)
+	RegisterEvent(ctx context.Context, shipmentID string, event domain.ShipmentEvent, author string) (*domain.Shipment, error)
+}
+
+func NewShipmentService(
+	shipmentRepository domain.ShipmentRepository,
+	caseRepository domain.CaseRepository,
+	caseHistoryRepository domain.CaseHistoryRepository,
+	customerService CustomerService,
+	partnerService PartnerService,
+	transactionManager domain.TransactionManager,
+	carriers ...domain.Carrier,
+) ShipmentService {
+	carriersByName := make(map[string]domain.Carrier, len(carriers))
+	for _, carrier := range carriers {
+		carriersByName[carrier.Name()] = carrier
+	}
+
+	return &shipmentService{
+		shipmentRepository:    shipmentRepository,
+		caseRepository:        caseRepository,
+		caseHistoryRepository: caseHistoryRepository,
+		customerService:       customerService,
+		partnerService:        partnerService,
+		transactionManager:    transactionManager,
+		carriers:              carriersByName,
+	}
+}
+
+func (s *shipmentService) Create(ctx context.Context, input domain.CreateShipment) (*domain.Shipment, error) {
+	carrier, err := s.getCarrier(input.Carrier)
+	if err != nil {
+		return nil, err
+	}
+
+	crmCase, err := s.caseRepository.GetByID(ctx, input.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains([]domain.CaseStatus{domain.CLOSED, domain.CANCELED, domain.REJECTED}, crmCase.Status) {
+		return nil, domain.NewValidationError("cannot ship products of a finished case", map[string]any{"status": crmCase.Status})
+	}
+
+	if crmCase.PartnerID == "" {
+		return nil, domain.NewValidationError("case must have a partner before shipping", map[string]any{"case_id": crmCase.CaseID})
+	}
+
+	customer, err := s.customerService.GetByID(ctx, crmCase.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	partner, err := s.partnerService.GetByID(ctx, crmCase.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	origin, destination := customer.ShippingAddress, partner.ShippingAddress
+	if input.Direction == domain.SHIPMENT_TO_CUSTOMER {
+		origin, destination = destination, origin
+	}
+
+	shipment, err := domain.NewShipment(crmCase.CaseID, input.Direction, carrier.Name(), origin, destination, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	trackingCode, err := carrier.CreateShipment(ctx, shipment)
+	if err != nil {
+		return nil, err
+	}
+	shipment.TrackingCode = trackingCode
+
+	err = s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.shipmentRepository.Create(txCtx, shipment); err != nil {
+			return err
+		}
+
+		return s.recordHistory(txCtx, shipment.CaseID, domain.ShipmentCreatedEvent, input.CreatedBy, map[string]any{}, shipment.Snapshot())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &shipment, nil
+}
+
+func (s *shipmentService) GetByID(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	if shipmentID == "" {
+		return nil, domain.NewValidationError("shipmentID cannot be empty", nil)
+	}
+
+	return s.shipmentRepository.GetByID(ctx, shipmentID)
+}
+
+func (s *shipmentService) GetByCaseID(ctx context.Context, caseID string) ([]domain.Shipment, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID cannot be empty", nil)
+	}
+
+	return s.shipmentRepository.GetByCaseID(ctx, caseID)
+}
+
+func (s *shipmentService) Refresh(ctx context.Context, shipmentID string, author string) (*domain.Shipment, error) {
+	shipment, err := s.GetByID(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shipment.IsFinished() {
+		return shipment, nil
+	}
+
+	carrier, err := s.getCarrier(shipment.Carrier)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := carrier.Track(ctx, shipment.TrackingCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyEvents(ctx, shipment, events, author); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+func (s *shipmentService) RegisterEvent(ctx context.Context, shipmentID string, event domain.ShipmentEvent, author string) (*domain.Shipment, error) {
+	shipment, err := s.GetByID(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shipment.IsFinished() {
+		return nil, domain.NewConflictError("shipment is already finished", map[string]any{"status": shipment.Status})
+	}
+
+	if err := s.applyEvents(ctx, shipment, []domain.ShipmentEvent{event}, author); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// applyEvents persists the new tracking events and, when the status moved,
+// records it in the case timeline.
+func (s *shipmentService) applyEvents(ctx context.Context, shipment *domain.Shipment, events []domain.ShipmentEvent, author string) error {
+	oldValues := shipment.Snapshot()
+
+	newEvents, err := shipment.ApplyEvents(events, author)
+	if err != nil {
+		return err
+	}
+
+	if len(newEvents) == 0 {
+		return nil
+	}
+
+	return s.transactionManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.shipmentRepository.CreateEvents(txCtx, newEvents); err != nil {
+			return err
+		}
+
+		if err := s.shipmentRepository.Update(txCtx, *shipment); err != nil {
+			return err
+		}
+
+		if oldValues["status"] == shipment.Status {
+			return nil
+		}
+
+		newValues := shipment.Snapshot()
+		latest := newEvents[len(newEvents)-1]
+		newValues["description"] = latest.Description
+		newValues["location"] = latest.Location
+
+		return s.recordHistory(txCtx, shipment.CaseID, domain.ShipmentStatusChangedEvent, author, oldValues, newValues)
+	})
+}
+
+func (s *shipmentService) getCarrier(name string) (domain.Carrier, error) {
+	carrier, found := s.carriers[name]
+	if !found {
+		return nil, domain.NewValidationError("unknown carrier", map[string]any{"carrier": name})
+	}
+
+	return carrier, nil
+}
+
+func (s *shipmentService) recordHistory(ctx context.Context, caseID, eventName, author string, oldValues, newValues map[string]any) error {
+	history, err := domain.NewCaseHistory(caseID, eventName, author, oldValues, newValues)
+	if err != nil {
+		return err
+	}
+
+	return s.caseHistoryRepository.Create(ctx, history)
+}
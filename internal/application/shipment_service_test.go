@@ -0,0 +1,159 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/domain/mock_domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type shipmentServiceMocks struct {
+	shipmentRepository    *mock_domain.MockShipmentRepository
+	caseRepository        *mock_domain.MockCaseRepository
+	caseHistoryRepository *mock_domain.MockCaseHistoryRepository
+	customerService       *mock_application.MockCustomerService
+	partnerService        *mock_application.MockPartnerService
+	transactionManager    *mock_domain.MockTransactionManager
+	carrier               *mock_domain.MockCarrier
+}
+
+func newShipmentServiceForTest(t *testing.T) (ShipmentService, *shipmentServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mocks := &shipmentServiceMocks{
+		shipmentRepository:    mock_domain.NewMockShipmentRepository(ctrl),
+		caseRepository:        mock_domain.NewMockCaseRepository(ctrl),
+		caseHistoryRepository: mock_domain.NewMockCaseHistoryRepository(ctrl),
+		customerService:       mock_application.NewMockCustomerService(ctrl),
+		partnerService:        mock_application.NewMockPartnerService(ctrl),
+		transactionManager:    mock_domain.NewMockTransactionManager(ctrl),
+		carrier:               mock_domain.NewMockCarrier(ctrl),
+	}
+
+	mocks.transactionManager.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+	mocks.carrier.EXPECT().Name().Return("fake").AnyTimes()
+
+	service := NewShipmentService(
+		mocks.shipmentRepository,
+		mocks.caseRepository,
+		mocks.caseHistoryRepository,
+		mocks.customerService,
+		mocks.partnerService,
+		mocks.transactionManager,
+		mocks.carrier,
+	)
+
+	return service, mocks
+}
+
+func TestShipmentService_Create(t *testing.T) {
+	t.Run("ships from the customer to the partner and records the timeline", func(t *testing.T) {
+		service, mocks := newShipmentServiceForTest(t)
+
+		mocks.caseRepository.EXPECT().GetByID(gomock.Any(), "case-1").
+			Return(&domain.Case{CaseID: "case-1", CustomerID: "customer-1", PartnerID: "partner-1", Status: domain.WAITING_PARTNER}, nil)
+		mocks.customerService.EXPECT().GetByID(gomock.Any(), "customer-1").
+			Return(&domain.Customer{ShippingAddress: domain.Address{Address: "Rua do Cliente"}}, nil)
+		mocks.partnerService.EXPECT().GetByID(gomock.Any(), "partner-1").
+			Return(&domain.Partner{ShippingAddress: domain.Address{Address: "Rua do Parceiro"}}, nil)
+		mocks.carrier.EXPECT().CreateShipment(gomock.Any(), gomock.Any()).Return("TRACK-1", nil)
+		mocks.shipmentRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, shipment domain.Shipment) (string, error) {
+				assert.Equal(t, "Rua do Cliente", shipment.Origin.Address)
+				assert.Equal(t, "Rua do Parceiro", shipment.Destination.Address)
+				assert.Equal(t, "TRACK-1", shipment.TrackingCode)
+				return shipment.ShipmentID, nil
+			},
+		)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.ShipmentCreatedEvent, history.EventName)
+				return nil
+			},
+		)
+
+		shipment, err := service.Create(context.Background(), domain.CreateShipment{
+			CaseID:    "case-1",
+			Direction: domain.SHIPMENT_TO_PARTNER,
+			Carrier:   "fake",
+			CreatedBy: "operator-1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SHIPMENT_CREATED, shipment.Status)
+	})
+
+	t.Run("returns validation error for an unknown carrier", func(t *testing.T) {
+		service, _ := newShipmentServiceForTest(t)
+
+		_, err := service.Create(context.Background(), domain.CreateShipment{CaseID: "case-1", Carrier: "pigeon"})
+
+		require.Error(t, err)
+	})
+}
+
+func TestShipmentService_Refresh(t *testing.T) {
+	pickedUpAt := time.Now().UTC().Add(-time.Hour)
+
+	newPickedUpShipment := func() *domain.Shipment {
+		return &domain.Shipment{
+			ShipmentID:   "shipment-1",
+			CaseID:       "case-1",
+			Carrier:      "fake",
+			TrackingCode: "TRACK-1",
+			Status:       domain.SHIPMENT_PICKED_UP,
+			Events:       []domain.ShipmentEvent{{Status: domain.SHIPMENT_PICKED_UP, OccurredAt: pickedUpAt}},
+		}
+	}
+
+	t.Run("records status changes reported by the carrier in the timeline", func(t *testing.T) {
+		service, mocks := newShipmentServiceForTest(t)
+
+		mocks.shipmentRepository.EXPECT().GetByID(gomock.Any(), "shipment-1").Return(newPickedUpShipment(), nil)
+		mocks.carrier.EXPECT().Track(gomock.Any(), "TRACK-1").Return([]domain.ShipmentEvent{
+			{Status: domain.SHIPMENT_PICKED_UP, OccurredAt: pickedUpAt},
+			{Status: domain.SHIPMENT_IN_TRANSIT, Location: "Sao Paulo", OccurredAt: pickedUpAt.Add(30 * time.Minute)},
+		}, nil)
+		mocks.shipmentRepository.EXPECT().CreateEvents(gomock.Any(), gomock.Len(1)).Return(nil)
+		mocks.shipmentRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mocks.caseHistoryRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, history domain.CaseHistory) error {
+				assert.Equal(t, domain.ShipmentStatusChangedEvent, history.EventName)
+				assert.Equal(t, domain.SHIPMENT_PICKED_UP, history.OldValues["status"])
+				assert.Equal(t, domain.SHIPMENT_IN_TRANSIT, history.NewValues["status"])
+				assert.Equal(t, "Sao Paulo", history.NewValues["location"])
+				return nil
+			},
+		)
+
+		refreshed, err := service.Refresh(context.Background(), "shipment-1", "operator-1")
+
+		require.NoError(t, err)
+		assert.Len(t, refreshed.Events, 2)
+	})
+
+	t.Run("does nothing when the carrier has no new events", func(t *testing.T) {
+		service, mocks := newShipmentServiceForTest(t)
+
+		mocks.shipmentRepository.EXPECT().GetByID(gomock.Any(), "shipment-1").Return(newPickedUpShipment(), nil)
+		mocks.carrier.EXPECT().Track(gomock.Any(), "TRACK-1").Return([]domain.ShipmentEvent{
+			{Status: domain.SHIPMENT_PICKED_UP, OccurredAt: pickedUpAt},
+		}, nil)
+
+		refreshed, err := service.Refresh(context.Background(), "shipment-1", "operator-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SHIPMENT_PICKED_UP, refreshed.Status)
+	})
+}
@@ -1,11 +1,23 @@
 package application
 
 import (
+	"bytes"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/xls"
 
 	xlsx "github.com/thedatashed/xlsxreader"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
 )
 
 func ParseDocument(document string) string {
@@ -27,9 +39,152 @@ func parseCNPJ(cnpj string) string {
 	return fmt.Sprintf("%s.%s.%s/%s-%s", cnpj[:2], cnpj[2:5], cnpj[5:8], cnpj[8:12], cnpj[12:14])
 }
 
+// maxHeaderSearchRows bounds how far down title and notes rows are looked past
+// when detecting the header of a spreadsheet.
+const maxHeaderSearchRows = 20
+
+// errorReportColumns are the line number and errors columns an import error
+// report adds after the columns of the uploaded file.
+var errorReportColumns = []string{"Linha", "Erros"}
+
+// spreadsheetOptions tune how an uploaded spreadsheet is read. The zero value
+// reads the first sheet and detects the header row.
+type spreadsheetOptions struct {
+	sheet     string
+	headerRow int
+}
+
+func spreadsheetOptionsOf(job domain.ImportJob) spreadsheetOptions {
+	headerRow, _ := strconv.Atoi(job.Param(domain.ImportParamHeaderRow))
+	return spreadsheetOptions{
+		sheet:     job.Param(domain.ImportParamSheet),
+		headerRow: headerRow,
+	}
+}
+
+// spreadsheet keeps every line of the file, so rows[n-1] is line n, together
+// with the index of its header row. Lines above the header are titles or notes
+// insurers put on top of their exports.
+type spreadsheet struct {
+	rows      [][]string
+	headerIdx int
+}
+
+func (s spreadsheet) header() []string {
+	return s.rows[s.headerIdx]
+}
+
+func (s spreadsheet) dataRows() [][]string {
+	return s.rows[s.headerIdx+1:]
+}
+
+// firstDataLine is the 1-based line number of the first row after the header.
+func (s spreadsheet) firstDataLine() int {
+	return s.headerIdx + 2
+}
+
+// readSpreadsheet reads a .csv, .xls or .xlsx upload, picking the reader by
+// the file extension, and locates its header row.
+func readSpreadsheet(fileName string, file io.Reader, options spreadsheetOptions) (*spreadsheet, error) {
+	fileExtension := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+	if !slices.Contains([]string{"csv", "xls", "xlsx"}, fileExtension) {
+		return nil, domain.NewValidationError("file cannot be different from .csv, .xls, .xlsx", nil)
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	if fileExtension == "csv" {
+		rows, err = readCSV(bytes.NewReader(content))
+	} else {
+		rows, err = readWorkbook(content, options.sheet)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, domain.NewValidationError("file has no rows", map[string]any{"file_name": fileName})
+	}
+
+	headerIdx := detectHeaderRow(rows)
+	if options.headerRow > 0 {
+		if options.headerRow > len(rows) {
+			return nil, domain.NewValidationError("header row is past the end of the file", map[string]any{"header_row": options.headerRow, "rows": len(rows)})
+		}
+		headerIdx = options.headerRow - 1
+	}
+
+	sheet := &spreadsheet{rows: rows, headerIdx: headerIdx}
+	sheet.dropErrorReportColumns()
+
+	return sheet, nil
+}
+
+// dropErrorReportColumns cuts the columns an error report ends with, so a
+// fixed report uploaded again reads as the file it was built from.
+func (s *spreadsheet) dropErrorReportColumns() {
+	header := s.header()
+	width := len(header) - len(errorReportColumns)
+	if width < 0 {
+		return
+	}
+
+	for i, column := range errorReportColumns {
+		if strings.TrimSpace(header[width+i]) != column {
+			return
+		}
+	}
+
+	for i, row := range s.rows {
+		s.rows[i] = row[:min(len(row), width)]
+	}
+}
+
+// detectHeaderRow picks the first row, among the top ones, filled almost as
+// much as the widest of them. Title rows above the header fill a cell or two.
+func detectHeaderRow(rows [][]string) int {
+	searchRows := rows[:min(len(rows), maxHeaderSearchRows)]
+
+	filled := make([]int, len(searchRows))
+	widest := 0
+	for i, row := range searchRows {
+		for _, cell := range row {
+			if strings.TrimSpace(cell) != "" {
+				filled[i]++
+			}
+		}
+		widest = max(widest, filled[i])
+	}
+
+	for i, count := range filled {
+		if count > 0 && count*5 >= widest*4 {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// readCSV reads a CSV export, whatever the separator and encoding it was
+// saved with.
 func readCSV(file io.Reader) ([][]string, error) {
-	fileCSV := csv.NewReader(file)
-	fileCSV.Comma = ';' // CSV separator
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err = decodeText(content)
+	if err != nil {
+		return nil, err
+	}
+
+	fileCSV := csv.NewReader(bytes.NewReader(content))
+	fileCSV.Comma = sniffDelimiter(content)
+	fileCSV.FieldsPerRecord = -1 // short rows are reported by row validation
 
 	csvRows := make([][]string, 0)
 
@@ -42,35 +197,212 @@ func readCSV(file io.Reader) ([][]string, error) {
 			return nil, err
 		}
 
+		// blank lines are skipped by the reader, keep them as empty rows so
+		// row numbers match the lines of the file
+		line, _ := fileCSV.FieldPos(0)
+		for len(csvRows) < line-1 {
+			csvRows = append(csvRows, nil)
+		}
+
 		csvRows = append(csvRows, row)
 	}
 
 	return csvRows, nil
 }
 
-func readXLS(file io.Reader) ([][]string, error) {
-	xlsFile, err := io.ReadAll(file)
+// decodeText turns a text upload into UTF-8. Excel saves "Unicode text" as
+// UTF-16 with a BOM and plain CSV in the Windows-1252 code page, which is
+// also a superset of the Latin-1 some insurer systems export.
+func decodeText(content []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xEF, 0xBB, 0xBF}):
+		return content[3:], nil
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}), bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewDecoder().Bytes(content)
+	case !utf8.Valid(content):
+		return charmap.Windows1252.NewDecoder().Bytes(content)
+	default:
+		return content, nil
+	}
+}
+
+// sniffDelimiter picks the separator splitting the first lines of the file
+// into the same number of columns most often, preferring the one giving more
+// columns on ties. Separators inside quoted values are not counted.
+func sniffDelimiter(content []byte) rune {
+	candidates := []rune{';', ',', '\t', '|'}
+
+	lines := make([]string, 0, maxHeaderSearchRows)
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == maxHeaderSearchRows {
+			break
+		}
+	}
+
+	best, bestLines, bestColumns := ';', 0, 0
+	for _, candidate := range candidates {
+		linesByCount := make(map[int]int)
+		for _, line := range lines {
+			count, quoted := 0, false
+			for _, char := range line {
+				switch {
+				case char == '"':
+					quoted = !quoted
+				case char == candidate && !quoted:
+					count++
+				}
+			}
+			if count > 0 {
+				linesByCount[count]++
+			}
+		}
+
+		for count, matchingLines := range linesByCount {
+			if matchingLines > bestLines || matchingLines == bestLines && count > bestColumns {
+				best, bestLines, bestColumns = candidate, matchingLines, count
+			}
+		}
+	}
+
+	return best
+}
+
+func writeCSV(rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	fileCSV := csv.NewWriter(&buffer)
+	fileCSV.Comma = ';' // CSV separator
+
+	if err := fileCSV.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// readWorkbook reads a sheet of an Excel workbook, the first one unless a
+// name is given. The format is told by the content rather than the extension
+// since .xlsx files renamed to .xls, and the other way around, are common.
+func readWorkbook(content []byte, sheet string) ([][]string, error) {
+	if bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		return readXLSX(content, sheet)
+	}
+
+	return readXLS(content, sheet)
+}
+
+func readXLSX(content []byte, sheet string) ([][]string, error) {
+	fileXLSX, err := xlsx.NewReader(content)
 	if err != nil {
 		return nil, err
 	}
 
-	fileXLS, err := xlsx.NewReader(xlsFile)
+	sheetName, err := selectSheet(fileXLSX.Sheets, sheet)
 	if err != nil {
 		return nil, err
 	}
 
-	xlsRows := make([][]string, 0)
+	xlsxRows := make([][]string, 0)
+
+	for row := range fileXLSX.ReadRows(sheetName) {
+		if row.Error != nil {
+			return nil, row.Error
+		}
+
+		// empty rows and cells are left out of the file, place the others
+		// where they belong so row numbers and columns line up
+		for len(xlsxRows) < row.Index-1 {
+			xlsxRows = append(xlsxRows, nil)
+		}
 
-	for row := range fileXLS.ReadRows(fileXLS.Sheets[0]) {
-		xlsxRowCells := make([]string, 0)
+		xlsxRowCells := make([]string, 0, len(row.Cells))
 		for _, cell := range row.Cells {
+			columnIdx := cell.ColumnIndex()
+			for len(xlsxRowCells) < columnIdx {
+				xlsxRowCells = append(xlsxRowCells, "")
+			}
 			xlsxRowCells = append(xlsxRowCells, cell.Value)
 		}
 
-		xlsRows = append(xlsRows, xlsxRowCells)
+		xlsxRows = append(xlsxRows, xlsxRowCells)
+	}
+
+	return xlsxRows, nil
+}
+
+func readXLS(content []byte, sheet string) ([][]string, error) {
+	workbook, err := xls.Read(content)
+	if err != nil {
+		if errors.Is(err, xls.ErrNotXLS) || errors.Is(err, xls.ErrUnsupported) {
+			return nil, domain.NewValidationError(err.Error(), nil)
+		}
+		return nil, err
+	}
+
+	sheetNames := make([]string, 0, len(workbook.Sheets))
+	for _, workbookSheet := range workbook.Sheets {
+		sheetNames = append(sheetNames, workbookSheet.Name)
+	}
+
+	sheetName, err := selectSheet(sheetNames, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	return workbook.Sheets[slices.Index(sheetNames, sheetName)].Rows, nil
+}
+
+// selectSheet finds the sheet named by the operator, ignoring case and
+// surrounding spaces, or the first one when no name is given.
+func selectSheet(sheetNames []string, sheet string) (string, error) {
+	if len(sheetNames) == 0 {
+		return "", domain.NewValidationError("workbook has no sheets", nil)
+	}
+
+	if sheet == "" {
+		return sheetNames[0], nil
+	}
+
+	for _, sheetName := range sheetNames {
+		if strings.EqualFold(strings.TrimSpace(sheetName), strings.TrimSpace(sheet)) {
+			return sheetName, nil
+		}
+	}
+
+	return "", domain.NewValidationError("sheet not found", map[string]any{"sheet": sheet, "sheets": sheetNames})
+}
+
+// padRow fills short rows with empty cells so builders can index every
+// column of the header.
+func padRow(row []string, size int) []string {
+	if len(row) >= size {
+		return row
+	}
+
+	padded := make([]string, size)
+	copy(padded, row)
+
+	return padded
+}
+
+func columnName(header []string, columnIdx int) string {
+	if columnIdx < 0 || columnIdx >= len(header) {
+		return ""
+	}
+
+	return header[columnIdx]
+}
+
+func withRowNumber(rowErrors []domain.ImportRowError, rowNumber int) []domain.ImportRowError {
+	for i := range rowErrors {
+		rowErrors[i].Row = rowNumber
 	}
 
-	return xlsRows, nil
+	return rowErrors
 }
 
 func getColumnHeadersIndex(header []string) map[string]int {
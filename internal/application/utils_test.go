@@ -0,0 +1,165 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSpreadsheet_CSV(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "semicolon separated", content: "Protocolo;Segurado;Valor\nP-1;João Souza;1.299,90\n"},
+		{name: "comma separated with quoted values", content: "Protocolo,Segurado,Valor\nP-1,João Souza,\"1.299,90\"\n"},
+		{name: "tab separated", content: "Protocolo\tSegurado\tValor\nP-1\tJoão Souza\t1.299,90\n"},
+		{name: "pipe separated", content: "Protocolo|Segurado|Valor\nP-1|João Souza|1.299,90\n"},
+		{name: "UTF-8 with BOM", content: "\xef\xbb\xbfProtocolo;Segurado;Valor\nP-1;João Souza;1.299,90\n"},
+		{name: "Windows-1252", content: "Protocolo;Segurado;Valor\nP-1;Jo\xe3o Souza;1.299,90\n"},
+		{name: "UTF-16 with BOM", content: "\xff\xfe" + utf16LEString("Protocolo;Segurado;Valor\r\nP-1;João Souza;1.299,90\r\n")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sheet, err := readSpreadsheet("cases.CSV", strings.NewReader(tc.content), spreadsheetOptions{})
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Protocolo", "Segurado", "Valor"}, sheet.header())
+			assert.Equal(t, [][]string{{"P-1", "João Souza", "1.299,90"}}, sheet.dataRows())
+		})
+	}
+}
+
+func TestReadSpreadsheet_HeaderRow(t *testing.T) {
+	content := "Relatório de sinistros\nGerado em 31/01/2025;;\n\nProtocolo;Segurado;CPF\nP-1;João Souza;529.982.247-25\n"
+
+	t.Run("detects the header below title rows", func(t *testing.T) {
+		sheet, err := readSpreadsheet("cases.csv", strings.NewReader(content), spreadsheetOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Protocolo", "Segurado", "CPF"}, sheet.header())
+		assert.Equal(t, 5, sheet.firstDataLine())
+	})
+
+	t.Run("uses the header row given by the operator", func(t *testing.T) {
+		sheet, err := readSpreadsheet("cases.csv", strings.NewReader(content), spreadsheetOptions{headerRow: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Gerado em 31/01/2025", "", ""}, sheet.header())
+		assert.Equal(t, 3, sheet.firstDataLine())
+	})
+
+	t.Run("fails when the header row is past the end of the file", func(t *testing.T) {
+		_, err := readSpreadsheet("cases.csv", strings.NewReader(content), spreadsheetOptions{headerRow: 10})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+}
+
+func TestReadSpreadsheet_ErrorReport(t *testing.T) {
+	content := "Protocolo;Segurado;;Linha;Erros\nP-1;;extra;3;Segurado: required value is empty\n"
+
+	sheet, err := readSpreadsheet("cases_erros.csv", strings.NewReader(content), spreadsheetOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Protocolo", "Segurado", ""}, sheet.header())
+	assert.Equal(t, [][]string{{"P-1", "", "extra"}}, sheet.dataRows())
+}
+
+func TestReadSpreadsheet_XLSX(t *testing.T) {
+	content := buildXLSXForTest(t, map[string]string{
+		"Capa": `<row r="1"><c r="A1" t="inlineStr"><is><t>Relatório</t></is></c></row>`,
+		"Casos": `<row r="2"><c r="A2" t="inlineStr"><is><t>Protocolo</t></is></c><c r="B2" t="inlineStr"><is><t>Segurado</t></is></c><c r="C2" t="inlineStr"><is><t>Valor</t></is></c></row>` +
+			`<row r="4"><c r="A4" t="inlineStr"><is><t>P-1</t></is></c><c r="C4"><v>1299.9</v></c></row>`,
+	})
+
+	t.Run("reads the sheet by name keeping cells in place", func(t *testing.T) {
+		sheet, err := readSpreadsheet("cases.xlsx", bytes.NewReader(content), spreadsheetOptions{sheet: " casos "})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Protocolo", "Segurado", "Valor"}, sheet.header())
+		assert.Equal(t, 3, sheet.firstDataLine())
+		assert.Equal(t, [][]string{nil, {"P-1", "", "1299.9"}}, sheet.dataRows())
+	})
+
+	t.Run("reads workbooks saved with the .xls extension", func(t *testing.T) {
+		sheet, err := readSpreadsheet("cases.xls", bytes.NewReader(content), spreadsheetOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Relatório"}, sheet.header())
+	})
+
+	t.Run("fails when the sheet does not exist", func(t *testing.T) {
+		_, err := readSpreadsheet("cases.xlsx", bytes.NewReader(content), spreadsheetOptions{sheet: "Sinistros"})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+}
+
+func TestReadSpreadsheet_InvalidFiles(t *testing.T) {
+	t.Run("rejects unknown extensions", func(t *testing.T) {
+		_, err := readSpreadsheet("cases.txt", strings.NewReader("Protocolo\n"), spreadsheetOptions{})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+
+	t.Run("rejects .xls files that are not workbooks", func(t *testing.T) {
+		_, err := readSpreadsheet("cases.xls", strings.NewReader("<html><table></table></html>"), spreadsheetOptions{})
+
+		var customErr *domain.CustomError
+		require.ErrorAs(t, err, &customErr)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode())
+	})
+}
+
+func buildXLSXForTest(t *testing.T, sheets map[string]string) []byte {
+	t.Helper()
+
+	sheetNames := []string{"Capa", "Casos"}
+	var workbookSheets, relationships strings.Builder
+	files := map[string]string{
+		"xl/styles.xml": `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cellXfs count="1"><xf numFmtId="0"/></cellXfs></styleSheet>`,
+	}
+	for i, name := range sheetNames {
+		workbookSheets.WriteString(fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, i+1, i+1))
+		relationships.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Target="worksheets/sheet%d.xml"/>`, i+1, i+1))
+		files[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + sheets[name] + `</sheetData></worksheet>`
+	}
+	files["xl/workbook.xml"] = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` + workbookSheets.String() + `</sheets></workbook>`
+	files["xl/_rels/workbook.xml.rels"] = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + relationships.String() + `</Relationships>`
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range files {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return buffer.Bytes()
+}
+
+func utf16LEString(text string) string {
+	var out []byte
+	for _, char := range text {
+		out = append(out, byte(char), byte(char>>8))
+	}
+
+	return string(out)
+}
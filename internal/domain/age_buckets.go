@@ -0,0 +1,37 @@
+package domain
+
+// AgeBuckets spreads open cases by how many days they have been open. Each
+// bucket holds the ages up to its bound, past the previous one, and a last
+// bucket holds the ages over the last bound. Ages are counted in calendar
+// days, or in weekdays when BusinessDays is set.
+type AgeBuckets struct {
+	Bounds       []int
+	BusinessDays bool
+}
+
+// Len is how many buckets the bounds make.
+func (b AgeBuckets) Len() int {
+	return len(b.Bounds) + 1
+}
+
+// IndexOf is the position of the bucket an age in days falls in.
+func (b AgeBuckets) IndexOf(days int) int {
+	for i, bound := range b.Bounds {
+		if days <= bound {
+			return i
+		}
+	}
+
+	return len(b.Bounds)
+}
+
+// CaseAgeCount is how many cases of a queue, owner and status fall in an age
+// bucket, by its position. CaseIDs lists them, only when asked for.
+type CaseAgeCount struct {
+	QueueID string
+	OwnerID string
+	Status  CaseStatus
+	Bucket  int
+	Cases   int
+	CaseIDs []string
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgeBuckets_IndexOf(t *testing.T) {
+	assert.Equal(t, 0, BacklogAgingBuckets.IndexOf(0))
+	assert.Equal(t, 0, BacklogAgingBuckets.IndexOf(2))
+	assert.Equal(t, 1, BacklogAgingBuckets.IndexOf(3))
+	assert.Equal(t, 2, BacklogAgingBuckets.IndexOf(10))
+	assert.Equal(t, 3, BacklogAgingBuckets.IndexOf(11))
+
+	assert.Equal(t, 0, CaseAgingBuckets.IndexOf(7))
+	assert.Equal(t, 1, CaseAgingBuckets.IndexOf(8))
+	assert.Equal(t, 4, CaseAgingBuckets.IndexOf(61))
+	assert.Equal(t, len(CaseAgingBuckets.Bounds)+1, CaseAgingBuckets.Len())
+}
@@ -2,11 +2,13 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+//go:generate mockgen -source=attachments.go -destination=mock_domain/mock_attachments.go -package=mock_domain
 type AttachmentRepository interface {
 	Save(ctx context.Context, attachment Attachment) error
 	SaveBatch(ctx context.Context, attachments []Attachment) error
@@ -17,6 +19,12 @@ type AttachmentRepository interface {
 
 type AttachmentBucket interface {
 	Download(ctx context.Context, attachmentID string) ([]byte, error)
+	// DownloadStream opens the object for reading along with its size, -1 when
+	// the bucket does not tell. The caller closes it.
+	DownloadStream(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	Upload(ctx context.Context, key string, content []byte, contentType string) error
+	UploadStream(ctx context.Context, key string, content io.ReadSeeker, contentType string) error
+	Delete(ctx context.Context, key string) error
 }
 
 type Attachment struct {
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=backlog.go -destination=mock_domain/mock_backlog_snapshot_repository.go -package=mock_domain
+type BacklogSnapshotRepository interface {
+	CreateBatch(ctx context.Context, snapshots []BacklogSnapshot) error
+	HasDay(ctx context.Context, day time.Time) (bool, error)
+	Search(ctx context.Context, filters BacklogSnapshotFilters) ([]BacklogSnapshot, error)
+}
+
+// BacklogAgeBucket is a range of business days an open case has been waiting.
+type BacklogAgeBucket string
+
+// BacklogAgingBuckets are the business day ranges of the backlog, in the order
+// of BacklogAgeBuckets.
+var BacklogAgingBuckets = AgeBuckets{Bounds: []int{2, 5, 10}, BusinessDays: true}
+
+const (
+	BACKLOG_UP_TO_2_DAYS  BacklogAgeBucket = "0-2"
+	BACKLOG_UP_TO_5_DAYS  BacklogAgeBucket = "3-5"
+	BACKLOG_UP_TO_10_DAYS BacklogAgeBucket = "6-10"
+	BACKLOG_OVER_10_DAYS  BacklogAgeBucket = "10+"
+)
+
+var BacklogAgeBuckets = []BacklogAgeBucket{
+	BACKLOG_UP_TO_2_DAYS,
+	BACKLOG_UP_TO_5_DAYS,
+	BACKLOG_UP_TO_10_DAYS,
+	BACKLOG_OVER_10_DAYS,
+}
+
+// BacklogCaseStatuses are the statuses of the cases still waiting to be
+// closed, the ones the backlog is made of.
+var BacklogCaseStatuses = []CaseStatus{DRAFT, NEW, CUSTOMER_INFO, WAITING_PARTNER, ONGOING, REPORT, PAYMENT, RECEIPT}
+
+// BacklogAging spreads the open cases of every queue, owner and status by how
+// many business days they have been open.
+type BacklogAging struct {
+	GeneratedAt time.Time
+	Total       int
+	Groups      []BacklogAgingGroup
+}
+
+// BacklogAgingGroup counts the open cases sharing a queue, owner and status
+// per age bucket. CaseIDs lists them per bucket, only when asked for.
+type BacklogAgingGroup struct {
+	QueueID string
+	OwnerID string
+	Status  CaseStatus
+	Counts  map[BacklogAgeBucket]int
+	Total   int
+	CaseIDs map[BacklogAgeBucket][]string
+}
+
+// BacklogSnapshot is a BacklogAgingGroup as it was on a day, kept to chart how
+// the backlog evolves.
+type BacklogSnapshot struct {
+	SnapshotID   string
+	SnapshotDate time.Time
+	QueueID      string
+	OwnerID      string
+	Status       CaseStatus
+	UpTo2Days    int
+	UpTo5Days    int
+	UpTo10Days   int
+	Over10Days   int
+	Total        int
+	CreatedAt    time.Time
+}
+
+// BacklogSnapshotFilters narrow the snapshots to queues, owners and an
+// inclusive range of days.
+type BacklogSnapshotFilters struct {
+	QueueID   []string
+	OwnerID   []string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// NewBacklogAging gathers the counts of each queue, owner and status, taken on
+// now with BacklogAgingBuckets, in the groups of an aging.
+func NewBacklogAging(counts []CaseAgeCount, now time.Time, withCaseIDs bool) BacklogAging {
+	aging := BacklogAging{
+		GeneratedAt: now,
+		Groups:      make([]BacklogAgingGroup, 0),
+	}
+
+	positions := make(map[[3]string]int)
+	for _, count := range counts {
+		key := [3]string{count.QueueID, count.OwnerID, string(count.Status)}
+		position, found := positions[key]
+		if !found {
+			position = len(aging.Groups)
+			positions[key] = position
+			aging.Groups = append(aging.Groups, BacklogAgingGroup{
+				QueueID: count.QueueID,
+				OwnerID: count.OwnerID,
+				Status:  count.Status,
+				Counts:  newBacklogCounts(),
+			})
+			if withCaseIDs {
+				aging.Groups[position].CaseIDs = make(map[BacklogAgeBucket][]string)
+			}
+		}
+
+		group := &aging.Groups[position]
+		bucket := BacklogAgeBuckets[count.Bucket]
+		group.Counts[bucket] += count.Cases
+		group.Total += count.Cases
+		if withCaseIDs {
+			group.CaseIDs[bucket] = append(group.CaseIDs[bucket], count.CaseIDs...)
+		}
+		aging.Total += count.Cases
+	}
+
+	slices.SortFunc(aging.Groups, func(a, b BacklogAgingGroup) int {
+		if order := strings.Compare(a.QueueID, b.QueueID); order != 0 {
+			return order
+		}
+		if order := strings.Compare(a.OwnerID, b.OwnerID); order != 0 {
+			return order
+		}
+		return strings.Compare(string(a.Status), string(b.Status))
+	})
+
+	return aging
+}
+
+// BacklogDay is the UTC calendar day of t, at midnight as snapshot days are
+// stored.
+func BacklogDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Snapshots records the groups of the aging as the backlog of its day. An
+// empty backlog is recorded as a single row without queue, owner nor status
+// counting zero cases, so the day is still charted and not snapshotted again.
+func (a BacklogAging) Snapshots() ([]BacklogSnapshot, error) {
+	groups := a.Groups
+	if len(groups) == 0 {
+		groups = []BacklogAgingGroup{{Counts: newBacklogCounts()}}
+	}
+
+	snapshots := make([]BacklogSnapshot, 0, len(groups))
+	for _, group := range groups {
+		snapshotID, err := uuid.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, BacklogSnapshot{
+			SnapshotID:   snapshotID.String(),
+			SnapshotDate: BacklogDay(a.GeneratedAt),
+			QueueID:      group.QueueID,
+			OwnerID:      group.OwnerID,
+			Status:       group.Status,
+			UpTo2Days:    group.Counts[BACKLOG_UP_TO_2_DAYS],
+			UpTo5Days:    group.Counts[BACKLOG_UP_TO_5_DAYS],
+			UpTo10Days:   group.Counts[BACKLOG_UP_TO_10_DAYS],
+			Over10Days:   group.Counts[BACKLOG_OVER_10_DAYS],
+			Total:        group.Total,
+			CreatedAt:    time.Now().UTC(),
+		})
+	}
+
+	return snapshots, nil
+}
+
+func newBacklogCounts() map[BacklogAgeBucket]int {
+	counts := make(map[BacklogAgeBucket]int, len(BacklogAgeBuckets))
+	for _, bucket := range BacklogAgeBuckets {
+		counts[bucket] = 0
+	}
+
+	return counts
+}
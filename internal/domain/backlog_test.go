@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBacklogAging(t *testing.T) {
+	now := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+	counts := []CaseAgeCount{
+		{QueueID: "queue-b", OwnerID: "user-1", Status: ONGOING, Bucket: 0, Cases: 2, CaseIDs: []string{"case-1", "case-4"}},
+		{QueueID: "queue-b", OwnerID: "user-1", Status: ONGOING, Bucket: 3, Cases: 1, CaseIDs: []string{"case-3"}},
+		{QueueID: "queue-a", OwnerID: "user-1", Status: NEW, Bucket: 1, Cases: 1, CaseIDs: []string{"case-2"}},
+	}
+
+	t.Run("groups the counts by queue, owner and status", func(t *testing.T) {
+		aging := NewBacklogAging(counts, now, false)
+
+		assert.Equal(t, 4, aging.Total)
+		require.Len(t, aging.Groups, 2)
+		assert.Equal(t, BacklogAgingGroup{
+			QueueID: "queue-a",
+			OwnerID: "user-1",
+			Status:  NEW,
+			Counts:  map[BacklogAgeBucket]int{BACKLOG_UP_TO_2_DAYS: 0, BACKLOG_UP_TO_5_DAYS: 1, BACKLOG_UP_TO_10_DAYS: 0, BACKLOG_OVER_10_DAYS: 0},
+			Total:   1,
+		}, aging.Groups[0])
+		assert.Equal(t, map[BacklogAgeBucket]int{BACKLOG_UP_TO_2_DAYS: 2, BACKLOG_UP_TO_5_DAYS: 0, BACKLOG_UP_TO_10_DAYS: 0, BACKLOG_OVER_10_DAYS: 1}, aging.Groups[1].Counts)
+		assert.Nil(t, aging.Groups[1].CaseIDs)
+	})
+
+	t.Run("drills down to the cases of each bucket", func(t *testing.T) {
+		aging := NewBacklogAging(counts, now, true)
+
+		require.Len(t, aging.Groups, 2)
+		assert.Equal(t, map[BacklogAgeBucket][]string{
+			BACKLOG_UP_TO_2_DAYS: {"case-1", "case-4"},
+			BACKLOG_OVER_10_DAYS: {"case-3"},
+		}, aging.Groups[1].CaseIDs)
+	})
+
+	t.Run("snapshots the groups on the day of the aging", func(t *testing.T) {
+		snapshots, err := NewBacklogAging(counts, now, false).Snapshots()
+
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.NotEmpty(t, snapshots[1].SnapshotID)
+		assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), snapshots[1].SnapshotDate)
+		assert.Equal(t, 2, snapshots[1].UpTo2Days)
+		assert.Equal(t, 1, snapshots[1].Over10Days)
+		assert.Equal(t, 3, snapshots[1].Total)
+	})
+
+	t.Run("snapshots an empty backlog as a zero row", func(t *testing.T) {
+		snapshots, err := NewBacklogAging(nil, now, false).Snapshots()
+
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), snapshots[0].SnapshotDate)
+		assert.Empty(t, snapshots[0].QueueID)
+		assert.Empty(t, snapshots[0].OwnerID)
+		assert.Empty(t, snapshots[0].Status)
+		assert.Zero(t, snapshots[0].Total)
+	})
+}
+
+func TestBacklogDay(t *testing.T) {
+	evening := time.Date(2025, 3, 14, 23, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	assert.Equal(t, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), BacklogDay(evening))
+}
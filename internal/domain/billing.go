@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
 type Billing struct {
 	Key             string
 	Option          string
@@ -13,3 +20,77 @@ type BillingType string
 const (
 	PIX BillingType = "PIX"
 )
+
+// PixKeyOption is the kind of key a PIX payment is sent to.
+type PixKeyOption string
+
+const (
+	PIX_KEY_CPF    PixKeyOption = "CPF"
+	PIX_KEY_CNPJ   PixKeyOption = "CNPJ"
+	PIX_KEY_EMAIL  PixKeyOption = "EMAIL"
+	PIX_KEY_PHONE  PixKeyOption = "PHONE"
+	PIX_KEY_RANDOM PixKeyOption = "RANDOM"
+)
+
+// NewPixBilling checks the key against its option, telling the option from
+// the key itself when none is given. An empty owner means the account belongs
+// to the partner.
+func NewPixBilling(key, option, owner string) (Billing, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return Billing{}, NewValidationError("pix key cannot be empty", nil)
+	}
+
+	keyOption := PixKeyOption(strings.ToUpper(strings.TrimSpace(option)))
+	if keyOption == "" {
+		keyOption = pixKeyOptionOf(key)
+	}
+
+	if !isValidPixKey(key, keyOption) {
+		return Billing{}, NewValidationError("invalid pix key", map[string]any{"key": key, "option": keyOption})
+	}
+
+	owner = strings.TrimSpace(owner)
+
+	return Billing{
+		Key:             key,
+		Option:          string(keyOption),
+		Type:            PIX,
+		Name:            owner,
+		IsSameFromOwner: owner == "",
+	}, nil
+}
+
+func pixKeyOptionOf(key string) PixKeyOption {
+	switch {
+	case strings.Contains(key, "@"):
+		return PIX_KEY_EMAIL
+	case uuid.Validate(key) == nil:
+		return PIX_KEY_RANDOM
+	case IsValidDocument(key) && len(DocumentDigits(key)) == 11:
+		return PIX_KEY_CPF
+	case IsValidDocument(key):
+		return PIX_KEY_CNPJ
+	default:
+		return PIX_KEY_PHONE
+	}
+}
+
+func isValidPixKey(key string, option PixKeyOption) bool {
+	switch option {
+	case PIX_KEY_CPF:
+		return IsValidDocument(key) && len(DocumentDigits(key)) == 11
+	case PIX_KEY_CNPJ:
+		return IsValidDocument(key) && len(DocumentDigits(key)) == 14
+	case PIX_KEY_EMAIL:
+		_, err := mail.ParseAddress(key)
+		return err == nil
+	case PIX_KEY_PHONE:
+		digits := DocumentDigits(key)
+		return len(digits) >= 10 && len(digits) <= 13
+	case PIX_KEY_RANDOM:
+		return uuid.Validate(key) == nil
+	default:
+		return false
+	}
+}
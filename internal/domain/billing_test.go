@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPixBilling(t *testing.T) {
+	testCases := []struct {
+		name       string
+		key        string
+		option     string
+		wantOption string
+		wantErr    bool
+	}{
+		{name: "infers CPF keys", key: "529.982.247-25", wantOption: "CPF"},
+		{name: "infers CNPJ keys", key: "11.222.333/0001-81", wantOption: "CNPJ"},
+		{name: "infers email keys", key: "maria@example.com", wantOption: "EMAIL"},
+		{name: "infers random keys", key: "123e4567-e89b-12d3-a456-426614174000", wantOption: "RANDOM"},
+		{name: "infers phone keys", key: "+55 11 99999-0000", wantOption: "PHONE"},
+		{name: "keeps the given option", key: "11999990000", option: "phone", wantOption: "PHONE"},
+		{name: "rejects keys not matching the option", key: "maria@example.com", option: "CPF", wantErr: true},
+		{name: "rejects unknown options", key: "maria@example.com", option: "BOLETO", wantErr: true},
+		{name: "rejects invalid keys", key: "chave", wantErr: true},
+		{name: "rejects empty keys", key: " ", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			billing, err := NewPixBilling(tc.key, tc.option, "")
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOption, billing.Option)
+			assert.Equal(t, PIX, billing.Type)
+			assert.True(t, billing.IsSameFromOwner)
+		})
+	}
+
+	t.Run("keeps the account owner", func(t *testing.T) {
+		billing, err := NewPixBilling("maria@example.com", "", " Maria Silva ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Maria Silva", billing.Name)
+		assert.False(t, billing.IsSameFromOwner)
+	})
+}
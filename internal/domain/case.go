@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,9 @@ type CaseRepository interface {
 	Update(ctx context.Context, crmCase Case) error
 	CreateBatch(ctx context.Context, cases []Case) ([]string, error)
 	SearchFull(ctx context.Context, filters CaseFilters) (PagingResult[CaseFull], error)
+	GetByExternalReferences(ctx context.Context, contractorIDs []string, externalReferences []string) ([]Case, error)
+	CountGrouped(ctx context.Context, filters CaseFilters, groupBy []CaseStatsDimension) ([]CaseStatsGroup, error)
+	CountByAge(ctx context.Context, filters CaseFilters, buckets AgeBuckets, today time.Time, withCaseIDs bool) ([]CaseAgeCount, error)
 }
 
 type CreateCase struct {
@@ -89,9 +93,74 @@ type CaseFilters struct {
 	ClosedAtEnd       *string
 	ShippingState     []string
 	QueueID           []string
+	FraudFlagged      *bool
 	PagingFilter
 }
 
+// HasSelection tells whether the filters narrow the cases down at all.
+func (f CaseFilters) HasSelection() bool {
+	for _, values := range [][]string{
+		f.CaseID, f.OwnerID, f.PartnerID, f.ContractorID, f.CustomerID, f.Status,
+		f.Region, f.ExternalReference, f.ShippingState, f.QueueID,
+	} {
+		if len(values) > 0 {
+			return true
+		}
+	}
+
+	return f.StartDate != nil || f.EndDate != nil || f.ClosedAtStart != nil || f.ClosedAtEnd != nil || f.FraudFlagged != nil
+}
+
+// caseFilterDateLayouts are the layouts the date filters are accepted in.
+var caseFilterDateLayouts = []string{time.DateOnly, time.RFC3339}
+
+// ValidateDateRanges checks that the date filters hold dates and that no range
+// ends before it starts, so a bad range is refused before any query runs.
+func (f CaseFilters) ValidateDateRanges() error {
+	ranges := []struct {
+		startField, endField string
+		start, end           *string
+	}{
+		{"start_date", "end_date", f.StartDate, f.EndDate},
+		{"closed_at_start", "closed_at_end", f.ClosedAtStart, f.ClosedAtEnd},
+	}
+
+	for _, dateRange := range ranges {
+		start, err := parseCaseFilterDate(dateRange.startField, dateRange.start)
+		if err != nil {
+			return err
+		}
+
+		end, err := parseCaseFilterDate(dateRange.endField, dateRange.end)
+		if err != nil {
+			return err
+		}
+
+		if start != nil && end != nil && end.Before(*start) {
+			return NewValidationError(
+				fmt.Sprintf("%s cannot be before %s", dateRange.endField, dateRange.startField),
+				map[string]any{dateRange.startField: *dateRange.start, dateRange.endField: *dateRange.end},
+			)
+		}
+	}
+
+	return nil
+}
+
+func parseCaseFilterDate(field string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	for _, layout := range caseFilterDateLayouts {
+		if parsed, err := time.Parse(layout, *value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, NewValidationError(fmt.Sprintf("invalid %s, expected YYYY-MM-DD", field), map[string]any{field: *value})
+}
+
 type CaseUpdate struct {
 	Status     *CaseStatus
 	PartnerID  *string
@@ -210,6 +279,30 @@ func (c *Case) MergeUpdate(updateCase CaseUpdate) {
 	}
 }
 
+// MergeImport refreshes the fields a re-imported spreadsheet row may change.
+// Blank values keep what the case already has. It reports whether anything
+// changed.
+func (c *Case) MergeImport(imported Case) bool {
+	changed := false
+
+	if imported.Subject != "" && imported.Subject != c.Subject {
+		c.Subject = imported.Subject
+		changed = true
+	}
+
+	if c.ProductID == "" && imported.ProductID != "" {
+		c.ProductID = imported.ProductID
+		changed = true
+	}
+
+	if changed {
+		c.UpdatedAt = time.Now().UTC()
+		c.UpdatedBy = imported.UpdatedBy
+	}
+
+	return changed
+}
+
 // caseDiff accumulates the old/new values of fields changed by a CaseUpdate.
 type caseDiff struct {
 	oldValues map[string]any
@@ -10,4 +10,5 @@ type CaseBuilder interface {
 	BuildCase(row []string, contractors []Contractor, customerID string, customerRegion int) (*Case, error)
 	BuildProduct(row []string) (*Product, error)
 	BuildCustomer(row []string) (*Customer, error)
+	ValidateRow(row []string) []ImportRowError
 }
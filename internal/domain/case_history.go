@@ -35,8 +35,28 @@ const (
 	CaseUpdatedEvent           = "case_updated"
 	CaseResetEvent             = "case_reset"
 	CaseQueueChangedEvent      = "case_queue_changed"
+	QuoteSubmittedEvent        = "quote_submitted"
+	QuoteApprovedEvent         = "quote_approved"
+	QuoteRejectedEvent         = "quote_rejected"
+	CaseSettlementDecidedEvent = "case_settlement_decided"
+	ShipmentCreatedEvent       = "shipment_created"
+	ShipmentStatusChangedEvent = "shipment_status_changed"
+	CaseFraudFlaggedEvent      = "case_fraud_flagged"
+	CaseFraudReviewedEvent     = "case_fraud_reviewed"
+	CaseReportGeneratedEvent   = "case_report_generated"
 )
 
+// CaseStatusEvents are the events that can move a case to another status, the
+// ones whose values hold a CaseStatus. Other events record the status of
+// quotes or shipments under the same key.
+var CaseStatusEvents = []string{
+	CaseCreatedEvent,
+	CaseAssignedEvent,
+	CaseStatusChangedEvent,
+	CaseClosedEvent,
+	CaseResetEvent,
+}
+
 func NewCaseHistory(
 	caseID string,
 	eventName string,
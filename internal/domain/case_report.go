@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=case_report.go -destination=mock_domain/mock_case_report_repository.go -package=mock_domain
+type CaseReportRepository interface {
+	Create(ctx context.Context, caseReport CaseReport) error
+	GetByID(ctx context.Context, caseReportID string) (*CaseReport, error)
+	GetByCaseID(ctx context.Context, caseID string) ([]CaseReport, error)
+}
+
+// CaseReport is a report generated for a case, archived in the attachment
+// bucket as it was sent. ContentHash is the SHA-256 of the file, proving a
+// copy is the one generated; the attachment sits on a GeneratedReport
+// comment of the case.
+type CaseReport struct {
+	CaseReportID    string
+	CaseID          string
+	CommentID       string
+	AttachmentID    string
+	ReportID        string
+	TemplateVersion int
+	Format          ReportFormat
+	FileName        string
+	Key             string
+	ContentHash     string
+	Size            int
+	CreatedBy       string
+	CreatedAt       time.Time
+}
+
+func NewCaseReport(caseID string, template Report, format ReportFormat, fileName string, content []byte, author string) (CaseReport, error) {
+	if caseID == "" {
+		return CaseReport{}, NewValidationError("caseID cannot be empty", nil)
+	}
+
+	if author == "" {
+		return CaseReport{}, NewValidationError("author cannot be empty", nil)
+	}
+
+	caseReportID, err := uuid.NewRandom()
+	if err != nil {
+		return CaseReport{}, err
+	}
+
+	hash := sha256.Sum256(content)
+
+	return CaseReport{
+		CaseReportID:    caseReportID.String(),
+		CaseID:          caseID,
+		ReportID:        template.ReportID,
+		TemplateVersion: template.Version,
+		Format:          format,
+		FileName:        fmt.Sprintf("%s.%s", fileName, format),
+		Key:             fmt.Sprintf("case-reports/%s/%s.%s", caseID, caseReportID.String(), format),
+		ContentHash:     hex.EncodeToString(hash[:]),
+		Size:            len(content),
+		CreatedBy:       author,
+		CreatedAt:       time.Now().UTC(),
+	}, nil
+}
+
+// Attachment is the file of the report as attached to its comment.
+func (r CaseReport) Attachment() (Attachment, error) {
+	attachment, err := NewAttachment(r.FileName, "", string(r.Format), r.Key, r.CreatedBy, r.Size)
+	if err != nil {
+		return Attachment{}, err
+	}
+	attachment.CreatedAt = r.CreatedAt
+
+	return attachment, nil
+}
+
+// Snapshot is what the case history records of the report.
+func (r CaseReport) Snapshot() map[string]any {
+	return map[string]any{
+		"case_report_id":   r.CaseReportID,
+		"report_id":        r.ReportID,
+		"template_version": r.TemplateVersion,
+		"format":           string(r.Format),
+		"content_hash":     r.ContentHash,
+	}
+}
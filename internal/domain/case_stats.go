@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// CaseStatsDimension is a field cases can be counted by in the case statistics.
+type CaseStatsDimension string
+
+const (
+	CASE_STATS_STATUS         CaseStatsDimension = "status"
+	CASE_STATS_REGION         CaseStatsDimension = "region"
+	CASE_STATS_QUEUE          CaseStatsDimension = "queue"
+	CASE_STATS_OWNER          CaseStatsDimension = "owner"
+	CASE_STATS_CONTRACTOR     CaseStatsDimension = "contractor"
+	CASE_STATS_SHIPPING_STATE CaseStatsDimension = "shipping_state"
+)
+
+var CaseStatsDimensions = []CaseStatsDimension{
+	CASE_STATS_STATUS,
+	CASE_STATS_REGION,
+	CASE_STATS_QUEUE,
+	CASE_STATS_OWNER,
+	CASE_STATS_CONTRACTOR,
+	CASE_STATS_SHIPPING_STATE,
+}
+
+// CaseStats counts the cases matching a search, grouped by the dimensions
+// asked for.
+type CaseStats struct {
+	GroupBy []CaseStatsDimension
+	Total   int
+	Groups  []CaseStatsGroup
+}
+
+// CaseStatsGroup is how many cases share the same value on every dimension
+// of the grouping. Keys hold the values as stored on the case, ids for queues,
+// owners and contractors, which also get their names in Labels. Cases without
+// a value on a dimension are grouped under an empty key.
+type CaseStatsGroup struct {
+	Keys   map[CaseStatsDimension]string
+	Labels map[CaseStatsDimension]string
+	Cases  int
+}
+
+// ParseCaseStatsDimensions reads the dimensions to group the case statistics
+// by, ignoring repeated ones. Cases are grouped by status when none is given.
+func ParseCaseStatsDimensions(values []string) ([]CaseStatsDimension, error) {
+	dimensions := make([]CaseStatsDimension, 0, len(values))
+	for _, value := range values {
+		dimension := CaseStatsDimension(strings.ToLower(strings.TrimSpace(value)))
+		if !slices.Contains(CaseStatsDimensions, dimension) {
+			return nil, NewValidationError("invalid case stats group", map[string]any{"group_by": value, "available": CaseStatsDimensions})
+		}
+
+		if !slices.Contains(dimensions, dimension) {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+
+	if len(dimensions) == 0 {
+		return []CaseStatsDimension{CASE_STATS_STATUS}, nil
+	}
+
+	return dimensions, nil
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCaseStatsDimensions(t *testing.T) {
+	dimensions, err := ParseCaseStatsDimensions(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []CaseStatsDimension{CASE_STATS_STATUS}, dimensions)
+
+	dimensions, err = ParseCaseStatsDimensions([]string{"queue", " Owner ", "queue"})
+	require.NoError(t, err)
+	assert.Equal(t, []CaseStatsDimension{CASE_STATS_QUEUE, CASE_STATS_OWNER}, dimensions)
+
+	_, err = ParseCaseStatsDimensions([]string{"customer"})
+	assert.Error(t, err)
+}
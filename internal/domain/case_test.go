@@ -27,3 +27,39 @@ func TestNewCaseFull_EmptyQueue(t *testing.T) {
 
 	assert.Equal(t, Queue{}, caseFull.Queue)
 }
+
+func TestCase_MergeImport(t *testing.T) {
+	t.Run("keeps stored values for blank imported fields", func(t *testing.T) {
+		crmCase := Case{Subject: "Tela quebrada", ProductID: "product-1"}
+
+		changed := crmCase.MergeImport(Case{Subject: "", ProductID: "product-2", UpdatedBy: "operator-1"})
+
+		assert.False(t, changed)
+		assert.Equal(t, "Tela quebrada", crmCase.Subject)
+		assert.Equal(t, "product-1", crmCase.ProductID)
+	})
+
+	t.Run("refreshes the subject and fills a missing product", func(t *testing.T) {
+		crmCase := Case{Subject: "Tela quebrada"}
+
+		changed := crmCase.MergeImport(Case{Subject: "Não liga", ProductID: "product-2", UpdatedBy: "operator-1"})
+
+		assert.True(t, changed)
+		assert.Equal(t, "Não liga", crmCase.Subject)
+		assert.Equal(t, "product-2", crmCase.ProductID)
+		assert.Equal(t, "operator-1", crmCase.UpdatedBy)
+	})
+}
+
+func TestProduct_ImportUpdate(t *testing.T) {
+	product := Product{Value: 800, Brand: "LG", Model: "TV"}
+
+	_, changed := product.ImportUpdate(Product{Brand: "LG", Model: "TV"})
+	assert.False(t, changed)
+
+	update, changed := product.ImportUpdate(Product{Value: 900, Brand: "LG", Model: "OLED TV"})
+	assert.True(t, changed)
+	assert.Nil(t, update.Brand)
+	assert.Equal(t, "OLED TV", *update.Model)
+	assert.Equal(t, 900.0, *update.Value)
+}
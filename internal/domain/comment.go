@@ -34,6 +34,10 @@ const (
 	COMMENT_RESOLUTION CommentType = "Resolution"
 	COMMENT_REPORT     CommentType = "Report"
 	COMMENT_REJECTION  CommentType = "Rejection"
+	// COMMENT_GENERATED_REPORT holds a generated report archived by the
+	// system. It is kept apart from COMMENT_REPORT, whose content is the
+	// resolution printed in reports.
+	COMMENT_GENERATED_REPORT CommentType = "GeneratedReport"
 )
 
 func NewComment(
@@ -42,6 +42,15 @@ func NewParserError(message string, metadata map[string]any) error {
 	}
 }
 
+func NewUnprocessableError(message string, metadata map[string]any) error {
+	return &CustomError{
+		messagePrefix: "Unprocessable error - Message:",
+		message:       message,
+		statusCode:    http.StatusUnprocessableEntity,
+		metadata:      metadata,
+	}
+}
+
 func NewNotFoundError(message string, metadata map[string]any) error {
 	return &CustomError{
 		messagePrefix: "NotFound error - Message:",
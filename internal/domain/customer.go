@@ -7,12 +7,15 @@ import (
 	"github.com/google/uuid"
 )
 
+//go:generate mockgen -source=customer.go -destination=mock_domain/mock_customer_repository.go -package=mock_domain
 type CustomerRepository interface {
 	Create(ctx context.Context, customer Customer) (string, error)
+	CreateBatch(ctx context.Context, customers []Customer) ([]string, error)
 	GetByID(ctx context.Context, customerID string) (*Customer, error)
 	Search(ctx context.Context, filters CustomerFilters) (PagingResult[Customer], error)
 	Update(ctx context.Context, customer Customer) error
 	Delete(ctx context.Context, customerID string) error
+	GetByDocuments(ctx context.Context, documents []string) ([]Customer, error)
 }
 
 type Customer struct {
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DocumentDigits strips the punctuation of a formatted CPF or CNPJ.
+func DocumentDigits(document string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, document)
+}
+
+// IsValidDocument reports whether the document is a CPF or CNPJ with valid
+// check digits, formatted or not.
+func IsValidDocument(document string) bool {
+	digits := DocumentDigits(document)
+
+	switch len(digits) {
+	case 11:
+		return isValidCPF(digits)
+	case 14:
+		return isValidCNPJ(digits)
+	default:
+		return false
+	}
+}
+
+func isValidCPF(digits string) bool {
+	if allSameDigit(digits) {
+		return false
+	}
+
+	return checkDigit(digits[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == int(digits[9]-'0') &&
+		checkDigit(digits[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == int(digits[10]-'0')
+}
+
+func isValidCNPJ(digits string) bool {
+	if allSameDigit(digits) {
+		return false
+	}
+
+	return checkDigit(digits[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == int(digits[12]-'0') &&
+		checkDigit(digits[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == int(digits[13]-'0')
+}
+
+func checkDigit(digits string, weights []int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += int(digits[i]-'0') * weight
+	}
+
+	remainder := sum % 11
+	if remainder < 2 {
+		return 0
+	}
+
+	return 11 - remainder
+}
+
+func allSameDigit(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidDocument(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		want     bool
+	}{
+		{name: "formatted CPF", document: "529.982.247-25", want: true},
+		{name: "unformatted CPF", document: "52998224725", want: true},
+		{name: "CPF with wrong check digit", document: "529.982.247-26", want: false},
+		{name: "CPF with repeated digits", document: "111.111.111-11", want: false},
+		{name: "formatted CNPJ", document: "11.222.333/0001-81", want: true},
+		{name: "CNPJ with wrong check digit", document: "11.222.333/0001-80", want: false},
+		{name: "wrong length", document: "12345", want: false},
+		{name: "empty", document: "", want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsValidDocument(tc.document))
+		})
+	}
+}
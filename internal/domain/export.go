@@ -0,0 +1,36 @@
+package domain
+
+import "strings"
+
+// ExportFormat is the file format a listing is exported to.
+type ExportFormat string
+
+const (
+	EXPORT_XLSX ExportFormat = "xlsx"
+	EXPORT_CSV  ExportFormat = "csv"
+)
+
+// ParseExportFormat reads the format asked for an export, XLSX when none is.
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch format := ExportFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return EXPORT_XLSX, nil
+	case EXPORT_XLSX, EXPORT_CSV:
+		return format, nil
+	default:
+		return "", NewValidationError("export format must be xlsx or csv", map[string]any{"format": value})
+	}
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == EXPORT_CSV {
+		return "text/csv; charset=utf-8"
+	}
+
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// FileName names an export file after the listing it holds.
+func (f ExportFormat) FileName(name string) string {
+	return name + "." + string(f)
+}
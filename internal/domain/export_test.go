@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := ParseExportFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, EXPORT_XLSX, format)
+
+	format, err = ParseExportFormat(" CSV ")
+	require.NoError(t, err)
+	assert.Equal(t, EXPORT_CSV, format)
+	assert.Equal(t, "clientes.csv", format.FileName("clientes"))
+
+	_, err = ParseExportFormat("pdf")
+	assert.Error(t, err)
+}
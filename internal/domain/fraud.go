@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+//go:generate mockgen -source=fraud.go -destination=mock_domain/mock_fraud_repository.go -package=mock_domain
+type FraudRepository interface {
+	Save(ctx context.Context, assessment FraudAssessment) error
+	GetByCaseID(ctx context.Context, caseID string) (*FraudAssessment, error)
+	CountRecentCasesByCustomer(ctx context.Context, customerID string, since time.Time, excludeCaseID string) (int, error)
+	GetContractorsBySerialNumber(ctx context.Context, serialNumber string, excludeCaseID string) ([]string, error)
+	CountCustomersSharingContact(ctx context.Context, customer Customer) (int, error)
+}
+
+type FraudReason string
+
+const (
+	FRAUD_REPEAT_DOCUMENT      FraudReason = "repeat_document"
+	FRAUD_REPEAT_SERIAL        FraudReason = "repeat_serial"
+	FRAUD_SERIAL_OTHER_INSURER FraudReason = "serial_other_insurer"
+	FRAUD_SHARED_CONTACT       FraudReason = "shared_contact"
+	FRAUD_HIGH_PRODUCT_VALUE   FraudReason = "high_product_value"
+)
+
+type FraudReviewStatus string
+
+const (
+	FRAUD_REVIEW_NOT_REQUIRED FraudReviewStatus = "not_required"
+	FRAUD_REVIEW_PENDING      FraudReviewStatus = "pending"
+	FRAUD_REVIEW_CLEARED      FraudReviewStatus = "cleared"
+	FRAUD_REVIEW_CONFIRMED    FraudReviewStatus = "confirmed"
+)
+
+const maxFraudScore = 100
+
+// FraudRules holds the weights and thresholds of every heuristic. A case is
+// flagged once the sum of the triggered weights reaches FlagScore.
+type FraudRules struct {
+	Window                  time.Duration
+	RepeatDocumentThreshold int
+	RepeatDocumentWeight    int
+	RepeatSerialWeight      int
+	SerialOtherInsurerScore int
+	SharedContactWeight     int
+	HighValueThreshold      float64
+	HighValueWeight         int
+	FlagScore               int
+}
+
+type FraudSignals struct {
+	ContractorID           string
+	RecentCustomerCases    int
+	SerialContractors      []string
+	SharedContactCustomers int
+	ProductValue           float64
+}
+
+type FraudAssessment struct {
+	CaseID       string
+	Score        int
+	Reasons      []FraudReason
+	Flagged      bool
+	ReviewStatus FraudReviewStatus
+	ReviewNotes  string
+	ReviewedBy   string
+	ReviewedAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type FraudReview struct {
+	Status     FraudReviewStatus
+	Notes      string
+	ReviewedBy string
+}
+
+func DefaultFraudRules() FraudRules {
+	return FraudRules{
+		Window:                  30 * 24 * time.Hour,
+		RepeatDocumentThreshold: 2,
+		RepeatDocumentWeight:    30,
+		RepeatSerialWeight:      25,
+		SerialOtherInsurerScore: 25,
+		SharedContactWeight:     20,
+		HighValueThreshold:      5000,
+		HighValueWeight:         15,
+		FlagScore:               40,
+	}
+}
+
+func (r FraudRules) Evaluate(caseID string, signals FraudSignals) FraudAssessment {
+	score := 0
+	reasons := make([]FraudReason, 0)
+
+	if signals.RecentCustomerCases >= r.RepeatDocumentThreshold {
+		score += r.RepeatDocumentWeight
+		reasons = append(reasons, FRAUD_REPEAT_DOCUMENT)
+	}
+
+	if len(signals.SerialContractors) > 0 {
+		score += r.RepeatSerialWeight
+		reasons = append(reasons, FRAUD_REPEAT_SERIAL)
+
+		if slices.ContainsFunc(signals.SerialContractors, func(contractorID string) bool {
+			return contractorID != signals.ContractorID
+		}) {
+			score += r.SerialOtherInsurerScore
+			reasons = append(reasons, FRAUD_SERIAL_OTHER_INSURER)
+		}
+	}
+
+	if signals.SharedContactCustomers > 0 {
+		score += r.SharedContactWeight
+		reasons = append(reasons, FRAUD_SHARED_CONTACT)
+	}
+
+	if r.HighValueThreshold > 0 && signals.ProductValue >= r.HighValueThreshold {
+		score += r.HighValueWeight
+		reasons = append(reasons, FRAUD_HIGH_PRODUCT_VALUE)
+	}
+
+	score = min(score, maxFraudScore)
+	flagged := score >= r.FlagScore
+
+	reviewStatus := FRAUD_REVIEW_NOT_REQUIRED
+	if flagged {
+		reviewStatus = FRAUD_REVIEW_PENDING
+	}
+
+	now := time.Now().UTC()
+	return FraudAssessment{
+		CaseID:       caseID,
+		Score:        score,
+		Reasons:      reasons,
+		Flagged:      flagged,
+		ReviewStatus: reviewStatus,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func (a *FraudAssessment) Review(review FraudReview) error {
+	if review.Status != FRAUD_REVIEW_CLEARED && review.Status != FRAUD_REVIEW_CONFIRMED {
+		return NewValidationError("review status must be cleared or confirmed", map[string]any{"status": review.Status})
+	}
+
+	if review.ReviewedBy == "" {
+		return NewValidationError("reviewed_by cannot be empty", nil)
+	}
+
+	if !a.Flagged {
+		return NewConflictError("case was not flagged for fraud review", map[string]any{"case_id": a.CaseID})
+	}
+
+	if a.ReviewStatus != FRAUD_REVIEW_PENDING {
+		return NewConflictError("fraud assessment was already reviewed", map[string]any{"status": a.ReviewStatus})
+	}
+
+	now := time.Now().UTC()
+	a.ReviewStatus = review.Status
+	a.ReviewNotes = review.Notes
+	a.ReviewedBy = review.ReviewedBy
+	a.ReviewedAt = &now
+	a.UpdatedAt = now
+
+	return nil
+}
+
+func (a FraudAssessment) Snapshot() map[string]any {
+	return map[string]any{
+		"score":         a.Score,
+		"reasons":       a.Reasons,
+		"flagged":       a.Flagged,
+		"review_status": a.ReviewStatus,
+	}
+}
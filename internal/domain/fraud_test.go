@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFraudRules_Evaluate(t *testing.T) {
+	rules := DefaultFraudRules()
+
+	t.Run("does not flag a case without signals", func(t *testing.T) {
+		assessment := rules.Evaluate("case-1", FraudSignals{ContractorID: "contractor-1", ProductValue: 1000})
+
+		assert.Zero(t, assessment.Score)
+		assert.Empty(t, assessment.Reasons)
+		assert.False(t, assessment.Flagged)
+		assert.Equal(t, FRAUD_REVIEW_NOT_REQUIRED, assessment.ReviewStatus)
+	})
+
+	t.Run("flags a serial number already claimed under another insurer", func(t *testing.T) {
+		assessment := rules.Evaluate("case-1", FraudSignals{
+			ContractorID:      "contractor-1",
+			SerialContractors: []string{"contractor-2"},
+		})
+
+		assert.Equal(t, 50, assessment.Score)
+		assert.Equal(t, []FraudReason{FRAUD_REPEAT_SERIAL, FRAUD_SERIAL_OTHER_INSURER}, assessment.Reasons)
+		assert.True(t, assessment.Flagged)
+		assert.Equal(t, FRAUD_REVIEW_PENDING, assessment.ReviewStatus)
+	})
+
+	t.Run("scores repeat documents only past the threshold", func(t *testing.T) {
+		below := rules.Evaluate("case-1", FraudSignals{RecentCustomerCases: 1})
+		above := rules.Evaluate("case-1", FraudSignals{RecentCustomerCases: 4})
+
+		assert.Zero(t, below.Score)
+		assert.Equal(t, []FraudReason{FRAUD_REPEAT_DOCUMENT}, above.Reasons)
+	})
+
+	t.Run("caps the score at the maximum", func(t *testing.T) {
+		assessment := rules.Evaluate("case-1", FraudSignals{
+			ContractorID:           "contractor-1",
+			RecentCustomerCases:    5,
+			SerialContractors:      []string{"contractor-2"},
+			SharedContactCustomers: 2,
+			ProductValue:           9000,
+		})
+
+		assert.Equal(t, maxFraudScore, assessment.Score)
+		assert.Len(t, assessment.Reasons, 5)
+	})
+}
+
+func TestFraudAssessment_Review(t *testing.T) {
+	newFlaggedAssessment := func() FraudAssessment {
+		return DefaultFraudRules().Evaluate("case-1", FraudSignals{RecentCustomerCases: 3, SharedContactCustomers: 1})
+	}
+
+	t.Run("clears a pending assessment", func(t *testing.T) {
+		assessment := newFlaggedAssessment()
+
+		err := assessment.Review(FraudReview{Status: FRAUD_REVIEW_CLEARED, Notes: "same household", ReviewedBy: "analyst-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, FRAUD_REVIEW_CLEARED, assessment.ReviewStatus)
+		assert.Equal(t, "analyst-1", assessment.ReviewedBy)
+		assert.NotNil(t, assessment.ReviewedAt)
+	})
+
+	t.Run("returns conflict error when already reviewed", func(t *testing.T) {
+		assessment := newFlaggedAssessment()
+		require.NoError(t, assessment.Review(FraudReview{Status: FRAUD_REVIEW_CONFIRMED, ReviewedBy: "analyst-1"}))
+
+		err := assessment.Review(FraudReview{Status: FRAUD_REVIEW_CLEARED, ReviewedBy: "analyst-2"})
+
+		require.Error(t, err)
+		assert.Equal(t, FRAUD_REVIEW_CONFIRMED, assessment.ReviewStatus)
+	})
+
+	t.Run("returns conflict error when the case was not flagged", func(t *testing.T) {
+		assessment := DefaultFraudRules().Evaluate("case-1", FraudSignals{})
+
+		err := assessment.Review(FraudReview{Status: FRAUD_REVIEW_CLEARED, ReviewedBy: "analyst-1"})
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns validation error for an unknown status", func(t *testing.T) {
+		assessment := newFlaggedAssessment()
+
+		err := assessment.Review(FraudReview{Status: FRAUD_REVIEW_PENDING, ReviewedBy: "analyst-1"})
+
+		require.Error(t, err)
+	})
+}
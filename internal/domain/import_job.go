@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=import_job.go -destination=mock_domain/mock_import_job_repository.go -package=mock_domain
+type ImportJobRepository interface {
+	Create(ctx context.Context, job ImportJob) error
+	CreateFile(ctx context.Context, file ImportFile) error
+	GetByID(ctx context.Context, jobID string) (*ImportJob, error)
+	GetFile(ctx context.Context, jobID string) (*ImportFile, error)
+	Update(ctx context.Context, job ImportJob) error
+	ClaimNext(ctx context.Context, leaseID string, staleBefore time.Time) (*ImportJob, error)
+	Heartbeat(ctx context.Context, jobID, leaseID string) error
+}
+
+type ImportJobType string
+
+const (
+	IMPORT_JOB_CASES     ImportJobType = "cases"
+	IMPORT_JOB_PARTNERS  ImportJobType = "partners"
+	IMPORT_JOB_CUSTOMERS ImportJobType = "customers"
+	// IMPORT_JOB_REPORTS generates case reports in bulk on the import queue,
+	// see ReportBatch.
+	IMPORT_JOB_REPORTS ImportJobType = "reports"
+)
+
+// ImportParamCompany is the job param holding the contractor company name the
+// imported spreadsheet belongs to.
+const ImportParamCompany = "company"
+
+// ImportParamUpdateExisting is the job param that, when "true", makes rows
+// matching an already imported case refresh it instead of being skipped.
+const ImportParamUpdateExisting = "update_existing"
+
+// ImportParamSheet is the job param naming the workbook sheet to import. The
+// first sheet is read when it is empty.
+const ImportParamSheet = "sheet"
+
+// ImportParamHeaderRow is the job param holding the 1-based line of the
+// header, for files where it cannot be detected past the title rows.
+const ImportParamHeaderRow = "header_row"
+
+type ImportJobStatus string
+
+const (
+	IMPORT_JOB_PENDING    ImportJobStatus = "pending"
+	IMPORT_JOB_PROCESSING ImportJobStatus = "processing"
+	IMPORT_JOB_COMPLETED  ImportJobStatus = "completed"
+	IMPORT_JOB_PARTIAL    ImportJobStatus = "completed_with_errors"
+	IMPORT_JOB_FAILED     ImportJobStatus = "failed"
+)
+
+// MaxImportJobAttempts bounds how many times an interrupted job is picked up
+// again before it is given up as failed.
+const MaxImportJobAttempts = 3
+
+// ImportProgressFunc is called by importers as rows are handled so the job
+// progress can be polled while the import runs.
+type ImportProgressFunc func(processedRows, totalRows int)
+
+type ImportJob struct {
+	JobID         string
+	Type          ImportJobType
+	Status        ImportJobStatus
+	FileName      string
+	Params        map[string]string
+	TotalRows     int
+	ProcessedRows int
+	CreatedIDs    []string
+	UpdatedIDs    []string
+	SkippedIDs    []string
+	RowErrors     []ImportRowError
+	Errors        []string
+	Attempts      int
+	// LeaseID identifies the worker that claimed the job. Updates from a
+	// worker whose job was reclaimed by another one are refused.
+	LeaseID     string
+	HeartbeatAt *time.Time
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// ImportRowError describes why a spreadsheet row was left out of an import.
+// Row is the 1-based line in the spreadsheet, counting any title rows above
+// the header.
+type ImportRowError struct {
+	Row     int
+	Column  string
+	Value   string
+	Message string
+}
+
+// ImportResult is what an importer hands back once the file was processed.
+// UpdatedIDs and SkippedIDs hold the existing records the file matched, either
+// refreshed or left untouched.
+type ImportResult struct {
+	CreatedIDs []string
+	UpdatedIDs []string
+	SkippedIDs []string
+	RowErrors  []ImportRowError
+}
+
+type ImportFile struct {
+	JobID    string
+	FileName string
+	Content  []byte
+}
+
+func NewImportJob(jobType ImportJobType, fileName string, params map[string]string, author string) (ImportJob, error) {
+	if jobType == "" {
+		return ImportJob{}, NewValidationError("import job type cannot be empty", nil)
+	}
+
+	if fileName == "" {
+		return ImportJob{}, NewValidationError("fileName cannot be empty", nil)
+	}
+
+	if author == "" {
+		return ImportJob{}, NewValidationError("author cannot be empty", nil)
+	}
+
+	jobID, err := uuid.NewUUID()
+	if err != nil {
+		return ImportJob{}, err
+	}
+
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	now := time.Now().UTC()
+	return ImportJob{
+		JobID:      jobID.String(),
+		Type:       jobType,
+		Status:     IMPORT_JOB_PENDING,
+		FileName:   fileName,
+		Params:     params,
+		CreatedIDs: []string{},
+		UpdatedIDs: []string{},
+		SkippedIDs: []string{},
+		RowErrors:  []ImportRowError{},
+		Errors:     []string{},
+		CreatedBy:  author,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func (j ImportJob) Param(key string) string {
+	return j.Params[key]
+}
+
+func (j ImportJob) BoolParam(key string) bool {
+	value, err := strconv.ParseBool(j.Params[key])
+	return err == nil && value
+}
+
+func (j ImportJob) IsFinished() bool {
+	return j.Status == IMPORT_JOB_COMPLETED || j.Status == IMPORT_JOB_PARTIAL || j.Status == IMPORT_JOB_FAILED
+}
+
+// FailedRows counts the distinct spreadsheet rows that were not imported.
+func (j ImportJob) FailedRows() int {
+	rows := make(map[int]struct{}, len(j.RowErrors))
+	for _, rowError := range j.RowErrors {
+		rows[rowError.Row] = struct{}{}
+	}
+
+	return len(rows)
+}
+
+// Start moves a claimed job into processing, resetting the progress of any
+// previous interrupted attempt.
+func (j *ImportJob) Start() {
+	now := time.Now().UTC()
+	j.Status = IMPORT_JOB_PROCESSING
+	j.Attempts++
+	j.TotalRows = 0
+	j.ProcessedRows = 0
+	j.RowErrors = []ImportRowError{}
+	j.StartedAt = &now
+	j.UpdatedAt = now
+}
+
+func (j *ImportJob) Progress(processedRows, totalRows int) {
+	j.ProcessedRows = processedRows
+	j.TotalRows = totalRows
+	j.UpdatedAt = time.Now().UTC()
+}
+
+func (j *ImportJob) Complete(result ImportResult) {
+	now := time.Now().UTC()
+	j.Status = IMPORT_JOB_COMPLETED
+	if len(result.RowErrors) > 0 {
+		j.Status = IMPORT_JOB_PARTIAL
+	}
+	j.CreatedIDs = result.CreatedIDs
+	j.UpdatedIDs = result.UpdatedIDs
+	j.SkippedIDs = result.SkippedIDs
+	j.RowErrors = result.RowErrors
+	j.ProcessedRows = j.TotalRows
+	j.FinishedAt = &now
+	j.UpdatedAt = now
+}
+
+func (j *ImportJob) Fail(err error) {
+	now := time.Now().UTC()
+	j.Status = IMPORT_JOB_FAILED
+	j.Errors = append(j.Errors, err.Error())
+	j.FinishedAt = &now
+	j.UpdatedAt = now
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImportJob(t *testing.T) {
+	t.Run("creates a pending job", func(t *testing.T) {
+		job, err := NewImportJob(IMPORT_JOB_CASES, "cases.csv", map[string]string{ImportParamCompany: "Assurant"}, "operator-1")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, job.JobID)
+		assert.Equal(t, IMPORT_JOB_PENDING, job.Status)
+		assert.Equal(t, "Assurant", job.Param(ImportParamCompany))
+	})
+
+	t.Run("returns validation error without an author", func(t *testing.T) {
+		_, err := NewImportJob(IMPORT_JOB_CASES, "cases.csv", nil, "")
+
+		require.Error(t, err)
+	})
+}
+
+func TestImportJob_Lifecycle(t *testing.T) {
+	t.Run("completes with the created ids", func(t *testing.T) {
+		job, err := NewImportJob(IMPORT_JOB_CASES, "cases.csv", nil, "operator-1")
+		require.NoError(t, err)
+
+		job.Start()
+		job.Progress(50, 120)
+		job.Complete(ImportResult{CreatedIDs: []string{"case-1", "case-2"}})
+
+		assert.Equal(t, IMPORT_JOB_COMPLETED, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		assert.Equal(t, 120, job.ProcessedRows)
+		assert.Len(t, job.CreatedIDs, 2)
+		assert.True(t, job.IsFinished())
+	})
+
+	t.Run("completes with errors when rows were left out", func(t *testing.T) {
+		job, err := NewImportJob(IMPORT_JOB_CASES, "cases.csv", nil, "operator-1")
+		require.NoError(t, err)
+
+		job.Start()
+		job.Complete(ImportResult{
+			CreatedIDs: []string{"case-1"},
+			RowErrors: []ImportRowError{
+				{Row: 3, Column: "CPF Cliente", Message: "invalid CPF/CNPJ"},
+				{Row: 3, Column: "Estado", Message: "unknown state acronym"},
+				{Row: 5, Column: "Número Sinistro", Message: "required value is empty"},
+			},
+		})
+
+		assert.Equal(t, IMPORT_JOB_PARTIAL, job.Status)
+		assert.Equal(t, 2, job.FailedRows())
+		assert.True(t, job.IsFinished())
+	})
+
+	t.Run("resets progress when an interrupted job restarts", func(t *testing.T) {
+		job, err := NewImportJob(IMPORT_JOB_CASES, "cases.csv", nil, "operator-1")
+		require.NoError(t, err)
+
+		job.Start()
+		job.Progress(50, 120)
+		job.Start()
+
+		assert.Equal(t, 2, job.Attempts)
+		assert.Zero(t, job.ProcessedRows)
+	})
+
+	t.Run("keeps the error when it fails", func(t *testing.T) {
+		job, err := NewImportJob(IMPORT_JOB_CASES, "cases.csv", nil, "operator-1")
+		require.NoError(t, err)
+
+		job.Start()
+		job.Fail(errors.New("company not found"))
+
+		assert.Equal(t, IMPORT_JOB_FAILED, job.Status)
+		assert.Equal(t, []string{"company not found"}, job.Errors)
+		assert.NotNil(t, job.FinishedAt)
+	})
+}
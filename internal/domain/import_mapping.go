@@ -0,0 +1,373 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportField is a case, customer or product attribute a spreadsheet column
+// can be mapped to.
+type ImportField string
+
+const (
+	IMPORT_FIELD_EXTERNAL_REFERENCE    ImportField = "case.external_reference"
+	IMPORT_FIELD_SUBJECT               ImportField = "case.subject"
+	IMPORT_FIELD_DUE_DATE              ImportField = "case.due_date"
+	IMPORT_FIELD_CUSTOMER_DOCUMENT     ImportField = "customer.document"
+	IMPORT_FIELD_CUSTOMER_NAME         ImportField = "customer.name"
+	IMPORT_FIELD_CUSTOMER_FIRST_NAME   ImportField = "customer.first_name"
+	IMPORT_FIELD_CUSTOMER_LAST_NAME    ImportField = "customer.last_name"
+	IMPORT_FIELD_CUSTOMER_COMPANY_NAME ImportField = "customer.company_name"
+	IMPORT_FIELD_CUSTOMER_PHONE        ImportField = "customer.phone"
+	IMPORT_FIELD_CUSTOMER_EMAIL        ImportField = "customer.email"
+	IMPORT_FIELD_CUSTOMER_ADDRESS      ImportField = "customer.address"
+	IMPORT_FIELD_CUSTOMER_DISTRICT     ImportField = "customer.district"
+	IMPORT_FIELD_CUSTOMER_CITY         ImportField = "customer.city"
+	IMPORT_FIELD_CUSTOMER_STATE        ImportField = "customer.state"
+	IMPORT_FIELD_CUSTOMER_ZIP_CODE     ImportField = "customer.zip_code"
+	IMPORT_FIELD_PRODUCT_NAME          ImportField = "product.name"
+	IMPORT_FIELD_PRODUCT_DESCRIPTION   ImportField = "product.description"
+	IMPORT_FIELD_PRODUCT_VALUE         ImportField = "product.value"
+	IMPORT_FIELD_PRODUCT_BRAND         ImportField = "product.brand"
+	IMPORT_FIELD_PRODUCT_MODEL         ImportField = "product.model"
+	IMPORT_FIELD_PRODUCT_SERIAL_NUMBER ImportField = "product.serial_number"
+)
+
+var importFields = []ImportField{
+	IMPORT_FIELD_EXTERNAL_REFERENCE,
+	IMPORT_FIELD_SUBJECT,
+	IMPORT_FIELD_DUE_DATE,
+	IMPORT_FIELD_CUSTOMER_DOCUMENT,
+	IMPORT_FIELD_CUSTOMER_NAME,
+	IMPORT_FIELD_CUSTOMER_FIRST_NAME,
+	IMPORT_FIELD_CUSTOMER_LAST_NAME,
+	IMPORT_FIELD_CUSTOMER_COMPANY_NAME,
+	IMPORT_FIELD_CUSTOMER_PHONE,
+	IMPORT_FIELD_CUSTOMER_EMAIL,
+	IMPORT_FIELD_CUSTOMER_ADDRESS,
+	IMPORT_FIELD_CUSTOMER_DISTRICT,
+	IMPORT_FIELD_CUSTOMER_CITY,
+	IMPORT_FIELD_CUSTOMER_STATE,
+	IMPORT_FIELD_CUSTOMER_ZIP_CODE,
+	IMPORT_FIELD_PRODUCT_NAME,
+	IMPORT_FIELD_PRODUCT_DESCRIPTION,
+	IMPORT_FIELD_PRODUCT_VALUE,
+	IMPORT_FIELD_PRODUCT_BRAND,
+	IMPORT_FIELD_PRODUCT_MODEL,
+	IMPORT_FIELD_PRODUCT_SERIAL_NUMBER,
+}
+
+// ImportTransform normalizes a cell value before it is assigned to a field.
+type ImportTransform string
+
+const (
+	IMPORT_TRANSFORM_NONE          ImportTransform = ""
+	IMPORT_TRANSFORM_DATE          ImportTransform = "date"
+	IMPORT_TRANSFORM_STATE_ACRONYM ImportTransform = "state_acronym"
+	IMPORT_TRANSFORM_STATE_NAME    ImportTransform = "state_name"
+	IMPORT_TRANSFORM_CURRENCY      ImportTransform = "currency"
+	IMPORT_TRANSFORM_DOCUMENT      ImportTransform = "document"
+)
+
+var importTransforms = []ImportTransform{
+	IMPORT_TRANSFORM_NONE,
+	IMPORT_TRANSFORM_DATE,
+	IMPORT_TRANSFORM_STATE_ACRONYM,
+	IMPORT_TRANSFORM_STATE_NAME,
+	IMPORT_TRANSFORM_CURRENCY,
+	IMPORT_TRANSFORM_DOCUMENT,
+}
+
+// DefaultImportDateFormat is used by date transforms without a format.
+const DefaultImportDateFormat = "DD/MM/YYYY"
+
+// The keys of a field entry in ContractorPlatformTemplate.Fields.
+const (
+	importMappingColumn    = "column"
+	importMappingTransform = "transform"
+	importMappingFormat    = "format"
+	importMappingRequired  = "required"
+	importMappingDefault   = "default"
+	importMappingOffset    = "offset"
+)
+
+// ImportFieldMapping reads one field from a spreadsheet column. Offset shifts
+// the cell read relative to the header, for layouts whose data is not aligned
+// with it. Default is used when the cell is empty.
+type ImportFieldMapping struct {
+	Field     ImportField
+	Column    string
+	Transform ImportTransform
+	Format    string
+	Required  bool
+	Default   string
+	Offset    int
+}
+
+// ImportMapping describes the spreadsheet layout of a contractor, letting a
+// new insurer be imported without a dedicated builder.
+type ImportMapping struct {
+	Fields []ImportFieldMapping
+}
+
+// NewImportMapping validates the field mappings. The external reference must
+// be mapped since it is what makes re-imports idempotent.
+func NewImportMapping(fields []ImportFieldMapping) (ImportMapping, error) {
+	seenFields := make(map[ImportField]struct{}, len(fields))
+	for _, field := range fields {
+		if !slices.Contains(importFields, field.Field) {
+			return ImportMapping{}, NewValidationError(fmt.Sprintf("unknown import field %s", field.Field), nil)
+		}
+
+		if _, seen := seenFields[field.Field]; seen {
+			return ImportMapping{}, NewValidationError(fmt.Sprintf("import field %s is mapped more than once", field.Field), nil)
+		}
+		seenFields[field.Field] = struct{}{}
+
+		if strings.TrimSpace(field.Column) == "" {
+			return ImportMapping{}, NewValidationError(fmt.Sprintf("column cannot be empty for import field %s", field.Field), nil)
+		}
+
+		if !slices.Contains(importTransforms, field.Transform) {
+			return ImportMapping{}, NewValidationError(fmt.Sprintf("unknown transform %s for import field %s", field.Transform, field.Field), nil)
+		}
+
+		if field.Offset < 0 {
+			return ImportMapping{}, NewValidationError(fmt.Sprintf("offset cannot be negative for import field %s", field.Field), nil)
+		}
+	}
+
+	if _, found := seenFields[IMPORT_FIELD_EXTERNAL_REFERENCE]; !found {
+		return ImportMapping{}, NewValidationError(fmt.Sprintf("import field %s must be mapped", IMPORT_FIELD_EXTERNAL_REFERENCE), nil)
+	}
+
+	return ImportMapping{Fields: fields}, nil
+}
+
+// ParseImportMapping reads a mapping from the template fields it is stored
+// in, keyed by import field.
+func ParseImportMapping(templateFields map[string]map[string]string) (ImportMapping, error) {
+	fields := make([]ImportFieldMapping, 0, len(templateFields))
+	for _, field := range importFields {
+		entry, found := templateFields[string(field)]
+		if !found {
+			continue
+		}
+
+		fieldMapping := ImportFieldMapping{
+			Field:     field,
+			Column:    entry[importMappingColumn],
+			Transform: ImportTransform(entry[importMappingTransform]),
+			Format:    entry[importMappingFormat],
+			Default:   entry[importMappingDefault],
+			Required:  entry[importMappingRequired] == "true",
+		}
+
+		if offset := entry[importMappingOffset]; offset != "" {
+			parsedOffset, err := strconv.Atoi(offset)
+			if err != nil {
+				return ImportMapping{}, NewValidationError(fmt.Sprintf("invalid offset for import field %s", field), nil)
+			}
+			fieldMapping.Offset = parsedOffset
+		}
+
+		fields = append(fields, fieldMapping)
+	}
+
+	for key := range templateFields {
+		if !slices.Contains(importFields, ImportField(key)) {
+			return ImportMapping{}, NewValidationError(fmt.Sprintf("unknown import field %s", key), nil)
+		}
+	}
+
+	return NewImportMapping(fields)
+}
+
+// TemplateFields converts the mapping to the shape it is stored in.
+func (m ImportMapping) TemplateFields() map[string]map[string]string {
+	templateFields := make(map[string]map[string]string, len(m.Fields))
+	for _, field := range m.Fields {
+		entry := map[string]string{importMappingColumn: field.Column}
+		if field.Transform != IMPORT_TRANSFORM_NONE {
+			entry[importMappingTransform] = string(field.Transform)
+		}
+		if field.Format != "" {
+			entry[importMappingFormat] = field.Format
+		}
+		if field.Required {
+			entry[importMappingRequired] = "true"
+		}
+		if field.Default != "" {
+			entry[importMappingDefault] = field.Default
+		}
+		if field.Offset != 0 {
+			entry[importMappingOffset] = strconv.Itoa(field.Offset)
+		}
+
+		templateFields[string(field.Field)] = entry
+	}
+
+	return templateFields
+}
+
+func (m ImportMapping) Field(field ImportField) (ImportFieldMapping, bool) {
+	idx := slices.IndexFunc(m.Fields, func(f ImportFieldMapping) bool {
+		return f.Field == field
+	})
+	if idx == -1 {
+		return ImportFieldMapping{}, false
+	}
+
+	return m.Fields[idx], true
+}
+
+// Apply runs the transform of the field on a trimmed cell value. Dates come
+// out as YYYY-MM-DD, currencies as plain decimals and state acronyms as the
+// state name stored on addresses.
+func (f ImportFieldMapping) Apply(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = f.Default
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	switch f.Transform {
+	case IMPORT_TRANSFORM_DATE:
+		format := f.Format
+		if format == "" {
+			format = DefaultImportDateFormat
+		}
+
+		date, err := ParseImportDate(value, format)
+		if err != nil {
+			return "", err
+		}
+
+		return date.Format(time.DateOnly), nil
+	case IMPORT_TRANSFORM_STATE_ACRONYM:
+		stateName, found := AcronymForState[strings.ToUpper(value)]
+		if !found {
+			return "", errors.New("unknown state acronym")
+		}
+
+		return stateName, nil
+	case IMPORT_TRANSFORM_STATE_NAME:
+		for _, stateName := range AcronymForState {
+			if strings.EqualFold(stateName, value) {
+				return stateName, nil
+			}
+		}
+
+		return "", errors.New("unknown state name")
+	case IMPORT_TRANSFORM_CURRENCY:
+		amount, err := ParseCurrency(value)
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatFloat(amount, 'f', -1, 64), nil
+	case IMPORT_TRANSFORM_DOCUMENT:
+		if !IsValidDocument(value) {
+			return "", errors.New("invalid CPF/CNPJ")
+		}
+
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// ParseImportDate reads a date written with the given format. Spreadsheet
+// readers hand date cells over as ISO timestamps, and cells without a date
+// style as Excel serial numbers, so both are accepted as well.
+func ParseImportDate(value, format string) (time.Time, error) {
+	if date, err := time.Parse(importDateLayout(format), value); err == nil {
+		return date, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", time.DateOnly} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	// serials below 61 fall before the 1900 leap year bug and above 2958465
+	// after 9999-12-31, neither is a date anyone imports
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 61 && serial <= 2958465 {
+		return excelEpoch.Add(time.Duration(serial * float64(24*time.Hour))).Round(time.Second), nil
+	}
+
+	return time.Time{}, fmt.Errorf("date does not match format %s", format)
+}
+
+// excelEpoch is day zero of the 1900 date system used by Excel serials.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// ParseCurrency reads an amount written either as 1.299,90 or 1,299.90,
+// optionally prefixed by R$. The last separator is taken as the decimal one.
+func ParseCurrency(value string) (float64, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "R$"))
+
+	lastComma := strings.LastIndex(value, ",")
+	lastDot := strings.LastIndex(value, ".")
+	if lastComma > lastDot {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.New("value is not a number")
+	}
+
+	return amount, nil
+}
+
+// importDateLayout turns the DD/MM/YYYY style formats operators write into a
+// Go time layout.
+func importDateLayout(format string) string {
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MM", "01",
+		"DD", "02",
+		"hh", "15",
+		"mi", "04",
+		"ss", "05",
+	).Replace(format)
+}
+
+// ImportMapping returns the spreadsheet mapping stored on the contractor
+// template, or nil when the contractor relies on a built-in layout.
+func (c Contractor) ImportMapping() (*ImportMapping, error) {
+	if len(c.Template.Fields) == 0 {
+		return nil, nil
+	}
+
+	mapping, err := ParseImportMapping(c.Template.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapping, nil
+}
+
+// SetImportMapping stores the mapping on the contractor template. A nil
+// mapping clears it, falling back to the built-in layout.
+func (c *Contractor) SetImportMapping(mapping *ImportMapping, author string) {
+	c.Template.Fields = nil
+	if mapping != nil {
+		c.Template.Fields = mapping.TemplateFields()
+	}
+
+	c.UpdatedBy = author
+	c.UpdatedAt = time.Now().UTC()
+}
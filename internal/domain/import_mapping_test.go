@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportMapping(t *testing.T) {
+	t.Run("round trips through the template fields", func(t *testing.T) {
+		mapping, err := NewImportMapping([]ImportFieldMapping{
+			{Field: IMPORT_FIELD_EXTERNAL_REFERENCE, Column: "Sinistro", Required: true},
+			{Field: IMPORT_FIELD_DUE_DATE, Column: "Prazo", Transform: IMPORT_TRANSFORM_DATE, Format: "DD/MM/YYYY"},
+			{Field: IMPORT_FIELD_CUSTOMER_STATE, Column: "UF", Transform: IMPORT_TRANSFORM_STATE_ACRONYM, Offset: 1},
+		})
+		require.NoError(t, err)
+
+		parsed, err := ParseImportMapping(mapping.TemplateFields())
+
+		require.NoError(t, err)
+		assert.Equal(t, mapping, parsed)
+	})
+
+	t.Run("requires the external reference", func(t *testing.T) {
+		_, err := ParseImportMapping(map[string]map[string]string{
+			string(IMPORT_FIELD_SUBJECT): {"column": "Defeito"},
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unknown fields and transforms", func(t *testing.T) {
+		_, err := ParseImportMapping(map[string]map[string]string{
+			string(IMPORT_FIELD_EXTERNAL_REFERENCE): {"column": "Sinistro"},
+			"case.color":                            {"column": "Cor"},
+		})
+		assert.Error(t, err)
+
+		_, err = NewImportMapping([]ImportFieldMapping{
+			{Field: IMPORT_FIELD_EXTERNAL_REFERENCE, Column: "Sinistro", Transform: "uppercase"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestImportFieldMapping_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    ImportFieldMapping
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "date with default format", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DATE}, value: "31/01/2025", expected: "2025-01-31"},
+		{name: "date with custom format", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DATE, Format: "YYYY-MM-DD hh:mi"}, value: "2025-01-31 10:30", expected: "2025-01-31"},
+		{name: "date read from a spreadsheet date cell", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DATE}, value: "2025-01-31T00:00:00Z", expected: "2025-01-31"},
+		{name: "date as an excel serial", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DATE}, value: "45688", expected: "2025-01-31"},
+		{name: "date not matching the format", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DATE}, value: "31-01-2025", wantErr: true},
+		{name: "number too small to be a date", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DATE}, value: "12", wantErr: true},
+		{name: "state acronym", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_STATE_ACRONYM}, value: "sp", expected: "São Paulo"},
+		{name: "unknown state acronym", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_STATE_ACRONYM}, value: "XX", wantErr: true},
+		{name: "brazilian currency", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_CURRENCY}, value: "R$ 1.299,90", expected: "1299.9"},
+		{name: "english currency", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_CURRENCY}, value: "1,299.90", expected: "1299.9"},
+		{name: "invalid document", field: ImportFieldMapping{Transform: IMPORT_TRANSFORM_DOCUMENT}, value: "529.982.247-26", wantErr: true},
+		{name: "default for empty cell", field: ImportFieldMapping{Default: "Sem descrição"}, value: " ", expected: "Sem descrição"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.field.Apply(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "strings"
+
+// ImportDuplicateReason tells why a previewed case would not be created.
+type ImportDuplicateReason string
+
+const (
+	IMPORT_DUPLICATE_EXISTING ImportDuplicateReason = "already_exists"
+	IMPORT_DUPLICATE_IN_FILE  ImportDuplicateReason = "repeated_in_file"
+)
+
+// ImportPreview is what a batch import would do with a file, computed without
+// writing anything.
+type ImportPreview struct {
+	TotalRows        int
+	NewCustomers     []PreviewCustomer
+	MatchedCustomers []PreviewCustomer
+	NewCases         []PreviewCase
+	Duplicates       []PreviewCase
+	RowErrors        []ImportRowError
+}
+
+type PreviewCustomer struct {
+	CustomerID string
+	Document   string
+	Name       string
+	Rows       []int
+}
+
+type PreviewCase struct {
+	Row               int
+	ContractorID      string
+	ExternalReference string
+	CustomerDocument  string
+	CustomerID        string
+	ExistingCaseID    string
+	DuplicateReason   ImportDuplicateReason
+}
+
+// PartnerImportPreview is what a partner import would do with a file,
+// computed without writing anything.
+type PartnerImportPreview struct {
+	TotalRows       int
+	NewPartners     []PreviewPartner
+	UpdatedPartners []PreviewPartner
+	RowErrors       []ImportRowError
+}
+
+type PreviewPartner struct {
+	Row       int
+	PartnerID string
+	Document  string
+	Name      string
+}
+
+func NewPreviewCustomer(customer Customer) PreviewCustomer {
+	name := strings.TrimSpace(customer.FirstName + " " + customer.LastName)
+	if name == "" {
+		name = customer.CompanyName
+	}
+
+	return PreviewCustomer{
+		CustomerID: customer.CustomerID,
+		Document:   customer.Document,
+		Name:       name,
+		Rows:       []int{},
+	}
+}
+
+func NewPreviewPartner(partner Partner, row int) PreviewPartner {
+	name := strings.TrimSpace(partner.FirstName + " " + partner.LastName)
+	if name == "" {
+		name = partner.CompanyName
+	}
+
+	return PreviewPartner{
+		Row:       row,
+		PartnerID: partner.PartnerID,
+		Document:  partner.Document,
+		Name:      name,
+	}
+}
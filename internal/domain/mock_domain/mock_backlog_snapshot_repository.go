@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backlog.go
+//
+// Generated by this command:
+//
+//	mockgen -source=backlog.go -destination=mock_domain/mock_backlog_snapshot_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBacklogSnapshotRepository is a mock of BacklogSnapshotRepository interface.
+type MockBacklogSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBacklogSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBacklogSnapshotRepositoryMockRecorder is the mock recorder for MockBacklogSnapshotRepository.
+type MockBacklogSnapshotRepositoryMockRecorder struct {
+	mock *MockBacklogSnapshotRepository
+}
+
+// NewMockBacklogSnapshotRepository creates a new mock instance.
+func NewMockBacklogSnapshotRepository(ctrl *gomock.Controller) *MockBacklogSnapshotRepository {
+	mock := &MockBacklogSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockBacklogSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBacklogSnapshotRepository) EXPECT() *MockBacklogSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBatch mocks base method.
+func (m *MockBacklogSnapshotRepository) CreateBatch(ctx context.Context, snapshots []domain.BacklogSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, snapshots)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockBacklogSnapshotRepositoryMockRecorder) CreateBatch(ctx, snapshots any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockBacklogSnapshotRepository)(nil).CreateBatch), ctx, snapshots)
+}
+
+// HasDay mocks base method.
+func (m *MockBacklogSnapshotRepository) HasDay(ctx context.Context, day time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDay", ctx, day)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDay indicates an expected call of HasDay.
+func (mr *MockBacklogSnapshotRepositoryMockRecorder) HasDay(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDay", reflect.TypeOf((*MockBacklogSnapshotRepository)(nil).HasDay), ctx, day)
+}
+
+// Search mocks base method.
+func (m *MockBacklogSnapshotRepository) Search(ctx context.Context, filters domain.BacklogSnapshotFilters) ([]domain.BacklogSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filters)
+	ret0, _ := ret[0].([]domain.BacklogSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBacklogSnapshotRepositoryMockRecorder) Search(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBacklogSnapshotRepository)(nil).Search), ctx, filters)
+}
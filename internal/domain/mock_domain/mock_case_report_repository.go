@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: case_report.go
+//
+// Generated by this command:
+//
+//	mockgen -source=case_report.go -destination=mock_domain/mock_case_report_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCaseReportRepository is a mock of CaseReportRepository interface.
+type MockCaseReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCaseReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCaseReportRepositoryMockRecorder is the mock recorder for MockCaseReportRepository.
+type MockCaseReportRepositoryMockRecorder struct {
+	mock *MockCaseReportRepository
+}
+
+// NewMockCaseReportRepository creates a new mock instance.
+func NewMockCaseReportRepository(ctrl *gomock.Controller) *MockCaseReportRepository {
+	mock := &MockCaseReportRepository{ctrl: ctrl}
+	mock.recorder = &MockCaseReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCaseReportRepository) EXPECT() *MockCaseReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCaseReportRepository) Create(ctx context.Context, caseReport domain.CaseReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, caseReport)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCaseReportRepositoryMockRecorder) Create(ctx, caseReport any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCaseReportRepository)(nil).Create), ctx, caseReport)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockCaseReportRepository) GetByCaseID(ctx context.Context, caseID string) ([]domain.CaseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].([]domain.CaseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockCaseReportRepositoryMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockCaseReportRepository)(nil).GetByCaseID), ctx, caseID)
+}
+
+// GetByID mocks base method.
+func (m *MockCaseReportRepository) GetByID(ctx context.Context, caseReportID string) (*domain.CaseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, caseReportID)
+	ret0, _ := ret[0].(*domain.CaseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCaseReportRepositoryMockRecorder) GetByID(ctx, caseReportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCaseReportRepository)(nil).GetByID), ctx, caseReportID)
+}
@@ -12,6 +12,7 @@ package mock_domain
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/icrxz/crm-api-core/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,36 @@ func (m *MockCaseRepository) EXPECT() *MockCaseRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByAge mocks base method.
+func (m *MockCaseRepository) CountByAge(ctx context.Context, filters domain.CaseFilters, buckets domain.AgeBuckets, today time.Time, withCaseIDs bool) ([]domain.CaseAgeCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAge", ctx, filters, buckets, today, withCaseIDs)
+	ret0, _ := ret[0].([]domain.CaseAgeCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAge indicates an expected call of CountByAge.
+func (mr *MockCaseRepositoryMockRecorder) CountByAge(ctx, filters, buckets, today, withCaseIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAge", reflect.TypeOf((*MockCaseRepository)(nil).CountByAge), ctx, filters, buckets, today, withCaseIDs)
+}
+
+// CountGrouped mocks base method.
+func (m *MockCaseRepository) CountGrouped(ctx context.Context, filters domain.CaseFilters, groupBy []domain.CaseStatsDimension) ([]domain.CaseStatsGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGrouped", ctx, filters, groupBy)
+	ret0, _ := ret[0].([]domain.CaseStatsGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGrouped indicates an expected call of CountGrouped.
+func (mr *MockCaseRepositoryMockRecorder) CountGrouped(ctx, filters, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGrouped", reflect.TypeOf((*MockCaseRepository)(nil).CountGrouped), ctx, filters, groupBy)
+}
+
 // Create mocks base method.
 func (m *MockCaseRepository) Create(ctx context.Context, crmCase domain.Case) (string, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +102,21 @@ func (mr *MockCaseRepositoryMockRecorder) CreateBatch(ctx, cases any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockCaseRepository)(nil).CreateBatch), ctx, cases)
 }
 
+// GetByExternalReferences mocks base method.
+func (m *MockCaseRepository) GetByExternalReferences(ctx context.Context, contractorIDs, externalReferences []string) ([]domain.Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExternalReferences", ctx, contractorIDs, externalReferences)
+	ret0, _ := ret[0].([]domain.Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExternalReferences indicates an expected call of GetByExternalReferences.
+func (mr *MockCaseRepositoryMockRecorder) GetByExternalReferences(ctx, contractorIDs, externalReferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalReferences", reflect.TypeOf((*MockCaseRepository)(nil).GetByExternalReferences), ctx, contractorIDs, externalReferences)
+}
+
 // GetByID mocks base method.
 func (m *MockCaseRepository) GetByID(ctx context.Context, caseID string) (*domain.Case, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fraud.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fraud.go -destination=mock_domain/mock_fraud_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFraudRepository is a mock of FraudRepository interface.
+type MockFraudRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudRepositoryMockRecorder is the mock recorder for MockFraudRepository.
+type MockFraudRepositoryMockRecorder struct {
+	mock *MockFraudRepository
+}
+
+// NewMockFraudRepository creates a new mock instance.
+func NewMockFraudRepository(ctrl *gomock.Controller) *MockFraudRepository {
+	mock := &MockFraudRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudRepository) EXPECT() *MockFraudRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountCustomersSharingContact mocks base method.
+func (m *MockFraudRepository) CountCustomersSharingContact(ctx context.Context, customer domain.Customer) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCustomersSharingContact", ctx, customer)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCustomersSharingContact indicates an expected call of CountCustomersSharingContact.
+func (mr *MockFraudRepositoryMockRecorder) CountCustomersSharingContact(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCustomersSharingContact", reflect.TypeOf((*MockFraudRepository)(nil).CountCustomersSharingContact), ctx, customer)
+}
+
+// CountRecentCasesByCustomer mocks base method.
+func (m *MockFraudRepository) CountRecentCasesByCustomer(ctx context.Context, customerID string, since time.Time, excludeCaseID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecentCasesByCustomer", ctx, customerID, since, excludeCaseID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecentCasesByCustomer indicates an expected call of CountRecentCasesByCustomer.
+func (mr *MockFraudRepositoryMockRecorder) CountRecentCasesByCustomer(ctx, customerID, since, excludeCaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentCasesByCustomer", reflect.TypeOf((*MockFraudRepository)(nil).CountRecentCasesByCustomer), ctx, customerID, since, excludeCaseID)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockFraudRepository) GetByCaseID(ctx context.Context, caseID string) (*domain.FraudAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].(*domain.FraudAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockFraudRepositoryMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockFraudRepository)(nil).GetByCaseID), ctx, caseID)
+}
+
+// GetContractorsBySerialNumber mocks base method.
+func (m *MockFraudRepository) GetContractorsBySerialNumber(ctx context.Context, serialNumber, excludeCaseID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractorsBySerialNumber", ctx, serialNumber, excludeCaseID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractorsBySerialNumber indicates an expected call of GetContractorsBySerialNumber.
+func (mr *MockFraudRepositoryMockRecorder) GetContractorsBySerialNumber(ctx, serialNumber, excludeCaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractorsBySerialNumber", reflect.TypeOf((*MockFraudRepository)(nil).GetContractorsBySerialNumber), ctx, serialNumber, excludeCaseID)
+}
+
+// Save mocks base method.
+func (m *MockFraudRepository) Save(ctx context.Context, assessment domain.FraudAssessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, assessment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFraudRepositoryMockRecorder) Save(ctx, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFraudRepository)(nil).Save), ctx, assessment)
+}
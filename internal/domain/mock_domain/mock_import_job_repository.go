@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import_job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=import_job.go -destination=mock_domain/mock_import_job_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportJobRepository is a mock of ImportJobRepository interface.
+type MockImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImportJobRepositoryMockRecorder is the mock recorder for MockImportJobRepository.
+type MockImportJobRepositoryMockRecorder struct {
+	mock *MockImportJobRepository
+}
+
+// NewMockImportJobRepository creates a new mock instance.
+func NewMockImportJobRepository(ctrl *gomock.Controller) *MockImportJobRepository {
+	mock := &MockImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobRepository) EXPECT() *MockImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimNext mocks base method.
+func (m *MockImportJobRepository) ClaimNext(ctx context.Context, leaseID string, staleBefore time.Time) (*domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNext", ctx, leaseID, staleBefore)
+	ret0, _ := ret[0].(*domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNext indicates an expected call of ClaimNext.
+func (mr *MockImportJobRepositoryMockRecorder) ClaimNext(ctx, leaseID, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNext", reflect.TypeOf((*MockImportJobRepository)(nil).ClaimNext), ctx, leaseID, staleBefore)
+}
+
+// Create mocks base method.
+func (m *MockImportJobRepository) Create(ctx context.Context, job domain.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportJobRepositoryMockRecorder) Create(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportJobRepository)(nil).Create), ctx, job)
+}
+
+// CreateFile mocks base method.
+func (m *MockImportJobRepository) CreateFile(ctx context.Context, file domain.ImportFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFile", ctx, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFile indicates an expected call of CreateFile.
+func (mr *MockImportJobRepositoryMockRecorder) CreateFile(ctx, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockImportJobRepository)(nil).CreateFile), ctx, file)
+}
+
+// GetByID mocks base method.
+func (m *MockImportJobRepository) GetByID(ctx context.Context, jobID string) (*domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, jobID)
+	ret0, _ := ret[0].(*domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImportJobRepositoryMockRecorder) GetByID(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImportJobRepository)(nil).GetByID), ctx, jobID)
+}
+
+// GetFile mocks base method.
+func (m *MockImportJobRepository) GetFile(ctx context.Context, jobID string) (*domain.ImportFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", ctx, jobID)
+	ret0, _ := ret[0].(*domain.ImportFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockImportJobRepositoryMockRecorder) GetFile(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockImportJobRepository)(nil).GetFile), ctx, jobID)
+}
+
+// Heartbeat mocks base method.
+func (m *MockImportJobRepository) Heartbeat(ctx context.Context, jobID, leaseID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", ctx, jobID, leaseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockImportJobRepositoryMockRecorder) Heartbeat(ctx, jobID, leaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockImportJobRepository)(nil).Heartbeat), ctx, jobID, leaseID)
+}
+
+// Update mocks base method.
+func (m *MockImportJobRepository) Update(ctx context.Context, job domain.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockImportJobRepositoryMockRecorder) Update(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockImportJobRepository)(nil).Update), ctx, job)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: operational_report.go
+//
+// Generated by this command:
+//
+//	mockgen -source=operational_report.go -destination=mock_domain/mock_operational_report_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationalReportRepository is a mock of OperationalReportRepository interface.
+type MockOperationalReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationalReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationalReportRepositoryMockRecorder is the mock recorder for MockOperationalReportRepository.
+type MockOperationalReportRepositoryMockRecorder struct {
+	mock *MockOperationalReportRepository
+}
+
+// NewMockOperationalReportRepository creates a new mock instance.
+func NewMockOperationalReportRepository(ctrl *gomock.Controller) *MockOperationalReportRepository {
+	mock := &MockOperationalReportRepository{ctrl: ctrl}
+	mock.recorder = &MockOperationalReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationalReportRepository) EXPECT() *MockOperationalReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountCasesByStatus mocks base method.
+func (m *MockOperationalReportRepository) CountCasesByStatus(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.CaseStatusCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCasesByStatus", ctx, filters)
+	ret0, _ := ret[0].([]domain.CaseStatusCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCasesByStatus indicates an expected call of CountCasesByStatus.
+func (mr *MockOperationalReportRepositoryMockRecorder) CountCasesByStatus(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCasesByStatus", reflect.TypeOf((*MockOperationalReportRepository)(nil).CountCasesByStatus), ctx, filters)
+}
+
+// CountClosedCasesByMonth mocks base method.
+func (m *MockOperationalReportRepository) CountClosedCasesByMonth(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.ClosedCasesCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClosedCasesByMonth", ctx, filters)
+	ret0, _ := ret[0].([]domain.ClosedCasesCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClosedCasesByMonth indicates an expected call of CountClosedCasesByMonth.
+func (mr *MockOperationalReportRepositoryMockRecorder) CountClosedCasesByMonth(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClosedCasesByMonth", reflect.TypeOf((*MockOperationalReportRepository)(nil).CountClosedCasesByMonth), ctx, filters)
+}
+
+// CountOpenCasesByAge mocks base method.
+func (m *MockOperationalReportRepository) CountOpenCasesByAge(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.CaseAgingCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenCasesByAge", ctx, filters)
+	ret0, _ := ret[0].([]domain.CaseAgingCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenCasesByAge indicates an expected call of CountOpenCasesByAge.
+func (mr *MockOperationalReportRepositoryMockRecorder) CountOpenCasesByAge(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenCasesByAge", reflect.TypeOf((*MockOperationalReportRepository)(nil).CountOpenCasesByAge), ctx, filters)
+}
+
+// SumPartnerPayouts mocks base method.
+func (m *MockOperationalReportRepository) SumPartnerPayouts(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.PartnerPayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPartnerPayouts", ctx, filters)
+	ret0, _ := ret[0].([]domain.PartnerPayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPartnerPayouts indicates an expected call of SumPartnerPayouts.
+func (mr *MockOperationalReportRepositoryMockRecorder) SumPartnerPayouts(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPartnerPayouts", reflect.TypeOf((*MockOperationalReportRepository)(nil).SumPartnerPayouts), ctx, filters)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: part.go
+//
+// Generated by this command:
+//
+//	mockgen -source=part.go -destination=mock_domain/mock_part_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPartRepository is a mock of PartRepository interface.
+type MockPartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPartRepositoryMockRecorder is the mock recorder for MockPartRepository.
+type MockPartRepositoryMockRecorder struct {
+	mock *MockPartRepository
+}
+
+// NewMockPartRepository creates a new mock instance.
+func NewMockPartRepository(ctrl *gomock.Controller) *MockPartRepository {
+	mock := &MockPartRepository{ctrl: ctrl}
+	mock.recorder = &MockPartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartRepository) EXPECT() *MockPartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPartRepository) Create(ctx context.Context, part domain.Part) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, part)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPartRepositoryMockRecorder) Create(ctx, part any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPartRepository)(nil).Create), ctx, part)
+}
+
+// CreateMovement mocks base method.
+func (m *MockPartRepository) CreateMovement(ctx context.Context, movement domain.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovement", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMovement indicates an expected call of CreateMovement.
+func (mr *MockPartRepositoryMockRecorder) CreateMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovement", reflect.TypeOf((*MockPartRepository)(nil).CreateMovement), ctx, movement)
+}
+
+// GetByID mocks base method.
+func (m *MockPartRepository) GetByID(ctx context.Context, partID string) (*domain.Part, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, partID)
+	ret0, _ := ret[0].(*domain.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPartRepositoryMockRecorder) GetByID(ctx, partID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPartRepository)(nil).GetByID), ctx, partID)
+}
+
+// GetMovementsByCaseID mocks base method.
+func (m *MockPartRepository) GetMovementsByCaseID(ctx context.Context, caseID string) ([]domain.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovementsByCaseID", ctx, caseID)
+	ret0, _ := ret[0].([]domain.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovementsByCaseID indicates an expected call of GetMovementsByCaseID.
+func (mr *MockPartRepositoryMockRecorder) GetMovementsByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovementsByCaseID", reflect.TypeOf((*MockPartRepository)(nil).GetMovementsByCaseID), ctx, caseID)
+}
+
+// LockByID mocks base method.
+func (m *MockPartRepository) LockByID(ctx context.Context, partID string) (*domain.Part, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", ctx, partID)
+	ret0, _ := ret[0].(*domain.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockPartRepositoryMockRecorder) LockByID(ctx, partID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockPartRepository)(nil).LockByID), ctx, partID)
+}
+
+// Search mocks base method.
+func (m *MockPartRepository) Search(ctx context.Context, filters domain.PartFilters) (domain.PagingResult[domain.Part], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filters)
+	ret0, _ := ret[0].(domain.PagingResult[domain.Part])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPartRepositoryMockRecorder) Search(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPartRepository)(nil).Search), ctx, filters)
+}
+
+// Update mocks base method.
+func (m *MockPartRepository) Update(ctx context.Context, part domain.Part) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, part)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPartRepositoryMockRecorder) Update(ctx, part any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPartRepository)(nil).Update), ctx, part)
+}
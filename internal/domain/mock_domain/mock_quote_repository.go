@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quote.go
+//
+// Generated by this command:
+//
+//	mockgen -source=quote.go -destination=mock_domain/mock_quote_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteRepository is a mock of QuoteRepository interface.
+type MockQuoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteRepositoryMockRecorder is the mock recorder for MockQuoteRepository.
+type MockQuoteRepositoryMockRecorder struct {
+	mock *MockQuoteRepository
+}
+
+// NewMockQuoteRepository creates a new mock instance.
+func NewMockQuoteRepository(ctrl *gomock.Controller) *MockQuoteRepository {
+	mock := &MockQuoteRepository{ctrl: ctrl}
+	mock.recorder = &MockQuoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteRepository) EXPECT() *MockQuoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockQuoteRepository) Create(ctx context.Context, quote domain.Quote) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, quote)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuoteRepositoryMockRecorder) Create(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuoteRepository)(nil).Create), ctx, quote)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockQuoteRepository) GetByCaseID(ctx context.Context, caseID string) ([]domain.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].([]domain.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockQuoteRepositoryMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockQuoteRepository)(nil).GetByCaseID), ctx, caseID)
+}
+
+// GetByID mocks base method.
+func (m *MockQuoteRepository) GetByID(ctx context.Context, quoteID string) (*domain.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, quoteID)
+	ret0, _ := ret[0].(*domain.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockQuoteRepositoryMockRecorder) GetByID(ctx, quoteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockQuoteRepository)(nil).GetByID), ctx, quoteID)
+}
+
+// Update mocks base method.
+func (m *MockQuoteRepository) Update(ctx context.Context, quote domain.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQuoteRepositoryMockRecorder) Update(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuoteRepository)(nil).Update), ctx, quote)
+}
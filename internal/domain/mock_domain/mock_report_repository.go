@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report.go
+//
+// Generated by this command:
+//
+//	mockgen -source=report.go -destination=mock_domain/mock_report_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReportRepository) Create(ctx context.Context, report domain.Report) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, report)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReportRepositoryMockRecorder) Create(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReportRepository)(nil).Create), ctx, report)
+}
+
+// GetActiveByContractor mocks base method.
+func (m *MockReportRepository) GetActiveByContractor(ctx context.Context, contractorID string) (*domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByContractor", ctx, contractorID)
+	ret0, _ := ret[0].(*domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByContractor indicates an expected call of GetActiveByContractor.
+func (mr *MockReportRepositoryMockRecorder) GetActiveByContractor(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByContractor", reflect.TypeOf((*MockReportRepository)(nil).GetActiveByContractor), ctx, contractorID)
+}
+
+// GetByContractor mocks base method.
+func (m *MockReportRepository) GetByContractor(ctx context.Context, contractorID string) ([]domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByContractor", ctx, contractorID)
+	ret0, _ := ret[0].([]domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByContractor indicates an expected call of GetByContractor.
+func (mr *MockReportRepositoryMockRecorder) GetByContractor(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByContractor", reflect.TypeOf((*MockReportRepository)(nil).GetByContractor), ctx, contractorID)
+}
+
+// GetByID mocks base method.
+func (m *MockReportRepository) GetByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, reportID)
+	ret0, _ := ret[0].(*domain.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReportRepositoryMockRecorder) GetByID(ctx, reportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReportRepository)(nil).GetByID), ctx, reportID)
+}
+
+// Update mocks base method.
+func (m *MockReportRepository) Update(ctx context.Context, report domain.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReportRepositoryMockRecorder) Update(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReportRepository)(nil).Update), ctx, report)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settlement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=settlement.go -destination=mock_domain/mock_settlement_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSettlementRepository is a mock of SettlementRepository interface.
+type MockSettlementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSettlementRepositoryMockRecorder is the mock recorder for MockSettlementRepository.
+type MockSettlementRepositoryMockRecorder struct {
+	mock *MockSettlementRepository
+}
+
+// NewMockSettlementRepository creates a new mock instance.
+func NewMockSettlementRepository(ctrl *gomock.Controller) *MockSettlementRepository {
+	mock := &MockSettlementRepository{ctrl: ctrl}
+	mock.recorder = &MockSettlementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementRepository) EXPECT() *MockSettlementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDecision mocks base method.
+func (m *MockSettlementRepository) GetDecision(ctx context.Context, caseID string) (*domain.SettlementDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecision", ctx, caseID)
+	ret0, _ := ret[0].(*domain.SettlementDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecision indicates an expected call of GetDecision.
+func (mr *MockSettlementRepositoryMockRecorder) GetDecision(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecision", reflect.TypeOf((*MockSettlementRepository)(nil).GetDecision), ctx, caseID)
+}
+
+// GetPolicy mocks base method.
+func (m *MockSettlementRepository) GetPolicy(ctx context.Context, contractorID string) (*domain.SettlementPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, contractorID)
+	ret0, _ := ret[0].(*domain.SettlementPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockSettlementRepositoryMockRecorder) GetPolicy(ctx, contractorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockSettlementRepository)(nil).GetPolicy), ctx, contractorID)
+}
+
+// SaveDecision mocks base method.
+func (m *MockSettlementRepository) SaveDecision(ctx context.Context, decision domain.SettlementDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDecision", ctx, decision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDecision indicates an expected call of SaveDecision.
+func (mr *MockSettlementRepositoryMockRecorder) SaveDecision(ctx, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDecision", reflect.TypeOf((*MockSettlementRepository)(nil).SaveDecision), ctx, decision)
+}
+
+// SavePolicy mocks base method.
+func (m *MockSettlementRepository) SavePolicy(ctx context.Context, policy domain.SettlementPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePolicy indicates an expected call of SavePolicy.
+func (mr *MockSettlementRepositoryMockRecorder) SavePolicy(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePolicy", reflect.TypeOf((*MockSettlementRepository)(nil).SavePolicy), ctx, policy)
+}
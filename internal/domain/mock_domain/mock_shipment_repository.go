@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shipment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=shipment.go -destination=mock_domain/mock_shipment_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShipmentRepository is a mock of ShipmentRepository interface.
+type MockShipmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockShipmentRepositoryMockRecorder is the mock recorder for MockShipmentRepository.
+type MockShipmentRepositoryMockRecorder struct {
+	mock *MockShipmentRepository
+}
+
+// NewMockShipmentRepository creates a new mock instance.
+func NewMockShipmentRepository(ctrl *gomock.Controller) *MockShipmentRepository {
+	mock := &MockShipmentRepository{ctrl: ctrl}
+	mock.recorder = &MockShipmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentRepository) EXPECT() *MockShipmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentRepository) Create(ctx context.Context, shipment domain.Shipment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, shipment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentRepositoryMockRecorder) Create(ctx, shipment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentRepository)(nil).Create), ctx, shipment)
+}
+
+// CreateEvents mocks base method.
+func (m *MockShipmentRepository) CreateEvents(ctx context.Context, events []domain.ShipmentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvents indicates an expected call of CreateEvents.
+func (mr *MockShipmentRepositoryMockRecorder) CreateEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockShipmentRepository)(nil).CreateEvents), ctx, events)
+}
+
+// GetByCaseID mocks base method.
+func (m *MockShipmentRepository) GetByCaseID(ctx context.Context, caseID string) ([]domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCaseID", ctx, caseID)
+	ret0, _ := ret[0].([]domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCaseID indicates an expected call of GetByCaseID.
+func (mr *MockShipmentRepositoryMockRecorder) GetByCaseID(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCaseID", reflect.TypeOf((*MockShipmentRepository)(nil).GetByCaseID), ctx, caseID)
+}
+
+// GetByID mocks base method.
+func (m *MockShipmentRepository) GetByID(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, shipmentID)
+	ret0, _ := ret[0].(*domain.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockShipmentRepositoryMockRecorder) GetByID(ctx, shipmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockShipmentRepository)(nil).GetByID), ctx, shipmentID)
+}
+
+// Update mocks base method.
+func (m *MockShipmentRepository) Update(ctx context.Context, shipment domain.Shipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, shipment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShipmentRepositoryMockRecorder) Update(ctx, shipment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShipmentRepository)(nil).Update), ctx, shipment)
+}
+
+// MockCarrier is a mock of Carrier interface.
+type MockCarrier struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarrierMockRecorder
+	isgomock struct{}
+}
+
+// MockCarrierMockRecorder is the mock recorder for MockCarrier.
+type MockCarrierMockRecorder struct {
+	mock *MockCarrier
+}
+
+// NewMockCarrier creates a new mock instance.
+func NewMockCarrier(ctrl *gomock.Controller) *MockCarrier {
+	mock := &MockCarrier{ctrl: ctrl}
+	mock.recorder = &MockCarrierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarrier) EXPECT() *MockCarrierMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockCarrier) CreateShipment(ctx context.Context, shipment domain.Shipment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, shipment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockCarrierMockRecorder) CreateShipment(ctx, shipment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockCarrier)(nil).CreateShipment), ctx, shipment)
+}
+
+// Name mocks base method.
+func (m *MockCarrier) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockCarrierMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockCarrier)(nil).Name))
+}
+
+// Track mocks base method.
+func (m *MockCarrier) Track(ctx context.Context, trackingCode string) ([]domain.ShipmentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", ctx, trackingCode)
+	ret0, _ := ret[0].([]domain.ShipmentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockCarrierMockRecorder) Track(ctx, trackingCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockCarrier)(nil).Track), ctx, trackingCode)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: status_duration.go
+//
+// Generated by this command:
+//
+//	mockgen -source=status_duration.go -destination=mock_domain/mock_status_duration_repository.go -package=mock_domain
+//
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/icrxz/crm-api-core/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatusDurationRepository is a mock of StatusDurationRepository interface.
+type MockStatusDurationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusDurationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusDurationRepositoryMockRecorder is the mock recorder for MockStatusDurationRepository.
+type MockStatusDurationRepositoryMockRecorder struct {
+	mock *MockStatusDurationRepository
+}
+
+// NewMockStatusDurationRepository creates a new mock instance.
+func NewMockStatusDurationRepository(ctrl *gomock.Controller) *MockStatusDurationRepository {
+	mock := &MockStatusDurationRepository{ctrl: ctrl}
+	mock.recorder = &MockStatusDurationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusDurationRepository) EXPECT() *MockStatusDurationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SummarizeCycleTimes mocks base method.
+func (m *MockStatusDurationRepository) SummarizeCycleTimes(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) ([]domain.CycleTimeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeCycleTimes", ctx, filters, groupBy)
+	ret0, _ := ret[0].([]domain.CycleTimeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeCycleTimes indicates an expected call of SummarizeCycleTimes.
+func (mr *MockStatusDurationRepositoryMockRecorder) SummarizeCycleTimes(ctx, filters, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeCycleTimes", reflect.TypeOf((*MockStatusDurationRepository)(nil).SummarizeCycleTimes), ctx, filters, groupBy)
+}
+
+// SummarizeStatusDurations mocks base method.
+func (m *MockStatusDurationRepository) SummarizeStatusDurations(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) ([]domain.StatusDurationStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeStatusDurations", ctx, filters, groupBy)
+	ret0, _ := ret[0].([]domain.StatusDurationStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeStatusDurations indicates an expected call of SummarizeStatusDurations.
+func (mr *MockStatusDurationRepositoryMockRecorder) SummarizeStatusDurations(ctx, filters, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeStatusDurations", reflect.TypeOf((*MockStatusDurationRepository)(nil).SummarizeStatusDurations), ctx, filters, groupBy)
+}
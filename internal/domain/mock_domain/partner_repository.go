@@ -85,6 +85,21 @@ func (mr *MockPartnerRepositoryMockRecorder) Delete(ctx, partnerID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPartnerRepository)(nil).Delete), ctx, partnerID)
 }
 
+// GetByDocuments mocks base method.
+func (m *MockPartnerRepository) GetByDocuments(ctx context.Context, documents []string) ([]domain.Partner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDocuments", ctx, documents)
+	ret0, _ := ret[0].([]domain.Partner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDocuments indicates an expected call of GetByDocuments.
+func (mr *MockPartnerRepositoryMockRecorder) GetByDocuments(ctx, documents any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDocuments", reflect.TypeOf((*MockPartnerRepository)(nil).GetByDocuments), ctx, documents)
+}
+
 // GetByID mocks base method.
 func (m *MockPartnerRepository) GetByID(ctx context.Context, partnerID string) (*domain.Partner, error) {
 	m.ctrl.T.Helper()
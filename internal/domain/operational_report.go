@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=operational_report.go -destination=mock_domain/mock_operational_report_repository.go -package=mock_domain
+type OperationalReportRepository interface {
+	CountCasesByStatus(ctx context.Context, filters OperationalReportFilters) ([]CaseStatusCount, error)
+	CountOpenCasesByAge(ctx context.Context, filters OperationalReportFilters) ([]CaseAgingCount, error)
+	CountClosedCasesByMonth(ctx context.Context, filters OperationalReportFilters) ([]ClosedCasesCount, error)
+	SumPartnerPayouts(ctx context.Context, filters OperationalReportFilters) ([]PartnerPayout, error)
+}
+
+// OperationalReportName names a spreadsheet of the catalog management
+// downloads from GET /reports/operational/:name.
+type OperationalReportName string
+
+const (
+	OPERATIONAL_CASES_BY_STATUS  OperationalReportName = "cases-by-status"
+	OPERATIONAL_CASE_AGING       OperationalReportName = "case-aging"
+	OPERATIONAL_CLOSED_PER_MONTH OperationalReportName = "closed-per-month"
+	OPERATIONAL_PARTNER_PAYOUTS  OperationalReportName = "partner-payouts"
+)
+
+var OperationalReportNames = []OperationalReportName{
+	OPERATIONAL_CASES_BY_STATUS,
+	OPERATIONAL_CASE_AGING,
+	OPERATIONAL_CLOSED_PER_MONTH,
+	OPERATIONAL_PARTNER_PAYOUTS,
+}
+
+// CaseAgingBuckets are the calendar day ranges of the case aging report: up to
+// 7, 8 to 15, 16 to 30, 31 to 60 and over 60 days.
+var CaseAgingBuckets = AgeBuckets{Bounds: []int{7, 15, 30, 60}}
+
+func ParseOperationalReportName(value string) (OperationalReportName, error) {
+	for _, name := range OperationalReportNames {
+		if string(name) == value {
+			return name, nil
+		}
+	}
+
+	return "", NewNotFoundError("unknown operational report", map[string]any{"name": value, "available": OperationalReportNames})
+}
+
+// OperationalReportFilters narrow the cases an operational report counts.
+// The dates bound when cases were opened, or closed for the closed cases
+// report and paid for the payouts one; EndDate is inclusive.
+type OperationalReportFilters struct {
+	ContractorID []string
+	StartDate    *time.Time
+	EndDate      *time.Time
+}
+
+// CaseStatusCount is how many cases a contractor has in a status, per region
+// and operator.
+type CaseStatusCount struct {
+	ContractorName string
+	Region         int
+	OwnerName      string
+	Status         CaseStatus
+	Cases          int
+}
+
+// CaseAgingCount spreads the open cases of a contractor and status by how
+// many days they have been open.
+type CaseAgingCount struct {
+	ContractorName string
+	Region         int
+	Status         CaseStatus
+	UpTo7Days      int
+	UpTo15Days     int
+	UpTo30Days     int
+	UpTo60Days     int
+	Over60Days     int
+}
+
+// ClosedCasesCount is how many cases an operator closed in a month for a
+// contractor, with how long they took on average.
+type ClosedCasesCount struct {
+	Month           time.Time
+	ContractorName  string
+	Region          int
+	OwnerName       string
+	Cases           int
+	AverageOpenDays float64
+}
+
+// PartnerPayout sums what was paid to a partner through the approved
+// outgoing transactions of its cases.
+type PartnerPayout struct {
+	PartnerID    string
+	PartnerName  string
+	Document     string
+	PaymentKey   string
+	Cases        int
+	Transactions int
+	Total        float64
+}
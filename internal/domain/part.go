@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=part.go -destination=mock_domain/mock_part_repository.go -package=mock_domain
+type PartRepository interface {
+	Create(ctx context.Context, part Part) (string, error)
+	GetByID(ctx context.Context, partID string) (*Part, error)
+	LockByID(ctx context.Context, partID string) (*Part, error)
+	Search(ctx context.Context, filters PartFilters) (PagingResult[Part], error)
+	Update(ctx context.Context, part Part) error
+	CreateMovement(ctx context.Context, movement StockMovement) error
+	GetMovementsByCaseID(ctx context.Context, caseID string) ([]StockMovement, error)
+}
+
+type StockMovementType string
+
+const (
+	STOCK_RESTOCK StockMovementType = "restock"
+	STOCK_RESERVE StockMovementType = "reserve"
+	STOCK_CONSUME StockMovementType = "consume"
+	STOCK_RETURN  StockMovementType = "return"
+)
+
+type Part struct {
+	PartID           string
+	SKU              string
+	Name             string
+	Description      string
+	Brand            string
+	Model            string
+	UnitCost         float64
+	StockQuantity    int
+	ReservedQuantity int
+	MinimumStock     int
+	Active           bool
+	CreatedBy        string
+	CreatedAt        time.Time
+	UpdatedBy        string
+	UpdatedAt        time.Time
+}
+
+type PartFilters struct {
+	PartID   []string
+	SKU      []string
+	Name     string
+	Brand    []string
+	Model    []string
+	LowStock bool
+	Active   *bool
+	PagingFilter
+}
+
+type UpdatePart struct {
+	Name         *string
+	Description  *string
+	Brand        *string
+	Model        *string
+	UnitCost     *float64
+	MinimumStock *int
+	Active       *bool
+	UpdatedBy    string
+}
+
+type StockMovement struct {
+	MovementID    string
+	PartID        string
+	CaseID        string
+	ProductID     string
+	Type          StockMovementType
+	Quantity      int
+	UnitCost      float64
+	TransactionID string
+	CreatedBy     string
+	CreatedAt     time.Time
+}
+
+// CasePartUsage summarises the movements of a single part within a case.
+type CasePartUsage struct {
+	Part     Part
+	Reserved int
+	Consumed int
+	Returned int
+	Cost     float64
+}
+
+func NewPart(sku, name, description, brand, model string, unitCost float64, minimumStock int, author string) (Part, error) {
+	if sku == "" {
+		return Part{}, NewValidationError("sku cannot be empty", nil)
+	}
+
+	if name == "" {
+		return Part{}, NewValidationError("name cannot be empty", nil)
+	}
+
+	if unitCost < 0 || minimumStock < 0 {
+		return Part{}, NewValidationError("unit cost and minimum stock cannot be negative", map[string]any{"unit_cost": unitCost, "minimum_stock": minimumStock})
+	}
+
+	now := time.Now().UTC()
+	partID, err := uuid.NewUUID()
+	if err != nil {
+		return Part{}, err
+	}
+
+	return Part{
+		PartID:       partID.String(),
+		SKU:          sku,
+		Name:         name,
+		Description:  description,
+		Brand:        brand,
+		Model:        model,
+		UnitCost:     unitCost,
+		MinimumStock: minimumStock,
+		Active:       true,
+		CreatedBy:    author,
+		CreatedAt:    now,
+		UpdatedBy:    author,
+		UpdatedAt:    now,
+	}, nil
+}
+
+func NewStockMovement(partID, caseID, productID string, movementType StockMovementType, quantity int, unitCost float64, author string) (StockMovement, error) {
+	if partID == "" {
+		return StockMovement{}, NewValidationError("partID cannot be empty", nil)
+	}
+
+	if quantity <= 0 {
+		return StockMovement{}, NewValidationError("quantity must be greater than zero", map[string]any{"quantity": quantity})
+	}
+
+	switch movementType {
+	case STOCK_RESTOCK:
+	case STOCK_RESERVE, STOCK_CONSUME, STOCK_RETURN:
+		if caseID == "" {
+			return StockMovement{}, NewValidationError("caseID is required for case movements", map[string]any{"type": movementType})
+		}
+	default:
+		return StockMovement{}, NewValidationError("invalid stock movement type", map[string]any{"type": movementType})
+	}
+
+	movementID, err := uuid.NewUUID()
+	if err != nil {
+		return StockMovement{}, err
+	}
+
+	return StockMovement{
+		MovementID: movementID.String(),
+		PartID:     partID,
+		CaseID:     caseID,
+		ProductID:  productID,
+		Type:       movementType,
+		Quantity:   quantity,
+		UnitCost:   unitCost,
+		CreatedBy:  author,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+func (p *Part) MergeUpdate(update UpdatePart) {
+	p.UpdatedBy = update.UpdatedBy
+	p.UpdatedAt = time.Now().UTC()
+
+	if update.Name != nil {
+		p.Name = *update.Name
+	}
+
+	if update.Description != nil {
+		p.Description = *update.Description
+	}
+
+	if update.Brand != nil {
+		p.Brand = *update.Brand
+	}
+
+	if update.Model != nil {
+		p.Model = *update.Model
+	}
+
+	if update.UnitCost != nil {
+		p.UnitCost = *update.UnitCost
+	}
+
+	if update.MinimumStock != nil {
+		p.MinimumStock = *update.MinimumStock
+	}
+
+	if update.Active != nil {
+		p.Active = *update.Active
+	}
+}
+
+func (p Part) Available() int {
+	return p.StockQuantity - p.ReservedQuantity
+}
+
+func (p Part) IsLowStock() bool {
+	return p.Available() <= p.MinimumStock
+}
+
+// Apply moves the part stock according to the movement. Reserved parts are
+// held for a case until they are consumed in the repair or returned to the
+// shelf, so consume and return are bounded by what the case still has reserved.
+func (p *Part) Apply(movement StockMovement, caseReserved int) error {
+	switch movement.Type {
+	case STOCK_RESTOCK:
+		p.StockQuantity += movement.Quantity
+	case STOCK_RESERVE:
+		if !p.Active {
+			return NewValidationError("cannot reserve an inactive part", map[string]any{"part_id": p.PartID})
+		}
+		if movement.Quantity > p.Available() {
+			return NewConflictError("not enough parts in stock", map[string]any{"part_id": p.PartID, "available": p.Available(), "requested": movement.Quantity})
+		}
+		p.ReservedQuantity += movement.Quantity
+	case STOCK_CONSUME:
+		if movement.Quantity > caseReserved {
+			return NewConflictError("cannot consume more parts than reserved for the case", map[string]any{"part_id": p.PartID, "reserved": caseReserved, "requested": movement.Quantity})
+		}
+		p.ReservedQuantity -= movement.Quantity
+		p.StockQuantity -= movement.Quantity
+	case STOCK_RETURN:
+		if movement.Quantity > caseReserved {
+			return NewConflictError("cannot return more parts than reserved for the case", map[string]any{"part_id": p.PartID, "reserved": caseReserved, "requested": movement.Quantity})
+		}
+		p.ReservedQuantity -= movement.Quantity
+	default:
+		return NewValidationError("invalid stock movement type", map[string]any{"type": movement.Type})
+	}
+
+	p.UpdatedBy = movement.CreatedBy
+	p.UpdatedAt = movement.CreatedAt
+
+	return nil
+}
+
+// ToTransaction converts a consumption into the outgoing transaction that
+// charges the part cost to the case.
+func (m StockMovement) ToTransaction(partName string) (Transaction, error) {
+	if m.Type != STOCK_CONSUME {
+		return Transaction{}, NewValidationError("only consumed parts generate transactions", map[string]any{"type": m.Type})
+	}
+
+	return NewTransaction(
+		OUTGOING,
+		m.UnitCost*float64(m.Quantity),
+		m.CaseID,
+		m.CreatedBy,
+		fmt.Sprintf("parts: %s x%d", partName, m.Quantity),
+	)
+}
+
+// OutstandingReservation returns how many units of the part are still
+// reserved for the case given its movements.
+func OutstandingReservation(movements []StockMovement, partID string) int {
+	outstanding := 0
+	for _, movement := range movements {
+		if movement.PartID != partID {
+			continue
+		}
+
+		switch movement.Type {
+		case STOCK_RESERVE:
+			outstanding += movement.Quantity
+		case STOCK_CONSUME, STOCK_RETURN:
+			outstanding -= movement.Quantity
+		}
+	}
+
+	return outstanding
+}
+
+// SummarizeCaseParts groups case movements per part, keeping the order in
+// which the parts were first moved.
+func SummarizeCaseParts(movements []StockMovement, parts map[string]Part) []CasePartUsage {
+	usages := make([]CasePartUsage, 0)
+	indexByPart := make(map[string]int)
+
+	for _, movement := range movements {
+		idx, found := indexByPart[movement.PartID]
+		if !found {
+			idx = len(usages)
+			indexByPart[movement.PartID] = idx
+			usages = append(usages, CasePartUsage{Part: parts[movement.PartID]})
+		}
+
+		switch movement.Type {
+		case STOCK_RESERVE:
+			usages[idx].Reserved += movement.Quantity
+		case STOCK_CONSUME:
+			usages[idx].Consumed += movement.Quantity
+			usages[idx].Cost += movement.UnitCost * float64(movement.Quantity)
+		case STOCK_RETURN:
+			usages[idx].Returned += movement.Quantity
+		}
+	}
+
+	return usages
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPartForTest(t *testing.T, stock int) Part {
+	t.Helper()
+
+	part, err := NewPart("SKU-1", "display", "", "Samsung", "A10", 120, 2, "author-1")
+	require.NoError(t, err)
+	part.StockQuantity = stock
+
+	return part
+}
+
+func newMovementForTest(t *testing.T, movementType StockMovementType, quantity int) StockMovement {
+	t.Helper()
+
+	movement, err := NewStockMovement("part-1", "case-1", "product-1", movementType, quantity, 120, "author-1")
+	require.NoError(t, err)
+
+	return movement
+}
+
+func TestNewStockMovement(t *testing.T) {
+	t.Run("requires a case for case movements", func(t *testing.T) {
+		_, err := NewStockMovement("part-1", "", "", STOCK_RESERVE, 1, 0, "author-1")
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns validation error for a non positive quantity", func(t *testing.T) {
+		_, err := NewStockMovement("part-1", "case-1", "", STOCK_CONSUME, 0, 0, "author-1")
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns validation error for an unknown type", func(t *testing.T) {
+		_, err := NewStockMovement("part-1", "case-1", "", "lost", 1, 0, "author-1")
+
+		require.Error(t, err)
+	})
+}
+
+func TestPart_Apply(t *testing.T) {
+	t.Run("reserves available parts", func(t *testing.T) {
+		part := newPartForTest(t, 5)
+
+		require.NoError(t, part.Apply(newMovementForTest(t, STOCK_RESERVE, 3), 0))
+
+		assert.Equal(t, 3, part.ReservedQuantity)
+		assert.Equal(t, 2, part.Available())
+		assert.True(t, part.IsLowStock())
+	})
+
+	t.Run("refuses to reserve more than available", func(t *testing.T) {
+		part := newPartForTest(t, 1)
+
+		err := part.Apply(newMovementForTest(t, STOCK_RESERVE, 2), 0)
+
+		require.Error(t, err)
+		assert.Equal(t, 0, part.ReservedQuantity)
+	})
+
+	t.Run("consumes reserved parts from stock", func(t *testing.T) {
+		part := newPartForTest(t, 5)
+		part.ReservedQuantity = 2
+
+		require.NoError(t, part.Apply(newMovementForTest(t, STOCK_CONSUME, 2), 2))
+
+		assert.Equal(t, 3, part.StockQuantity)
+		assert.Equal(t, 0, part.ReservedQuantity)
+	})
+
+	t.Run("cannot consume more than the case reserved", func(t *testing.T) {
+		part := newPartForTest(t, 5)
+		part.ReservedQuantity = 4
+
+		require.Error(t, part.Apply(newMovementForTest(t, STOCK_CONSUME, 2), 1))
+	})
+
+	t.Run("returns reserved parts to the shelf", func(t *testing.T) {
+		part := newPartForTest(t, 5)
+		part.ReservedQuantity = 2
+
+		require.NoError(t, part.Apply(newMovementForTest(t, STOCK_RETURN, 2), 2))
+
+		assert.Equal(t, 5, part.StockQuantity)
+		assert.Equal(t, 5, part.Available())
+	})
+}
+
+func TestStockMovement_ToTransaction(t *testing.T) {
+	t.Run("charges consumed parts to the case", func(t *testing.T) {
+		transaction, err := newMovementForTest(t, STOCK_CONSUME, 2).ToTransaction("display")
+
+		require.NoError(t, err)
+		assert.Equal(t, OUTGOING, transaction.Type)
+		assert.Equal(t, "case-1", transaction.CaseID)
+		assert.InDelta(t, 240, transaction.Value, 0.001)
+		assert.Equal(t, "parts: display x2", transaction.Description)
+	})
+
+	t.Run("refuses movements that are not consumptions", func(t *testing.T) {
+		_, err := newMovementForTest(t, STOCK_RESERVE, 2).ToTransaction("display")
+
+		require.Error(t, err)
+	})
+}
+
+func TestSummarizeCaseParts(t *testing.T) {
+	movements := []StockMovement{
+		{PartID: "part-1", Type: STOCK_RESERVE, Quantity: 3, UnitCost: 10},
+		{PartID: "part-2", Type: STOCK_RESERVE, Quantity: 1, UnitCost: 50},
+		{PartID: "part-1", Type: STOCK_CONSUME, Quantity: 2, UnitCost: 10},
+		{PartID: "part-1", Type: STOCK_RETURN, Quantity: 1, UnitCost: 10},
+	}
+
+	usages := SummarizeCaseParts(movements, map[string]Part{"part-1": {PartID: "part-1", Name: "screw"}})
+
+	require.Len(t, usages, 2)
+	assert.Equal(t, "screw", usages[0].Part.Name)
+	assert.Equal(t, 3, usages[0].Reserved)
+	assert.Equal(t, 2, usages[0].Consumed)
+	assert.Equal(t, 1, usages[0].Returned)
+	assert.InDelta(t, 20, usages[0].Cost, 0.001)
+	assert.Equal(t, 0, OutstandingReservation(movements, "part-1"))
+	assert.Equal(t, 1, OutstandingReservation(movements, "part-2"))
+}
@@ -16,6 +16,7 @@ type PartnerRepository interface {
 	Update(ctx context.Context, partnerToUpdate Partner) error
 	Delete(ctx context.Context, partnerID string) error
 	CreateBatch(ctx context.Context, partners []Partner) ([]string, error)
+	GetByDocuments(ctx context.Context, documents []string) ([]Partner, error)
 }
 
 type Partner struct {
@@ -9,6 +9,7 @@ import (
 
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product Product) (string, error)
+	CreateBatch(ctx context.Context, products []Product) ([]string, error)
 	UpdateProduct(ctx context.Context, product Product) error
 	GetProductByID(ctx context.Context, productID string) (*Product, error)
 }
@@ -95,3 +96,32 @@ func (p *Product) MergeUpdate(updateProduct UpdateProduct) {
 		p.SerialNumber = *updateProduct.SerialNumber
 	}
 }
+
+// ImportUpdate lists the fields of a re-imported product that differ from the
+// stored one. Blank values in the import are ignored. The boolean is false
+// when there is nothing to update.
+func (p Product) ImportUpdate(imported Product) (UpdateProduct, bool) {
+	update := UpdateProduct{UpdatedBy: imported.UpdatedBy}
+	changed := false
+
+	importString := func(current, importedValue string) *string {
+		if importedValue == "" || importedValue == current {
+			return nil
+		}
+		changed = true
+		return &importedValue
+	}
+
+	update.Name = importString(p.Name, imported.Name)
+	update.Description = importString(p.Description, imported.Description)
+	update.Brand = importString(p.Brand, imported.Brand)
+	update.Model = importString(p.Model, imported.Model)
+	update.SerialNumber = importString(p.SerialNumber, imported.SerialNumber)
+
+	if imported.Value != 0 && imported.Value != p.Value {
+		update.Value = &imported.Value
+		changed = true
+	}
+
+	return update, changed
+}
@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=quote.go -destination=mock_domain/mock_quote_repository.go -package=mock_domain
+type QuoteRepository interface {
+	Create(ctx context.Context, quote Quote) (string, error)
+	GetByID(ctx context.Context, quoteID string) (*Quote, error)
+	GetByCaseID(ctx context.Context, caseID string) ([]Quote, error)
+	// Update stores the review of a pending quote, ConflictError when it was
+	// reviewed meanwhile.
+	Update(ctx context.Context, quote Quote) error
+}
+
+type Quote struct {
+	QuoteID         string
+	CaseID          string
+	Origin          QuoteOrigin
+	Status          QuoteStatus
+	Items           []QuoteItem
+	Notes           string
+	RejectionReason string
+	ReviewedBy      string
+	ReviewedAt      *time.Time
+	CreatedBy       string
+	CreatedAt       time.Time
+	UpdatedBy       string
+	UpdatedAt       time.Time
+}
+
+type QuoteItem struct {
+	QuoteItemID string
+	QuoteID     string
+	Type        QuoteItemType
+	Description string
+	Quantity    float64
+	UnitValue   float64
+}
+
+// ReviewQuote is the decision on a quote. ReviewedBy is the authenticated
+// user, never a value taken from the request body.
+type ReviewQuote struct {
+	Reason     string
+	ReviewedBy string
+}
+
+// CanReviewQuote tells whether the user may approve or reject the quotes of
+// the case: admins review any case, operators only the cases they own.
+func CanReviewQuote(reviewer User, crmCase Case) bool {
+	if !reviewer.Active {
+		return false
+	}
+
+	return reviewer.Role.IsAdmin() || crmCase.OwnerID == reviewer.UserID
+}
+
+type QuoteOrigin string
+
+const (
+	QUOTE_FROM_OPERATOR QuoteOrigin = "operator"
+	QUOTE_FROM_PARTNER  QuoteOrigin = "partner"
+)
+
+type QuoteStatus string
+
+const (
+	QUOTE_PENDING  QuoteStatus = "pending"
+	QUOTE_APPROVED QuoteStatus = "approved"
+	QUOTE_REJECTED QuoteStatus = "rejected"
+)
+
+type QuoteItemType string
+
+const (
+	QUOTE_ITEM_PARTS  QuoteItemType = "parts"
+	QUOTE_ITEM_LABOR  QuoteItemType = "labor"
+	QUOTE_ITEM_TRAVEL QuoteItemType = "travel"
+)
+
+var validQuoteItemTypes = map[QuoteItemType]bool{
+	QUOTE_ITEM_PARTS:  true,
+	QUOTE_ITEM_LABOR:  true,
+	QUOTE_ITEM_TRAVEL: true,
+}
+
+func NewQuote(caseID string, origin QuoteOrigin, notes string, items []QuoteItem, author string) (Quote, error) {
+	if caseID == "" {
+		return Quote{}, NewValidationError("caseID cannot be empty", nil)
+	}
+
+	if origin != QUOTE_FROM_OPERATOR && origin != QUOTE_FROM_PARTNER {
+		return Quote{}, NewValidationError("origin must be operator or partner", map[string]any{"origin": origin})
+	}
+
+	if len(items) == 0 {
+		return Quote{}, NewValidationError("quote must have at least one item", nil)
+	}
+
+	quoteID, err := uuid.NewUUID()
+	if err != nil {
+		return Quote{}, err
+	}
+
+	quoteItems := make([]QuoteItem, 0, len(items))
+	for idx, item := range items {
+		if !validQuoteItemTypes[item.Type] {
+			return Quote{}, NewValidationError("invalid quote item type", map[string]any{"item": idx, "type": item.Type})
+		}
+
+		if item.Quantity <= 0 || item.UnitValue < 0 {
+			return Quote{}, NewValidationError("quote item quantity must be positive and value cannot be negative", map[string]any{"item": idx})
+		}
+
+		itemID, err := uuid.NewUUID()
+		if err != nil {
+			return Quote{}, err
+		}
+
+		item.QuoteItemID = itemID.String()
+		item.QuoteID = quoteID.String()
+		quoteItems = append(quoteItems, item)
+	}
+
+	now := time.Now().UTC()
+
+	return Quote{
+		QuoteID:   quoteID.String(),
+		CaseID:    caseID,
+		Origin:    origin,
+		Status:    QUOTE_PENDING,
+		Items:     quoteItems,
+		Notes:     notes,
+		CreatedBy: author,
+		CreatedAt: now,
+		UpdatedBy: author,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (i QuoteItem) Total() float64 {
+	return i.Quantity * i.UnitValue
+}
+
+func (q Quote) Total() float64 {
+	var total float64
+	for _, item := range q.Items {
+		total += item.Total()
+	}
+	return total
+}
+
+func (q *Quote) Approve(author string) error {
+	return q.review(QUOTE_APPROVED, "", author)
+}
+
+func (q *Quote) Reject(reason, author string) error {
+	if reason == "" {
+		return NewValidationError("rejection reason cannot be empty", nil)
+	}
+
+	return q.review(QUOTE_REJECTED, reason, author)
+}
+
+func (q *Quote) review(status QuoteStatus, reason, author string) error {
+	if q.Status != QUOTE_PENDING {
+		return NewConflictError("quote was already reviewed", map[string]any{"quote_id": q.QuoteID, "status": q.Status})
+	}
+
+	now := time.Now().UTC()
+	q.Status = status
+	q.RejectionReason = reason
+	q.ReviewedBy = author
+	q.ReviewedAt = &now
+	q.UpdatedBy = author
+	q.UpdatedAt = now
+
+	return nil
+}
+
+// ToTransactions converts every item of an approved quote into a pending
+// outgoing transaction, so the repair cost is tracked from the moment it is
+// authorized instead of being typed again when the case reaches PAYMENT.
+func (q Quote) ToTransactions(author string) ([]Transaction, error) {
+	if q.Status != QUOTE_APPROVED {
+		return nil, NewValidationError("only approved quotes can generate transactions", map[string]any{"quote_id": q.QuoteID})
+	}
+
+	transactions := make([]Transaction, 0, len(q.Items))
+	for _, item := range q.Items {
+		transaction, err := NewTransaction(OUTGOING, item.Total(), q.CaseID, author, fmt.Sprintf("%s: %s", item.Type, item.Description))
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// EnsureQuoteApproved decides whether a case with the given quotes may start
+// execution. Cases without quotes are not gated; otherwise the most recent
+// quote must have been approved.
+func EnsureQuoteApproved(quotes []Quote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	latest := quotes[0]
+	for _, quote := range quotes[1:] {
+		if quote.CreatedAt.After(latest.CreatedAt) {
+			latest = quote
+		}
+	}
+
+	if latest.Status != QUOTE_APPROVED {
+		return NewValidationError("case quote must be approved before execution starts", map[string]any{"quote_id": latest.QuoteID, "quote_status": latest.Status})
+	}
+
+	return nil
+}
+
+func (q Quote) Snapshot() map[string]any {
+	return map[string]any{
+		"quote_id": q.QuoteID,
+		"status":   q.Status,
+		"origin":   q.Origin,
+		"total":    q.Total(),
+	}
+}
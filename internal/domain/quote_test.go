@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQuoteForTest(t *testing.T) Quote {
+	t.Helper()
+
+	quote, err := NewQuote("case-1", QUOTE_FROM_PARTNER, "screen replacement", []QuoteItem{
+		{Type: QUOTE_ITEM_PARTS, Description: "display", Quantity: 1, UnitValue: 350},
+		{Type: QUOTE_ITEM_LABOR, Description: "repair", Quantity: 2, UnitValue: 50},
+	}, "author-1")
+	require.NoError(t, err)
+
+	return quote
+}
+
+func TestNewQuote(t *testing.T) {
+	t.Run("creates a pending quote with linked items", func(t *testing.T) {
+		quote := newQuoteForTest(t)
+
+		assert.NotEmpty(t, quote.QuoteID)
+		assert.Equal(t, QUOTE_PENDING, quote.Status)
+		assert.Len(t, quote.Items, 2)
+		for _, item := range quote.Items {
+			assert.NotEmpty(t, item.QuoteItemID)
+			assert.Equal(t, quote.QuoteID, item.QuoteID)
+		}
+		assert.InDelta(t, 450, quote.Total(), 0.001)
+	})
+
+	t.Run("returns validation error without items", func(t *testing.T) {
+		_, err := NewQuote("case-1", QUOTE_FROM_OPERATOR, "", nil, "author-1")
+
+		require.Error(t, err)
+		assert.IsType(t, &CustomError{}, err)
+	})
+
+	t.Run("returns validation error for an unknown origin", func(t *testing.T) {
+		_, err := NewQuote("case-1", "insurer", "", []QuoteItem{{Type: QUOTE_ITEM_TRAVEL, Quantity: 1}}, "author-1")
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns validation error for an unknown item type", func(t *testing.T) {
+		_, err := NewQuote("case-1", QUOTE_FROM_OPERATOR, "", []QuoteItem{{Type: "shipping", Quantity: 1}}, "author-1")
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns validation error for a non positive quantity", func(t *testing.T) {
+		_, err := NewQuote("case-1", QUOTE_FROM_OPERATOR, "", []QuoteItem{{Type: QUOTE_ITEM_LABOR, Quantity: 0}}, "author-1")
+
+		require.Error(t, err)
+	})
+}
+
+func TestQuote_Review(t *testing.T) {
+	t.Run("approves a pending quote", func(t *testing.T) {
+		quote := newQuoteForTest(t)
+
+		require.NoError(t, quote.Approve("contractor-1"))
+
+		assert.Equal(t, QUOTE_APPROVED, quote.Status)
+		assert.Equal(t, "contractor-1", quote.ReviewedBy)
+		assert.NotNil(t, quote.ReviewedAt)
+	})
+
+	t.Run("requires a reason to reject", func(t *testing.T) {
+		quote := newQuoteForTest(t)
+
+		require.Error(t, quote.Reject("", "contractor-1"))
+		assert.Equal(t, QUOTE_PENDING, quote.Status)
+	})
+
+	t.Run("cannot review a quote twice", func(t *testing.T) {
+		quote := newQuoteForTest(t)
+		require.NoError(t, quote.Reject("too expensive", "contractor-1"))
+
+		err := quote.Approve("contractor-1")
+
+		require.Error(t, err)
+		assert.Equal(t, QUOTE_REJECTED, quote.Status)
+	})
+}
+
+func TestQuote_ToTransactions(t *testing.T) {
+	t.Run("converts each item into a pending outgoing transaction", func(t *testing.T) {
+		quote := newQuoteForTest(t)
+		require.NoError(t, quote.Approve("contractor-1"))
+
+		transactions, err := quote.ToTransactions("contractor-1")
+
+		require.NoError(t, err)
+		require.Len(t, transactions, 2)
+		assert.Equal(t, OUTGOING, transactions[0].Type)
+		assert.Equal(t, TRANSACTION_PENDING, transactions[0].Status)
+		assert.Equal(t, "case-1", transactions[0].CaseID)
+		assert.InDelta(t, 350, transactions[0].Value, 0.001)
+		assert.InDelta(t, 100, transactions[1].Value, 0.001)
+		assert.Equal(t, "labor: repair", transactions[1].Description)
+	})
+
+	t.Run("refuses quotes that are not approved", func(t *testing.T) {
+		quote := newQuoteForTest(t)
+
+		_, err := quote.ToTransactions("contractor-1")
+
+		require.Error(t, err)
+	})
+}
+
+func TestEnsureQuoteApproved(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("does not gate cases without quotes", func(t *testing.T) {
+		assert.NoError(t, EnsureQuoteApproved(nil))
+	})
+
+	t.Run("uses the most recent quote", func(t *testing.T) {
+		quotes := []Quote{
+			{QuoteID: "quote-2", Status: QUOTE_APPROVED, CreatedAt: now},
+			{QuoteID: "quote-1", Status: QUOTE_REJECTED, CreatedAt: now.Add(-time.Hour)},
+		}
+
+		assert.NoError(t, EnsureQuoteApproved(quotes))
+	})
+
+	t.Run("blocks while the latest quote is pending", func(t *testing.T) {
+		quotes := []Quote{
+			{QuoteID: "quote-1", Status: QUOTE_APPROVED, CreatedAt: now.Add(-time.Hour)},
+			{QuoteID: "quote-2", Status: QUOTE_PENDING, CreatedAt: now},
+		}
+
+		assert.Error(t, EnsureQuoteApproved(quotes))
+	})
+}
+
+func TestCanReviewQuote(t *testing.T) {
+	crmCase := Case{CaseID: "case-1", OwnerID: "operator-1"}
+
+	assert.True(t, CanReviewQuote(User{UserID: "operator-1", Role: OPERATOR, Active: true}, crmCase))
+	assert.True(t, CanReviewQuote(User{UserID: "admin-1", Role: ADMIN, Active: true}, crmCase))
+	assert.False(t, CanReviewQuote(User{UserID: "operator-2", Role: OPERATOR, Active: true}, crmCase))
+	assert.False(t, CanReviewQuote(User{UserID: "operator-1", Role: OPERATOR}, crmCase))
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 var regions = map[string]int{
 	"Acre":                6,
 	"Alagoas":             2,
@@ -59,3 +61,20 @@ var AcronymForState = map[string]string{
 	"SE": "Sergipe",
 	"TO": "Tocantins",
 }
+
+// StateName reads a state written either as its acronym or as its name,
+// returning the name stored on addresses.
+func StateName(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if stateName, found := AcronymForState[strings.ToUpper(value)]; found {
+		return stateName, true
+	}
+
+	for _, stateName := range AcronymForState {
+		if strings.EqualFold(stateName, value) {
+			return stateName, true
+		}
+	}
+
+	return "", false
+}
@@ -1,14 +1,143 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=report.go -destination=mock_domain/mock_report_repository.go -package=mock_domain
+type ReportRepository interface {
+	Create(ctx context.Context, report Report) (int, error)
+	GetByID(ctx context.Context, reportID string) (*Report, error)
+	GetActiveByContractor(ctx context.Context, contractorID string) (*Report, error)
+	GetByContractor(ctx context.Context, contractorID string) ([]Report, error)
+	Update(ctx context.Context, report Report) error
+}
+
+type ReportStatus string
+
+const (
+	REPORT_DRAFT   ReportStatus = "draft"
+	REPORT_ACTIVE  ReportStatus = "active"
+	REPORT_RETIRED ReportStatus = "retired"
+)
+
+// Report is a version of the docx template used to write the reports of a
+// contractor's cases. ReportTemplate is the key of the file in the attachment
+// bucket; only one version per contractor can be active at a time.
 type Report struct {
 	ReportID       string
+	ContractorID   string
 	ReportName     string
 	ReportTemplate string
-	Active         bool
+	FileName       string
+	Version        int
+	Status         ReportStatus
 	CreatedBy      string
 	CreatedAt      time.Time
 	UpdatedBy      string
 	UpdatedAt      time.Time
 }
+
+func NewReport(contractorID, reportName, fileName, author string) (Report, error) {
+	if contractorID == "" {
+		return Report{}, NewValidationError("contractorID cannot be empty", nil)
+	}
+
+	if fileName == "" {
+		return Report{}, NewValidationError("fileName cannot be empty", nil)
+	}
+
+	if author == "" {
+		return Report{}, NewValidationError("author cannot be empty", nil)
+	}
+
+	reportID, err := uuid.NewRandom()
+	if err != nil {
+		return Report{}, err
+	}
+
+	if reportName == "" {
+		reportName = fileName
+	}
+
+	now := time.Now().UTC()
+
+	return Report{
+		ReportID:       reportID.String(),
+		ContractorID:   contractorID,
+		ReportName:     reportName,
+		ReportTemplate: fmt.Sprintf("report-templates/%s/%s.docx", contractorID, reportID.String()),
+		FileName:       fileName,
+		Status:         REPORT_DRAFT,
+		CreatedBy:      author,
+		CreatedAt:      now,
+		UpdatedBy:      author,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// Activate makes the version the one used by new reports. Retired versions
+// can be activated again to roll back a template.
+func (r *Report) Activate(author string) error {
+	if r.Status == REPORT_ACTIVE {
+		return NewValidationError("report template is already active", map[string]any{"report_id": r.ReportID})
+	}
+
+	r.setStatus(REPORT_ACTIVE, author)
+	return nil
+}
+
+func (r *Report) Retire(author string) error {
+	if r.Status == REPORT_RETIRED {
+		return NewValidationError("report template is already retired", map[string]any{"report_id": r.ReportID})
+	}
+
+	r.setStatus(REPORT_RETIRED, author)
+	return nil
+}
+
+func (r *Report) setStatus(status ReportStatus, author string) {
+	r.Status = status
+	r.UpdatedBy = author
+	r.UpdatedAt = time.Now().UTC()
+}
+
+// ReportValidation lists the placeholders of a template version that reports
+// cannot be filled with.
+type ReportValidation struct {
+	Report              Report
+	UnknownPlaceholders []string
+}
+
+// ReportFormat is the file format a case report is generated in.
+type ReportFormat string
+
+const (
+	REPORT_DOCX ReportFormat = "docx"
+	REPORT_PDF  ReportFormat = "pdf"
+)
+
+// ParseReportFormat reads the format asked for a report, DOCX when none is.
+func ParseReportFormat(value string) (ReportFormat, error) {
+	switch format := ReportFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return REPORT_DOCX, nil
+	case REPORT_DOCX, REPORT_PDF:
+		return format, nil
+	default:
+		return "", NewValidationError("report format must be pdf or docx", map[string]any{"format": value})
+	}
+}
+
+func (f ReportFormat) ContentType() string {
+	if f == REPORT_PDF {
+		return "application/pdf"
+	}
+
+	return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+}
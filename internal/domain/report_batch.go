@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MaxReportBatchCases bounds how many reports a single batch generates, so a
+// loose filter cannot hold the worker for hours.
+const MaxReportBatchCases = 500
+
+// ImportParamReportFormat is the job param holding the format the reports of
+// a batch are generated in.
+const ImportParamReportFormat = "format"
+
+const reportBatchFileName = "report_batch.json"
+
+// ReportBatch selects the cases a report batch generates reports for, either
+// by id or by the filters of the case search. It is stored as the file of its
+// job, which leaves the ZIP of the reports in the attachment bucket.
+type ReportBatch struct {
+	Filters CaseFilters
+	Format  ReportFormat
+}
+
+func NewReportBatchJob(batch ReportBatch, author string) (ImportJob, []byte, error) {
+	if !batch.Filters.HasSelection() {
+		return ImportJob{}, nil, NewValidationError("report batches need case ids or filters", nil)
+	}
+
+	if err := batch.Filters.ValidateDateRanges(); err != nil {
+		return ImportJob{}, nil, err
+	}
+
+	content, err := json.Marshal(batch)
+	if err != nil {
+		return ImportJob{}, nil, err
+	}
+
+	job, err := NewImportJob(IMPORT_JOB_REPORTS, reportBatchFileName, map[string]string{ImportParamReportFormat: string(batch.Format)}, author)
+	if err != nil {
+		return ImportJob{}, nil, err
+	}
+
+	return job, content, nil
+}
+
+func ParseReportBatch(content []byte) (ReportBatch, error) {
+	var batch ReportBatch
+	if err := json.Unmarshal(content, &batch); err != nil {
+		return ReportBatch{}, NewParserError("invalid report batch", map[string]any{"error": err.Error()})
+	}
+
+	return batch, nil
+}
+
+// ReportBatchKey is where the ZIP of a report batch job is kept in the
+// attachment bucket.
+func ReportBatchKey(jobID string) string {
+	return fmt.Sprintf("report-batches/%s.zip", jobID)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	t.Run("starts as a draft stored under the contractor", func(t *testing.T) {
+		report, err := NewReport("contractor-1", "", "laudo.docx", "operator-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, REPORT_DRAFT, report.Status)
+		assert.Equal(t, "laudo.docx", report.ReportName)
+		assert.Equal(t, "report-templates/contractor-1/"+report.ReportID+".docx", report.ReportTemplate)
+	})
+
+	t.Run("requires the contractor, file and author", func(t *testing.T) {
+		_, err := NewReport("", "Laudo", "laudo.docx", "operator-1")
+		assert.Error(t, err)
+
+		_, err = NewReport("contractor-1", "Laudo", "", "operator-1")
+		assert.Error(t, err)
+
+		_, err = NewReport("contractor-1", "Laudo", "laudo.docx", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestReport_StatusChanges(t *testing.T) {
+	report := Report{ReportID: "report-1", Status: REPORT_DRAFT}
+
+	require.NoError(t, report.Activate("operator-1"))
+	assert.Equal(t, REPORT_ACTIVE, report.Status)
+	assert.Equal(t, "operator-1", report.UpdatedBy)
+	assert.Error(t, report.Activate("operator-1"))
+
+	require.NoError(t, report.Retire("operator-2"))
+	assert.Equal(t, REPORT_RETIRED, report.Status)
+	assert.Error(t, report.Retire("operator-2"))
+
+	// retired versions can be activated again to roll back
+	require.NoError(t, report.Activate("operator-3"))
+	assert.Equal(t, REPORT_ACTIVE, report.Status)
+}
+
+func TestParseReportFormat(t *testing.T) {
+	format, err := ParseReportFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, REPORT_DOCX, format)
+
+	format, err = ParseReportFormat(" PDF ")
+	require.NoError(t, err)
+	assert.Equal(t, REPORT_PDF, format)
+	assert.Equal(t, "application/pdf", format.ContentType())
+
+	_, err = ParseReportFormat("odt")
+	assert.Error(t, err)
+}
+
+func TestNewCaseReport(t *testing.T) {
+	template := Report{ReportID: "report-1", Version: 3}
+
+	caseReport, err := NewCaseReport("case-1", template, REPORT_PDF, "Assurant-SIN-1", []byte("report"), "operator-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "report-1", caseReport.ReportID)
+	assert.Equal(t, 3, caseReport.TemplateVersion)
+	assert.Equal(t, "Assurant-SIN-1.pdf", caseReport.FileName)
+	assert.Equal(t, "case-reports/case-1/"+caseReport.CaseReportID+".pdf", caseReport.Key)
+	assert.Equal(t, "845e91831319e89c4d656bdb80c278ac09a7230d61e5dfd2e1b1fbb436ac8917", caseReport.ContentHash)
+	assert.Equal(t, 6, caseReport.Size)
+
+	attachment, err := caseReport.Attachment()
+	require.NoError(t, err)
+	assert.Equal(t, caseReport.Key, attachment.Key)
+	assert.Equal(t, "pdf", attachment.FileExtension)
+
+	_, err = NewCaseReport("case-1", template, REPORT_PDF, "Assurant-SIN-1", nil, "")
+	assert.Error(t, err)
+}
+
+func TestNewReportBatchJob(t *testing.T) {
+	batch := ReportBatch{Filters: CaseFilters{ContractorID: []string{"contractor-1"}}, Format: REPORT_PDF}
+
+	job, content, err := NewReportBatchJob(batch, "operator-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, IMPORT_JOB_REPORTS, job.Type)
+	assert.Equal(t, "pdf", job.Param(ImportParamReportFormat))
+
+	parsed, err := ParseReportBatch(content)
+	require.NoError(t, err)
+	assert.Equal(t, batch, parsed)
+
+	_, _, err = NewReportBatchJob(ReportBatch{Format: REPORT_PDF}, "operator-1")
+	assert.Error(t, err)
+}
+
+func TestNewReportBatchJob_ValidatesDateRanges(t *testing.T) {
+	date := func(value string) *string { return &value }
+
+	for name, filters := range map[string]CaseFilters{
+		"end before start":        {StartDate: date("2026-05-10"), EndDate: date("2026-05-01")},
+		"closed end before start": {ClosedAtStart: date("2026-05-10T00:00:00Z"), ClosedAtEnd: date("2026-05-01")},
+		"not a date":              {StartDate: date("10/05/2026")},
+	} {
+		_, _, err := NewReportBatchJob(ReportBatch{Filters: filters, Format: REPORT_PDF}, "operator-1")
+
+		var customErr *CustomError
+		require.ErrorAs(t, err, &customErr, name)
+		assert.Equal(t, http.StatusBadRequest, customErr.StatusCode(), name)
+	}
+
+	_, _, err := NewReportBatchJob(ReportBatch{Filters: CaseFilters{StartDate: date("2026-05-01"), EndDate: date("2026-05-01")}, Format: REPORT_PDF}, "operator-1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTotalLossThreshold is the repair cost / product value ratio applied to
+// contractors that have not configured their own settlement policy.
+const DefaultTotalLossThreshold = 0.75
+
+//go:generate mockgen -source=settlement.go -destination=mock_domain/mock_settlement_repository.go -package=mock_domain
+type SettlementRepository interface {
+	GetPolicy(ctx context.Context, contractorID string) (*SettlementPolicy, error)
+	SavePolicy(ctx context.Context, policy SettlementPolicy) error
+	GetDecision(ctx context.Context, caseID string) (*SettlementDecision, error)
+	SaveDecision(ctx context.Context, decision SettlementDecision) error
+}
+
+type SettlementType string
+
+const (
+	SETTLEMENT_REPAIR         SettlementType = "repair"
+	SETTLEMENT_REPLACEMENT    SettlementType = "replacement"
+	SETTLEMENT_CASH_INDEMNITY SettlementType = "cash_indemnity"
+)
+
+type SettlementPolicy struct {
+	ContractorID        string
+	TotalLossThreshold  float64
+	TotalLossSettlement SettlementType
+	UpdatedBy           string
+	UpdatedAt           time.Time
+}
+
+type SettlementDecision struct {
+	DecisionID   string
+	CaseID       string
+	Type         SettlementType
+	RepairCost   float64
+	ProductValue float64
+	LossRatio    float64
+	Threshold    float64
+	Overridden   bool
+	Reason       string
+	DecidedBy    string
+	DecidedAt    time.Time
+}
+
+type DecideSettlement struct {
+	Override  *SettlementType
+	Reason    string
+	DecidedBy string
+}
+
+func NewSettlementPolicy(contractorID string, threshold float64, totalLossSettlement SettlementType, author string) (SettlementPolicy, error) {
+	if contractorID == "" {
+		return SettlementPolicy{}, NewValidationError("contractorID cannot be empty", nil)
+	}
+
+	if threshold <= 0 || threshold > 1 {
+		return SettlementPolicy{}, NewValidationError("total loss threshold must be between 0 and 1", map[string]any{"threshold": threshold})
+	}
+
+	if totalLossSettlement != SETTLEMENT_REPLACEMENT && totalLossSettlement != SETTLEMENT_CASH_INDEMNITY {
+		return SettlementPolicy{}, NewValidationError("total loss settlement must be replacement or cash_indemnity", map[string]any{"settlement": totalLossSettlement})
+	}
+
+	return SettlementPolicy{
+		ContractorID:        contractorID,
+		TotalLossThreshold:  threshold,
+		TotalLossSettlement: totalLossSettlement,
+		UpdatedBy:           author,
+		UpdatedAt:           time.Now().UTC(),
+	}, nil
+}
+
+// DefaultSettlementPolicy is used when a contractor has no policy of its own.
+func DefaultSettlementPolicy(contractorID string) SettlementPolicy {
+	return SettlementPolicy{
+		ContractorID:        contractorID,
+		TotalLossThreshold:  DefaultTotalLossThreshold,
+		TotalLossSettlement: SETTLEMENT_REPLACEMENT,
+	}
+}
+
+// Decide picks repair while the repair cost stays under the policy threshold
+// of the product value, and the policy total loss settlement otherwise. An
+// explicit override always wins but is flagged so it can be audited.
+func (p SettlementPolicy) Decide(caseID string, productValue, repairCost float64, input DecideSettlement) (SettlementDecision, error) {
+	if productValue <= 0 && input.Override == nil {
+		return SettlementDecision{}, NewValidationError("product value is required to evaluate total loss", map[string]any{"case_id": caseID})
+	}
+
+	decisionID, err := uuid.NewUUID()
+	if err != nil {
+		return SettlementDecision{}, err
+	}
+
+	var lossRatio float64
+	if productValue > 0 {
+		lossRatio = repairCost / productValue
+	}
+
+	settlementType := SETTLEMENT_REPAIR
+	if lossRatio >= p.TotalLossThreshold {
+		settlementType = p.TotalLossSettlement
+	}
+
+	overridden := false
+	if input.Override != nil {
+		if !isValidSettlementType(*input.Override) {
+			return SettlementDecision{}, NewValidationError("invalid settlement type", map[string]any{"type": *input.Override})
+		}
+
+		if input.Reason == "" {
+			return SettlementDecision{}, NewValidationError("reason is required when overriding the settlement", nil)
+		}
+
+		overridden = *input.Override != settlementType
+		settlementType = *input.Override
+	}
+
+	return SettlementDecision{
+		DecisionID:   decisionID.String(),
+		CaseID:       caseID,
+		Type:         settlementType,
+		RepairCost:   repairCost,
+		ProductValue: productValue,
+		LossRatio:    lossRatio,
+		Threshold:    p.TotalLossThreshold,
+		Overridden:   overridden,
+		Reason:       input.Reason,
+		DecidedBy:    input.DecidedBy,
+		DecidedAt:    time.Now().UTC(),
+	}, nil
+}
+
+func isValidSettlementType(settlementType SettlementType) bool {
+	switch settlementType {
+	case SETTLEMENT_REPAIR, SETTLEMENT_REPLACEMENT, SETTLEMENT_CASH_INDEMNITY:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d SettlementDecision) Snapshot() map[string]any {
+	return map[string]any{
+		"settlement_type": d.Type,
+		"repair_cost":     d.RepairCost,
+		"product_value":   d.ProductValue,
+		"loss_ratio":      d.LossRatio,
+		"threshold":       d.Threshold,
+		"overridden":      d.Overridden,
+		"reason":          d.Reason,
+	}
+}
+
+// LatestApprovedQuote returns the most recently approved quote, if any.
+func LatestApprovedQuote(quotes []Quote) *Quote {
+	var latest *Quote
+	for idx := range quotes {
+		if quotes[idx].Status != QUOTE_APPROVED {
+			continue
+		}
+
+		if latest == nil || quotes[idx].CreatedAt.After(latest.CreatedAt) {
+			latest = &quotes[idx]
+		}
+	}
+
+	return latest
+}
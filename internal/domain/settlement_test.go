@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSettlementPolicy(t *testing.T) {
+	t.Run("creates a policy with a valid threshold", func(t *testing.T) {
+		policy, err := NewSettlementPolicy("contractor-1", 0.6, SETTLEMENT_CASH_INDEMNITY, "author-1")
+
+		require.NoError(t, err)
+		assert.InDelta(t, 0.6, policy.TotalLossThreshold, 0.0001)
+		assert.Equal(t, SETTLEMENT_CASH_INDEMNITY, policy.TotalLossSettlement)
+	})
+
+	t.Run("returns validation error for a threshold out of range", func(t *testing.T) {
+		_, err := NewSettlementPolicy("contractor-1", 1.5, SETTLEMENT_REPLACEMENT, "author-1")
+
+		require.Error(t, err)
+		assert.IsType(t, &CustomError{}, err)
+	})
+
+	t.Run("returns validation error when repair is the total loss settlement", func(t *testing.T) {
+		_, err := NewSettlementPolicy("contractor-1", 0.7, SETTLEMENT_REPAIR, "author-1")
+
+		require.Error(t, err)
+	})
+}
+
+func TestSettlementPolicy_Decide(t *testing.T) {
+	policy := DefaultSettlementPolicy("contractor-1")
+
+	t.Run("repairs while the cost stays under the threshold", func(t *testing.T) {
+		decision, err := policy.Decide("case-1", 1000, 400, DecideSettlement{DecidedBy: "author-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, SETTLEMENT_REPAIR, decision.Type)
+		assert.InDelta(t, 0.4, decision.LossRatio, 0.0001)
+		assert.False(t, decision.Overridden)
+	})
+
+	t.Run("declares total loss when the cost reaches the threshold", func(t *testing.T) {
+		decision, err := policy.Decide("case-1", 1000, 750, DecideSettlement{DecidedBy: "author-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, SETTLEMENT_REPLACEMENT, decision.Type)
+	})
+
+	t.Run("requires a reason to override", func(t *testing.T) {
+		override := SETTLEMENT_CASH_INDEMNITY
+
+		_, err := policy.Decide("case-1", 1000, 100, DecideSettlement{Override: &override, DecidedBy: "author-1"})
+
+		require.Error(t, err)
+	})
+
+	t.Run("flags overrides that differ from the policy", func(t *testing.T) {
+		override := SETTLEMENT_CASH_INDEMNITY
+
+		decision, err := policy.Decide("case-1", 1000, 100, DecideSettlement{Override: &override, Reason: "product discontinued", DecidedBy: "author-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, SETTLEMENT_CASH_INDEMNITY, decision.Type)
+		assert.True(t, decision.Overridden)
+	})
+
+	t.Run("returns validation error without a product value", func(t *testing.T) {
+		_, err := policy.Decide("case-1", 0, 100, DecideSettlement{DecidedBy: "author-1"})
+
+		require.Error(t, err)
+	})
+}
+
+func TestLatestApprovedQuote(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("ignores quotes that were not approved", func(t *testing.T) {
+		quotes := []Quote{
+			{QuoteID: "quote-1", Status: QUOTE_APPROVED, CreatedAt: now.Add(-time.Hour)},
+			{QuoteID: "quote-2", Status: QUOTE_PENDING, CreatedAt: now},
+		}
+
+		latest := LatestApprovedQuote(quotes)
+
+		require.NotNil(t, latest)
+		assert.Equal(t, "quote-1", latest.QuoteID)
+	})
+
+	t.Run("returns nil without approved quotes", func(t *testing.T) {
+		assert.Nil(t, LatestApprovedQuote([]Quote{{QuoteID: "quote-1", Status: QUOTE_REJECTED}}))
+	})
+}
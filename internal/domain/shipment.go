@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=shipment.go -destination=mock_domain/mock_shipment_repository.go -package=mock_domain
+type ShipmentRepository interface {
+	Create(ctx context.Context, shipment Shipment) (string, error)
+	GetByID(ctx context.Context, shipmentID string) (*Shipment, error)
+	GetByCaseID(ctx context.Context, caseID string) ([]Shipment, error)
+	Update(ctx context.Context, shipment Shipment) error
+	CreateEvents(ctx context.Context, events []ShipmentEvent) error
+}
+
+// Carrier is the adapter every logistics provider must implement.
+type Carrier interface {
+	Name() string
+	CreateShipment(ctx context.Context, shipment Shipment) (string, error)
+	Track(ctx context.Context, trackingCode string) ([]ShipmentEvent, error)
+}
+
+type ShipmentDirection string
+
+const (
+	SHIPMENT_TO_PARTNER  ShipmentDirection = "to_partner"
+	SHIPMENT_TO_CUSTOMER ShipmentDirection = "to_customer"
+)
+
+type ShipmentStatus string
+
+const (
+	SHIPMENT_CREATED    ShipmentStatus = "created"
+	SHIPMENT_PICKED_UP  ShipmentStatus = "picked_up"
+	SHIPMENT_IN_TRANSIT ShipmentStatus = "in_transit"
+	SHIPMENT_DELIVERED  ShipmentStatus = "delivered"
+	SHIPMENT_FAILED     ShipmentStatus = "failed"
+	SHIPMENT_CANCELED   ShipmentStatus = "canceled"
+)
+
+type Shipment struct {
+	ShipmentID   string
+	CaseID       string
+	Direction    ShipmentDirection
+	Carrier      string
+	TrackingCode string
+	Status       ShipmentStatus
+	Origin       Address
+	Destination  Address
+	Events       []ShipmentEvent
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+type ShipmentEvent struct {
+	EventID     string
+	ShipmentID  string
+	Status      ShipmentStatus
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+type CreateShipment struct {
+	CaseID    string
+	Direction ShipmentDirection
+	Carrier   string
+	CreatedBy string
+}
+
+func NewShipment(caseID string, direction ShipmentDirection, carrier string, origin, destination Address, author string) (Shipment, error) {
+	if caseID == "" {
+		return Shipment{}, NewValidationError("caseID cannot be empty", nil)
+	}
+
+	if direction != SHIPMENT_TO_PARTNER && direction != SHIPMENT_TO_CUSTOMER {
+		return Shipment{}, NewValidationError("invalid shipment direction", map[string]any{"direction": direction})
+	}
+
+	if carrier == "" {
+		return Shipment{}, NewValidationError("carrier cannot be empty", nil)
+	}
+
+	if origin.Address == "" || destination.Address == "" {
+		return Shipment{}, NewValidationError("origin and destination addresses are required", map[string]any{"case_id": caseID})
+	}
+
+	now := time.Now().UTC()
+	shipmentID, err := uuid.NewUUID()
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	return Shipment{
+		ShipmentID:  shipmentID.String(),
+		CaseID:      caseID,
+		Direction:   direction,
+		Carrier:     carrier,
+		Status:      SHIPMENT_CREATED,
+		Origin:      origin,
+		Destination: destination,
+		Events:      []ShipmentEvent{},
+		CreatedBy:   author,
+		CreatedAt:   now,
+		UpdatedBy:   author,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (s Shipment) IsFinished() bool {
+	return s.Status == SHIPMENT_DELIVERED || s.Status == SHIPMENT_CANCELED
+}
+
+// ApplyEvents keeps only the tracking events newer than the ones already
+// known and moves the shipment to the status of the latest of them. It
+// returns the events that were actually added, oldest first.
+func (s *Shipment) ApplyEvents(events []ShipmentEvent, author string) ([]ShipmentEvent, error) {
+	var lastKnown time.Time
+	for _, event := range s.Events {
+		if event.OccurredAt.After(lastKnown) {
+			lastKnown = event.OccurredAt
+		}
+	}
+
+	newEvents := make([]ShipmentEvent, 0)
+	for _, event := range events {
+		if !event.OccurredAt.After(lastKnown) {
+			continue
+		}
+
+		if !isValidShipmentStatus(event.Status) {
+			return nil, NewValidationError("invalid shipment status", map[string]any{"status": event.Status})
+		}
+
+		if event.EventID == "" {
+			eventID, err := uuid.NewUUID()
+			if err != nil {
+				return nil, err
+			}
+			event.EventID = eventID.String()
+		}
+		event.ShipmentID = s.ShipmentID
+		newEvents = append(newEvents, event)
+	}
+
+	if len(newEvents) == 0 {
+		return newEvents, nil
+	}
+
+	slices.SortFunc(newEvents, func(a, b ShipmentEvent) int {
+		return a.OccurredAt.Compare(b.OccurredAt)
+	})
+
+	s.Events = append(s.Events, newEvents...)
+	s.Status = newEvents[len(newEvents)-1].Status
+	s.UpdatedBy = author
+	s.UpdatedAt = time.Now().UTC()
+
+	return newEvents, nil
+}
+
+func (s Shipment) Snapshot() map[string]any {
+	return map[string]any{
+		"shipment_id":   s.ShipmentID,
+		"direction":     s.Direction,
+		"carrier":       s.Carrier,
+		"tracking_code": s.TrackingCode,
+		"status":        s.Status,
+	}
+}
+
+func isValidShipmentStatus(status ShipmentStatus) bool {
+	switch status {
+	case SHIPMENT_CREATED, SHIPMENT_PICKED_UP, SHIPMENT_IN_TRANSIT, SHIPMENT_DELIVERED, SHIPMENT_FAILED, SHIPMENT_CANCELED:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newShipmentForTest(t *testing.T) Shipment {
+	t.Helper()
+
+	shipment, err := NewShipment("case-1", SHIPMENT_TO_PARTNER, "file", Address{Address: "Rua A"}, Address{Address: "Rua B"}, "author-1")
+	require.NoError(t, err)
+
+	return shipment
+}
+
+func TestNewShipment(t *testing.T) {
+	t.Run("creates a shipment waiting for pickup", func(t *testing.T) {
+		shipment := newShipmentForTest(t)
+
+		assert.NotEmpty(t, shipment.ShipmentID)
+		assert.Equal(t, SHIPMENT_CREATED, shipment.Status)
+	})
+
+	t.Run("returns validation error for an unknown direction", func(t *testing.T) {
+		_, err := NewShipment("case-1", "sideways", "file", Address{Address: "Rua A"}, Address{Address: "Rua B"}, "author-1")
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns validation error without addresses", func(t *testing.T) {
+		_, err := NewShipment("case-1", SHIPMENT_TO_CUSTOMER, "file", Address{}, Address{Address: "Rua B"}, "author-1")
+
+		require.Error(t, err)
+	})
+}
+
+func TestShipment_ApplyEvents(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("moves to the status of the latest event", func(t *testing.T) {
+		shipment := newShipmentForTest(t)
+
+		added, err := shipment.ApplyEvents([]ShipmentEvent{
+			{Status: SHIPMENT_IN_TRANSIT, OccurredAt: now},
+			{Status: SHIPMENT_PICKED_UP, OccurredAt: now.Add(-time.Hour)},
+		}, "author-1")
+
+		require.NoError(t, err)
+		require.Len(t, added, 2)
+		assert.Equal(t, SHIPMENT_PICKED_UP, added[0].Status)
+		assert.Equal(t, SHIPMENT_IN_TRANSIT, shipment.Status)
+		assert.Equal(t, shipment.ShipmentID, added[0].ShipmentID)
+		assert.NotEmpty(t, added[0].EventID)
+	})
+
+	t.Run("ignores events that were already applied", func(t *testing.T) {
+		shipment := newShipmentForTest(t)
+		_, err := shipment.ApplyEvents([]ShipmentEvent{{Status: SHIPMENT_PICKED_UP, OccurredAt: now}}, "author-1")
+		require.NoError(t, err)
+
+		added, err := shipment.ApplyEvents([]ShipmentEvent{
+			{Status: SHIPMENT_PICKED_UP, OccurredAt: now},
+			{Status: SHIPMENT_DELIVERED, OccurredAt: now.Add(time.Hour)},
+		}, "author-1")
+
+		require.NoError(t, err)
+		require.Len(t, added, 1)
+		assert.Equal(t, SHIPMENT_DELIVERED, shipment.Status)
+		assert.True(t, shipment.IsFinished())
+	})
+
+	t.Run("returns validation error for an unknown status", func(t *testing.T) {
+		shipment := newShipmentForTest(t)
+
+		_, err := shipment.ApplyEvents([]ShipmentEvent{{Status: "lost", OccurredAt: now}}, "author-1")
+
+		require.Error(t, err)
+		assert.Equal(t, SHIPMENT_CREATED, shipment.Status)
+	})
+}
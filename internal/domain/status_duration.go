@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+)
+
+//go:generate mockgen -source=status_duration.go -destination=mock_domain/mock_status_duration_repository.go -package=mock_domain
+type StatusDurationRepository interface {
+	SummarizeStatusDurations(ctx context.Context, filters CaseFilters, groupBy *CaseStatsDimension) ([]StatusDurationStats, error)
+	SummarizeCycleTimes(ctx context.Context, filters CaseFilters, groupBy *CaseStatsDimension) ([]CycleTimeStats, error)
+}
+
+// StatusDurationDimensions are the fields the status durations can be broken
+// down by to find where cases get stuck.
+var StatusDurationDimensions = []CaseStatsDimension{
+	CASE_STATS_CONTRACTOR,
+	CASE_STATS_QUEUE,
+	CASE_STATS_OWNER,
+}
+
+// FinalCaseStatuses end the life of a case, the time spent in them is not
+// counted.
+var FinalCaseStatuses = []CaseStatus{CLOSED, CANCELED, REJECTED}
+
+// DurationStats summarizes how many hours the cases of a group took.
+type DurationStats struct {
+	Cases        int
+	AverageHours float64
+	P50Hours     float64
+	P90Hours     float64
+	P95Hours     float64
+	MaxHours     float64
+}
+
+// StatusDurationStats is how long the cases of a group stayed in a status,
+// adding up every time a case went through it.
+type StatusDurationStats struct {
+	GroupKey   string
+	GroupLabel string
+	Status     CaseStatus
+	DurationStats
+}
+
+// CycleTimeStats is how long the cases of a group took from New to Closed.
+type CycleTimeStats struct {
+	GroupKey   string
+	GroupLabel string
+	DurationStats
+}
+
+// StatusDurationReport gathers the status durations and cycle times of the
+// cases matching a search, overall or per contractor, queue or operator.
+type StatusDurationReport struct {
+	GroupBy    *CaseStatsDimension
+	Statuses   []StatusDurationStats
+	CycleTimes []CycleTimeStats
+}
+
+// StatusDuration is how long a case stayed in a status and how many times it
+// entered it. Current tells the case is still in it, its time still running.
+type StatusDuration struct {
+	Status    CaseStatus
+	Hours     float64
+	Visits    int
+	EnteredAt time.Time
+	Current   bool
+}
+
+// CaseStatusDurations is the time a case spent in each status it went
+// through, in the order they were first entered. The cycle time runs from the
+// first time the case was New to the first time it was Closed.
+type CaseStatusDurations struct {
+	CaseID         string
+	CurrentStatus  CaseStatus
+	Statuses       []StatusDuration
+	CycleTimeHours *float64
+}
+
+// statusPeriod is a stretch of time a case spent in a status; an open period
+// has no end.
+type statusPeriod struct {
+	status    CaseStatus
+	startedAt time.Time
+	endedAt   *time.Time
+}
+
+// ParseStatusDurationDimension reads the optional field the status durations
+// are broken down by.
+func ParseStatusDurationDimension(value string) (*CaseStatsDimension, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	dimension := CaseStatsDimension(value)
+	if !slices.Contains(StatusDurationDimensions, dimension) {
+		return nil, NewValidationError("invalid status durations group", map[string]any{"group_by": value, "available": StatusDurationDimensions})
+	}
+
+	return &dimension, nil
+}
+
+// NewCaseStatusDurations replays the status changes recorded in the history
+// of a case, read from the CaseStatusEvents only. The case is in the status it was created with until the first
+// change, or in its current status since creation when none was recorded.
+// The time in the status it is in now runs until now, unless it is final.
+func NewCaseStatusDurations(crmCase Case, history []CaseHistory, now time.Time) CaseStatusDurations {
+	periods := caseStatusPeriods(crmCase, history)
+
+	durations := CaseStatusDurations{
+		CaseID:        crmCase.CaseID,
+		CurrentStatus: crmCase.Status,
+		Statuses:      make([]StatusDuration, 0),
+	}
+
+	positions := make(map[CaseStatus]int)
+	var newAt, closedAt *time.Time
+	for _, period := range periods {
+		if period.status == NEW && newAt == nil {
+			newAt = &period.startedAt
+		}
+		if period.status == CLOSED && closedAt == nil {
+			closedAt = &period.startedAt
+		}
+
+		endedAt := now
+		if period.endedAt != nil {
+			endedAt = *period.endedAt
+		} else if slices.Contains(FinalCaseStatuses, period.status) {
+			continue
+		}
+
+		position, seen := positions[period.status]
+		if !seen {
+			position = len(durations.Statuses)
+			positions[period.status] = position
+			durations.Statuses = append(durations.Statuses, StatusDuration{Status: period.status, EnteredAt: period.startedAt})
+		}
+
+		durations.Statuses[position].Hours += endedAt.Sub(period.startedAt).Hours()
+		durations.Statuses[position].Visits++
+		durations.Statuses[position].Current = period.endedAt == nil
+	}
+
+	if newAt != nil && closedAt != nil && !closedAt.Before(*newAt) {
+		cycleTime := closedAt.Sub(*newAt).Hours()
+		durations.CycleTimeHours = &cycleTime
+	}
+
+	return durations
+}
+
+func caseStatusPeriods(crmCase Case, history []CaseHistory) []statusPeriod {
+	sortedHistory := slices.Clone(history)
+	sort.SliceStable(sortedHistory, func(i, j int) bool {
+		return sortedHistory[i].CreatedAt.Before(sortedHistory[j].CreatedAt)
+	})
+
+	periods := make([]statusPeriod, 0)
+	for _, event := range sortedHistory {
+		if !slices.Contains(CaseStatusEvents, event.EventName) {
+			continue
+		}
+
+		status, ok := historyStatus(event.NewValues)
+		if !ok {
+			continue
+		}
+
+		if len(periods) == 0 {
+			if previous, ok := historyStatus(event.OldValues); ok {
+				periods = append(periods, statusPeriod{status: previous, startedAt: crmCase.CreatedAt})
+			}
+		}
+
+		periods = append(periods, statusPeriod{status: status, startedAt: event.CreatedAt})
+	}
+
+	if len(periods) == 0 {
+		periods = append(periods, statusPeriod{status: crmCase.Status, startedAt: crmCase.CreatedAt})
+	}
+
+	for i := range len(periods) - 1 {
+		periods[i].endedAt = &periods[i+1].startedAt
+	}
+
+	return periods
+}
+
+// historyStatus reads the status recorded in the values of a history event,
+// stored as text once read back from the database.
+func historyStatus(values map[string]any) (CaseStatus, bool) {
+	switch status := values["status"].(type) {
+	case CaseStatus:
+		return status, status != ""
+	case string:
+		return CaseStatus(status), status != ""
+	default:
+		return "", false
+	}
+}
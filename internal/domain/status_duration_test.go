@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCaseStatusDurations(t *testing.T) {
+	createdAt := time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return createdAt.Add(time.Duration(hours) * time.Hour)
+	}
+
+	t.Run("adds up every visit of a status until the case closes", func(t *testing.T) {
+		crmCase := Case{CaseID: "case-1", Status: CLOSED, CreatedAt: createdAt}
+		history := []CaseHistory{
+			{EventName: CaseStatusChangedEvent, OldValues: map[string]any{"status": "Ongoing"}, NewValues: map[string]any{"status": "Report"}, CreatedAt: at(30)},
+			{EventName: CaseCreatedEvent, OldValues: map[string]any{}, NewValues: map[string]any{"status": NEW}, CreatedAt: createdAt},
+			{EventName: CaseAssignedEvent, OldValues: map[string]any{"status": "New"}, NewValues: map[string]any{"status": "Ongoing", "owner_id": "user-1"}, CreatedAt: at(10)},
+			{EventName: CaseStatusChangedEvent, OldValues: map[string]any{"status": "Report"}, NewValues: map[string]any{"status": "Ongoing"}, CreatedAt: at(35)},
+			{EventName: CaseReportGeneratedEvent, NewValues: map[string]any{"report_id": "report-1"}, CreatedAt: at(36)},
+			{EventName: CaseClosedEvent, OldValues: map[string]any{"status": "Ongoing"}, NewValues: map[string]any{"status": "Closed"}, CreatedAt: at(40)},
+		}
+
+		durations := NewCaseStatusDurations(crmCase, history, at(100))
+
+		assert.Equal(t, []StatusDuration{
+			{Status: NEW, Hours: 10, Visits: 1, EnteredAt: createdAt},
+			{Status: ONGOING, Hours: 25, Visits: 2, EnteredAt: at(10)},
+			{Status: REPORT, Hours: 5, Visits: 1, EnteredAt: at(30)},
+		}, durations.Statuses)
+		require.NotNil(t, durations.CycleTimeHours)
+		assert.Equal(t, 40.0, *durations.CycleTimeHours)
+	})
+
+	t.Run("starts imported cases in the status their first change left", func(t *testing.T) {
+		crmCase := Case{CaseID: "case-1", Status: WAITING_PARTNER, CreatedAt: createdAt}
+		history := []CaseHistory{
+			{EventName: CaseStatusChangedEvent, OldValues: map[string]any{"status": "New"}, NewValues: map[string]any{"status": "WaitingPartner"}, CreatedAt: at(4)},
+		}
+
+		durations := NewCaseStatusDurations(crmCase, history, at(10))
+
+		assert.Equal(t, []StatusDuration{
+			{Status: NEW, Hours: 4, Visits: 1, EnteredAt: createdAt},
+			{Status: WAITING_PARTNER, Hours: 6, Visits: 1, EnteredAt: at(4), Current: true},
+		}, durations.Statuses)
+		assert.Nil(t, durations.CycleTimeHours)
+	})
+
+	t.Run("ignores the status of quotes and shipments", func(t *testing.T) {
+		crmCase := Case{CaseID: "case-1", Status: ONGOING, CreatedAt: createdAt}
+		history := []CaseHistory{
+			{EventName: CaseCreatedEvent, OldValues: map[string]any{}, NewValues: map[string]any{"status": "New"}, CreatedAt: createdAt},
+			{EventName: CaseAssignedEvent, OldValues: map[string]any{"status": "New"}, NewValues: map[string]any{"status": "Ongoing", "owner_id": "user-1"}, CreatedAt: at(2)},
+			{EventName: QuoteSubmittedEvent, OldValues: map[string]any{}, NewValues: map[string]any{"quote_id": "quote-1", "status": "pending"}, CreatedAt: at(5)},
+			{EventName: QuoteApprovedEvent, OldValues: map[string]any{"status": "pending"}, NewValues: map[string]any{"quote_id": "quote-1", "status": "approved"}, CreatedAt: at(8)},
+			{EventName: ShipmentCreatedEvent, OldValues: map[string]any{}, NewValues: map[string]any{"shipment_id": "shipment-1", "status": "created"}, CreatedAt: at(9)},
+			{EventName: ShipmentStatusChangedEvent, OldValues: map[string]any{"status": "created"}, NewValues: map[string]any{"status": "delivered"}, CreatedAt: at(11)},
+		}
+
+		durations := NewCaseStatusDurations(crmCase, history, at(20))
+
+		assert.Equal(t, []StatusDuration{
+			{Status: NEW, Hours: 2, Visits: 1, EnteredAt: createdAt},
+			{Status: ONGOING, Hours: 18, Visits: 1, EnteredAt: at(2), Current: true},
+		}, durations.Statuses)
+	})
+
+	t.Run("keeps cases without changes in their current status", func(t *testing.T) {
+		crmCase := Case{CaseID: "case-1", Status: NEW, CreatedAt: createdAt}
+
+		durations := NewCaseStatusDurations(crmCase, nil, at(3))
+
+		assert.Equal(t, []StatusDuration{{Status: NEW, Hours: 3, Visits: 1, EnteredAt: createdAt, Current: true}}, durations.Statuses)
+	})
+}
+
+func TestParseStatusDurationDimension(t *testing.T) {
+	dimension, err := ParseStatusDurationDimension("")
+	require.NoError(t, err)
+	assert.Nil(t, dimension)
+
+	dimension, err = ParseStatusDurationDimension("queue")
+	require.NoError(t, err)
+	assert.Equal(t, CASE_STATS_QUEUE, *dimension)
+
+	_, err = ParseStatusDurationDimension("status")
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 )
 
+//go:generate mockgen -source=transaction.go -destination=mock_domain/mock_transaction_repository.go -package=mock_domain
 type TransactionRepository interface {
 	CreateTransaction(ctx context.Context, transaction Transaction) (string, error)
 	GetTransaction(ctx context.Context, transactionID string) (Transaction, error)
@@ -15,8 +15,9 @@ const (
 type AppConfig struct {
 	Database          Database `properties:"database"`
 	SecretJWTKey      string   `properties:"jwtKeyEnv"`
-	ReportFolder      string   `properties:"reportFolder,default=resources/reports"`
 	AttachmentsBucket Bucket   `properties:"attachmentBucket"`
+	ImportWorker      Worker   `properties:"importWorker"`
+	BacklogSnapshot   Snapshot `properties:"backlogSnapshot"`
 }
 
 type Database struct {
@@ -33,6 +34,17 @@ type Database struct {
 	ConnMaxLifetime time.Duration `properties:"connMaxLifetime,default=10m"`
 }
 
+type Worker struct {
+	PollInterval time.Duration `properties:"pollInterval,default=5s"`
+	StaleAfter   time.Duration `properties:"staleAfter,default=10m"`
+}
+
+// Snapshot sets how often the backlog snapshot worker checks whether the day
+// is due.
+type Snapshot struct {
+	Interval time.Duration `properties:"interval,default=1h"`
+}
+
 type Bucket struct {
 	Name            string        `properties:"name"`
 	Region          string        `properties:"region"`
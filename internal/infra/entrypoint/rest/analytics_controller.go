@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type AnalyticsController struct {
+	analyticsService application.AnalyticsService
+	backlogService   application.BacklogService
+}
+
+func NewAnalyticsController(analyticsService application.AnalyticsService, backlogService application.BacklogService) AnalyticsController {
+	return AnalyticsController{
+		analyticsService: analyticsService,
+		backlogService:   backlogService,
+	}
+}
+
+// GetStatusDurations summarizes the time in each status and the cycle time of
+// the cases matching the same filters as the case search, broken down by the
+// optional group_by field.
+func (c *AnalyticsController) GetStatusDurations(ctx *gin.Context) {
+	groupBy, err := domain.ParseStatusDurationDimension(ctx.Query("group_by"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var filters domain.CaseFilters
+	parseCaseListFilters(ctx, &filters)
+	parseCasePagingFilters(ctx, &filters)
+
+	report, err := c.analyticsService.GetStatusDurations(ctx.Request.Context(), filters, groupBy)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapStatusDurationReportToDTO(report))
+}
+
+func (c *AnalyticsController) GetCaseStatusDurations(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	durations, err := c.analyticsService.GetCaseStatusDurations(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapCaseStatusDurationsToDTO(durations))
+}
+
+// GetBacklogAging buckets the open cases matching the same filters as the
+// case search by age, per queue, owner and status. With include_cases the case
+// ids of every bucket are listed too.
+func (c *AnalyticsController) GetBacklogAging(ctx *gin.Context) {
+	withCaseIDs := false
+	if includeCases := ctx.Query("include_cases"); includeCases != "" {
+		parsedIncludeCases, err := strconv.ParseBool(includeCases)
+		if err != nil {
+			_ = ctx.Error(domain.NewValidationError("query param include_cases must be a boolean", map[string]any{"include_cases": includeCases}))
+			return
+		}
+		withCaseIDs = parsedIncludeCases
+	}
+
+	var filters domain.CaseFilters
+	parseCaseListFilters(ctx, &filters)
+	parseCasePagingFilters(ctx, &filters)
+
+	aging, err := c.backlogService.GetAging(ctx.Request.Context(), filters, withCaseIDs)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapBacklogAgingToDTO(aging))
+}
+
+// GetBacklogSnapshots lists the daily backlog snapshots of the queues and
+// owners asked for between start_date and end_date.
+func (c *AnalyticsController) GetBacklogSnapshots(ctx *gin.Context) {
+	filters := domain.BacklogSnapshotFilters{
+		QueueID: ctx.QueryArray("queue_id"),
+		OwnerID: ctx.QueryArray("owner_id"),
+	}
+
+	startDate, err := parseReportDate(ctx, "start_date")
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	filters.StartDate = startDate
+
+	endDate, err := parseReportDate(ctx, "end_date")
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	filters.EndDate = endDate
+
+	snapshots, err := c.backlogService.GetSnapshots(ctx.Request.Context(), filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapBacklogSnapshotsToDTOs(snapshots))
+}
@@ -0,0 +1,196 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type DurationStatsDTO struct {
+	Cases        int     `json:"cases"`
+	AverageHours float64 `json:"average_hours"`
+	P50Hours     float64 `json:"p50_hours"`
+	P90Hours     float64 `json:"p90_hours"`
+	P95Hours     float64 `json:"p95_hours"`
+	MaxHours     float64 `json:"max_hours"`
+}
+
+type StatusDurationStatsDTO struct {
+	GroupKey   string `json:"group_key,omitempty"`
+	GroupLabel string `json:"group_label,omitempty"`
+	Status     string `json:"status"`
+	DurationStatsDTO
+}
+
+type CycleTimeStatsDTO struct {
+	GroupKey   string `json:"group_key,omitempty"`
+	GroupLabel string `json:"group_label,omitempty"`
+	DurationStatsDTO
+}
+
+type StatusDurationReportDTO struct {
+	GroupBy    *string                  `json:"group_by"`
+	Statuses   []StatusDurationStatsDTO `json:"statuses"`
+	CycleTimes []CycleTimeStatsDTO      `json:"cycle_times"`
+}
+
+type StatusDurationDTO struct {
+	Status    string    `json:"status"`
+	Hours     float64   `json:"hours"`
+	Visits    int       `json:"visits"`
+	EnteredAt time.Time `json:"entered_at"`
+	Current   bool      `json:"current"`
+}
+
+type CaseStatusDurationsDTO struct {
+	CaseID         string              `json:"case_id"`
+	CurrentStatus  string              `json:"current_status"`
+	Statuses       []StatusDurationDTO `json:"statuses"`
+	CycleTimeHours *float64            `json:"cycle_time_hours"`
+}
+
+func mapDurationStatsToDurationStatsDTO(stats domain.DurationStats) DurationStatsDTO {
+	return DurationStatsDTO{
+		Cases:        stats.Cases,
+		AverageHours: stats.AverageHours,
+		P50Hours:     stats.P50Hours,
+		P90Hours:     stats.P90Hours,
+		P95Hours:     stats.P95Hours,
+		MaxHours:     stats.MaxHours,
+	}
+}
+
+func mapStatusDurationReportToDTO(report domain.StatusDurationReport) StatusDurationReportDTO {
+	reportDTO := StatusDurationReportDTO{
+		Statuses:   make([]StatusDurationStatsDTO, 0, len(report.Statuses)),
+		CycleTimes: make([]CycleTimeStatsDTO, 0, len(report.CycleTimes)),
+	}
+
+	if report.GroupBy != nil {
+		groupBy := string(*report.GroupBy)
+		reportDTO.GroupBy = &groupBy
+	}
+
+	for _, stats := range report.Statuses {
+		reportDTO.Statuses = append(reportDTO.Statuses, StatusDurationStatsDTO{
+			GroupKey:         stats.GroupKey,
+			GroupLabel:       stats.GroupLabel,
+			Status:           string(stats.Status),
+			DurationStatsDTO: mapDurationStatsToDurationStatsDTO(stats.DurationStats),
+		})
+	}
+
+	for _, stats := range report.CycleTimes {
+		reportDTO.CycleTimes = append(reportDTO.CycleTimes, CycleTimeStatsDTO{
+			GroupKey:         stats.GroupKey,
+			GroupLabel:       stats.GroupLabel,
+			DurationStatsDTO: mapDurationStatsToDurationStatsDTO(stats.DurationStats),
+		})
+	}
+
+	return reportDTO
+}
+
+func mapCaseStatusDurationsToDTO(durations domain.CaseStatusDurations) CaseStatusDurationsDTO {
+	durationsDTO := CaseStatusDurationsDTO{
+		CaseID:         durations.CaseID,
+		CurrentStatus:  string(durations.CurrentStatus),
+		Statuses:       make([]StatusDurationDTO, 0, len(durations.Statuses)),
+		CycleTimeHours: durations.CycleTimeHours,
+	}
+
+	for _, duration := range durations.Statuses {
+		durationsDTO.Statuses = append(durationsDTO.Statuses, StatusDurationDTO{
+			Status:    string(duration.Status),
+			Hours:     duration.Hours,
+			Visits:    duration.Visits,
+			EnteredAt: duration.EnteredAt,
+			Current:   duration.Current,
+		})
+	}
+
+	return durationsDTO
+}
+
+type BacklogAgingDTO struct {
+	GeneratedAt time.Time              `json:"generated_at"`
+	Buckets     []string               `json:"buckets"`
+	Total       int                    `json:"total"`
+	Groups      []BacklogAgingGroupDTO `json:"groups"`
+}
+
+type BacklogAgingGroupDTO struct {
+	QueueID string              `json:"queue_id"`
+	OwnerID string              `json:"owner_id"`
+	Status  string              `json:"status"`
+	Counts  map[string]int      `json:"counts"`
+	Total   int                 `json:"total"`
+	CaseIDs map[string][]string `json:"case_ids,omitempty"`
+}
+
+type BacklogSnapshotDTO struct {
+	SnapshotDate string `json:"snapshot_date"`
+	QueueID      string `json:"queue_id"`
+	OwnerID      string `json:"owner_id"`
+	Status       string `json:"status"`
+	UpTo2Days    int    `json:"up_to_2_days"`
+	UpTo5Days    int    `json:"up_to_5_days"`
+	UpTo10Days   int    `json:"up_to_10_days"`
+	Over10Days   int    `json:"over_10_days"`
+	Total        int    `json:"total"`
+}
+
+func mapBacklogAgingToDTO(aging domain.BacklogAging) BacklogAgingDTO {
+	agingDTO := BacklogAgingDTO{
+		GeneratedAt: aging.GeneratedAt,
+		Buckets:     make([]string, 0, len(domain.BacklogAgeBuckets)),
+		Total:       aging.Total,
+		Groups:      make([]BacklogAgingGroupDTO, 0, len(aging.Groups)),
+	}
+
+	for _, bucket := range domain.BacklogAgeBuckets {
+		agingDTO.Buckets = append(agingDTO.Buckets, string(bucket))
+	}
+
+	for _, group := range aging.Groups {
+		groupDTO := BacklogAgingGroupDTO{
+			QueueID: group.QueueID,
+			OwnerID: group.OwnerID,
+			Status:  string(group.Status),
+			Counts:  make(map[string]int, len(group.Counts)),
+			Total:   group.Total,
+		}
+		for bucket, count := range group.Counts {
+			groupDTO.Counts[string(bucket)] = count
+		}
+		if group.CaseIDs != nil {
+			groupDTO.CaseIDs = make(map[string][]string, len(group.CaseIDs))
+			for bucket, caseIDs := range group.CaseIDs {
+				groupDTO.CaseIDs[string(bucket)] = caseIDs
+			}
+		}
+
+		agingDTO.Groups = append(agingDTO.Groups, groupDTO)
+	}
+
+	return agingDTO
+}
+
+func mapBacklogSnapshotsToDTOs(snapshots []domain.BacklogSnapshot) []BacklogSnapshotDTO {
+	snapshotDTOs := make([]BacklogSnapshotDTO, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotDTOs = append(snapshotDTOs, BacklogSnapshotDTO{
+			SnapshotDate: snapshot.SnapshotDate.Format(time.DateOnly),
+			QueueID:      snapshot.QueueID,
+			OwnerID:      snapshot.OwnerID,
+			Status:       string(snapshot.Status),
+			UpTo2Days:    snapshot.UpTo2Days,
+			UpTo5Days:    snapshot.UpTo5Days,
+			UpTo10Days:   snapshot.UpTo10Days,
+			Over10Days:   snapshot.Over10Days,
+			Total:        snapshot.Total,
+		})
+	}
+
+	return snapshotDTOs
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// attachmentWriter streams a file to the response, sending the download
+// headers with its first bytes. Until then nothing is sent, so an export that
+// fails early still answers with its error instead of a broken download.
+type attachmentWriter struct {
+	ctx         *gin.Context
+	contentType string
+	fileName    string
+}
+
+func newAttachmentWriter(ctx *gin.Context, contentType, fileName string) attachmentWriter {
+	return attachmentWriter{
+		ctx:         ctx,
+		contentType: contentType,
+		fileName:    fileName,
+	}
+}
+
+func (w attachmentWriter) Write(p []byte) (int, error) {
+	if !w.ctx.Writer.Written() {
+		w.ctx.Header("Content-Type", w.contentType)
+		w.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.fileName))
+		w.ctx.Status(http.StatusOK)
+	}
+
+	return w.ctx.Writer.Write(p)
+}
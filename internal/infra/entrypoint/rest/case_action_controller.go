@@ -104,13 +104,20 @@ func (c *CaseActionController) DownloadReport(ctx *gin.Context) {
 		return
 	}
 
-	report, filename, err := c.caseActionService.GenerateReport(ctx.Request.Context(), caseID)
+	format, err := domain.ParseReportFormat(ctx.Query("format"))
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	contentType := fmt.Sprintf("application/vnd.openxmlformats-officedocument.wordprocessingml.document;%s.docx", filename)
+	// reports are archived under the user who generated them
+	report, filename, err := c.caseActionService.GenerateReport(ctx.Request.Context(), caseID, format, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	contentType := fmt.Sprintf("%s;%s.%s", format.ContentType(), filename, format)
 	ctx.Data(http.StatusOK, contentType, report)
 }
 
@@ -2,7 +2,6 @@ package rest
 
 import (
 	"net/http"
-	"slices"
 	"strconv"
 	"strings"
 
@@ -14,15 +13,18 @@ import (
 type CaseController struct {
 	caseService      application.CaseService
 	batchCaseService application.BatchCaseService
+	importJobService application.ImportJobService
 }
 
 func NewCaseController(
 	caseService application.CaseService,
 	batchCaseService application.BatchCaseService,
+	importJobService application.ImportJobService,
 ) CaseController {
 	return CaseController{
 		caseService:      caseService,
 		batchCaseService: batchCaseService,
+		importJobService: importJobService,
 	}
 }
 
@@ -94,6 +96,31 @@ func (c *CaseController) SearchCasesFull(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, searchResult)
 }
 
+// GetCaseStats counts the cases matching the same filters as the case search,
+// grouped by the group_by fields, repeated or comma separated.
+func (c *CaseController) GetCaseStats(ctx *gin.Context) {
+	filters := c.parseQueryToFilters(ctx)
+
+	var groupBy []string
+	for _, value := range ctx.QueryArray("group_by") {
+		groupBy = append(groupBy, strings.Split(value, ",")...)
+	}
+
+	dimensions, err := domain.ParseCaseStatsDimensions(groupBy)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	stats, err := c.caseService.GetCaseStats(ctx.Request.Context(), filters, dimensions)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapCaseStatsToCaseStatsDTO(stats))
+}
+
 func (c *CaseController) CreateBatch(ctx *gin.Context) {
 	author := ctx.GetHeader("X-Author")
 	if author == "" {
@@ -113,10 +140,6 @@ func (c *CaseController) CreateBatch(ctx *gin.Context) {
 		return
 	}
 
-	fileNameSplit := strings.Split(fileHeader.Filename, ".")
-	fileExtension := fileNameSplit[len(fileNameSplit)-1]
-	allowExtensions := []string{"csv", "xls", "xlsx"}
-
 	file, err := fileHeader.Open()
 	if err != nil {
 		ctx.Error(err)
@@ -124,18 +147,46 @@ func (c *CaseController) CreateBatch(ctx *gin.Context) {
 	}
 	defer file.Close()
 
-	if !slices.Contains(allowExtensions, fileExtension) {
-		ctx.Error(domain.NewValidationError("file must be a csv", nil))
+	if err := validateImportFileName(fileHeader.Filename); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	params, err := importParamsFromForm(ctx, map[string]string{
+		domain.ImportParamUpdateExisting: "update_existing",
+		domain.ImportParamSheet:          "sheet",
+		domain.ImportParamHeaderRow:      "header_row",
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	params[domain.ImportParamCompany] = company
+
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_CASES, fileHeader.Filename, params, author)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if dryRun, _ := strconv.ParseBool(ctx.Query("dry_run")); dryRun {
+		preview, err := c.batchCaseService.Preview(ctx.Request.Context(), job, file)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, mapImportPreviewToDTO(preview))
 		return
 	}
 
-	result, err := c.batchCaseService.CreateBatch(ctx, file, fileHeader.Filename, author, company)
+	enqueuedJob, err := c.importJobService.Enqueue(ctx.Request.Context(), job, file)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"case_ids": result})
+	ctx.JSON(http.StatusAccepted, mapImportJobToDTO(*enqueuedJob))
 }
 
 func (c *CaseController) parseQueryToFilters(ctx *gin.Context) domain.CaseFilters {
@@ -194,6 +245,12 @@ func parseCaseListFilters(ctx *gin.Context, filters *domain.CaseFilters) {
 	if state := ctx.QueryArray("state"); len(state) > 0 {
 		filters.ShippingState = state
 	}
+
+	if fraudFlagged := ctx.Query("fraud_flagged"); fraudFlagged != "" {
+		if fraudFlaggedBool, err := strconv.ParseBool(fraudFlagged); err == nil {
+			filters.FraudFlagged = &fraudFlaggedBool
+		}
+	}
 }
 
 func parseCasePagingFilters(ctx *gin.Context, filters *domain.CaseFilters) {
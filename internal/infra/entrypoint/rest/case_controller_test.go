@@ -175,3 +175,43 @@ func TestCaseController_SearchCases(t *testing.T) {
 		})
 	}
 }
+
+func TestCaseController_GetCaseStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("groups by the requested fields with the search filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		expectedFilters := domain.CaseFilters{
+			Status:       []string{"New"},
+			PagingFilter: domain.PagingFilter{Limit: 10, Offset: 0, SortBy: "created_at", SortOrder: "DESC"},
+		}
+		groupBy := []domain.CaseStatsDimension{domain.CASE_STATS_QUEUE, domain.CASE_STATS_OWNER}
+
+		mockCaseService := mock_application.NewMockCaseService(ctrl)
+		mockCaseService.EXPECT().GetCaseStats(gomock.Any(), expectedFilters, groupBy).Return(domain.CaseStats{
+			GroupBy: groupBy,
+			Total:   2,
+			Groups: []domain.CaseStatsGroup{{
+				Keys:   map[domain.CaseStatsDimension]string{domain.CASE_STATS_QUEUE: "queue-1", domain.CASE_STATS_OWNER: "user-1"},
+				Labels: map[domain.CaseStatsDimension]string{domain.CASE_STATS_QUEUE: "SP Mobile"},
+				Cases:  2,
+			}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		_, engine := gin.CreateTestContext(w)
+		controller := CaseController{caseService: mockCaseService}
+		engine.GET("/cases/stats", controller.GetCaseStats)
+
+		query := url.Values{"status": {"New"}, "group_by": {"queue,owner"}}
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/cases/stats?"+query.Encode(), nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{
+			"group_by": ["queue", "owner"],
+			"total": 2,
+			"groups": [{"keys": {"queue": "queue-1", "owner": "user-1"}, "labels": {"queue": "SP Mobile"}, "cases": 2}]
+		}`, w.Body.String())
+	})
+}
@@ -248,3 +248,45 @@ func mapCaseHistoriesToCaseHistoryDTOs(histories []domain.CaseHistory) []CaseHis
 	}
 	return historyDTOs
 }
+
+type CaseStatsDTO struct {
+	GroupBy []string            `json:"group_by"`
+	Total   int                 `json:"total"`
+	Groups  []CaseStatsGroupDTO `json:"groups"`
+}
+
+type CaseStatsGroupDTO struct {
+	Keys   map[string]string `json:"keys"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Cases  int               `json:"cases"`
+}
+
+func mapCaseStatsToCaseStatsDTO(stats domain.CaseStats) CaseStatsDTO {
+	statsDTO := CaseStatsDTO{
+		GroupBy: make([]string, 0, len(stats.GroupBy)),
+		Total:   stats.Total,
+		Groups:  make([]CaseStatsGroupDTO, 0, len(stats.Groups)),
+	}
+
+	for _, dimension := range stats.GroupBy {
+		statsDTO.GroupBy = append(statsDTO.GroupBy, string(dimension))
+	}
+
+	for _, group := range stats.Groups {
+		groupDTO := CaseStatsGroupDTO{
+			Keys:   make(map[string]string, len(group.Keys)),
+			Labels: make(map[string]string, len(group.Labels)),
+			Cases:  group.Cases,
+		}
+		for dimension, key := range group.Keys {
+			groupDTO.Keys[string(dimension)] = key
+		}
+		for dimension, label := range group.Labels {
+			groupDTO.Labels[string(dimension)] = label
+		}
+
+		statsDTO.Groups = append(statsDTO.Groups, groupDTO)
+	}
+
+	return statsDTO
+}
@@ -114,6 +114,49 @@ func (c *ContractorController) DeleteContractor(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+func (c *ContractorController) GetImportMapping(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	mapping, err := c.contractorService.GetImportMapping(ctx.Request.Context(), contractorID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapImportMappingToDTO(*mapping))
+}
+
+func (c *ContractorController) UpdateImportMapping(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	var mappingDTO *UpdateImportMappingDTO
+	if err := ctx.BindJSON(&mappingDTO); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	mapping, err := mapUpdateImportMappingDTOToImportMapping(*mappingDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := c.contractorService.UpdateImportMapping(ctx.Request.Context(), contractorID, mapping, mappingDTO.UpdatedBy); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
 func (c *ContractorController) parseQueryToFilters(ctx *gin.Context) domain.ContractorFilters {
 	filters := domain.ContractorFilters{
 		PagingFilter: domain.PagingFilter{
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -10,12 +11,20 @@ import (
 )
 
 type CustomerController struct {
-	customerService application.CustomerService
+	customerService      application.CustomerService
+	batchCustomerService application.BatchCustomerService
+	importJobService     application.ImportJobService
 }
 
-func NewCustomerController(customerService application.CustomerService) CustomerController {
+func NewCustomerController(
+	customerService application.CustomerService,
+	batchCustomerService application.BatchCustomerService,
+	importJobService application.ImportJobService,
+) CustomerController {
 	return CustomerController{
-		customerService: customerService,
+		customerService:      customerService,
+		batchCustomerService: batchCustomerService,
+		importJobService:     importJobService,
 	}
 }
 
@@ -115,6 +124,80 @@ func (c *CustomerController) DeleteCustomer(ctx *gin.Context) {
 	ctx.JSON(204, nil)
 }
 
+func (c *CustomerController) CreateBatch(ctx *gin.Context) {
+	author := ctx.GetHeader("X-Author")
+	if author == "" {
+		ctx.Error(domain.NewValidationError("header X-Author cannot be empty", nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer file.Close()
+
+	if err := validateImportFileName(fileHeader.Filename); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	params, err := importParamsFromForm(ctx, map[string]string{
+		domain.ImportParamSheet:     "sheet",
+		domain.ImportParamHeaderRow: "header_row",
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_CUSTOMERS, fileHeader.Filename, params, author)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	enqueuedJob, err := c.importJobService.Enqueue(ctx.Request.Context(), job, file)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, mapImportJobToDTO(*enqueuedJob))
+}
+
+// ExportCustomers streams every customer matching the search filters as a
+// spreadsheet. Paging params are ignored.
+func (c *CustomerController) ExportCustomers(ctx *gin.Context) {
+	format, err := domain.ParseExportFormat(ctx.Query("format"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	filters := c.parseQueryToFilters(ctx)
+
+	file := newAttachmentWriter(ctx, format.ContentType(), format.FileName("clientes"))
+	err = c.batchCustomerService.Export(ctx.Request.Context(), filters, format, file)
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.Error(err)
+			return
+		}
+
+		// the status is already sent, all that is left is to cut the file short
+		fmt.Printf("error exporting customers: %v\n", err.Error())
+		ctx.Abort()
+	}
+}
+
 func (c *CustomerController) parseQueryToFilters(ctx *gin.Context) domain.CustomerFilters {
 	filters := domain.CustomerFilters{
 		PagingFilter: domain.PagingFilter{
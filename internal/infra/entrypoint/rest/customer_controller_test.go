@@ -0,0 +1,71 @@
+package rest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application/mock_application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/infra/entrypoint"
+	"github.com/icrxz/crm-api-core/internal/infra/entrypoint/rest"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newCustomerExportRouter(t *testing.T) (*gin.Engine, *mock_application.MockBatchCustomerService) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	mockService := mock_application.NewMockBatchCustomerService(ctrl)
+	c := rest.NewCustomerController(mock_application.NewMockCustomerService(ctrl), mockService, mock_application.NewMockImportJobService(ctrl))
+
+	router := gin.New()
+	router.Use(entrypoint.CustomErrorEncoder())
+	router.GET("/customers/export", c.ExportCustomers)
+
+	return router, mockService
+}
+
+func TestCustomerController_ExportCustomers(t *testing.T) {
+	t.Run("sends the file as a download", func(t *testing.T) {
+		router, mockService := newCustomerExportRouter(t)
+
+		mockService.EXPECT().Export(gomock.Any(), gomock.Any(), domain.EXPORT_CSV, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ domain.CustomerFilters, _ domain.ExportFormat, w io.Writer) error {
+				_, err := io.WriteString(w, "Nome;CPF/CNPJ\n")
+				return err
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/export?format=csv", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.EXPORT_CSV.ContentType(), w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment;")
+		assert.Equal(t, "Nome;CPF/CNPJ\n", w.Body.String())
+	})
+
+	t.Run("answers errors before the first row as JSON", func(t *testing.T) {
+		router, mockService := newCustomerExportRouter(t)
+
+		mockService.EXPECT().Export(gomock.Any(), gomock.Any(), domain.EXPORT_XLSX, gomock.Any()).
+			Return(domain.NewValidationError("invalid filters", nil))
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/export?format=xlsx", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+}
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type FraudController struct {
+	fraudService application.FraudService
+}
+
+func NewFraudController(fraudService application.FraudService) FraudController {
+	return FraudController{
+		fraudService: fraudService,
+	}
+}
+
+func (c *FraudController) GetAssessment(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	assessment, err := c.fraudService.GetByCaseID(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapFraudAssessmentToDTO(*assessment))
+}
+
+func (c *FraudController) ReviewAssessment(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	var reviewDTO *ReviewFraudAssessmentDTO
+	if err := ctx.BindJSON(&reviewDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	assessment, err := c.fraudService.Review(ctx.Request.Context(), caseID, mapReviewFraudAssessmentDTOToReview(*reviewDTO))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapFraudAssessmentToDTO(*assessment))
+}
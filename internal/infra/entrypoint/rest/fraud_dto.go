@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type FraudAssessmentDTO struct {
+	CaseID       string     `json:"case_id"`
+	Score        int        `json:"score"`
+	Reasons      []string   `json:"reasons"`
+	Flagged      bool       `json:"flagged"`
+	ReviewStatus string     `json:"review_status"`
+	ReviewNotes  string     `json:"review_notes,omitempty"`
+	ReviewedBy   string     `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type ReviewFraudAssessmentDTO struct {
+	Status     string `json:"status" validate:"required"`
+	Notes      string `json:"notes"`
+	ReviewedBy string `json:"reviewed_by" validate:"required"`
+}
+
+func mapFraudAssessmentToDTO(assessment domain.FraudAssessment) FraudAssessmentDTO {
+	reasons := make([]string, 0, len(assessment.Reasons))
+	for _, reason := range assessment.Reasons {
+		reasons = append(reasons, string(reason))
+	}
+
+	return FraudAssessmentDTO{
+		CaseID:       assessment.CaseID,
+		Score:        assessment.Score,
+		Reasons:      reasons,
+		Flagged:      assessment.Flagged,
+		ReviewStatus: string(assessment.ReviewStatus),
+		ReviewNotes:  assessment.ReviewNotes,
+		ReviewedBy:   assessment.ReviewedBy,
+		ReviewedAt:   assessment.ReviewedAt,
+		CreatedAt:    assessment.CreatedAt,
+		UpdatedAt:    assessment.UpdatedAt,
+	}
+}
+
+func mapReviewFraudAssessmentDTOToReview(reviewDTO ReviewFraudAssessmentDTO) domain.FraudReview {
+	return domain.FraudReview{
+		Status:     domain.FraudReviewStatus(reviewDTO.Status),
+		Notes:      reviewDTO.Notes,
+		ReviewedBy: reviewDTO.ReviewedBy,
+	}
+}
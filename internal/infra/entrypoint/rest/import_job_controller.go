@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ImportJobController struct {
+	importJobService application.ImportJobService
+}
+
+func NewImportJobController(importJobService application.ImportJobService) ImportJobController {
+	return ImportJobController{
+		importJobService: importJobService,
+	}
+}
+
+func (c *ImportJobController) GetImportJob(ctx *gin.Context) {
+	jobID := ctx.Param("jobID")
+	if jobID == "" {
+		_ = ctx.Error(domain.NewValidationError("param jobID cannot be empty", nil))
+		return
+	}
+
+	job, err := c.importJobService.GetByID(ctx.Request.Context(), jobID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapImportJobToDTO(*job))
+}
+
+func (c *ImportJobController) DownloadErrorReport(ctx *gin.Context) {
+	jobID := ctx.Param("jobID")
+	if jobID == "" {
+		_ = ctx.Error(domain.NewValidationError("param jobID cannot be empty", nil))
+		return
+	}
+
+	report, fileName, err := c.importJobService.GetErrorReport(ctx.Request.Context(), jobID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", report)
+}
+
+// validateImportFileName accepts the spreadsheet formats batch imports read.
+func validateImportFileName(fileName string) error {
+	fileExtension := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+	if !slices.Contains([]string{"csv", "xls", "xlsx"}, fileExtension) {
+		return domain.NewValidationError("file must be a csv, xls or xlsx", map[string]any{"file_name": fileName})
+	}
+
+	return nil
+}
+
+// importParamsFromForm reads the import job params sent as form fields,
+// keyed by param, leaving out the empty ones.
+func importParamsFromForm(ctx *gin.Context, formFields map[string]string) (map[string]string, error) {
+	params := make(map[string]string)
+	for param, formField := range formFields {
+		if value := ctx.Request.FormValue(formField); value != "" {
+			params[param] = value
+		}
+	}
+
+	if headerRow, ok := params[domain.ImportParamHeaderRow]; ok {
+		if row, err := strconv.Atoi(headerRow); err != nil || row < 1 {
+			return nil, domain.NewValidationError("header_row must be a positive line number", map[string]any{"header_row": headerRow})
+		}
+	}
+
+	return params, nil
+}
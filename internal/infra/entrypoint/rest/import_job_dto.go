@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ImportJobDTO struct {
+	JobID         string              `json:"job_id"`
+	Type          string              `json:"type"`
+	Status        string              `json:"status"`
+	FileName      string              `json:"file_name"`
+	Params        map[string]string   `json:"params"`
+	TotalRows     int                 `json:"total_rows"`
+	ProcessedRows int                 `json:"processed_rows"`
+	CreatedCount  int                 `json:"created_count"`
+	CreatedIDs    []string            `json:"created_ids"`
+	UpdatedCount  int                 `json:"updated_count"`
+	UpdatedIDs    []string            `json:"updated_ids"`
+	SkippedCount  int                 `json:"skipped_count"`
+	SkippedIDs    []string            `json:"skipped_ids"`
+	FailedRows    int                 `json:"failed_rows"`
+	RowErrors     []ImportRowErrorDTO `json:"row_errors"`
+	Errors        []string            `json:"errors"`
+	Attempts      int                 `json:"attempts"`
+	CreatedBy     string              `json:"created_by"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	StartedAt     *time.Time          `json:"started_at,omitempty"`
+	FinishedAt    *time.Time          `json:"finished_at,omitempty"`
+}
+
+type ImportRowErrorDTO struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+func mapImportJobToDTO(job domain.ImportJob) ImportJobDTO {
+	return ImportJobDTO{
+		JobID:         job.JobID,
+		Type:          string(job.Type),
+		Status:        string(job.Status),
+		FileName:      job.FileName,
+		Params:        job.Params,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  len(job.CreatedIDs),
+		CreatedIDs:    job.CreatedIDs,
+		UpdatedCount:  len(job.UpdatedIDs),
+		UpdatedIDs:    job.UpdatedIDs,
+		SkippedCount:  len(job.SkippedIDs),
+		SkippedIDs:    job.SkippedIDs,
+		FailedRows:    job.FailedRows(),
+		RowErrors:     mapImportRowErrorsToDTOs(job.RowErrors),
+		Errors:        job.Errors,
+		Attempts:      job.Attempts,
+		CreatedBy:     job.CreatedBy,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+}
+
+func mapImportRowErrorsToDTOs(rowErrors []domain.ImportRowError) []ImportRowErrorDTO {
+	rowErrorDTOs := make([]ImportRowErrorDTO, 0, len(rowErrors))
+	for _, rowError := range rowErrors {
+		rowErrorDTOs = append(rowErrorDTOs, ImportRowErrorDTO{
+			Row:     rowError.Row,
+			Column:  rowError.Column,
+			Value:   rowError.Value,
+			Message: rowError.Message,
+		})
+	}
+
+	return rowErrorDTOs
+}
@@ -0,0 +1,67 @@
+package rest
+
+import "github.com/icrxz/crm-api-core/internal/domain"
+
+type ImportMappingDTO struct {
+	Fields []ImportFieldMappingDTO `json:"fields"`
+}
+
+type UpdateImportMappingDTO struct {
+	Fields    []ImportFieldMappingDTO `json:"fields"`
+	UpdatedBy string                  `json:"updated_by"`
+}
+
+type ImportFieldMappingDTO struct {
+	Field     string `json:"field"`
+	Column    string `json:"column"`
+	Transform string `json:"transform,omitempty"`
+	Format    string `json:"format,omitempty"`
+	Required  bool   `json:"required"`
+	Default   string `json:"default,omitempty"`
+	Offset    int    `json:"offset,omitempty"`
+}
+
+func mapImportMappingToDTO(mapping domain.ImportMapping) ImportMappingDTO {
+	fieldDTOs := make([]ImportFieldMappingDTO, 0, len(mapping.Fields))
+	for _, field := range mapping.Fields {
+		fieldDTOs = append(fieldDTOs, ImportFieldMappingDTO{
+			Field:     string(field.Field),
+			Column:    field.Column,
+			Transform: string(field.Transform),
+			Format:    field.Format,
+			Required:  field.Required,
+			Default:   field.Default,
+			Offset:    field.Offset,
+		})
+	}
+
+	return ImportMappingDTO{Fields: fieldDTOs}
+}
+
+// mapUpdateImportMappingDTOToImportMapping returns nil for an empty field list,
+// which removes the mapping of the contractor.
+func mapUpdateImportMappingDTOToImportMapping(mappingDTO UpdateImportMappingDTO) (*domain.ImportMapping, error) {
+	if len(mappingDTO.Fields) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]domain.ImportFieldMapping, 0, len(mappingDTO.Fields))
+	for _, fieldDTO := range mappingDTO.Fields {
+		fields = append(fields, domain.ImportFieldMapping{
+			Field:     domain.ImportField(fieldDTO.Field),
+			Column:    fieldDTO.Column,
+			Transform: domain.ImportTransform(fieldDTO.Transform),
+			Format:    fieldDTO.Format,
+			Required:  fieldDTO.Required,
+			Default:   fieldDTO.Default,
+			Offset:    fieldDTO.Offset,
+		})
+	}
+
+	mapping, err := domain.NewImportMapping(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapping, nil
+}
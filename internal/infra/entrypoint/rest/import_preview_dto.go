@@ -0,0 +1,122 @@
+package rest
+
+import "github.com/icrxz/crm-api-core/internal/domain"
+
+type ImportPreviewDTO struct {
+	TotalRows        int                  `json:"total_rows"`
+	NewCustomers     []PreviewCustomerDTO `json:"new_customers"`
+	MatchedCustomers []PreviewCustomerDTO `json:"matched_customers"`
+	NewCases         []PreviewCaseDTO     `json:"new_cases"`
+	Duplicates       []PreviewCaseDTO     `json:"duplicates"`
+	FailedRows       int                  `json:"failed_rows"`
+	RowErrors        []ImportRowErrorDTO  `json:"row_errors"`
+}
+
+type PreviewCustomerDTO struct {
+	CustomerID string `json:"customer_id,omitempty"`
+	Document   string `json:"document"`
+	Name       string `json:"name"`
+	Rows       []int  `json:"rows"`
+}
+
+type PreviewCaseDTO struct {
+	Row               int    `json:"row"`
+	ContractorID      string `json:"contractor_id"`
+	ExternalReference string `json:"external_reference"`
+	CustomerDocument  string `json:"customer_document,omitempty"`
+	CustomerID        string `json:"customer_id,omitempty"`
+	ExistingCaseID    string `json:"existing_case_id,omitempty"`
+	DuplicateReason   string `json:"duplicate_reason,omitempty"`
+}
+
+type PartnerImportPreviewDTO struct {
+	TotalRows       int                 `json:"total_rows"`
+	NewPartners     []PreviewPartnerDTO `json:"new_partners"`
+	UpdatedPartners []PreviewPartnerDTO `json:"updated_partners"`
+	FailedRows      int                 `json:"failed_rows"`
+	RowErrors       []ImportRowErrorDTO `json:"row_errors"`
+}
+
+type PreviewPartnerDTO struct {
+	Row       int    `json:"row"`
+	PartnerID string `json:"partner_id,omitempty"`
+	Document  string `json:"document"`
+	Name      string `json:"name"`
+}
+
+func mapImportPreviewToDTO(preview domain.ImportPreview) ImportPreviewDTO {
+	failedRows := make(map[int]struct{}, len(preview.RowErrors))
+	for _, rowError := range preview.RowErrors {
+		failedRows[rowError.Row] = struct{}{}
+	}
+
+	return ImportPreviewDTO{
+		TotalRows:        preview.TotalRows,
+		NewCustomers:     mapPreviewCustomersToDTOs(preview.NewCustomers),
+		MatchedCustomers: mapPreviewCustomersToDTOs(preview.MatchedCustomers),
+		NewCases:         mapPreviewCasesToDTOs(preview.NewCases),
+		Duplicates:       mapPreviewCasesToDTOs(preview.Duplicates),
+		FailedRows:       len(failedRows),
+		RowErrors:        mapImportRowErrorsToDTOs(preview.RowErrors),
+	}
+}
+
+func mapPartnerImportPreviewToDTO(preview domain.PartnerImportPreview) PartnerImportPreviewDTO {
+	failedRows := make(map[int]struct{}, len(preview.RowErrors))
+	for _, rowError := range preview.RowErrors {
+		failedRows[rowError.Row] = struct{}{}
+	}
+
+	return PartnerImportPreviewDTO{
+		TotalRows:       preview.TotalRows,
+		NewPartners:     mapPreviewPartnersToDTOs(preview.NewPartners),
+		UpdatedPartners: mapPreviewPartnersToDTOs(preview.UpdatedPartners),
+		FailedRows:      len(failedRows),
+		RowErrors:       mapImportRowErrorsToDTOs(preview.RowErrors),
+	}
+}
+
+func mapPreviewCustomersToDTOs(customers []domain.PreviewCustomer) []PreviewCustomerDTO {
+	customerDTOs := make([]PreviewCustomerDTO, 0, len(customers))
+	for _, customer := range customers {
+		customerDTOs = append(customerDTOs, PreviewCustomerDTO{
+			CustomerID: customer.CustomerID,
+			Document:   customer.Document,
+			Name:       customer.Name,
+			Rows:       customer.Rows,
+		})
+	}
+
+	return customerDTOs
+}
+
+func mapPreviewCasesToDTOs(cases []domain.PreviewCase) []PreviewCaseDTO {
+	caseDTOs := make([]PreviewCaseDTO, 0, len(cases))
+	for _, previewCase := range cases {
+		caseDTOs = append(caseDTOs, PreviewCaseDTO{
+			Row:               previewCase.Row,
+			ContractorID:      previewCase.ContractorID,
+			ExternalReference: previewCase.ExternalReference,
+			CustomerDocument:  previewCase.CustomerDocument,
+			CustomerID:        previewCase.CustomerID,
+			ExistingCaseID:    previewCase.ExistingCaseID,
+			DuplicateReason:   string(previewCase.DuplicateReason),
+		})
+	}
+
+	return caseDTOs
+}
+
+func mapPreviewPartnersToDTOs(partners []domain.PreviewPartner) []PreviewPartnerDTO {
+	partnerDTOs := make([]PreviewPartnerDTO, 0, len(partners))
+	for _, partner := range partners {
+		partnerDTOs = append(partnerDTOs, PreviewPartnerDTO{
+			Row:       partner.Row,
+			PartnerID: partner.PartnerID,
+			Document:  partner.Document,
+			Name:      partner.Name,
+		})
+	}
+
+	return partnerDTOs
+}
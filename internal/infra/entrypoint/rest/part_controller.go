@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type PartController struct {
+	partService application.PartService
+}
+
+func NewPartController(partService application.PartService) PartController {
+	return PartController{
+		partService: partService,
+	}
+}
+
+func (c *PartController) CreatePart(ctx *gin.Context) {
+	var partDTO *CreatePartDTO
+	if err := ctx.BindJSON(&partDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	part, err := mapCreatePartDTOToPart(*partDTO)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	partID, err := c.partService.Create(ctx.Request.Context(), part)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"part_id": partID})
+}
+
+func (c *PartController) GetPart(ctx *gin.Context) {
+	partID := ctx.Param("partID")
+	if partID == "" {
+		_ = ctx.Error(domain.NewValidationError("param partID cannot be empty", nil))
+		return
+	}
+
+	part, err := c.partService.GetByID(ctx.Request.Context(), partID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapPartToPartDTO(*part))
+}
+
+func (c *PartController) UpdatePart(ctx *gin.Context) {
+	partID := ctx.Param("partID")
+	if partID == "" {
+		_ = ctx.Error(domain.NewValidationError("param partID cannot be empty", nil))
+		return
+	}
+
+	var partDTO *UpdatePartDTO
+	if err := ctx.BindJSON(&partDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := c.partService.Update(ctx.Request.Context(), partID, mapUpdatePartDTOToUpdatePart(*partDTO)); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (c *PartController) SearchParts(ctx *gin.Context) {
+	filters := c.parseQueryToFilters(ctx)
+
+	parts, err := c.partService.Search(ctx.Request.Context(), filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSearchResultToSearchResultDTO(parts, mapPartsToPartDTOs))
+}
+
+func (c *PartController) SearchLowStock(ctx *gin.Context) {
+	filters := c.parseQueryToFilters(ctx)
+	filters.LowStock = true
+
+	parts, err := c.partService.Search(ctx.Request.Context(), filters)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSearchResultToSearchResultDTO(parts, mapPartsToPartDTOs))
+}
+
+func (c *PartController) RestockPart(ctx *gin.Context) {
+	partID := ctx.Param("partID")
+	if partID == "" {
+		_ = ctx.Error(domain.NewValidationError("param partID cannot be empty", nil))
+		return
+	}
+
+	var restockDTO *RestockPartDTO
+	if err := ctx.BindJSON(&restockDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := c.partService.Restock(ctx.Request.Context(), partID, restockDTO.Quantity, restockDTO.CreatedBy); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (c *PartController) MoveCasePart(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	var movementDTO *CasePartMovementDTO
+	if err := ctx.BindJSON(&movementDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	movement, err := mapCasePartMovementDTOToStockMovement(*movementDTO, caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := c.partService.MoveCasePart(ctx.Request.Context(), movement); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"movement_id": movement.MovementID})
+}
+
+func (c *PartController) GetCaseParts(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	usages, err := c.partService.GetCaseParts(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapCasePartUsagesToDTOs(usages))
+}
+
+func (c *PartController) parseQueryToFilters(ctx *gin.Context) domain.PartFilters {
+	filters := domain.PartFilters{
+		PagingFilter: domain.PagingFilter{
+			Limit:  10,
+			Offset: 0,
+		},
+	}
+
+	if partIDs := ctx.QueryArray("part_id"); len(partIDs) > 0 {
+		filters.PartID = partIDs
+	}
+
+	if skus := ctx.QueryArray("sku"); len(skus) > 0 {
+		filters.SKU = skus
+	}
+
+	if brands := ctx.QueryArray("brand"); len(brands) > 0 {
+		filters.Brand = brands
+	}
+
+	if models := ctx.QueryArray("model"); len(models) > 0 {
+		filters.Model = models
+	}
+
+	filters.Name = ctx.Query("name")
+
+	if lowStock := ctx.Query("low_stock"); lowStock != "" {
+		if lowStockBool, err := strconv.ParseBool(lowStock); err == nil {
+			filters.LowStock = lowStockBool
+		}
+	}
+
+	if active := ctx.Query("active"); active != "" {
+		activeBool, err := strconv.ParseBool(active)
+		if err == nil {
+			filters.Active = &activeBool
+		}
+	}
+
+	if limitParam := ctx.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil {
+			filters.Limit = parsedLimit
+		}
+	}
+
+	if offsetParam := ctx.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.Atoi(offsetParam); err == nil {
+			filters.Offset = parsedOffset
+		}
+	}
+
+	return filters
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CreatePartDTO struct {
+	SKU          string  `json:"sku" validate:"required"`
+	Name         string  `json:"name" validate:"required"`
+	Description  string  `json:"description"`
+	Brand        string  `json:"brand"`
+	Model        string  `json:"model"`
+	UnitCost     float64 `json:"unit_cost"`
+	MinimumStock int     `json:"minimum_stock"`
+	CreatedBy    string  `json:"created_by" validate:"required"`
+}
+
+type UpdatePartDTO struct {
+	Name         *string  `json:"name"`
+	Description  *string  `json:"description"`
+	Brand        *string  `json:"brand"`
+	Model        *string  `json:"model"`
+	UnitCost     *float64 `json:"unit_cost"`
+	MinimumStock *int     `json:"minimum_stock"`
+	Active       *bool    `json:"active"`
+	UpdatedBy    string   `json:"updated_by" validate:"required"`
+}
+
+type PartDTO struct {
+	PartID           string    `json:"part_id"`
+	SKU              string    `json:"sku"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	Brand            string    `json:"brand"`
+	Model            string    `json:"model"`
+	UnitCost         float64   `json:"unit_cost"`
+	StockQuantity    int       `json:"stock_quantity"`
+	ReservedQuantity int       `json:"reserved_quantity"`
+	AvailableStock   int       `json:"available_stock"`
+	MinimumStock     int       `json:"minimum_stock"`
+	LowStock         bool      `json:"low_stock"`
+	Active           bool      `json:"active"`
+	CreatedBy        string    `json:"created_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedBy        string    `json:"updated_by"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type RestockPartDTO struct {
+	Quantity  int    `json:"quantity" validate:"required"`
+	CreatedBy string `json:"created_by" validate:"required"`
+}
+
+type CasePartMovementDTO struct {
+	PartID    string `json:"part_id" validate:"required"`
+	Type      string `json:"type" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required"`
+	CreatedBy string `json:"created_by" validate:"required"`
+}
+
+type CasePartUsageDTO struct {
+	PartID   string  `json:"part_id"`
+	SKU      string  `json:"sku"`
+	Name     string  `json:"name"`
+	Reserved int     `json:"reserved"`
+	Consumed int     `json:"consumed"`
+	Returned int     `json:"returned"`
+	Cost     float64 `json:"cost"`
+}
+
+func mapCreatePartDTOToPart(partDTO CreatePartDTO) (domain.Part, error) {
+	return domain.NewPart(
+		partDTO.SKU,
+		partDTO.Name,
+		partDTO.Description,
+		partDTO.Brand,
+		partDTO.Model,
+		partDTO.UnitCost,
+		partDTO.MinimumStock,
+		partDTO.CreatedBy,
+	)
+}
+
+func mapUpdatePartDTOToUpdatePart(partDTO UpdatePartDTO) domain.UpdatePart {
+	return domain.UpdatePart{
+		Name:         partDTO.Name,
+		Description:  partDTO.Description,
+		Brand:        partDTO.Brand,
+		Model:        partDTO.Model,
+		UnitCost:     partDTO.UnitCost,
+		MinimumStock: partDTO.MinimumStock,
+		Active:       partDTO.Active,
+		UpdatedBy:    partDTO.UpdatedBy,
+	}
+}
+
+func mapPartToPartDTO(part domain.Part) PartDTO {
+	return PartDTO{
+		PartID:           part.PartID,
+		SKU:              part.SKU,
+		Name:             part.Name,
+		Description:      part.Description,
+		Brand:            part.Brand,
+		Model:            part.Model,
+		UnitCost:         part.UnitCost,
+		StockQuantity:    part.StockQuantity,
+		ReservedQuantity: part.ReservedQuantity,
+		AvailableStock:   part.Available(),
+		MinimumStock:     part.MinimumStock,
+		LowStock:         part.IsLowStock(),
+		Active:           part.Active,
+		CreatedBy:        part.CreatedBy,
+		CreatedAt:        part.CreatedAt,
+		UpdatedBy:        part.UpdatedBy,
+		UpdatedAt:        part.UpdatedAt,
+	}
+}
+
+func mapPartsToPartDTOs(parts []domain.Part) []PartDTO {
+	partDTOs := make([]PartDTO, 0, len(parts))
+	for _, part := range parts {
+		partDTOs = append(partDTOs, mapPartToPartDTO(part))
+	}
+
+	return partDTOs
+}
+
+func mapCasePartMovementDTOToStockMovement(movementDTO CasePartMovementDTO, caseID string) (domain.StockMovement, error) {
+	return domain.NewStockMovement(
+		movementDTO.PartID,
+		caseID,
+		"",
+		domain.StockMovementType(movementDTO.Type),
+		movementDTO.Quantity,
+		0,
+		movementDTO.CreatedBy,
+	)
+}
+
+func mapCasePartUsagesToDTOs(usages []domain.CasePartUsage) []CasePartUsageDTO {
+	usageDTOs := make([]CasePartUsageDTO, 0, len(usages))
+	for _, usage := range usages {
+		usageDTOs = append(usageDTOs, CasePartUsageDTO{
+			PartID:   usage.Part.PartID,
+			SKU:      usage.Part.SKU,
+			Name:     usage.Part.Name,
+			Reserved: usage.Reserved,
+			Consumed: usage.Consumed,
+			Returned: usage.Returned,
+			Cost:     usage.Cost,
+		})
+	}
+
+	return usageDTOs
+}
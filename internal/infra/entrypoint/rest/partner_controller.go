@@ -4,7 +4,6 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
@@ -12,12 +11,20 @@ import (
 )
 
 type PartnerController struct {
-	partnerService application.PartnerService
+	partnerService      application.PartnerService
+	batchPartnerService application.BatchPartnerService
+	importJobService    application.ImportJobService
 }
 
-func NewPartnerController(partnerService application.PartnerService) PartnerController {
+func NewPartnerController(
+	partnerService application.PartnerService,
+	batchPartnerService application.BatchPartnerService,
+	importJobService application.ImportJobService,
+) PartnerController {
 	return PartnerController{
-		partnerService: partnerService,
+		partnerService:      partnerService,
+		batchPartnerService: batchPartnerService,
+		importJobService:    importJobService,
 	}
 }
 
@@ -141,18 +148,44 @@ func (c *PartnerController) CreateBatch(ctx *gin.Context) {
 	}
 	defer file.Close()
 
-	if !strings.Contains(fileHeader.Filename, ".csv") {
-		ctx.Error(domain.NewValidationError("file must be a csv", nil))
+	if err := validateImportFileName(fileHeader.Filename); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	params, err := importParamsFromForm(ctx, map[string]string{
+		domain.ImportParamSheet:     "sheet",
+		domain.ImportParamHeaderRow: "header_row",
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	job, err := domain.NewImportJob(domain.IMPORT_JOB_PARTNERS, fileHeader.Filename, params, author)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if dryRun, _ := strconv.ParseBool(ctx.Query("dry_run")); dryRun {
+		preview, err := c.batchPartnerService.Preview(ctx.Request.Context(), job, file)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, mapPartnerImportPreviewToDTO(preview))
 		return
 	}
 
-	result, err := c.partnerService.CreateBatch(ctx, file, author)
+	enqueuedJob, err := c.importJobService.Enqueue(ctx.Request.Context(), job, file)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"partner_ids": result})
+	ctx.JSON(http.StatusAccepted, mapImportJobToDTO(*enqueuedJob))
 }
 
 func (c *PartnerController) parseQueryToFilters(ctx *gin.Context) (domain.PartnerFilters, error) {
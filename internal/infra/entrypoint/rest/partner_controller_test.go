@@ -166,7 +166,7 @@ func TestPartnerController_SearchPartners(t *testing.T) {
 			mockService := mock_application.NewMockPartnerService(ctrl)
 			tt.mockSetup(mockService)
 
-			c := NewPartnerController(mockService)
+			c := NewPartnerController(mockService, nil, nil)
 
 			router := gin.New()
 			router.GET("/partners", c.SearchPartners)
@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type QuoteController struct {
+	quoteService application.QuoteService
+}
+
+func NewQuoteController(quoteService application.QuoteService) QuoteController {
+	return QuoteController{
+		quoteService: quoteService,
+	}
+}
+
+func (c *QuoteController) CreateQuote(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	var quoteDTO *CreateQuoteDTO
+	if err := ctx.BindJSON(&quoteDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	quote, err := mapCreateQuoteDTOToQuote(*quoteDTO, caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	quoteID, err := c.quoteService.Create(ctx.Request.Context(), quote)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"quote_id": quoteID})
+}
+
+func (c *QuoteController) GetQuote(ctx *gin.Context) {
+	quoteID := ctx.Param("quoteID")
+	if quoteID == "" {
+		_ = ctx.Error(domain.NewValidationError("param quoteID cannot be empty", nil))
+		return
+	}
+
+	quote, err := c.quoteService.GetByID(ctx.Request.Context(), quoteID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapQuoteToQuoteDTO(*quote))
+}
+
+func (c *QuoteController) GetByCaseID(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	quotes, err := c.quoteService.GetByCaseID(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapQuotesToQuoteDTOs(quotes))
+}
+
+func (c *QuoteController) ApproveQuote(ctx *gin.Context) {
+	quoteID := ctx.Param("quoteID")
+	if quoteID == "" {
+		_ = ctx.Error(domain.NewValidationError("param quoteID cannot be empty", nil))
+		return
+	}
+
+	review := domain.ReviewQuote{ReviewedBy: ctx.GetString("user_id")}
+	if err := c.quoteService.Approve(ctx.Request.Context(), quoteID, review); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (c *QuoteController) RejectQuote(ctx *gin.Context) {
+	quoteID := ctx.Param("quoteID")
+	if quoteID == "" {
+		_ = ctx.Error(domain.NewValidationError("param quoteID cannot be empty", nil))
+		return
+	}
+
+	var reviewDTO *ReviewQuoteDTO
+	if err := ctx.BindJSON(&reviewDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := c.quoteService.Reject(ctx.Request.Context(), quoteID, mapReviewQuoteDTOToReviewQuote(*reviewDTO, ctx.GetString("user_id"))); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
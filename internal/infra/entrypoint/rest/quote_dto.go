@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CreateQuoteDTO struct {
+	Origin    string               `json:"origin" validate:"required"`
+	Notes     string               `json:"notes"`
+	Items     []CreateQuoteItemDTO `json:"items" validate:"required"`
+	CreatedBy string               `json:"created_by" validate:"required"`
+}
+
+type CreateQuoteItemDTO struct {
+	Type        string  `json:"type" validate:"required"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitValue   float64 `json:"unit_value"`
+}
+
+type ReviewQuoteDTO struct {
+	Reason string `json:"reason"`
+}
+
+type QuoteDTO struct {
+	QuoteID         string         `json:"quote_id"`
+	CaseID          string         `json:"case_id"`
+	Origin          string         `json:"origin"`
+	Status          string         `json:"status"`
+	Items           []QuoteItemDTO `json:"items"`
+	Total           float64        `json:"total"`
+	Notes           string         `json:"notes"`
+	RejectionReason string         `json:"rejection_reason"`
+	ReviewedBy      string         `json:"reviewed_by"`
+	ReviewedAt      *time.Time     `json:"reviewed_at"`
+	CreatedBy       string         `json:"created_by"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedBy       string         `json:"updated_by"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+type QuoteItemDTO struct {
+	QuoteItemID string  `json:"quote_item_id"`
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitValue   float64 `json:"unit_value"`
+	Total       float64 `json:"total"`
+}
+
+func mapCreateQuoteDTOToQuote(quoteDTO CreateQuoteDTO, caseID string) (domain.Quote, error) {
+	items := make([]domain.QuoteItem, 0, len(quoteDTO.Items))
+	for _, itemDTO := range quoteDTO.Items {
+		items = append(items, domain.QuoteItem{
+			Type:        domain.QuoteItemType(itemDTO.Type),
+			Description: itemDTO.Description,
+			Quantity:    itemDTO.Quantity,
+			UnitValue:   itemDTO.UnitValue,
+		})
+	}
+
+	return domain.NewQuote(
+		caseID,
+		domain.QuoteOrigin(quoteDTO.Origin),
+		quoteDTO.Notes,
+		items,
+		quoteDTO.CreatedBy,
+	)
+}
+
+func mapReviewQuoteDTOToReviewQuote(reviewDTO ReviewQuoteDTO, reviewerID string) domain.ReviewQuote {
+	return domain.ReviewQuote{
+		Reason:     reviewDTO.Reason,
+		ReviewedBy: reviewerID,
+	}
+}
+
+func mapQuoteToQuoteDTO(quote domain.Quote) QuoteDTO {
+	items := make([]QuoteItemDTO, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		items = append(items, QuoteItemDTO{
+			QuoteItemID: item.QuoteItemID,
+			Type:        string(item.Type),
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitValue:   item.UnitValue,
+			Total:       item.Total(),
+		})
+	}
+
+	return QuoteDTO{
+		QuoteID:         quote.QuoteID,
+		CaseID:          quote.CaseID,
+		Origin:          string(quote.Origin),
+		Status:          string(quote.Status),
+		Items:           items,
+		Total:           quote.Total(),
+		Notes:           quote.Notes,
+		RejectionReason: quote.RejectionReason,
+		ReviewedBy:      quote.ReviewedBy,
+		ReviewedAt:      quote.ReviewedAt,
+		CreatedBy:       quote.CreatedBy,
+		CreatedAt:       quote.CreatedAt,
+		UpdatedBy:       quote.UpdatedBy,
+		UpdatedAt:       quote.UpdatedAt,
+	}
+}
+
+func mapQuotesToQuoteDTOs(quotes []domain.Quote) []QuoteDTO {
+	quoteDTOs := make([]QuoteDTO, 0, len(quotes))
+	for _, quote := range quotes {
+		quoteDTOs = append(quoteDTOs, mapQuoteToQuoteDTO(quote))
+	}
+
+	return quoteDTOs
+}
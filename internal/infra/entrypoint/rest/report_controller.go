@@ -0,0 +1,396 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportController struct {
+	reportTemplateService application.ReportTemplateService
+	reportService         application.ReportService
+	batchReportService    application.BatchReportService
+	importJobService      application.ImportJobService
+
+	operationalReportService application.OperationalReportService
+}
+
+func NewReportController(
+	reportTemplateService application.ReportTemplateService,
+	reportService application.ReportService,
+	batchReportService application.BatchReportService,
+	importJobService application.ImportJobService,
+	operationalReportService application.OperationalReportService,
+) ReportController {
+	return ReportController{
+		reportTemplateService: reportTemplateService,
+		reportService:         reportService,
+		batchReportService:    batchReportService,
+		importJobService:      importJobService,
+
+		operationalReportService: operationalReportService,
+	}
+}
+
+func (c *ReportController) UploadTemplate(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		_ = ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	author := ctx.GetHeader("X-Author")
+	if author == "" {
+		_ = ctx.Error(domain.NewValidationError("header X-Author cannot be empty", nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	defer file.Close()
+
+	report, err := domain.NewReport(contractorID, ctx.Request.FormValue("name"), fileHeader.Filename, author)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	uploadedReport, err := c.reportTemplateService.Upload(ctx.Request.Context(), report, file)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapReportToReportTemplateDTO(*uploadedReport))
+}
+
+func (c *ReportController) GetTemplates(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		_ = ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	reports, err := c.reportTemplateService.GetByContractor(ctx.Request.Context(), contractorID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportsToReportTemplateDTOs(reports))
+}
+
+func (c *ReportController) DownloadTemplate(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	reportID := ctx.Param("reportID")
+	if contractorID == "" || reportID == "" {
+		_ = ctx.Error(domain.NewValidationError("params contractorID and reportID cannot be empty", nil))
+		return
+	}
+
+	content, report, err := c.reportTemplateService.Download(ctx.Request.Context(), contractorID, reportID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", report.FileName))
+	ctx.Data(http.StatusOK, domain.REPORT_DOCX.ContentType(), content)
+}
+
+func (c *ReportController) ValidateTemplate(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	reportID := ctx.Param("reportID")
+	if contractorID == "" || reportID == "" {
+		_ = ctx.Error(domain.NewValidationError("params contractorID and reportID cannot be empty", nil))
+		return
+	}
+
+	validation, err := c.reportTemplateService.Validate(ctx.Request.Context(), contractorID, reportID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportTemplateValidationToDTO(*validation))
+}
+
+// ValidateTemplateFile checks the placeholders of a docx sent as the "file"
+// form field without storing it, so a template can be fixed before upload.
+func (c *ReportController) ValidateTemplateFile(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		_ = ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	defer file.Close()
+
+	validation, err := c.reportTemplateService.ValidateFile(ctx.Request.Context(), contractorID, fileHeader.Filename, file)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportTemplateValidationToDTO(*validation))
+}
+
+func (c *ReportController) ActivateTemplate(ctx *gin.Context) {
+	c.changeTemplateStatus(ctx, c.reportTemplateService.Activate)
+}
+
+func (c *ReportController) RetireTemplate(ctx *gin.Context) {
+	c.changeTemplateStatus(ctx, c.reportTemplateService.Retire)
+}
+
+func (c *ReportController) changeTemplateStatus(
+	ctx *gin.Context,
+	change func(ctx context.Context, contractorID, reportID, author string) (*domain.Report, error),
+) {
+	contractorID := ctx.Param("contractorID")
+	reportID := ctx.Param("reportID")
+	if contractorID == "" || reportID == "" {
+		_ = ctx.Error(domain.NewValidationError("params contractorID and reportID cannot be empty", nil))
+		return
+	}
+
+	var statusDTO *ChangeReportTemplateStatusDTO
+	if err := ctx.BindJSON(&statusDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if statusDTO.UpdatedBy == "" {
+		_ = ctx.Error(domain.NewValidationError("updated_by cannot be empty", nil))
+		return
+	}
+
+	report, err := change(ctx.Request.Context(), contractorID, reportID, statusDTO.UpdatedBy)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportToReportTemplateDTO(*report))
+}
+
+func (c *ReportController) GetCaseReports(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	caseReports, err := c.reportService.GetCaseReports(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapCaseReportsToCaseReportDTOs(caseReports))
+}
+
+func (c *ReportController) DownloadCaseReport(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	caseReportID := ctx.Param("caseReportID")
+	if caseID == "" || caseReportID == "" {
+		_ = ctx.Error(domain.NewValidationError("params caseID and caseReportID cannot be empty", nil))
+		return
+	}
+
+	content, caseReport, err := c.reportService.DownloadCaseReport(ctx.Request.Context(), caseID, caseReportID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", caseReport.FileName))
+	ctx.Data(http.StatusOK, caseReport.Format.ContentType(), content)
+}
+
+// CreateBatch enqueues the generation of the reports of many cases, picked by
+// id or by the filters of the case search, as a job whose ZIP is downloaded
+// once it finishes.
+func (c *ReportController) CreateBatch(ctx *gin.Context) {
+	author := ctx.GetHeader("X-Author")
+	if author == "" {
+		_ = ctx.Error(domain.NewValidationError("header X-Author cannot be empty", nil))
+		return
+	}
+
+	var batchDTO CreateReportBatchDTO
+	if err := ctx.BindJSON(&batchDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	batch, err := mapCreateReportBatchDTOToReportBatch(batchDTO)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	job, content, err := domain.NewReportBatchJob(batch, author)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	enqueuedJob, err := c.importJobService.Enqueue(ctx.Request.Context(), job, bytes.NewReader(content))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, mapImportJobToDTO(*enqueuedJob))
+}
+
+func (c *ReportController) GetBatch(ctx *gin.Context) {
+	job, err := c.getBatchJob(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapImportJobToDTO(*job))
+}
+
+func (c *ReportController) DownloadBatch(ctx *gin.Context) {
+	job, err := c.getBatchJob(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	content, size, fileName, err := c.batchReportService.Download(ctx.Request.Context(), *job)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, size, "application/zip", content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", fileName),
+	})
+}
+
+func (c *ReportController) getBatchJob(ctx *gin.Context) (*domain.ImportJob, error) {
+	jobID := ctx.Param("jobID")
+	if jobID == "" {
+		return nil, domain.NewValidationError("param jobID cannot be empty", nil)
+	}
+
+	job, err := c.importJobService.GetByID(ctx.Request.Context(), jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != domain.IMPORT_JOB_REPORTS {
+		return nil, domain.NewNotFoundError("no report batch found with this id", map[string]any{"job_id": jobID})
+	}
+
+	return job, nil
+}
+
+// ExportOperationalReport streams a report of the operational catalog as a
+// spreadsheet, narrowed by contractor and by a start_date and end_date in the
+// YYYY-MM-DD layout.
+func (c *ReportController) ExportOperationalReport(ctx *gin.Context) {
+	name, err := domain.ParseOperationalReportName(ctx.Param("name"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	format, err := domain.ParseExportFormat(ctx.Query("format"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	filters, err := parseOperationalReportFilters(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	file := newAttachmentWriter(ctx, format.ContentType(), format.FileName(string(name)))
+	err = c.operationalReportService.Export(ctx.Request.Context(), name, filters, format, file)
+	if err != nil {
+		if !ctx.Writer.Written() {
+			_ = ctx.Error(err)
+			return
+		}
+
+		// the status is already sent, all that is left is to cut the file short
+		fmt.Printf("error exporting operational report %s: %v\n", name, err.Error())
+		ctx.Abort()
+	}
+}
+
+func parseOperationalReportFilters(ctx *gin.Context) (domain.OperationalReportFilters, error) {
+	filters := domain.OperationalReportFilters{
+		ContractorID: ctx.QueryArray("contractor_id"),
+	}
+
+	startDate, err := parseReportDate(ctx, "start_date")
+	if err != nil {
+		return domain.OperationalReportFilters{}, err
+	}
+	filters.StartDate = startDate
+
+	endDate, err := parseReportDate(ctx, "end_date")
+	if err != nil {
+		return domain.OperationalReportFilters{}, err
+	}
+	filters.EndDate = endDate
+
+	if filters.StartDate != nil && filters.EndDate != nil && filters.EndDate.Before(*filters.StartDate) {
+		return domain.OperationalReportFilters{}, domain.NewValidationError("end_date cannot be before start_date", nil)
+	}
+
+	return filters, nil
+}
+
+func parseReportDate(ctx *gin.Context, param string) (*time.Time, error) {
+	value := ctx.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, domain.NewValidationError(
+			fmt.Sprintf("query param %s must be a date in the YYYY-MM-DD layout", param),
+			map[string]any{param: value},
+		)
+	}
+
+	return &date, nil
+}
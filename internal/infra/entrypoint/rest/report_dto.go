@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportTemplateDTO struct {
+	ReportID     string    `json:"report_id"`
+	ContractorID string    `json:"contractor_id"`
+	ReportName   string    `json:"report_name"`
+	FileName     string    `json:"file_name"`
+	Version      int       `json:"version"`
+	Status       string    `json:"status"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedBy    string    `json:"updated_by"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ReportTemplateValidationDTO struct {
+	ReportID            string   `json:"report_id,omitempty"`
+	Version             int      `json:"version,omitempty"`
+	FileName            string   `json:"file_name"`
+	Valid               bool     `json:"valid"`
+	UnknownPlaceholders []string `json:"unknown_placeholders"`
+}
+
+type CaseReportDTO struct {
+	CaseReportID    string    `json:"case_report_id"`
+	CaseID          string    `json:"case_id"`
+	CommentID       string    `json:"comment_id"`
+	AttachmentID    string    `json:"attachment_id"`
+	ReportID        string    `json:"report_id"`
+	TemplateVersion int       `json:"template_version"`
+	Format          string    `json:"format"`
+	FileName        string    `json:"file_name"`
+	ContentHash     string    `json:"content_hash"`
+	Size            int       `json:"size"`
+	CreatedBy       string    `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CreateReportBatchDTO picks the cases of a report batch by id or with the
+// filters of the case search.
+type CreateReportBatchDTO struct {
+	CaseIDs           []string `json:"case_ids"`
+	ContractorID      []string `json:"contractor_id"`
+	PartnerID         []string `json:"partner_id"`
+	OwnerID           []string `json:"owner_id"`
+	Status            []string `json:"status"`
+	Region            []string `json:"region"`
+	QueueID           []string `json:"queue_id"`
+	ExternalReference []string `json:"external_reference"`
+	StartDate         *string  `json:"start_date"`
+	EndDate           *string  `json:"end_date"`
+	ClosedAtStart     *string  `json:"closed_at_start"`
+	ClosedAtEnd       *string  `json:"closed_at_end"`
+	Format            string   `json:"format"`
+}
+
+type ChangeReportTemplateStatusDTO struct {
+	UpdatedBy string `json:"updated_by" validate:"required"`
+}
+
+func mapReportToReportTemplateDTO(report domain.Report) ReportTemplateDTO {
+	return ReportTemplateDTO{
+		ReportID:     report.ReportID,
+		ContractorID: report.ContractorID,
+		ReportName:   report.ReportName,
+		FileName:     report.FileName,
+		Version:      report.Version,
+		Status:       string(report.Status),
+		CreatedBy:    report.CreatedBy,
+		CreatedAt:    report.CreatedAt,
+		UpdatedBy:    report.UpdatedBy,
+		UpdatedAt:    report.UpdatedAt,
+	}
+}
+
+func mapReportsToReportTemplateDTOs(reports []domain.Report) []ReportTemplateDTO {
+	reportDTOs := make([]ReportTemplateDTO, 0, len(reports))
+	for _, report := range reports {
+		reportDTOs = append(reportDTOs, mapReportToReportTemplateDTO(report))
+	}
+
+	return reportDTOs
+}
+
+func mapReportTemplateValidationToDTO(validation domain.ReportValidation) ReportTemplateValidationDTO {
+	return ReportTemplateValidationDTO{
+		ReportID:            validation.Report.ReportID,
+		Version:             validation.Report.Version,
+		FileName:            validation.Report.FileName,
+		Valid:               len(validation.UnknownPlaceholders) == 0,
+		UnknownPlaceholders: validation.UnknownPlaceholders,
+	}
+}
+
+func mapCaseReportToCaseReportDTO(caseReport domain.CaseReport) CaseReportDTO {
+	return CaseReportDTO{
+		CaseReportID:    caseReport.CaseReportID,
+		CaseID:          caseReport.CaseID,
+		CommentID:       caseReport.CommentID,
+		AttachmentID:    caseReport.AttachmentID,
+		ReportID:        caseReport.ReportID,
+		TemplateVersion: caseReport.TemplateVersion,
+		Format:          string(caseReport.Format),
+		FileName:        caseReport.FileName,
+		ContentHash:     caseReport.ContentHash,
+		Size:            caseReport.Size,
+		CreatedBy:       caseReport.CreatedBy,
+		CreatedAt:       caseReport.CreatedAt,
+	}
+}
+
+func mapCaseReportsToCaseReportDTOs(caseReports []domain.CaseReport) []CaseReportDTO {
+	caseReportDTOs := make([]CaseReportDTO, 0, len(caseReports))
+	for _, caseReport := range caseReports {
+		caseReportDTOs = append(caseReportDTOs, mapCaseReportToCaseReportDTO(caseReport))
+	}
+
+	return caseReportDTOs
+}
+
+func mapCreateReportBatchDTOToReportBatch(batchDTO CreateReportBatchDTO) (domain.ReportBatch, error) {
+	format, err := domain.ParseReportFormat(batchDTO.Format)
+	if err != nil {
+		return domain.ReportBatch{}, err
+	}
+
+	return domain.ReportBatch{
+		Filters: domain.CaseFilters{
+			CaseID:            batchDTO.CaseIDs,
+			ContractorID:      batchDTO.ContractorID,
+			PartnerID:         batchDTO.PartnerID,
+			OwnerID:           batchDTO.OwnerID,
+			Status:            batchDTO.Status,
+			Region:            batchDTO.Region,
+			QueueID:           batchDTO.QueueID,
+			ExternalReference: batchDTO.ExternalReference,
+			StartDate:         batchDTO.StartDate,
+			EndDate:           batchDTO.EndDate,
+			ClosedAtStart:     batchDTO.ClosedAtStart,
+			ClosedAtEnd:       batchDTO.ClosedAtEnd,
+		},
+		Format: format,
+	}, nil
+}
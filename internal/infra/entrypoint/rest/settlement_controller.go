@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type SettlementController struct {
+	settlementService application.SettlementService
+}
+
+func NewSettlementController(settlementService application.SettlementService) SettlementController {
+	return SettlementController{
+		settlementService: settlementService,
+	}
+}
+
+func (c *SettlementController) GetPolicy(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		_ = ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	policy, err := c.settlementService.GetPolicy(ctx.Request.Context(), contractorID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSettlementPolicyToDTO(*policy))
+}
+
+func (c *SettlementController) UpdatePolicy(ctx *gin.Context) {
+	contractorID := ctx.Param("contractorID")
+	if contractorID == "" {
+		_ = ctx.Error(domain.NewValidationError("param contractorID cannot be empty", nil))
+		return
+	}
+
+	var policyDTO *UpdateSettlementPolicyDTO
+	if err := ctx.BindJSON(&policyDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	policy, err := mapUpdateSettlementPolicyDTOToPolicy(*policyDTO, contractorID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := c.settlementService.SavePolicy(ctx.Request.Context(), policy); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (c *SettlementController) DecideSettlement(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	var decideDTO *DecideSettlementDTO
+	if err := ctx.BindJSON(&decideDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	decision, err := c.settlementService.Decide(ctx.Request.Context(), caseID, mapDecideSettlementDTOToDecideSettlement(*decideDTO))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapSettlementDecisionToDTO(*decision))
+}
+
+func (c *SettlementController) GetDecision(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	decision, err := c.settlementService.GetDecision(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSettlementDecisionToDTO(*decision))
+}
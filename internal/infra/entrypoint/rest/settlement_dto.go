@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type SettlementPolicyDTO struct {
+	ContractorID        string    `json:"contractor_id"`
+	TotalLossThreshold  float64   `json:"total_loss_threshold"`
+	TotalLossSettlement string    `json:"total_loss_settlement"`
+	UpdatedBy           string    `json:"updated_by"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type UpdateSettlementPolicyDTO struct {
+	TotalLossThreshold  float64 `json:"total_loss_threshold" validate:"required"`
+	TotalLossSettlement string  `json:"total_loss_settlement" validate:"required"`
+	UpdatedBy           string  `json:"updated_by" validate:"required"`
+}
+
+type DecideSettlementDTO struct {
+	Type      *string `json:"type"`
+	Reason    string  `json:"reason"`
+	DecidedBy string  `json:"decided_by" validate:"required"`
+}
+
+type SettlementDecisionDTO struct {
+	DecisionID   string    `json:"decision_id"`
+	CaseID       string    `json:"case_id"`
+	Type         string    `json:"type"`
+	RepairCost   float64   `json:"repair_cost"`
+	ProductValue float64   `json:"product_value"`
+	LossRatio    float64   `json:"loss_ratio"`
+	Threshold    float64   `json:"threshold"`
+	Overridden   bool      `json:"overridden"`
+	Reason       string    `json:"reason"`
+	DecidedBy    string    `json:"decided_by"`
+	DecidedAt    time.Time `json:"decided_at"`
+}
+
+func mapUpdateSettlementPolicyDTOToPolicy(policyDTO UpdateSettlementPolicyDTO, contractorID string) (domain.SettlementPolicy, error) {
+	return domain.NewSettlementPolicy(
+		contractorID,
+		policyDTO.TotalLossThreshold,
+		domain.SettlementType(policyDTO.TotalLossSettlement),
+		policyDTO.UpdatedBy,
+	)
+}
+
+func mapSettlementPolicyToDTO(policy domain.SettlementPolicy) SettlementPolicyDTO {
+	return SettlementPolicyDTO{
+		ContractorID:        policy.ContractorID,
+		TotalLossThreshold:  policy.TotalLossThreshold,
+		TotalLossSettlement: string(policy.TotalLossSettlement),
+		UpdatedBy:           policy.UpdatedBy,
+		UpdatedAt:           policy.UpdatedAt,
+	}
+}
+
+func mapDecideSettlementDTOToDecideSettlement(decideDTO DecideSettlementDTO) domain.DecideSettlement {
+	var override *domain.SettlementType
+	if decideDTO.Type != nil {
+		settlementType := domain.SettlementType(*decideDTO.Type)
+		override = &settlementType
+	}
+
+	return domain.DecideSettlement{
+		Override:  override,
+		Reason:    decideDTO.Reason,
+		DecidedBy: decideDTO.DecidedBy,
+	}
+}
+
+func mapSettlementDecisionToDTO(decision domain.SettlementDecision) SettlementDecisionDTO {
+	return SettlementDecisionDTO{
+		DecisionID:   decision.DecisionID,
+		CaseID:       decision.CaseID,
+		Type:         string(decision.Type),
+		RepairCost:   decision.RepairCost,
+		ProductValue: decision.ProductValue,
+		LossRatio:    decision.LossRatio,
+		Threshold:    decision.Threshold,
+		Overridden:   decision.Overridden,
+		Reason:       decision.Reason,
+		DecidedBy:    decision.DecidedBy,
+		DecidedAt:    decision.DecidedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ShipmentController struct {
+	shipmentService application.ShipmentService
+}
+
+func NewShipmentController(shipmentService application.ShipmentService) ShipmentController {
+	return ShipmentController{
+		shipmentService: shipmentService,
+	}
+}
+
+func (c *ShipmentController) CreateShipment(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	var shipmentDTO *CreateShipmentDTO
+	if err := ctx.BindJSON(&shipmentDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	shipment, err := c.shipmentService.Create(ctx.Request.Context(), mapCreateShipmentDTOToCreateShipment(*shipmentDTO, caseID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapShipmentToShipmentDTO(*shipment))
+}
+
+func (c *ShipmentController) GetShipment(ctx *gin.Context) {
+	shipmentID := ctx.Param("shipmentID")
+	if shipmentID == "" {
+		_ = ctx.Error(domain.NewValidationError("param shipmentID cannot be empty", nil))
+		return
+	}
+
+	shipment, err := c.shipmentService.GetByID(ctx.Request.Context(), shipmentID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapShipmentToShipmentDTO(*shipment))
+}
+
+func (c *ShipmentController) GetByCaseID(ctx *gin.Context) {
+	caseID := ctx.Param("caseID")
+	if caseID == "" {
+		_ = ctx.Error(domain.NewValidationError("param caseID cannot be empty", nil))
+		return
+	}
+
+	shipments, err := c.shipmentService.GetByCaseID(ctx.Request.Context(), caseID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapShipmentsToShipmentDTOs(shipments))
+}
+
+func (c *ShipmentController) RefreshShipment(ctx *gin.Context) {
+	shipmentID := ctx.Param("shipmentID")
+	if shipmentID == "" {
+		_ = ctx.Error(domain.NewValidationError("param shipmentID cannot be empty", nil))
+		return
+	}
+
+	var refreshDTO *RefreshShipmentDTO
+	if err := ctx.BindJSON(&refreshDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	shipment, err := c.shipmentService.Refresh(ctx.Request.Context(), shipmentID, refreshDTO.UpdatedBy)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapShipmentToShipmentDTO(*shipment))
+}
+
+func (c *ShipmentController) RegisterEvent(ctx *gin.Context) {
+	shipmentID := ctx.Param("shipmentID")
+	if shipmentID == "" {
+		_ = ctx.Error(domain.NewValidationError("param shipmentID cannot be empty", nil))
+		return
+	}
+
+	var eventDTO *CreateShipmentEventDTO
+	if err := ctx.BindJSON(&eventDTO); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	shipment, err := c.shipmentService.RegisterEvent(ctx.Request.Context(), shipmentID, mapCreateShipmentEventDTOToEvent(*eventDTO), eventDTO.CreatedBy)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapShipmentToShipmentDTO(*shipment))
+}
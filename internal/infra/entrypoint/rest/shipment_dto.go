@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CreateShipmentDTO struct {
+	Direction string `json:"direction" validate:"required"`
+	Carrier   string `json:"carrier" validate:"required"`
+	CreatedBy string `json:"created_by" validate:"required"`
+}
+
+type RefreshShipmentDTO struct {
+	UpdatedBy string `json:"updated_by" validate:"required"`
+}
+
+type CreateShipmentEventDTO struct {
+	Status      string     `json:"status" validate:"required"`
+	Description string     `json:"description"`
+	Location    string     `json:"location"`
+	OccurredAt  *time.Time `json:"occurred_at"`
+	CreatedBy   string     `json:"created_by" validate:"required"`
+}
+
+type ShipmentDTO struct {
+	ShipmentID   string             `json:"shipment_id"`
+	CaseID       string             `json:"case_id"`
+	Direction    string             `json:"direction"`
+	Carrier      string             `json:"carrier"`
+	TrackingCode string             `json:"tracking_code"`
+	Status       string             `json:"status"`
+	Origin       AddressDTO         `json:"origin"`
+	Destination  AddressDTO         `json:"destination"`
+	Events       []ShipmentEventDTO `json:"events"`
+	CreatedBy    string             `json:"created_by"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedBy    string             `json:"updated_by"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+type ShipmentEventDTO struct {
+	EventID     string    `json:"event_id"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+func mapCreateShipmentDTOToCreateShipment(shipmentDTO CreateShipmentDTO, caseID string) domain.CreateShipment {
+	return domain.CreateShipment{
+		CaseID:    caseID,
+		Direction: domain.ShipmentDirection(shipmentDTO.Direction),
+		Carrier:   shipmentDTO.Carrier,
+		CreatedBy: shipmentDTO.CreatedBy,
+	}
+}
+
+func mapCreateShipmentEventDTOToEvent(eventDTO CreateShipmentEventDTO) domain.ShipmentEvent {
+	occurredAt := time.Now().UTC()
+	if eventDTO.OccurredAt != nil {
+		occurredAt = eventDTO.OccurredAt.UTC()
+	}
+
+	return domain.ShipmentEvent{
+		Status:      domain.ShipmentStatus(eventDTO.Status),
+		Description: eventDTO.Description,
+		Location:    eventDTO.Location,
+		OccurredAt:  occurredAt,
+	}
+}
+
+func mapShipmentToShipmentDTO(shipment domain.Shipment) ShipmentDTO {
+	events := make([]ShipmentEventDTO, 0, len(shipment.Events))
+	for _, event := range shipment.Events {
+		events = append(events, ShipmentEventDTO{
+			EventID:     event.EventID,
+			Status:      string(event.Status),
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt,
+		})
+	}
+
+	return ShipmentDTO{
+		ShipmentID:   shipment.ShipmentID,
+		CaseID:       shipment.CaseID,
+		Direction:    string(shipment.Direction),
+		Carrier:      shipment.Carrier,
+		TrackingCode: shipment.TrackingCode,
+		Status:       string(shipment.Status),
+		Origin:       mapAddressToAddressDTO(shipment.Origin),
+		Destination:  mapAddressToAddressDTO(shipment.Destination),
+		Events:       events,
+		CreatedBy:    shipment.CreatedBy,
+		CreatedAt:    shipment.CreatedAt,
+		UpdatedBy:    shipment.UpdatedBy,
+		UpdatedAt:    shipment.UpdatedAt,
+	}
+}
+
+func mapShipmentsToShipmentDTOs(shipments []domain.Shipment) []ShipmentDTO {
+	shipmentDTOs := make([]ShipmentDTO, 0, len(shipments))
+	for _, shipment := range shipments {
+		shipmentDTOs = append(shipmentDTOs, mapShipmentToShipmentDTO(shipment))
+	}
+
+	return shipmentDTOs
+}
@@ -22,6 +22,14 @@ func LoadRoutes(
 	transactionController rest.TransactionController,
 	caseActionController rest.CaseActionController,
 	queueController rest.QueueController,
+	quoteController rest.QuoteController,
+	settlementController rest.SettlementController,
+	partController rest.PartController,
+	shipmentController rest.ShipmentController,
+	fraudController rest.FraudController,
+	importJobController rest.ImportJobController,
+	reportController rest.ReportController,
+	analyticsController rest.AnalyticsController,
 ) {
 	authGroup := app.Group("/crm/core/api/v1")
 	authGroup.Use(authMiddleware.Authenticate())
@@ -50,6 +58,8 @@ func LoadRoutes(
 	// customers
 	authGroup.POST("/customers", customerController.CreateCustomer)
 	authGroup.GET("/customers", customerController.SearchCustomers)
+	authGroup.POST("/customers/batch", customerController.CreateBatch)
+	authGroup.GET("/customers/export", customerController.ExportCustomers)
 	authGroup.GET("/customers/:customerID", customerController.GetCustomer)
 	authGroup.PUT("/customers/:customerID", customerController.UpdateCustomer)
 	authGroup.DELETE("/customers/:customerID", customerController.DeleteCustomer)
@@ -60,6 +70,23 @@ func LoadRoutes(
 	authGroup.GET("/contractors/:contractorID", contractorController.GetContractor)
 	authGroup.PUT("/contractors/:contractorID", contractorController.UpdateContractor)
 	authGroup.DELETE("/contractors/:contractorID", contractorController.DeleteContractor)
+	authGroup.GET("/contractors/:contractorID/import-mapping", contractorController.GetImportMapping)
+	authGroup.PUT("/contractors/:contractorID/import-mapping", contractorController.UpdateImportMapping)
+
+	// report templates
+	authGroup.POST("/contractors/:contractorID/report-templates", reportController.UploadTemplate)
+	authGroup.GET("/contractors/:contractorID/report-templates", reportController.GetTemplates)
+	authGroup.POST("/contractors/:contractorID/report-templates/validation", reportController.ValidateTemplateFile)
+	authGroup.GET("/contractors/:contractorID/report-templates/:reportID/file", reportController.DownloadTemplate)
+	authGroup.GET("/contractors/:contractorID/report-templates/:reportID/validation", reportController.ValidateTemplate)
+	authGroup.PATCH("/contractors/:contractorID/report-templates/:reportID/activate", reportController.ActivateTemplate)
+	authGroup.PATCH("/contractors/:contractorID/report-templates/:reportID/retire", reportController.RetireTemplate)
+
+	// reports
+	authGroup.POST("/reports/batch", reportController.CreateBatch)
+	authGroup.GET("/reports/batch/:jobID", reportController.GetBatch)
+	authGroup.GET("/reports/batch/:jobID/file", reportController.DownloadBatch)
+	authGroup.GET("/reports/operational/:name", reportController.ExportOperationalReport)
 
 	// auth
 	publicGroup.POST("/login", authController.Login)
@@ -74,6 +101,7 @@ func LoadRoutes(
 	authGroup.PUT("/cases/:caseID", caseController.UpdateCase)
 	authGroup.GET("/cases", caseController.SearchCases)
 	authGroup.GET("/cases/full", caseController.SearchCasesFull)
+	authGroup.GET("/cases/stats", caseController.GetCaseStats)
 	authGroup.POST("/cases/batch", caseController.CreateBatch)
 	authGroup.GET("/cases/:caseID/full", caseController.GetCaseFull)
 	authGroup.GET("/cases/:caseID/history", caseController.GetCaseHistory)
@@ -100,6 +128,8 @@ func LoadRoutes(
 	authGroup.PATCH("/cases/:caseID/status", caseActionController.ChangeStatus)
 	authGroup.PATCH("/cases/:caseID/partner", caseActionController.ChangePartner)
 	authGroup.GET("/cases/:caseID/report", caseActionController.DownloadReport)
+	authGroup.GET("/cases/:caseID/reports", reportController.GetCaseReports)
+	authGroup.GET("/cases/:caseID/reports/:caseReportID/file", reportController.DownloadCaseReport)
 	authGroup.PATCH("/cases/:caseID/reset", caseActionController.ResetCase)
 
 	// queues
@@ -112,4 +142,48 @@ func LoadRoutes(
 	authGroup.POST("/queues/:queueID/members", queueController.AddMember)
 	authGroup.DELETE("/queues/:queueID/members/:userID", queueController.RemoveMember)
 	authGroup.GET("/users/:userID/queues", queueController.GetQueuesByUser)
+
+	// quotes
+	authGroup.POST("/cases/:caseID/quotes", quoteController.CreateQuote)
+	authGroup.GET("/cases/:caseID/quotes", quoteController.GetByCaseID)
+	authGroup.GET("/quotes/:quoteID", quoteController.GetQuote)
+	authGroup.PATCH("/quotes/:quoteID/approve", quoteController.ApproveQuote)
+	authGroup.PATCH("/quotes/:quoteID/reject", quoteController.RejectQuote)
+
+	// settlements
+	authGroup.GET("/contractors/:contractorID/settlement-policy", settlementController.GetPolicy)
+	authGroup.PUT("/contractors/:contractorID/settlement-policy", settlementController.UpdatePolicy)
+	authGroup.POST("/cases/:caseID/settlement", settlementController.DecideSettlement)
+	authGroup.GET("/cases/:caseID/settlement", settlementController.GetDecision)
+
+	// parts
+	authGroup.POST("/parts", partController.CreatePart)
+	authGroup.GET("/parts", partController.SearchParts)
+	authGroup.GET("/parts/low-stock", partController.SearchLowStock)
+	authGroup.GET("/parts/:partID", partController.GetPart)
+	authGroup.PUT("/parts/:partID", partController.UpdatePart)
+	authGroup.POST("/parts/:partID/restock", partController.RestockPart)
+	authGroup.POST("/cases/:caseID/parts", partController.MoveCasePart)
+	authGroup.GET("/cases/:caseID/parts", partController.GetCaseParts)
+
+	// shipments
+	authGroup.POST("/cases/:caseID/shipments", shipmentController.CreateShipment)
+	authGroup.GET("/cases/:caseID/shipments", shipmentController.GetByCaseID)
+	authGroup.GET("/shipments/:shipmentID", shipmentController.GetShipment)
+	authGroup.POST("/shipments/:shipmentID/refresh", shipmentController.RefreshShipment)
+	authGroup.POST("/shipments/:shipmentID/events", shipmentController.RegisterEvent)
+
+	// fraud
+	authGroup.GET("/cases/:caseID/fraud", fraudController.GetAssessment)
+	authGroup.PATCH("/cases/:caseID/fraud/review", fraudController.ReviewAssessment)
+
+	// imports
+	authGroup.GET("/imports/:jobID", importJobController.GetImportJob)
+	authGroup.GET("/imports/:jobID/errors", importJobController.DownloadErrorReport)
+
+	// analytics
+	authGroup.GET("/analytics/status-durations", analyticsController.GetStatusDurations)
+	authGroup.GET("/analytics/backlog-aging", analyticsController.GetBacklogAging)
+	authGroup.GET("/analytics/backlog-aging/snapshots", analyticsController.GetBacklogSnapshots)
+	authGroup.GET("/cases/:caseID/status-durations", analyticsController.GetCaseStatusDurations)
 }
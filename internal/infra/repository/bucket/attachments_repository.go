@@ -1,6 +1,7 @@
 package bucket
 
 import (
+	"bytes"
 	"context"
 	"io"
 
@@ -38,3 +39,53 @@ func (b *attachmentBucket) Download(ctx context.Context, fileID string) ([]byte,
 
 	return file, nil
 }
+
+func (b *attachmentBucket) DownloadStream(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	result, err := b.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size := int64(-1)
+	if result.ContentLength != nil {
+		size = *result.ContentLength
+	}
+
+	return result.Body, size, nil
+}
+
+func (b *attachmentBucket) Upload(ctx context.Context, key string, content []byte, contentType string) error {
+	_, err := b.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(content),
+		ContentType: aws.String(contentType),
+	})
+
+	return err
+}
+
+// UploadStream sends the object without holding it in memory, for files such
+// as report batches that can grow to hundreds of megabytes.
+func (b *attachmentBucket) UploadStream(ctx context.Context, key string, content io.ReadSeeker, contentType string) error {
+	_, err := b.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucketName),
+		Key:         aws.String(key),
+		Body:        content,
+		ContentType: aws.String(contentType),
+	})
+
+	return err
+}
+
+func (b *attachmentBucket) Delete(ctx context.Context, key string) error {
+	_, err := b.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucketName),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
@@ -0,0 +1,103 @@
+package carrier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const fileCarrierName = "file"
+
+// fileCarrier is a fake carrier backed by one JSON file per tracking code.
+// Tracking updates are simulated by appending events to those files. It only
+// backs tests, the service runs with the manual carrier.
+type fileCarrier struct {
+	folder string
+}
+
+type fileCarrierEvent struct {
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+func newFileCarrier(folder string) domain.Carrier {
+	return &fileCarrier{
+		folder: folder,
+	}
+}
+
+func (c *fileCarrier) Name() string {
+	return fileCarrierName
+}
+
+func (c *fileCarrier) CreateShipment(_ context.Context, shipment domain.Shipment) (string, error) {
+	if err := os.MkdirAll(c.folder, 0o755); err != nil {
+		return "", err
+	}
+
+	trackingCode := "FC" + strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:12])
+	events := []fileCarrierEvent{
+		{
+			Status:      string(domain.SHIPMENT_CREATED),
+			Description: fmt.Sprintf("shipment registered for case %s", shipment.CaseID),
+			Location:    shipment.Origin.City,
+			OccurredAt:  time.Now().UTC(),
+		},
+	}
+
+	if err := c.writeEvents(trackingCode, events); err != nil {
+		return "", err
+	}
+
+	return trackingCode, nil
+}
+
+func (c *fileCarrier) Track(_ context.Context, trackingCode string) ([]domain.ShipmentEvent, error) {
+	content, err := os.ReadFile(c.trackingFile(trackingCode))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domain.NewNotFoundError("tracking code not found in carrier", map[string]any{"tracking_code": trackingCode})
+		}
+		return nil, err
+	}
+
+	var fileEvents []fileCarrierEvent
+	if err := json.Unmarshal(content, &fileEvents); err != nil {
+		return nil, fmt.Errorf("failed to parse tracking file %s: %w", trackingCode, err)
+	}
+
+	events := make([]domain.ShipmentEvent, 0, len(fileEvents))
+	for _, fileEvent := range fileEvents {
+		events = append(events, domain.ShipmentEvent{
+			Status:      domain.ShipmentStatus(fileEvent.Status),
+			Description: fileEvent.Description,
+			Location:    fileEvent.Location,
+			OccurredAt:  fileEvent.OccurredAt,
+		})
+	}
+
+	return events, nil
+}
+
+func (c *fileCarrier) writeEvents(trackingCode string, events []fileCarrierEvent) error {
+	content, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.trackingFile(trackingCode), content, 0o644)
+}
+
+func (c *fileCarrier) trackingFile(trackingCode string) string {
+	return filepath.Join(c.folder, filepath.Base(trackingCode)+".json")
+}
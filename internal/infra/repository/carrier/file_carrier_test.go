@@ -0,0 +1,52 @@
+package carrier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCarrier(t *testing.T) {
+	t.Run("registers the shipment and tracks its events", func(t *testing.T) {
+		folder := t.TempDir()
+		carrier := newFileCarrier(folder)
+
+		trackingCode, err := carrier.CreateShipment(context.Background(), domain.Shipment{CaseID: "case-1"})
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(folder, trackingCode+".json"))
+
+		events, err := carrier.Track(context.Background(), trackingCode)
+
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.SHIPMENT_CREATED, events[0].Status)
+	})
+
+	t.Run("reads events appended to the tracking file", func(t *testing.T) {
+		folder := t.TempDir()
+		content := `[
+			{"status": "created", "occurred_at": "2024-05-01T10:00:00Z"},
+			{"status": "in_transit", "location": "Sao Paulo", "occurred_at": "2024-05-02T10:00:00Z"}
+		]`
+		require.NoError(t, os.WriteFile(filepath.Join(folder, "FC123.json"), []byte(content), 0o644))
+
+		events, err := newFileCarrier(folder).Track(context.Background(), "FC123")
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, domain.SHIPMENT_IN_TRANSIT, events[1].Status)
+		assert.Equal(t, "Sao Paulo", events[1].Location)
+	})
+
+	t.Run("returns not found for unknown tracking codes", func(t *testing.T) {
+		_, err := newFileCarrier(t.TempDir()).Track(context.Background(), "FC404")
+
+		require.Error(t, err)
+		assert.IsType(t, &domain.CustomError{}, err)
+	})
+}
@@ -0,0 +1,32 @@
+package carrier
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const ManualCarrierName = "manual"
+
+// manualCarrier is used while no logistics provider is integrated. It only
+// hands out tracking codes; the tracking events are registered by the
+// operators through the shipment events endpoint.
+type manualCarrier struct{}
+
+func NewManualCarrier() domain.Carrier {
+	return &manualCarrier{}
+}
+
+func (c *manualCarrier) Name() string {
+	return ManualCarrierName
+}
+
+func (c *manualCarrier) CreateShipment(_ context.Context, _ domain.Shipment) (string, error) {
+	return "MN" + strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:12]), nil
+}
+
+func (c *manualCarrier) Track(_ context.Context, _ string) ([]domain.ShipmentEvent, error) {
+	return nil, nil
+}
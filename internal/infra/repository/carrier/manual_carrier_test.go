@@ -0,0 +1,28 @@
+package carrier
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualCarrier(t *testing.T) {
+	t.Run("hands out a tracking code", func(t *testing.T) {
+		trackingCode, err := NewManualCarrier().CreateShipment(context.Background(), domain.Shipment{CaseID: "case-1"})
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(trackingCode, "MN"))
+		assert.Len(t, trackingCode, 14)
+	})
+
+	t.Run("has no events to track", func(t *testing.T) {
+		events, err := NewManualCarrier().Track(context.Background(), "MN123")
+
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
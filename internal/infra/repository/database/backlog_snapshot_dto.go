@@ -0,0 +1,71 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type BacklogSnapshotDTO struct {
+	SnapshotID   string    `db:"snapshot_id"`
+	SnapshotDate time.Time `db:"snapshot_date"`
+	QueueID      string    `db:"queue_id"`
+	OwnerID      string    `db:"owner_id"`
+	Status       string    `db:"status"`
+	UpTo2Days    int       `db:"up_to_2_days"`
+	UpTo5Days    int       `db:"up_to_5_days"`
+	UpTo10Days   int       `db:"up_to_10_days"`
+	Over10Days   int       `db:"over_10_days"`
+	Total        int       `db:"total"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func mapBacklogSnapshotToBacklogSnapshotDTO(snapshot domain.BacklogSnapshot) BacklogSnapshotDTO {
+	return BacklogSnapshotDTO{
+		SnapshotID:   snapshot.SnapshotID,
+		SnapshotDate: snapshot.SnapshotDate,
+		QueueID:      snapshot.QueueID,
+		OwnerID:      snapshot.OwnerID,
+		Status:       string(snapshot.Status),
+		UpTo2Days:    snapshot.UpTo2Days,
+		UpTo5Days:    snapshot.UpTo5Days,
+		UpTo10Days:   snapshot.UpTo10Days,
+		Over10Days:   snapshot.Over10Days,
+		Total:        snapshot.Total,
+		CreatedAt:    snapshot.CreatedAt,
+	}
+}
+
+func mapBacklogSnapshotsToBacklogSnapshotDTOs(snapshots []domain.BacklogSnapshot) []BacklogSnapshotDTO {
+	snapshotDTOs := make([]BacklogSnapshotDTO, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotDTOs = append(snapshotDTOs, mapBacklogSnapshotToBacklogSnapshotDTO(snapshot))
+	}
+
+	return snapshotDTOs
+}
+
+func mapBacklogSnapshotDTOToBacklogSnapshot(snapshotDTO BacklogSnapshotDTO) domain.BacklogSnapshot {
+	return domain.BacklogSnapshot{
+		SnapshotID:   snapshotDTO.SnapshotID,
+		SnapshotDate: snapshotDTO.SnapshotDate,
+		QueueID:      snapshotDTO.QueueID,
+		OwnerID:      snapshotDTO.OwnerID,
+		Status:       domain.CaseStatus(snapshotDTO.Status),
+		UpTo2Days:    snapshotDTO.UpTo2Days,
+		UpTo5Days:    snapshotDTO.UpTo5Days,
+		UpTo10Days:   snapshotDTO.UpTo10Days,
+		Over10Days:   snapshotDTO.Over10Days,
+		Total:        snapshotDTO.Total,
+		CreatedAt:    snapshotDTO.CreatedAt,
+	}
+}
+
+func mapBacklogSnapshotDTOsToBacklogSnapshots(snapshotDTOs []BacklogSnapshotDTO) []domain.BacklogSnapshot {
+	snapshots := make([]domain.BacklogSnapshot, 0, len(snapshotDTOs))
+	for _, snapshotDTO := range snapshotDTOs {
+		snapshots = append(snapshots, mapBacklogSnapshotDTOToBacklogSnapshot(snapshotDTO))
+	}
+
+	return snapshots
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// a day is snapshotted once, a second snapshot of the same day keeps the first
+var backlogSnapshotBulkInsert = bulkInsert{
+	table: "backlog_snapshots",
+	columns: []string{
+		"snapshot_id", "snapshot_date", "queue_id", "owner_id", "status",
+		"up_to_2_days", "up_to_5_days", "up_to_10_days", "over_10_days", "total", "created_at",
+	},
+	merge: "ON CONFLICT DO NOTHING",
+}
+
+type backlogSnapshotRepository struct {
+	client *sqlx.DB
+}
+
+func NewBacklogSnapshotRepository(client *sqlx.DB) domain.BacklogSnapshotRepository {
+	return &backlogSnapshotRepository{
+		client: client,
+	}
+}
+
+func (r *backlogSnapshotRepository) CreateBatch(ctx context.Context, snapshots []domain.BacklogSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	_, err := insertBatch(ctx, r.client, backlogSnapshotBulkInsert, mapBacklogSnapshotsToBacklogSnapshotDTOs(snapshots))
+	return err
+}
+
+func (r *backlogSnapshotRepository) HasDay(ctx context.Context, day time.Time) (bool, error) {
+	var exists bool
+	err := executor(ctx, r.client).GetContext(
+		ctx,
+		&exists,
+		"SELECT EXISTS (SELECT 1 FROM backlog_snapshots WHERE snapshot_date = $1::DATE)",
+		day.Format(time.DateOnly),
+	)
+
+	return exists, err
+}
+
+func (r *backlogSnapshotRepository) Search(ctx context.Context, filters domain.BacklogSnapshotFilters) ([]domain.BacklogSnapshot, error) {
+	whereQuery := []string{"1=1"}
+	whereArgs := make([]any, 0)
+
+	whereQuery, whereArgs = prepareInQuery(filters.QueueID, whereQuery, whereArgs, "queue_id")
+	whereQuery, whereArgs = prepareInQuery(filters.OwnerID, whereQuery, whereArgs, "owner_id")
+
+	if filters.StartDate != nil {
+		whereQuery, whereArgs = prepareLesserEqualQuery(filters.StartDate.Format(time.DateOnly), whereQuery, whereArgs, "snapshot_date")
+	}
+
+	if filters.EndDate != nil {
+		whereQuery, whereArgs = prepareGreaterEqualQuery(filters.EndDate.Format(time.DateOnly), whereQuery, whereArgs, "snapshot_date")
+	}
+
+	query := fmt.Sprintf(
+		"SELECT * FROM backlog_snapshots WHERE %s ORDER BY snapshot_date, queue_id, owner_id, status",
+		strings.Join(whereQuery, " AND "),
+	)
+
+	var snapshotDTOs []BacklogSnapshotDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &snapshotDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapBacklogSnapshotDTOsToBacklogSnapshots(snapshotDTOs), nil
+}
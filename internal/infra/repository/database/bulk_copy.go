@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/lib/pq"
+)
+
+// copyThreshold is the batch size from which COPY beats chunked INSERTs. Below
+// it the staging table costs more than it saves.
+const copyThreshold = 500
+
+// bulkInsert describes how a batch of DTOs is written to a table, either with
+// chunked named INSERTs or with COPY.
+type bulkInsert struct {
+	table   string
+	columns []string
+	// merge is appended to the INSERT, e.g. "ON CONFLICT DO NOTHING"
+	merge string
+}
+
+// insertQuery is the named INSERT used for batches under copyThreshold.
+func (b bulkInsert) insertQuery() string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (:%s) %s", b.table, strings.Join(b.columns, ", "), strings.Join(b.columns, ", :"), b.merge)
+}
+
+// insertBatch writes the DTOs with COPY from copyThreshold rows on and with
+// chunked INSERTs below it, returning the keys of the rows actually inserted.
+func insertBatch[T any](ctx context.Context, db *sqlx.DB, spec bulkInsert, dtos []T) ([]string, error) {
+	if len(dtos) >= copyThreshold {
+		return copyInsert(ctx, db, spec, dtos)
+	}
+
+	return chunkInsert(ctx, db, spec, dtos)
+}
+
+// chunkInsert runs a named INSERT per chunk of 100 DTOs, all of them in the
+// same transaction.
+func chunkInsert[T any](ctx context.Context, db *sqlx.DB, spec bulkInsert, dtos []T) ([]string, error) {
+	query := spec.insertQuery() + " RETURNING " + spec.columns[0]
+
+	insertedIDs := make([]string, 0, len(dtos))
+	err := NewTransactionManager(db).WithinTransaction(ctx, func(txCtx context.Context) error {
+		for _, chunk := range createChunks(dtos, 100) {
+			chunkQuery, args, err := db.BindNamed(query, chunk)
+			if err != nil {
+				return err
+			}
+
+			var chunkIDs []string
+			if err := executor(txCtx, db).SelectContext(txCtx, &chunkIDs, chunkQuery, args...); err != nil {
+				return err
+			}
+
+			insertedIDs = append(insertedIDs, chunkIDs...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedIDs, nil
+}
+
+// copyInsert streams the DTOs with COPY into a temporary staging table shaped
+// like the target, then merges them with a single INSERT ... SELECT so conflict
+// handling and RETURNING work as with a plain INSERT. It returns the first
+// column of the rows actually merged, which is expected to be the table key.
+// COPY needs a transaction, so it joins the caller's one or opens its own, and
+// the staging table is dropped once merged so several copies into the same
+// table can share it.
+func copyInsert[T any](ctx context.Context, db *sqlx.DB, spec bulkInsert, dtos []T) ([]string, error) {
+	rows, err := copyValues(db.Mapper, dtos, spec.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	staging := spec.table + "_staging"
+	columns := strings.Join(spec.columns, ", ")
+
+	insertedIDs := make([]string, 0, len(dtos))
+	err = NewTransactionManager(db).WithinTransaction(ctx, func(txCtx context.Context) error {
+		exec := executor(txCtx, db)
+
+		_, err := exec.ExecContext(txCtx, fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP", staging, spec.table))
+		if err != nil {
+			return err
+		}
+
+		stmt, err := exec.PrepareContext(txCtx, pq.CopyIn(staging, spec.columns...))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, row := range rows {
+			if _, err := stmt.ExecContext(txCtx, row...); err != nil {
+				return err
+			}
+		}
+
+		// an empty exec flushes the buffered rows and ends the COPY
+		if _, err := stmt.ExecContext(txCtx); err != nil {
+			return err
+		}
+		if err := stmt.Close(); err != nil {
+			return err
+		}
+
+		query := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s %s RETURNING %s", spec.table, columns, columns, staging, spec.merge, spec.columns[0])
+		if err := exec.SelectContext(txCtx, &insertedIDs, query); err != nil {
+			return err
+		}
+
+		_, err = exec.ExecContext(txCtx, "DROP TABLE "+staging)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedIDs, nil
+}
+
+// copyValues lays the DTO fields out in column order, matching columns to
+// their db tags the same way named queries do.
+func copyValues[T any](mapper *reflectx.Mapper, dtos []T, columns []string) ([][]any, error) {
+	traversals := mapper.TraversalsByName(reflect.TypeFor[T](), columns)
+	for i, traversal := range traversals {
+		if len(traversal) == 0 {
+			return nil, fmt.Errorf("column %s has no field in %s", columns[i], reflect.TypeFor[T]().Name())
+		}
+	}
+
+	rows := make([][]any, 0, len(dtos))
+	for _, dto := range dtos {
+		value := reflect.ValueOf(dto)
+
+		row := make([]any, len(columns))
+		for i, traversal := range traversals {
+			row[i] = reflectx.FieldByIndexesReadOnly(value, traversal).Interface()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkInsert_InsertQuery(t *testing.T) {
+	spec := bulkInsert{table: "products", columns: []string{"product_id", "name"}, merge: "ON CONFLICT DO NOTHING"}
+
+	assert.Equal(t, "INSERT INTO products (product_id, name) VALUES (:product_id, :name) ON CONFLICT DO NOTHING", spec.insertQuery())
+}
+
+func TestBulkInserts_Merge(t *testing.T) {
+	// only cases have a natural key, (external_reference, contractor_id), for a
+	// conflict clause to skip rows already imported on
+	assert.Equal(t, "ON CONFLICT DO NOTHING", caseBulkInsert.merge)
+
+	for _, spec := range []bulkInsert{customerBulkInsert, productBulkInsert, partnerBulkInsert} {
+		assert.Empty(t, spec.merge, spec.table)
+	}
+}
+
+func TestCopyValues(t *testing.T) {
+	mapper := reflectx.NewMapperFunc("db", func(name string) string { return name })
+
+	t.Run("lays the fields out in column order", func(t *testing.T) {
+		createdAt := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+		dtos := []ProductDTO{{ProductID: "product-1", Name: "Geladeira", Value: 1299.9, CreatedAt: createdAt}}
+
+		rows, err := copyValues(mapper, dtos, []string{"value", "product_id", "created_at", "name"})
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]any{{1299.9, "product-1", createdAt, "Geladeira"}}, rows)
+	})
+
+	t.Run("fails when a column has no field", func(t *testing.T) {
+		_, err := copyValues(mapper, []ProductDTO{{}}, []string{"product_id", "queue_id"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("every bulk insert column maps to its DTO", func(t *testing.T) {
+		_, err := copyValues(mapper, []CaseDTO{{}}, caseBulkInsert.columns)
+		assert.NoError(t, err)
+
+		_, err = copyValues(mapper, []CustomerDTO{{}}, customerBulkInsert.columns)
+		assert.NoError(t, err)
+
+		_, err = copyValues(mapper, []ProductDTO{{}}, productBulkInsert.columns)
+		assert.NoError(t, err)
+
+		_, err = copyValues(mapper, []PartnerDTO{{}}, partnerBulkInsert.columns)
+		assert.NoError(t, err)
+	})
+}
+
+var errRollbackBenchmark = errors.New("rollback benchmark")
+
+// BenchmarkBulkInsert compares chunked INSERTs with COPY on a real database.
+// It only runs when BENCH_DATABASE_URL points to a migrated database, and
+// every iteration is rolled back.
+func BenchmarkBulkInsert(b *testing.B) {
+	databaseURL := os.Getenv("BENCH_DATABASE_URL")
+	if databaseURL == "" {
+		b.Skip("BENCH_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Open("postgres", databaseURL)
+	require.NoError(b, err)
+	b.Cleanup(func() { _ = db.Close() })
+
+	inserts := map[string]func(ctx context.Context, db *sqlx.DB, spec bulkInsert, dtos []ProductDTO) ([]string, error){
+		"insert": chunkInsert[ProductDTO],
+		"copy":   copyInsert[ProductDTO],
+	}
+
+	for _, size := range []int{100, 1000, 20000} {
+		for _, name := range []string{"insert", "copy"} {
+			b.Run(fmt.Sprintf("%s/%d", name, size), func(b *testing.B) {
+				insert := inserts[name]
+				dtos := mapProductsToProductDTOs(productsForBenchmark(b, size))
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					err := NewTransactionManager(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+						if _, err := insert(ctx, db, productBulkInsert, dtos); err != nil {
+							return err
+						}
+
+						return errRollbackBenchmark
+					})
+					require.ErrorIs(b, err, errRollbackBenchmark)
+				}
+			})
+		}
+	}
+}
+
+func productsForBenchmark(b *testing.B, size int) []domain.Product {
+	b.Helper()
+
+	products := make([]domain.Product, 0, size)
+	for i := 0; i < size; i++ {
+		product, err := domain.NewProduct(fmt.Sprintf("Produto %d", i), "Geladeira frost free", 1299.9, "Brastemp", "BRM44HK", fmt.Sprintf("SN-%d", i), "benchmark")
+		require.NoError(b, err)
+		products = append(products, product)
+	}
+
+	return products
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/lib/pq"
 )
 
 type CaseDTO struct {
@@ -218,3 +219,47 @@ func mapCaseFullDTOsToCasesFull(crmCaseDTOs []CaseFullDTO) []domain.CaseFull {
 
 	return crmCases
 }
+
+// mapCaseStatsRowToGroup reads a row of the grouped case count, which holds
+// the key and label of every dimension in grouping order.
+func mapCaseStatsRowToGroup(groupBy []domain.CaseStatsDimension, values []string, cases int) domain.CaseStatsGroup {
+	group := domain.CaseStatsGroup{
+		Keys:   make(map[domain.CaseStatsDimension]string, len(groupBy)),
+		Labels: make(map[domain.CaseStatsDimension]string),
+		Cases:  cases,
+	}
+
+	for i, dimension := range groupBy {
+		group.Keys[dimension] = values[i*2]
+		if label := values[i*2+1]; label != "" {
+			group.Labels[dimension] = label
+		}
+	}
+
+	return group
+}
+
+type CaseAgeCountDTO struct {
+	QueueID string         `db:"queue_id"`
+	OwnerID string         `db:"owner_id"`
+	Status  string         `db:"status"`
+	Bucket  int            `db:"bucket"`
+	Cases   int            `db:"cases"`
+	CaseIDs pq.StringArray `db:"case_ids"`
+}
+
+func mapCaseAgeCountDTOsToCounts(countDTOs []CaseAgeCountDTO) []domain.CaseAgeCount {
+	counts := make([]domain.CaseAgeCount, 0, len(countDTOs))
+	for _, countDTO := range countDTOs {
+		counts = append(counts, domain.CaseAgeCount{
+			QueueID: countDTO.QueueID,
+			OwnerID: countDTO.OwnerID,
+			Status:  domain.CaseStatus(countDTO.Status),
+			Bucket:  countDTO.Bucket,
+			Cases:   countDTO.Cases,
+			CaseIDs: countDTO.CaseIDs,
+		})
+	}
+
+	return counts
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"testing"
 
+	"github.com/icrxz/crm-api-core/internal/domain"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,3 +37,13 @@ func TestMapCaseFullDTOToCaseFull(t *testing.T) {
 		assert.Empty(t, caseFull.Queue.QueueID)
 	})
 }
+
+func TestMapCaseStatsRowToGroup(t *testing.T) {
+	groupBy := []domain.CaseStatsDimension{domain.CASE_STATS_STATUS, domain.CASE_STATS_QUEUE}
+
+	group := mapCaseStatsRowToGroup(groupBy, []string{"New", "", "queue-1", "SP Mobile"}, 4)
+
+	assert.Equal(t, map[domain.CaseStatsDimension]string{domain.CASE_STATS_STATUS: "New", domain.CASE_STATS_QUEUE: "queue-1"}, group.Keys)
+	assert.Equal(t, map[domain.CaseStatsDimension]string{domain.CASE_STATS_QUEUE: "SP Mobile"}, group.Labels)
+	assert.Equal(t, 4, group.Cases)
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CaseReportDTO struct {
+	CaseReportID    string    `db:"case_report_id"`
+	CaseID          string    `db:"case_id"`
+	CommentID       string    `db:"comment_id"`
+	AttachmentID    string    `db:"attachment_id"`
+	ReportID        string    `db:"report_id"`
+	TemplateVersion int       `db:"template_version"`
+	Format          string    `db:"format"`
+	FileName        string    `db:"file_name"`
+	Key             string    `db:"key"`
+	ContentHash     string    `db:"content_hash"`
+	Size            int       `db:"size"`
+	CreatedBy       string    `db:"created_by"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+func mapCaseReportToCaseReportDTO(caseReport domain.CaseReport) CaseReportDTO {
+	return CaseReportDTO{
+		CaseReportID:    caseReport.CaseReportID,
+		CaseID:          caseReport.CaseID,
+		CommentID:       caseReport.CommentID,
+		AttachmentID:    caseReport.AttachmentID,
+		ReportID:        caseReport.ReportID,
+		TemplateVersion: caseReport.TemplateVersion,
+		Format:          string(caseReport.Format),
+		FileName:        caseReport.FileName,
+		Key:             caseReport.Key,
+		ContentHash:     caseReport.ContentHash,
+		Size:            caseReport.Size,
+		CreatedBy:       caseReport.CreatedBy,
+		CreatedAt:       caseReport.CreatedAt,
+	}
+}
+
+func mapCaseReportDTOToCaseReport(caseReportDTO CaseReportDTO) domain.CaseReport {
+	return domain.CaseReport{
+		CaseReportID:    caseReportDTO.CaseReportID,
+		CaseID:          caseReportDTO.CaseID,
+		CommentID:       caseReportDTO.CommentID,
+		AttachmentID:    caseReportDTO.AttachmentID,
+		ReportID:        caseReportDTO.ReportID,
+		TemplateVersion: caseReportDTO.TemplateVersion,
+		Format:          domain.ReportFormat(caseReportDTO.Format),
+		FileName:        caseReportDTO.FileName,
+		Key:             caseReportDTO.Key,
+		ContentHash:     caseReportDTO.ContentHash,
+		Size:            caseReportDTO.Size,
+		CreatedBy:       caseReportDTO.CreatedBy,
+		CreatedAt:       caseReportDTO.CreatedAt,
+	}
+}
+
+func mapCaseReportDTOsToCaseReports(caseReportDTOs []CaseReportDTO) []domain.CaseReport {
+	caseReports := make([]domain.CaseReport, 0, len(caseReportDTOs))
+	for _, caseReportDTO := range caseReportDTOs {
+		caseReports = append(caseReports, mapCaseReportDTOToCaseReport(caseReportDTO))
+	}
+
+	return caseReports
+}
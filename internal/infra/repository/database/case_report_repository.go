@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type caseReportRepository struct {
+	client *sqlx.DB
+}
+
+func NewCaseReportRepository(client *sqlx.DB) domain.CaseReportRepository {
+	return &caseReportRepository{
+		client: client,
+	}
+}
+
+func (r *caseReportRepository) Create(ctx context.Context, caseReport domain.CaseReport) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO case_reports "+
+			"(case_report_id, case_id, comment_id, attachment_id, report_id, template_version, format, file_name, key, content_hash, size, created_by, created_at) "+
+			"VALUES "+
+			"(:case_report_id, :case_id, :comment_id, :attachment_id, :report_id, :template_version, :format, :file_name, :key, :content_hash, :size, :created_by, :created_at)",
+		mapCaseReportToCaseReportDTO(caseReport),
+	)
+
+	return err
+}
+
+func (r *caseReportRepository) GetByID(ctx context.Context, caseReportID string) (*domain.CaseReport, error) {
+	if caseReportID == "" {
+		return nil, domain.NewValidationError("caseReportID is required", nil)
+	}
+
+	var caseReportDTO CaseReportDTO
+	err := executor(ctx, r.client).GetContext(ctx, &caseReportDTO, "SELECT * FROM case_reports WHERE case_report_id = $1", caseReportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no case report found with this id", map[string]any{"case_report_id": caseReportID})
+		}
+		return nil, err
+	}
+
+	caseReport := mapCaseReportDTOToCaseReport(caseReportDTO)
+
+	return &caseReport, nil
+}
+
+func (r *caseReportRepository) GetByCaseID(ctx context.Context, caseID string) ([]domain.CaseReport, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	var caseReportDTOs []CaseReportDTO
+	err := executor(ctx, r.client).SelectContext(
+		ctx,
+		&caseReportDTOs,
+		"SELECT * FROM case_reports WHERE case_id = $1 ORDER BY created_at DESC",
+		caseID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapCaseReportDTOsToCaseReports(caseReportDTOs), nil
+}
@@ -6,12 +6,35 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"github.com/jmoiron/sqlx"
 )
 
+var caseBulkInsert = bulkInsert{
+	table: "cases",
+	columns: []string{
+		"case_id", "contractor_id", "customer_id", "origin", "type", "subject", "priority",
+		"status", "due_date", "created_by", "created_at", "updated_by", "updated_at",
+		"external_reference", "product_id", "region", "owner_id", "queue_id",
+	},
+	merge: "ON CONFLICT DO NOTHING",
+}
+
+// caseStatsColumns are the columns each case stats dimension groups by, the
+// value kept on the case and the name shown for it.
+var caseStatsColumns = map[domain.CaseStatsDimension]struct{ key, label string }{
+	domain.CASE_STATS_STATUS:         {key: "ca.status", label: "''"},
+	domain.CASE_STATS_REGION:         {key: "ca.region::TEXT", label: "''"},
+	domain.CASE_STATS_QUEUE:          {key: "ca.queue_id", label: "qu.name"},
+	domain.CASE_STATS_OWNER:          {key: "ca.owner_id", label: "TRIM(us.first_name || ' ' || COALESCE(us.last_name, ''))"},
+	domain.CASE_STATS_CONTRACTOR:     {key: "ca.contractor_id", label: "co.company_name"},
+	domain.CASE_STATS_SHIPPING_STATE: {key: "cu.shipping_state", label: "''"},
+}
+
 type caseRepository struct {
 	client *sqlx.DB
 }
@@ -72,6 +95,7 @@ func (r *caseRepository) Search(ctx context.Context, filters domain.CaseFilters)
 	whereQuery, whereArgs = prepareInQuery(filters.Region, whereQuery, whereArgs, "region")
 	whereQuery, whereArgs = prepareInQuery(filters.QueueID, whereQuery, whereArgs, "queue_id")
 	whereQuery, whereArgs = prepareLikeQuery(filters.ExternalReference, whereQuery, whereArgs, "external_reference")
+	whereQuery = prepareFraudFlaggedQuery(filters.FraudFlagged, whereQuery, "case_id")
 
 	if filters.ClosedAtStart != nil {
 		whereQuery, whereArgs = prepareLesserEqualQuery(filters.ClosedAtStart, whereQuery, whereArgs, "closed_at")
@@ -146,73 +170,45 @@ func (r *caseRepository) Update(ctx context.Context, crmCase domain.Case) error
 	return nil
 }
 
+// CreateBatch inserts the cases, silently skipping the ones that conflict with
+// an existing contractor and external reference. Only the IDs of the cases
+// actually inserted are returned.
 func (r *caseRepository) CreateBatch(ctx context.Context, cases []domain.Case) ([]string, error) {
-	chunks := createChunks(cases, 100)
-	tx := r.client.MustBegin()
-
-	insertedIDs := make([]string, 0, len(cases))
-	for _, chunk := range chunks {
-		caseDTOs := mapCasesToCaseDTOs(chunk)
-
-		query := "INSERT INTO cases " +
-			"(case_id, contractor_id, customer_id, origin, type, subject, priority, status, due_date, created_by, created_at, updated_by, updated_at, external_reference, product_id, region, owner_id, queue_id) " +
-			"VALUES " +
-			"(:case_id, :contractor_id, :customer_id, :origin, :type, :subject, :priority, :status, :due_date, :created_by, :created_at, :updated_by, :updated_at, :external_reference, :product_id, :region, :owner_id, :queue_id)" +
-			"ON CONFLICT DO NOTHING"
-
-		_, err := tx.NamedExecContext(
-			ctx,
-			query,
-			caseDTOs,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, crmCase := range caseDTOs {
-			insertedIDs = append(insertedIDs, crmCase.CaseID)
-		}
-	}
+	return insertBatch(ctx, r.client, caseBulkInsert, mapCasesToCaseDTOs(cases))
+}
 
-	err := tx.Commit()
-	if err != nil {
-		return nil, err
+// GetByExternalReferences returns the cases of the given contractors whose
+// external reference exactly matches one of the given references.
+func (r *caseRepository) GetByExternalReferences(ctx context.Context, contractorIDs []string, externalReferences []string) ([]domain.Case, error) {
+	if len(contractorIDs) == 0 || len(externalReferences) == 0 {
+		return []domain.Case{}, nil
 	}
 
-	return insertedIDs, nil
-}
+	foundCases := make([]domain.Case, 0)
+	for _, chunk := range createChunks(externalReferences, 1000) {
+		whereQuery := []string{"1=1"}
+		whereArgs := make([]any, 0)
 
-func (r *caseRepository) SearchFull(ctx context.Context, filters domain.CaseFilters) (domain.PagingResult[domain.CaseFull], error) {
-	whereQuery := []string{"1=1"}
-	whereArgs := make([]any, 0)
-	var limitArgs []any
+		whereQuery, whereArgs = prepareInQuery(contractorIDs, whereQuery, whereArgs, "contractor_id")
+		whereQuery, whereArgs = prepareInQuery(chunk, whereQuery, whereArgs, "external_reference")
 
-	whereQuery, whereArgs = prepareInQuery(filters.CaseID, whereQuery, whereArgs, "ca.case_id")
-	whereQuery, whereArgs = prepareInQuery(filters.ContractorID, whereQuery, whereArgs, "ca.contractor_id")
-	whereQuery, whereArgs = prepareInQuery(filters.OwnerID, whereQuery, whereArgs, "ca.owner_id")
-	whereQuery, whereArgs = prepareInQuery(filters.CustomerID, whereQuery, whereArgs, "ca.customer_id")
-	whereQuery, whereArgs = prepareInQuery(filters.PartnerID, whereQuery, whereArgs, "ca.partner_id")
-	whereQuery, whereArgs = prepareInQuery(filters.Status, whereQuery, whereArgs, "ca.status")
-	whereQuery, whereArgs = prepareInQuery(filters.Region, whereQuery, whereArgs, "ca.region")
-	whereQuery, whereArgs = prepareInQuery(filters.QueueID, whereQuery, whereArgs, "ca.queue_id")
-	whereQuery, whereArgs = prepareLikeQuery(filters.ExternalReference, whereQuery, whereArgs, "ca.external_reference")
-	whereQuery, whereArgs = prepareInQuery(filters.ShippingState, whereQuery, whereArgs, "cu.shipping_state")
+		query := fmt.Sprintf("SELECT * FROM cases WHERE %s", strings.Join(whereQuery, " AND "))
 
-	if filters.StartDate != nil {
-		whereQuery, whereArgs = prepareLesserEqualQuery(filters.StartDate, whereQuery, whereArgs, "ca.created_at")
-	}
+		var caseDTOs []CaseDTO
+		err := executor(ctx, r.client).SelectContext(ctx, &caseDTOs, query, whereArgs...)
+		if err != nil {
+			return nil, err
+		}
 
-	if filters.EndDate != nil {
-		whereQuery, whereArgs = prepareGreaterEqualQuery(filters.EndDate, whereQuery, whereArgs, "ca.created_at")
+		foundCases = append(foundCases, mapCaseDTOsToCases(caseDTOs)...)
 	}
 
-	if filters.ClosedAtStart != nil {
-		whereQuery, whereArgs = prepareLesserEqualQuery(filters.ClosedAtStart, whereQuery, whereArgs, "ca.closed_at")
-	}
+	return foundCases, nil
+}
 
-	if filters.ClosedAtEnd != nil {
-		whereQuery, whereArgs = prepareGreaterEqualQuery(filters.ClosedAtEnd, whereQuery, whereArgs, "ca.closed_at")
-	}
+func (r *caseRepository) SearchFull(ctx context.Context, filters domain.CaseFilters) (domain.PagingResult[domain.CaseFull], error) {
+	whereQuery, whereArgs := caseFullWhere(filters)
+	var limitArgs []any
 
 	limitQuery := fmt.Sprintf("LIMIT $%d OFFSET $%d", len(whereArgs)+1, len(whereArgs)+2)
 	limitArgs = append(limitArgs, whereArgs...)
@@ -516,6 +512,95 @@ func (r *caseRepository) SearchFull(ctx context.Context, filters domain.CaseFilt
 	return result, nil
 }
 
+// CountGrouped counts the cases matching the filters for every combination of
+// values of the dimensions they are grouped by, biggest groups first.
+func (r *caseRepository) CountGrouped(ctx context.Context, filters domain.CaseFilters, groupBy []domain.CaseStatsDimension) ([]domain.CaseStatsGroup, error) {
+	whereQuery, whereArgs := caseFullWhere(filters)
+
+	selectColumns := make([]string, 0, len(groupBy)*2+1)
+	groupColumns := make([]string, 0, len(groupBy)*2)
+	for _, dimension := range groupBy {
+		column, ok := caseStatsColumns[dimension]
+		if !ok {
+			return nil, domain.NewValidationError("invalid case stats group", map[string]any{"group_by": dimension})
+		}
+
+		selectColumns = append(selectColumns, fmt.Sprintf("COALESCE(%s, '')", column.key), fmt.Sprintf("COALESCE(%s, '')", column.label))
+		groupColumns = append(groupColumns, strconv.Itoa(len(selectColumns)-1), strconv.Itoa(len(selectColumns)))
+	}
+	selectColumns = append(selectColumns, "COUNT(*)")
+
+	query := fmt.Sprintf(`SELECT
+		%s
+		FROM cases AS ca
+		LEFT JOIN contractors AS co ON ca.contractor_id = co.contractor_id
+		LEFT JOIN customers AS cu ON ca.customer_id = cu.customer_id
+		LEFT JOIN queues AS qu ON ca.queue_id = qu.queue_id
+		LEFT JOIN users AS us ON ca.owner_id = us.user_id
+		WHERE %s
+		GROUP BY %s
+		ORDER BY %d DESC`, strings.Join(selectColumns, ",\n\t\t"), strings.Join(whereQuery, " AND "), strings.Join(groupColumns, ", "), len(selectColumns))
+
+	rows, err := executor(ctx, r.client).QueryxContext(ctx, query, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]domain.CaseStatsGroup, 0)
+	for rows.Next() {
+		values := make([]string, len(groupBy)*2)
+		var cases int
+
+		dest := make([]any, 0, len(values)+1)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		dest = append(dest, &cases)
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, mapCaseStatsRowToGroup(groupBy, values, cases))
+	}
+
+	return groups, rows.Err()
+}
+
+// CountByAge counts the cases matching the filters per queue, owner, status
+// and the age bucket they fall in on the UTC day of today, listing their ids
+// when asked.
+func (r *caseRepository) CountByAge(ctx context.Context, filters domain.CaseFilters, buckets domain.AgeBuckets, today time.Time, withCaseIDs bool) ([]domain.CaseAgeCount, error) {
+	whereQuery, whereArgs := caseFullWhere(filters)
+	whereArgs = append(whereArgs, today.UTC().Format(time.DateOnly))
+
+	caseIDsColumn := ""
+	if withCaseIDs {
+		caseIDsColumn = ",\n\t\tarray_agg(ca.case_id ORDER BY ca.created_at) AS case_ids"
+	}
+
+	query := fmt.Sprintf(`SELECT
+		COALESCE(ca.queue_id, '') AS queue_id,
+		COALESCE(ca.owner_id, '') AS owner_id,
+		ca.status,
+		age_bucket.bucket,
+		COUNT(*) AS cases%s
+		FROM cases AS ca
+		LEFT JOIN customers AS cu ON ca.customer_id = cu.customer_id
+		%s
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 2, 3, 4`, caseIDsColumn, caseAgeBucketJoin(buckets, fmt.Sprintf("$%d::DATE", len(whereArgs))), strings.Join(whereQuery, " AND "))
+
+	var countDTOs []CaseAgeCountDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &countDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapCaseAgeCountDTOsToCounts(countDTOs), nil
+}
+
 func (r *caseRepository) getCaseTransactions(ctx context.Context, caseIDs []string) ([]TransactionDTO, error) {
 	if len(caseIDs) == 0 {
 		return nil, nil
@@ -536,3 +621,76 @@ func (r *caseRepository) getCaseTransactions(ctx context.Context, caseIDs []stri
 
 	return transactions, nil
 }
+
+// caseFullWhere filters the cases joined to their customers, as SearchFull and
+// CountGrouped read them.
+func caseFullWhere(filters domain.CaseFilters) ([]string, []any) {
+	whereQuery := []string{"1=1"}
+	whereArgs := make([]any, 0)
+
+	whereQuery, whereArgs = prepareInQuery(filters.CaseID, whereQuery, whereArgs, "ca.case_id")
+	whereQuery, whereArgs = prepareInQuery(filters.ContractorID, whereQuery, whereArgs, "ca.contractor_id")
+	whereQuery, whereArgs = prepareInQuery(filters.OwnerID, whereQuery, whereArgs, "ca.owner_id")
+	whereQuery, whereArgs = prepareInQuery(filters.CustomerID, whereQuery, whereArgs, "ca.customer_id")
+	whereQuery, whereArgs = prepareInQuery(filters.PartnerID, whereQuery, whereArgs, "ca.partner_id")
+	whereQuery, whereArgs = prepareInQuery(filters.Status, whereQuery, whereArgs, "ca.status")
+	whereQuery, whereArgs = prepareInQuery(filters.Region, whereQuery, whereArgs, "ca.region")
+	whereQuery, whereArgs = prepareInQuery(filters.QueueID, whereQuery, whereArgs, "ca.queue_id")
+	whereQuery, whereArgs = prepareLikeQuery(filters.ExternalReference, whereQuery, whereArgs, "ca.external_reference")
+	whereQuery, whereArgs = prepareInQuery(filters.ShippingState, whereQuery, whereArgs, "cu.shipping_state")
+	whereQuery = prepareFraudFlaggedQuery(filters.FraudFlagged, whereQuery, "ca.case_id")
+
+	if filters.StartDate != nil {
+		whereQuery, whereArgs = prepareLesserEqualQuery(filters.StartDate, whereQuery, whereArgs, "ca.created_at")
+	}
+
+	if filters.EndDate != nil {
+		whereQuery, whereArgs = prepareGreaterEqualQuery(filters.EndDate, whereQuery, whereArgs, "ca.created_at")
+	}
+
+	if filters.ClosedAtStart != nil {
+		whereQuery, whereArgs = prepareLesserEqualQuery(filters.ClosedAtStart, whereQuery, whereArgs, "ca.closed_at")
+	}
+
+	if filters.ClosedAtEnd != nil {
+		whereQuery, whereArgs = prepareGreaterEqualQuery(filters.ClosedAtEnd, whereQuery, whereArgs, "ca.closed_at")
+	}
+
+	return whereQuery, whereArgs
+}
+
+// caseAgeBucketJoin joins every case to the position of the age bucket it
+// falls in on today, a SQL date, as age_bucket.bucket. Business day ages count
+// the weekdays after the day the case was created up to today.
+func caseAgeBucketJoin(buckets domain.AgeBuckets, today string) string {
+	ageDays := fmt.Sprintf("%s - ca.created_at::DATE", today)
+	if buckets.BusinessDays {
+		ageDays = fmt.Sprintf(
+			"(SELECT COUNT(*) FROM generate_series(ca.created_at::DATE + 1, %s, INTERVAL '1 day') AS day WHERE EXTRACT(ISODOW FROM day) < 6)",
+			today,
+		)
+	}
+
+	bucketCases := make([]string, 0, len(buckets.Bounds))
+	for i, bound := range buckets.Bounds {
+		bucketCases = append(bucketCases, fmt.Sprintf("WHEN age.days <= %d THEN %d", bound, i))
+	}
+
+	return fmt.Sprintf(
+		"CROSS JOIN LATERAL (SELECT %s AS days) AS age CROSS JOIN LATERAL (SELECT CASE %s ELSE %d END AS bucket) AS age_bucket",
+		ageDays, strings.Join(bucketCases, " "), len(buckets.Bounds),
+	)
+}
+
+func prepareFraudFlaggedQuery(flagged *bool, query []string, key string) []string {
+	if flagged == nil {
+		return query
+	}
+
+	operator := "IN"
+	if !*flagged {
+		operator = "NOT IN"
+	}
+
+	return append(query, fmt.Sprintf("%s %s (SELECT case_id FROM case_fraud_assessments WHERE flagged)", key, operator))
+}
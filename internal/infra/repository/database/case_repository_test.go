@@ -0,0 +1,25 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseAgeBucketJoin(t *testing.T) {
+	t.Run("buckets calendar days", func(t *testing.T) {
+		join := caseAgeBucketJoin(domain.AgeBuckets{Bounds: []int{7, 15}}, "CURRENT_DATE")
+
+		assert.Equal(t, "CROSS JOIN LATERAL (SELECT CURRENT_DATE - ca.created_at::DATE AS days) AS age "+
+			"CROSS JOIN LATERAL (SELECT CASE WHEN age.days <= 7 THEN 0 WHEN age.days <= 15 THEN 1 ELSE 2 END AS bucket) AS age_bucket", join)
+	})
+
+	t.Run("counts the weekdays up to today for business days", func(t *testing.T) {
+		join := caseAgeBucketJoin(domain.BacklogAgingBuckets, "$3::DATE")
+
+		assert.Contains(t, join, "generate_series(ca.created_at::DATE + 1, $3::DATE, INTERVAL '1 day')")
+		assert.Contains(t, join, "EXTRACT(ISODOW FROM day) < 6")
+		assert.Contains(t, join, "WHEN age.days <= 10 THEN 2 ELSE 3 END")
+	})
+}
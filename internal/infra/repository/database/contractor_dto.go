@@ -1,40 +1,81 @@
 package database
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
 type ContractorDTO struct {
-	ContractorID  string    `db:"contractor_id"`
-	CompanyName   string    `db:"company_name"`
-	LegalName     string    `db:"legal_name"`
-	Document      string    `db:"document"`
-	DocumentType  string    `db:"document_type"`
-	BusinessPhone string    `db:"business_phone"`
-	BusinessEmail string    `db:"business_email"`
-	CreatedBy     string    `db:"created_by"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedBy     string    `db:"updated_by"`
-	UpdatedAt     time.Time `db:"updated_at"`
-	Active        bool      `db:"active"`
+	ContractorID   string            `db:"contractor_id"`
+	CompanyName    string            `db:"company_name"`
+	LegalName      string            `db:"legal_name"`
+	Document       string            `db:"document"`
+	DocumentType   string            `db:"document_type"`
+	BusinessPhone  string            `db:"business_phone"`
+	BusinessEmail  string            `db:"business_email"`
+	TemplateFields TemplateFieldsDTO `db:"template_fields"`
+	CreatedBy      string            `db:"created_by"`
+	CreatedAt      time.Time         `db:"created_at"`
+	UpdatedBy      string            `db:"updated_by"`
+	UpdatedAt      time.Time         `db:"updated_at"`
+	Active         bool              `db:"active"`
+}
+
+// TemplateFieldsDTO adapts the contractor template fields to a Postgres jsonb
+// column.
+type TemplateFieldsDTO map[string]map[string]string
+
+func (f TemplateFieldsDTO) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]map[string]string(f))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (f *TemplateFieldsDTO) Scan(src any) error {
+	if src == nil {
+		*f = TemplateFieldsDTO{}
+		return nil
+	}
+
+	var data []byte
+	switch value := src.(type) {
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("unsupported type for TemplateFieldsDTO: %T", src)
+	}
+
+	return json.Unmarshal(data, f)
 }
 
 func mapContractorToContractorDTO(contractor domain.Contractor) ContractorDTO {
 	return ContractorDTO{
-		ContractorID:  contractor.ContractorID,
-		CompanyName:   contractor.CompanyName,
-		LegalName:     contractor.LegalName,
-		Document:      contractor.Document,
-		DocumentType:  string(contractor.DocumentType),
-		BusinessPhone: contractor.BusinessContact.PhoneNumber,
-		BusinessEmail: contractor.BusinessContact.Email,
-		CreatedBy:     contractor.CreatedBy,
-		CreatedAt:     contractor.CreatedAt,
-		UpdatedBy:     contractor.UpdatedBy,
-		UpdatedAt:     contractor.UpdatedAt,
-		Active:        contractor.Active,
+		ContractorID:   contractor.ContractorID,
+		CompanyName:    contractor.CompanyName,
+		LegalName:      contractor.LegalName,
+		Document:       contractor.Document,
+		DocumentType:   string(contractor.DocumentType),
+		BusinessPhone:  contractor.BusinessContact.PhoneNumber,
+		BusinessEmail:  contractor.BusinessContact.Email,
+		TemplateFields: TemplateFieldsDTO(contractor.Template.Fields),
+		CreatedBy:      contractor.CreatedBy,
+		CreatedAt:      contractor.CreatedAt,
+		UpdatedBy:      contractor.UpdatedBy,
+		UpdatedAt:      contractor.UpdatedAt,
+		Active:         contractor.Active,
 	}
 }
 
@@ -49,6 +90,9 @@ func mapContractorDTOToContractor(contractorDTO ContractorDTO) domain.Contractor
 			PhoneNumber: contractorDTO.BusinessPhone,
 			Email:       contractorDTO.BusinessEmail,
 		},
+		Template: domain.ContractorPlatformTemplate{
+			Fields: map[string]map[string]string(contractorDTO.TemplateFields),
+		},
 		CreatedBy: contractorDTO.CreatedBy,
 		CreatedAt: contractorDTO.CreatedAt,
 		UpdatedBy: contractorDTO.UpdatedBy,
@@ -68,6 +68,15 @@ type CustomerOptionalDTO struct {
 	Active          *bool      `db:"active"`
 }
 
+func mapCustomersToCustomerDTOs(customers []domain.Customer) []CustomerDTO {
+	customerDTOs := make([]CustomerDTO, 0, len(customers))
+	for _, customer := range customers {
+		customerDTOs = append(customerDTOs, mapCustomerToCustomerDTO(customer))
+	}
+
+	return customerDTOs
+}
+
 func mapCustomerToCustomerDTO(customer domain.Customer) CustomerDTO {
 	return CustomerDTO{
 		CustomerID:      customer.CustomerID,
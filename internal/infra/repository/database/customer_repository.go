@@ -11,6 +11,18 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+var customerBulkInsert = bulkInsert{
+	table: "customers",
+	columns: []string{
+		"customer_id", "first_name", "last_name", "company_name", "legal_name",
+		"customer_type", "document", "document_type", "shipping_address", "shipping_city",
+		"shipping_state", "shipping_zip_code", "shipping_country", "billing_address",
+		"billing_city", "billing_state", "billing_zip_code", "billing_country",
+		"personal_phone", "business_phone", "personal_email", "business_email", "created_at",
+		"created_by", "updated_at", "updated_by", "active",
+	},
+}
+
 type customerRepository struct {
 	client *sqlx.DB
 }
@@ -24,7 +36,7 @@ func NewCustomerRepository(client *sqlx.DB) domain.CustomerRepository {
 func (db *customerRepository) Create(ctx context.Context, customer domain.Customer) (string, error) {
 	customerDTO := mapCustomerToCustomerDTO(customer)
 
-	_, err := db.client.NamedExecContext(
+	_, err := executor(ctx, db.client).NamedExecContext(
 		ctx,
 		"INSERT INTO customers "+
 			"(customer_id, first_name, last_name, company_name, legal_name, customer_type, document, document_type, shipping_address, shipping_city, shipping_state, shipping_zip_code, shipping_country, billing_address, billing_city, billing_state, billing_zip_code, billing_country, personal_phone, business_phone, personal_email, business_email, created_at, created_by, updated_at, updated_by, active) "+
@@ -39,9 +51,15 @@ func (db *customerRepository) Create(ctx context.Context, customer domain.Custom
 	return customer.CustomerID, nil
 }
 
+// CreateBatch inserts the customers, all or none of them, joining the
+// caller's transaction when there is one.
+func (db *customerRepository) CreateBatch(ctx context.Context, customers []domain.Customer) ([]string, error) {
+	return insertBatch(ctx, db.client, customerBulkInsert, mapCustomersToCustomerDTOs(customers))
+}
+
 func (db *customerRepository) GetByID(ctx context.Context, customerID string) (*domain.Customer, error) {
 	var customerDTO CustomerDTO
-	err := db.client.GetContext(ctx, &customerDTO, "SELECT * FROM customers WHERE customer_id=$1", customerID)
+	err := executor(ctx, db.client).GetContext(ctx, &customerDTO, "SELECT * FROM customers WHERE customer_id=$1", customerID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.NewNotFoundError("no customer found with this id", map[string]any{"customer_id": customerID})
@@ -67,17 +85,17 @@ func (db *customerRepository) Search(ctx context.Context, filters domain.Custome
 	limitArgs = append(limitArgs, whereArgs...)
 	limitArgs = append(limitArgs, filters.Limit, filters.Offset)
 
-	query := fmt.Sprintf("SELECT * FROM customers WHERE %s %s", strings.Join(whereQuery, " AND "), limitQuery)
+	query := fmt.Sprintf("SELECT * FROM customers WHERE %s ORDER BY created_at, customer_id %s", strings.Join(whereQuery, " AND "), limitQuery)
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM customers WHERE %s", strings.Join(whereQuery, " AND "))
 
 	var foundCustomers []CustomerDTO
-	err := db.client.SelectContext(ctx, &foundCustomers, query, limitArgs...)
+	err := executor(ctx, db.client).SelectContext(ctx, &foundCustomers, query, limitArgs...)
 	if err != nil {
 		return domain.PagingResult[domain.Customer]{}, err
 	}
 
 	var countResult int
-	err = db.client.GetContext(ctx, &countResult, countQuery, whereArgs...)
+	err = executor(ctx, db.client).GetContext(ctx, &countResult, countQuery, whereArgs...)
 	if err != nil {
 		return domain.PagingResult[domain.Customer]{}, err
 	}
@@ -99,7 +117,7 @@ func (db *customerRepository) Search(ctx context.Context, filters domain.Custome
 func (db *customerRepository) Update(ctx context.Context, customer domain.Customer) error {
 	customerDTO := mapCustomerToCustomerDTO(customer)
 
-	_, err := db.client.NamedExecContext(
+	_, err := executor(ctx, db.client).NamedExecContext(
 		ctx,
 		"UPDATE customers SET "+
 			"first_name = :first_name, "+
@@ -141,10 +159,41 @@ func (db *customerRepository) Delete(ctx context.Context, customerID string) err
 		return domain.NewValidationError("customer id is required", map[string]any{"customer_id": customerID})
 	}
 
-	_, err := db.client.ExecContext(ctx, "UPDATE customers SET active = false WHERE customer_id = $1", customerID)
+	_, err := executor(ctx, db.client).ExecContext(ctx, "UPDATE customers SET active = false WHERE customer_id = $1", customerID)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// GetByDocuments returns the customers whose document matches one of the
+// given ones. Documents are compared by their digits only, since case imports
+// store them as written in the spreadsheet.
+func (db *customerRepository) GetByDocuments(ctx context.Context, documents []string) ([]domain.Customer, error) {
+	if len(documents) == 0 {
+		return []domain.Customer{}, nil
+	}
+
+	foundCustomers := make([]domain.Customer, 0)
+	for _, chunk := range createChunks(documents, 1000) {
+		digits := make([]string, 0, len(chunk))
+		for _, document := range chunk {
+			digits = append(digits, domain.DocumentDigits(document))
+		}
+
+		whereQuery, whereArgs := prepareInQuery(digits, []string{"1=1"}, make([]any, 0), "regexp_replace(document, '[^0-9]', '', 'g')")
+
+		query := fmt.Sprintf("SELECT * FROM customers WHERE %s", strings.Join(whereQuery, " AND "))
+
+		var customerDTOs []CustomerDTO
+		err := executor(ctx, db.client).SelectContext(ctx, &customerDTOs, query, whereArgs...)
+		if err != nil {
+			return nil, err
+		}
+
+		foundCustomers = append(foundCustomers, mapCustomerDTOsToCustomers(customerDTOs)...)
+	}
+
+	return foundCustomers, nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/ptr"
+	"github.com/lib/pq"
+)
+
+type FraudAssessmentDTO struct {
+	CaseID       string         `db:"case_id"`
+	Score        int            `db:"score"`
+	Reasons      pq.StringArray `db:"reasons"`
+	Flagged      bool           `db:"flagged"`
+	ReviewStatus string         `db:"review_status"`
+	ReviewNotes  *string        `db:"review_notes"`
+	ReviewedBy   *string        `db:"reviewed_by"`
+	ReviewedAt   *time.Time     `db:"reviewed_at"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
+
+func mapFraudAssessmentToDTO(assessment domain.FraudAssessment) FraudAssessmentDTO {
+	reasons := make(pq.StringArray, 0, len(assessment.Reasons))
+	for _, reason := range assessment.Reasons {
+		reasons = append(reasons, string(reason))
+	}
+
+	return FraudAssessmentDTO{
+		CaseID:       assessment.CaseID,
+		Score:        assessment.Score,
+		Reasons:      reasons,
+		Flagged:      assessment.Flagged,
+		ReviewStatus: string(assessment.ReviewStatus),
+		ReviewNotes:  &assessment.ReviewNotes,
+		ReviewedBy:   &assessment.ReviewedBy,
+		ReviewedAt:   assessment.ReviewedAt,
+		CreatedAt:    assessment.CreatedAt,
+		UpdatedAt:    assessment.UpdatedAt,
+	}
+}
+
+func mapFraudAssessmentDTOToAssessment(assessmentDTO FraudAssessmentDTO) domain.FraudAssessment {
+	reasons := make([]domain.FraudReason, 0, len(assessmentDTO.Reasons))
+	for _, reason := range assessmentDTO.Reasons {
+		reasons = append(reasons, domain.FraudReason(reason))
+	}
+
+	return domain.FraudAssessment{
+		CaseID:       assessmentDTO.CaseID,
+		Score:        assessmentDTO.Score,
+		Reasons:      reasons,
+		Flagged:      assessmentDTO.Flagged,
+		ReviewStatus: domain.FraudReviewStatus(assessmentDTO.ReviewStatus),
+		ReviewNotes:  ptr.ToString(assessmentDTO.ReviewNotes),
+		ReviewedBy:   ptr.ToString(assessmentDTO.ReviewedBy),
+		ReviewedAt:   assessmentDTO.ReviewedAt,
+		CreatedAt:    assessmentDTO.CreatedAt,
+		UpdatedAt:    assessmentDTO.UpdatedAt,
+	}
+}
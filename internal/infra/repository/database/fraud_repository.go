@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type fraudRepository struct {
+	client *sqlx.DB
+}
+
+func NewFraudRepository(client *sqlx.DB) domain.FraudRepository {
+	return &fraudRepository{
+		client: client,
+	}
+}
+
+func (r *fraudRepository) Save(ctx context.Context, assessment domain.FraudAssessment) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO case_fraud_assessments "+
+			"(case_id, score, reasons, flagged, review_status, review_notes, reviewed_by, reviewed_at, created_at, updated_at) "+
+			"VALUES "+
+			"(:case_id, :score, :reasons, :flagged, :review_status, :review_notes, :reviewed_by, :reviewed_at, :created_at, :updated_at) "+
+			"ON CONFLICT (case_id) DO UPDATE SET "+
+			"score = EXCLUDED.score, "+
+			"reasons = EXCLUDED.reasons, "+
+			"flagged = EXCLUDED.flagged, "+
+			"review_status = EXCLUDED.review_status, "+
+			"review_notes = EXCLUDED.review_notes, "+
+			"reviewed_by = EXCLUDED.reviewed_by, "+
+			"reviewed_at = EXCLUDED.reviewed_at, "+
+			"updated_at = EXCLUDED.updated_at",
+		mapFraudAssessmentToDTO(assessment),
+	)
+
+	return err
+}
+
+func (r *fraudRepository) GetByCaseID(ctx context.Context, caseID string) (*domain.FraudAssessment, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	var assessmentDTO FraudAssessmentDTO
+	err := executor(ctx, r.client).GetContext(ctx, &assessmentDTO, "SELECT * FROM case_fraud_assessments WHERE case_id = $1", caseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no fraud assessment found for this case", map[string]any{"case_id": caseID})
+		}
+		return nil, err
+	}
+
+	assessment := mapFraudAssessmentDTOToAssessment(assessmentDTO)
+
+	return &assessment, nil
+}
+
+func (r *fraudRepository) CountRecentCasesByCustomer(ctx context.Context, customerID string, since time.Time, excludeCaseID string) (int, error) {
+	if customerID == "" {
+		return 0, nil
+	}
+
+	var count int
+	err := executor(ctx, r.client).GetContext(
+		ctx,
+		&count,
+		"SELECT COUNT(*) FROM cases WHERE customer_id = $1 AND created_at >= $2 AND case_id <> $3",
+		customerID,
+		since,
+		excludeCaseID,
+	)
+
+	return count, err
+}
+
+func (r *fraudRepository) GetContractorsBySerialNumber(ctx context.Context, serialNumber string, excludeCaseID string) ([]string, error) {
+	if strings.TrimSpace(serialNumber) == "" {
+		return nil, nil
+	}
+
+	var contractorIDs []string
+	err := executor(ctx, r.client).SelectContext(
+		ctx,
+		&contractorIDs,
+		"SELECT ca.contractor_id FROM cases ca "+
+			"INNER JOIN products pr ON pr.product_id = ca.product_id "+
+			"WHERE pr.serial_number = $1 AND ca.case_id <> $2",
+		serialNumber,
+		excludeCaseID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return contractorIDs, nil
+}
+
+func (r *fraudRepository) CountCustomersSharingContact(ctx context.Context, customer domain.Customer) (int, error) {
+	whereQuery := make([]string, 0)
+	whereArgs := []any{customer.CustomerID}
+
+	phones := make([]string, 0, 2)
+	for _, phone := range []string{customer.PersonalContact.PhoneNumber, customer.BusinessContact.PhoneNumber} {
+		if strings.TrimSpace(phone) != "" {
+			phones = append(phones, phone)
+		}
+	}
+
+	for _, phone := range phones {
+		whereArgs = append(whereArgs, phone)
+		whereQuery = append(whereQuery, fmt.Sprintf("personal_phone = $%d OR business_phone = $%d", len(whereArgs), len(whereArgs)))
+	}
+
+	if strings.TrimSpace(customer.ShippingAddress.Address) != "" && strings.TrimSpace(customer.ShippingAddress.ZipCode) != "" {
+		whereArgs = append(whereArgs, customer.ShippingAddress.Address, customer.ShippingAddress.ZipCode)
+		whereQuery = append(whereQuery, fmt.Sprintf("(shipping_address = $%d AND shipping_zip_code = $%d)", len(whereArgs)-1, len(whereArgs)))
+	}
+
+	if len(whereQuery) == 0 {
+		return 0, nil
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(*) FROM customers WHERE customer_id <> $1 AND (%s)", strings.Join(whereQuery, " OR "))
+
+	var count int
+	err := executor(ctx, r.client).GetContext(ctx, &count, query, whereArgs...)
+
+	return count, err
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/lib/pq"
+)
+
+type ImportJobDTO struct {
+	JobID         string             `db:"job_id"`
+	Type          string             `db:"type"`
+	Status        string             `db:"status"`
+	FileName      string             `db:"file_name"`
+	Params        JSONMap            `db:"params"`
+	TotalRows     int                `db:"total_rows"`
+	ProcessedRows int                `db:"processed_rows"`
+	CreatedIDs    pq.StringArray     `db:"created_ids"`
+	UpdatedIDs    pq.StringArray     `db:"updated_ids"`
+	SkippedIDs    pq.StringArray     `db:"skipped_ids"`
+	RowErrors     ImportRowErrorsDTO `db:"row_errors"`
+	Errors        pq.StringArray     `db:"errors"`
+	Attempts      int                `db:"attempts"`
+	LeaseID       string             `db:"lease_id"`
+	HeartbeatAt   *time.Time         `db:"heartbeat_at"`
+	CreatedBy     string             `db:"created_by"`
+	CreatedAt     time.Time          `db:"created_at"`
+	UpdatedAt     time.Time          `db:"updated_at"`
+	StartedAt     *time.Time         `db:"started_at"`
+	FinishedAt    *time.Time         `db:"finished_at"`
+}
+
+type ImportRowErrorDTO struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportRowErrorsDTO adapts the row errors of a job to a Postgres jsonb column.
+type ImportRowErrorsDTO []ImportRowErrorDTO
+
+func (e ImportRowErrorsDTO) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal([]ImportRowErrorDTO(e))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (e *ImportRowErrorsDTO) Scan(src any) error {
+	if src == nil {
+		*e = ImportRowErrorsDTO{}
+		return nil
+	}
+
+	var data []byte
+	switch value := src.(type) {
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("unsupported type for ImportRowErrorsDTO: %T", src)
+	}
+
+	return json.Unmarshal(data, e)
+}
+
+type ImportFileDTO struct {
+	JobID    string `db:"job_id"`
+	FileName string `db:"file_name"`
+	Content  []byte `db:"content"`
+}
+
+func mapImportJobToDTO(job domain.ImportJob) ImportJobDTO {
+	params := make(JSONMap, len(job.Params))
+	for key, value := range job.Params {
+		params[key] = value
+	}
+
+	return ImportJobDTO{
+		JobID:         job.JobID,
+		Type:          string(job.Type),
+		Status:        string(job.Status),
+		FileName:      job.FileName,
+		Params:        params,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedIDs:    pq.StringArray(job.CreatedIDs),
+		UpdatedIDs:    pq.StringArray(job.UpdatedIDs),
+		SkippedIDs:    pq.StringArray(job.SkippedIDs),
+		RowErrors:     mapImportRowErrorsToDTOs(job.RowErrors),
+		Errors:        pq.StringArray(job.Errors),
+		Attempts:      job.Attempts,
+		LeaseID:       job.LeaseID,
+		HeartbeatAt:   job.HeartbeatAt,
+		CreatedBy:     job.CreatedBy,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+}
+
+func mapImportJobDTOToImportJob(jobDTO ImportJobDTO) domain.ImportJob {
+	params := make(map[string]string, len(jobDTO.Params))
+	for key, value := range jobDTO.Params {
+		if stringValue, ok := value.(string); ok {
+			params[key] = stringValue
+		}
+	}
+
+	return domain.ImportJob{
+		JobID:         jobDTO.JobID,
+		Type:          domain.ImportJobType(jobDTO.Type),
+		Status:        domain.ImportJobStatus(jobDTO.Status),
+		FileName:      jobDTO.FileName,
+		Params:        params,
+		TotalRows:     jobDTO.TotalRows,
+		ProcessedRows: jobDTO.ProcessedRows,
+		CreatedIDs:    []string(jobDTO.CreatedIDs),
+		UpdatedIDs:    []string(jobDTO.UpdatedIDs),
+		SkippedIDs:    []string(jobDTO.SkippedIDs),
+		RowErrors:     mapImportRowErrorDTOsToRowErrors(jobDTO.RowErrors),
+		Errors:        []string(jobDTO.Errors),
+		Attempts:      jobDTO.Attempts,
+		LeaseID:       jobDTO.LeaseID,
+		HeartbeatAt:   jobDTO.HeartbeatAt,
+		CreatedBy:     jobDTO.CreatedBy,
+		CreatedAt:     jobDTO.CreatedAt,
+		UpdatedAt:     jobDTO.UpdatedAt,
+		StartedAt:     jobDTO.StartedAt,
+		FinishedAt:    jobDTO.FinishedAt,
+	}
+}
+
+func mapImportRowErrorsToDTOs(rowErrors []domain.ImportRowError) ImportRowErrorsDTO {
+	rowErrorDTOs := make(ImportRowErrorsDTO, 0, len(rowErrors))
+	for _, rowError := range rowErrors {
+		rowErrorDTOs = append(rowErrorDTOs, ImportRowErrorDTO{
+			Row:     rowError.Row,
+			Column:  rowError.Column,
+			Value:   rowError.Value,
+			Message: rowError.Message,
+		})
+	}
+
+	return rowErrorDTOs
+}
+
+func mapImportRowErrorDTOsToRowErrors(rowErrorDTOs ImportRowErrorsDTO) []domain.ImportRowError {
+	rowErrors := make([]domain.ImportRowError, 0, len(rowErrorDTOs))
+	for _, rowErrorDTO := range rowErrorDTOs {
+		rowErrors = append(rowErrors, domain.ImportRowError{
+			Row:     rowErrorDTO.Row,
+			Column:  rowErrorDTO.Column,
+			Value:   rowErrorDTO.Value,
+			Message: rowErrorDTO.Message,
+		})
+	}
+
+	return rowErrors
+}
+
+func mapImportFileToDTO(file domain.ImportFile) ImportFileDTO {
+	return ImportFileDTO{
+		JobID:    file.JobID,
+		FileName: file.FileName,
+		Content:  file.Content,
+	}
+}
+
+func mapImportFileDTOToImportFile(fileDTO ImportFileDTO) domain.ImportFile {
+	return domain.ImportFile{
+		JobID:    fileDTO.JobID,
+		FileName: fileDTO.FileName,
+		Content:  fileDTO.Content,
+	}
+}
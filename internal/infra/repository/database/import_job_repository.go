@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type importJobRepository struct {
+	client *sqlx.DB
+}
+
+func NewImportJobRepository(client *sqlx.DB) domain.ImportJobRepository {
+	return &importJobRepository{
+		client: client,
+	}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job domain.ImportJob) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO import_jobs "+
+			"(job_id, type, status, file_name, params, total_rows, processed_rows, created_ids, updated_ids, skipped_ids, row_errors, errors, attempts, created_by, created_at, updated_at, started_at, finished_at) "+
+			"VALUES "+
+			"(:job_id, :type, :status, :file_name, :params, :total_rows, :processed_rows, :created_ids, :updated_ids, :skipped_ids, :row_errors, :errors, :attempts, :created_by, :created_at, :updated_at, :started_at, :finished_at)",
+		mapImportJobToDTO(job),
+	)
+
+	return err
+}
+
+func (r *importJobRepository) CreateFile(ctx context.Context, file domain.ImportFile) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO import_files (job_id, file_name, content) VALUES (:job_id, :file_name, :content)",
+		mapImportFileToDTO(file),
+	)
+
+	return err
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, jobID string) (*domain.ImportJob, error) {
+	if jobID == "" {
+		return nil, domain.NewValidationError("jobID is required", nil)
+	}
+
+	var jobDTO ImportJobDTO
+	err := executor(ctx, r.client).GetContext(ctx, &jobDTO, "SELECT * FROM import_jobs WHERE job_id = $1", jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no import job found with this id", map[string]any{"job_id": jobID})
+		}
+		return nil, err
+	}
+
+	job := mapImportJobDTOToImportJob(jobDTO)
+
+	return &job, nil
+}
+
+func (r *importJobRepository) GetFile(ctx context.Context, jobID string) (*domain.ImportFile, error) {
+	if jobID == "" {
+		return nil, domain.NewValidationError("jobID is required", nil)
+	}
+
+	var fileDTO ImportFileDTO
+	err := executor(ctx, r.client).GetContext(ctx, &fileDTO, "SELECT * FROM import_files WHERE job_id = $1", jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no file found for this import job", map[string]any{"job_id": jobID})
+		}
+		return nil, err
+	}
+
+	file := mapImportFileDTOToImportFile(fileDTO)
+
+	return &file, nil
+}
+
+// Update stores the job as long as it is still leased to the worker that
+// claimed it.
+func (r *importJobRepository) Update(ctx context.Context, job domain.ImportJob) error {
+	result, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"UPDATE import_jobs SET "+
+			"status = :status, "+
+			"total_rows = :total_rows, "+
+			"processed_rows = :processed_rows, "+
+			"created_ids = :created_ids, "+
+			"updated_ids = :updated_ids, "+
+			"skipped_ids = :skipped_ids, "+
+			"row_errors = :row_errors, "+
+			"errors = :errors, "+
+			"attempts = :attempts, "+
+			"updated_at = :updated_at, "+
+			"started_at = :started_at, "+
+			"finished_at = :finished_at "+
+			"WHERE job_id = :job_id AND lease_id = :lease_id",
+		mapImportJobToDTO(job),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkImportJobLease(result, job.JobID)
+}
+
+// ClaimNext locks the oldest job waiting to run, or a processing one whose
+// worker has not sent a heartbeat since staleBefore, and leases it to
+// leaseID so concurrent workers skip it.
+func (r *importJobRepository) ClaimNext(ctx context.Context, leaseID string, staleBefore time.Time) (*domain.ImportJob, error) {
+	var jobDTO ImportJobDTO
+	err := executor(ctx, r.client).GetContext(
+		ctx,
+		&jobDTO,
+		"UPDATE import_jobs SET status = $3, updated_at = $1, heartbeat_at = $1, lease_id = $5 "+
+			"WHERE job_id = ("+
+			"SELECT job_id FROM import_jobs "+
+			"WHERE status = $2 OR (status = $3 AND COALESCE(heartbeat_at, updated_at) < $4) "+
+			"ORDER BY created_at "+
+			"LIMIT 1 FOR UPDATE SKIP LOCKED"+
+			") RETURNING *",
+		time.Now().UTC(),
+		domain.IMPORT_JOB_PENDING,
+		domain.IMPORT_JOB_PROCESSING,
+		staleBefore,
+		leaseID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no import job waiting to run", nil)
+		}
+		return nil, err
+	}
+
+	job := mapImportJobDTOToImportJob(jobDTO)
+
+	return &job, nil
+}
+
+// Heartbeat tells other workers the job is still running so it is not
+// reclaimed as stale.
+func (r *importJobRepository) Heartbeat(ctx context.Context, jobID, leaseID string) error {
+	result, err := executor(ctx, r.client).ExecContext(
+		ctx,
+		"UPDATE import_jobs SET heartbeat_at = $1 WHERE job_id = $2 AND lease_id = $3",
+		time.Now().UTC(),
+		jobID,
+		leaseID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkImportJobLease(result, jobID)
+}
+
+func checkImportJobLease(result sql.Result, jobID string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.NewConflictError("import job is no longer leased to this worker", map[string]any{"job_id": jobID})
+	}
+
+	return nil
+}
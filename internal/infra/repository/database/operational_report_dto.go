@@ -0,0 +1,111 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CaseStatusCountDTO struct {
+	ContractorName string `db:"contractor_name"`
+	Region         int    `db:"region"`
+	OwnerName      string `db:"owner_name"`
+	Status         string `db:"status"`
+	Cases          int    `db:"cases"`
+}
+
+type CaseAgingCountDTO struct {
+	ContractorName string `db:"contractor_name"`
+	Region         int    `db:"region"`
+	Status         string `db:"status"`
+	UpTo7Days      int    `db:"up_to_7_days"`
+	UpTo15Days     int    `db:"up_to_15_days"`
+	UpTo30Days     int    `db:"up_to_30_days"`
+	UpTo60Days     int    `db:"up_to_60_days"`
+	Over60Days     int    `db:"over_60_days"`
+}
+
+type ClosedCasesCountDTO struct {
+	Month           time.Time `db:"month"`
+	ContractorName  string    `db:"contractor_name"`
+	Region          int       `db:"region"`
+	OwnerName       string    `db:"owner_name"`
+	Cases           int       `db:"cases"`
+	AverageOpenDays float64   `db:"average_open_days"`
+}
+
+type PartnerPayoutDTO struct {
+	PartnerID    string  `db:"partner_id"`
+	PartnerName  string  `db:"partner_name"`
+	Document     string  `db:"document"`
+	PaymentKey   string  `db:"payment_key"`
+	Cases        int     `db:"cases"`
+	Transactions int     `db:"transactions"`
+	Total        float64 `db:"total"`
+}
+
+func mapCaseStatusCountDTOsToCounts(countDTOs []CaseStatusCountDTO) []domain.CaseStatusCount {
+	counts := make([]domain.CaseStatusCount, 0, len(countDTOs))
+	for _, countDTO := range countDTOs {
+		counts = append(counts, domain.CaseStatusCount{
+			ContractorName: countDTO.ContractorName,
+			Region:         countDTO.Region,
+			OwnerName:      countDTO.OwnerName,
+			Status:         domain.CaseStatus(countDTO.Status),
+			Cases:          countDTO.Cases,
+		})
+	}
+
+	return counts
+}
+
+func mapCaseAgingCountDTOsToCounts(countDTOs []CaseAgingCountDTO) []domain.CaseAgingCount {
+	counts := make([]domain.CaseAgingCount, 0, len(countDTOs))
+	for _, countDTO := range countDTOs {
+		counts = append(counts, domain.CaseAgingCount{
+			ContractorName: countDTO.ContractorName,
+			Region:         countDTO.Region,
+			Status:         domain.CaseStatus(countDTO.Status),
+			UpTo7Days:      countDTO.UpTo7Days,
+			UpTo15Days:     countDTO.UpTo15Days,
+			UpTo30Days:     countDTO.UpTo30Days,
+			UpTo60Days:     countDTO.UpTo60Days,
+			Over60Days:     countDTO.Over60Days,
+		})
+	}
+
+	return counts
+}
+
+func mapClosedCasesCountDTOsToCounts(countDTOs []ClosedCasesCountDTO) []domain.ClosedCasesCount {
+	counts := make([]domain.ClosedCasesCount, 0, len(countDTOs))
+	for _, countDTO := range countDTOs {
+		counts = append(counts, domain.ClosedCasesCount{
+			Month:           countDTO.Month,
+			ContractorName:  countDTO.ContractorName,
+			Region:          countDTO.Region,
+			OwnerName:       countDTO.OwnerName,
+			Cases:           countDTO.Cases,
+			AverageOpenDays: countDTO.AverageOpenDays,
+		})
+	}
+
+	return counts
+}
+
+func mapPartnerPayoutDTOsToPayouts(payoutDTOs []PartnerPayoutDTO) []domain.PartnerPayout {
+	payouts := make([]domain.PartnerPayout, 0, len(payoutDTOs))
+	for _, payoutDTO := range payoutDTOs {
+		payouts = append(payouts, domain.PartnerPayout{
+			PartnerID:    payoutDTO.PartnerID,
+			PartnerName:  payoutDTO.PartnerName,
+			Document:     payoutDTO.Document,
+			PaymentKey:   payoutDTO.PaymentKey,
+			Cases:        payoutDTO.Cases,
+			Transactions: payoutDTO.Transactions,
+			Total:        payoutDTO.Total,
+		})
+	}
+
+	return payouts
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	operationalReportJoins = `FROM cases AS ca
+		LEFT JOIN contractors AS co ON ca.contractor_id = co.contractor_id
+		LEFT JOIN users AS us ON ca.owner_id = us.user_id`
+	operationalContractorName = "COALESCE(co.company_name, '')"
+	operationalRegion         = "COALESCE(ca.region, 0)"
+	operationalOwnerName      = "COALESCE(TRIM(us.first_name || ' ' || COALESCE(us.last_name, '')), '')"
+)
+
+type operationalReportRepository struct {
+	client *sqlx.DB
+}
+
+func NewOperationalReportRepository(client *sqlx.DB) domain.OperationalReportRepository {
+	return &operationalReportRepository{
+		client: client,
+	}
+}
+
+func (r *operationalReportRepository) CountCasesByStatus(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.CaseStatusCount, error) {
+	whereQuery, whereArgs := operationalReportWhere(filters, "ca.created_at")
+
+	query := fmt.Sprintf(`SELECT
+		%s AS contractor_name,
+		%s AS region,
+		%s AS owner_name,
+		ca.status,
+		COUNT(*) AS cases
+		%s
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 2, 3, 4`, operationalContractorName, operationalRegion, operationalOwnerName, operationalReportJoins, strings.Join(whereQuery, " AND "))
+
+	var countDTOs []CaseStatusCountDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &countDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapCaseStatusCountDTOsToCounts(countDTOs), nil
+}
+
+func (r *operationalReportRepository) CountOpenCasesByAge(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.CaseAgingCount, error) {
+	whereQuery, whereArgs := operationalReportWhere(filters, "ca.created_at")
+	whereQuery = append(whereQuery, "ca.closed_at IS NULL", fmt.Sprintf("ca.status NOT IN ('%s', '%s')", domain.CLOSED, domain.CANCELED))
+
+	query := fmt.Sprintf(`SELECT
+		%s AS contractor_name,
+		%s AS region,
+		ca.status,
+		COUNT(*) FILTER (WHERE age_bucket.bucket = 0) AS up_to_7_days,
+		COUNT(*) FILTER (WHERE age_bucket.bucket = 1) AS up_to_15_days,
+		COUNT(*) FILTER (WHERE age_bucket.bucket = 2) AS up_to_30_days,
+		COUNT(*) FILTER (WHERE age_bucket.bucket = 3) AS up_to_60_days,
+		COUNT(*) FILTER (WHERE age_bucket.bucket = 4) AS over_60_days
+		%s
+		%s
+		WHERE %s
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`, operationalContractorName, operationalRegion, operationalReportJoins, caseAgeBucketJoin(domain.CaseAgingBuckets, "CURRENT_DATE"), strings.Join(whereQuery, " AND "))
+
+	var countDTOs []CaseAgingCountDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &countDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapCaseAgingCountDTOsToCounts(countDTOs), nil
+}
+
+func (r *operationalReportRepository) CountClosedCasesByMonth(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.ClosedCasesCount, error) {
+	whereQuery, whereArgs := operationalReportWhere(filters, "ca.closed_at")
+	whereQuery = append(whereQuery, "ca.closed_at IS NOT NULL")
+
+	query := fmt.Sprintf(`SELECT
+		date_trunc('month', ca.closed_at) AS month,
+		%s AS contractor_name,
+		%s AS region,
+		%s AS owner_name,
+		COUNT(*) AS cases,
+		COALESCE(AVG(EXTRACT(EPOCH FROM ca.closed_at - ca.created_at) / 86400), 0) AS average_open_days
+		%s
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 2, 3, 4`, operationalContractorName, operationalRegion, operationalOwnerName, operationalReportJoins, strings.Join(whereQuery, " AND "))
+
+	var countDTOs []ClosedCasesCountDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &countDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapClosedCasesCountDTOsToCounts(countDTOs), nil
+}
+
+func (r *operationalReportRepository) SumPartnerPayouts(ctx context.Context, filters domain.OperationalReportFilters) ([]domain.PartnerPayout, error) {
+	whereQuery, whereArgs := operationalReportWhere(filters, "tr.created_at")
+	whereQuery, whereArgs = prepareInQuery([]string{string(domain.OUTGOING)}, whereQuery, whereArgs, "tr.type")
+	whereQuery, whereArgs = prepareInQuery([]string{string(domain.TRANSACTION_APPROVED)}, whereQuery, whereArgs, "tr.status")
+
+	query := fmt.Sprintf(`SELECT
+		pa.partner_id,
+		COALESCE(NULLIF(TRIM(COALESCE(pa.first_name, '') || ' ' || COALESCE(pa.last_name, '')), ''), pa.company_name, '') AS partner_name,
+		COALESCE(pa.document, '') AS document,
+		COALESCE(pa.payment_key, '') AS payment_key,
+		COUNT(DISTINCT ca.case_id) AS cases,
+		COUNT(*) AS transactions,
+		SUM(tr.amount) AS total
+		FROM transactions AS tr
+		INNER JOIN cases AS ca ON tr.case_id = ca.case_id
+		INNER JOIN partners AS pa ON ca.partner_id = pa.partner_id
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 2`, strings.Join(whereQuery, " AND "))
+
+	var payoutDTOs []PartnerPayoutDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &payoutDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapPartnerPayoutDTOsToPayouts(payoutDTOs), nil
+}
+
+// operationalReportWhere filters by contractor and by the date column the
+// report counts on, its end date included.
+func operationalReportWhere(filters domain.OperationalReportFilters, dateColumn string) ([]string, []any) {
+	whereQuery := []string{"1=1"}
+	whereArgs := make([]any, 0)
+
+	whereQuery, whereArgs = prepareInQuery(filters.ContractorID, whereQuery, whereArgs, "ca.contractor_id")
+
+	if filters.StartDate != nil {
+		whereArgs = append(whereArgs, *filters.StartDate)
+		whereQuery = append(whereQuery, fmt.Sprintf("%s >= $%d", dateColumn, len(whereArgs)))
+	}
+
+	if filters.EndDate != nil {
+		whereArgs = append(whereArgs, filters.EndDate.Add(24*time.Hour))
+		whereQuery = append(whereQuery, fmt.Sprintf("%s < $%d", dateColumn, len(whereArgs)))
+	}
+
+	return whereQuery, whereArgs
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/ptr"
+)
+
+type PartDTO struct {
+	PartID           string    `db:"part_id"`
+	SKU              string    `db:"sku"`
+	Name             string    `db:"name"`
+	Description      *string   `db:"description"`
+	Brand            *string   `db:"brand"`
+	Model            *string   `db:"model"`
+	UnitCost         float64   `db:"unit_cost"`
+	StockQuantity    int       `db:"stock_quantity"`
+	ReservedQuantity int       `db:"reserved_quantity"`
+	MinimumStock     int       `db:"minimum_stock"`
+	Active           bool      `db:"active"`
+	CreatedBy        string    `db:"created_by"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedBy        string    `db:"updated_by"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+type StockMovementDTO struct {
+	MovementID    string    `db:"movement_id"`
+	PartID        string    `db:"part_id"`
+	CaseID        *string   `db:"case_id"`
+	ProductID     *string   `db:"product_id"`
+	Type          string    `db:"type"`
+	Quantity      int       `db:"quantity"`
+	UnitCost      float64   `db:"unit_cost"`
+	TransactionID *string   `db:"transaction_id"`
+	CreatedBy     string    `db:"created_by"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+func mapPartToPartDTO(part domain.Part) PartDTO {
+	return PartDTO{
+		PartID:           part.PartID,
+		SKU:              part.SKU,
+		Name:             part.Name,
+		Description:      &part.Description,
+		Brand:            &part.Brand,
+		Model:            &part.Model,
+		UnitCost:         part.UnitCost,
+		StockQuantity:    part.StockQuantity,
+		ReservedQuantity: part.ReservedQuantity,
+		MinimumStock:     part.MinimumStock,
+		Active:           part.Active,
+		CreatedBy:        part.CreatedBy,
+		CreatedAt:        part.CreatedAt,
+		UpdatedBy:        part.UpdatedBy,
+		UpdatedAt:        part.UpdatedAt,
+	}
+}
+
+func mapPartDTOToPart(partDTO PartDTO) domain.Part {
+	return domain.Part{
+		PartID:           partDTO.PartID,
+		SKU:              partDTO.SKU,
+		Name:             partDTO.Name,
+		Description:      ptr.ToString(partDTO.Description),
+		Brand:            ptr.ToString(partDTO.Brand),
+		Model:            ptr.ToString(partDTO.Model),
+		UnitCost:         partDTO.UnitCost,
+		StockQuantity:    partDTO.StockQuantity,
+		ReservedQuantity: partDTO.ReservedQuantity,
+		MinimumStock:     partDTO.MinimumStock,
+		Active:           partDTO.Active,
+		CreatedBy:        partDTO.CreatedBy,
+		CreatedAt:        partDTO.CreatedAt,
+		UpdatedBy:        partDTO.UpdatedBy,
+		UpdatedAt:        partDTO.UpdatedAt,
+	}
+}
+
+func mapPartDTOsToParts(partDTOs []PartDTO) []domain.Part {
+	parts := make([]domain.Part, 0, len(partDTOs))
+	for _, partDTO := range partDTOs {
+		parts = append(parts, mapPartDTOToPart(partDTO))
+	}
+
+	return parts
+}
+
+func mapStockMovementToDTO(movement domain.StockMovement) StockMovementDTO {
+	var caseID, productID, transactionID *string
+	if movement.CaseID != "" {
+		caseID = &movement.CaseID
+	}
+	if movement.ProductID != "" {
+		productID = &movement.ProductID
+	}
+	if movement.TransactionID != "" {
+		transactionID = &movement.TransactionID
+	}
+
+	return StockMovementDTO{
+		MovementID:    movement.MovementID,
+		PartID:        movement.PartID,
+		CaseID:        caseID,
+		ProductID:     productID,
+		Type:          string(movement.Type),
+		Quantity:      movement.Quantity,
+		UnitCost:      movement.UnitCost,
+		TransactionID: transactionID,
+		CreatedBy:     movement.CreatedBy,
+		CreatedAt:     movement.CreatedAt,
+	}
+}
+
+func mapStockMovementDTOsToMovements(movementDTOs []StockMovementDTO) []domain.StockMovement {
+	movements := make([]domain.StockMovement, 0, len(movementDTOs))
+	for _, movementDTO := range movementDTOs {
+		movements = append(movements, domain.StockMovement{
+			MovementID:    movementDTO.MovementID,
+			PartID:        movementDTO.PartID,
+			CaseID:        ptr.ToString(movementDTO.CaseID),
+			ProductID:     ptr.ToString(movementDTO.ProductID),
+			Type:          domain.StockMovementType(movementDTO.Type),
+			Quantity:      movementDTO.Quantity,
+			UnitCost:      movementDTO.UnitCost,
+			TransactionID: ptr.ToString(movementDTO.TransactionID),
+			CreatedBy:     movementDTO.CreatedBy,
+			CreatedAt:     movementDTO.CreatedAt,
+		})
+	}
+
+	return movements
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type partRepository struct {
+	client *sqlx.DB
+}
+
+func NewPartRepository(client *sqlx.DB) domain.PartRepository {
+	return &partRepository{
+		client: client,
+	}
+}
+
+func (r *partRepository) Create(ctx context.Context, part domain.Part) (string, error) {
+	partDTO := mapPartToPartDTO(part)
+
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO parts "+
+			"(part_id, sku, name, description, brand, model, unit_cost, stock_quantity, reserved_quantity, minimum_stock, active, created_at, created_by, updated_at, updated_by) "+
+			"VALUES "+
+			"(:part_id, :sku, :name, :description, :brand, :model, :unit_cost, :stock_quantity, :reserved_quantity, :minimum_stock, :active, :created_at, :created_by, :updated_at, :updated_by)",
+		partDTO,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return part.PartID, nil
+}
+
+func (r *partRepository) GetByID(ctx context.Context, partID string) (*domain.Part, error) {
+	return r.getByID(ctx, "SELECT * FROM parts WHERE part_id = $1", partID)
+}
+
+func (r *partRepository) LockByID(ctx context.Context, partID string) (*domain.Part, error) {
+	return r.getByID(ctx, "SELECT * FROM parts WHERE part_id = $1 FOR UPDATE", partID)
+}
+
+func (r *partRepository) getByID(ctx context.Context, query string, partID string) (*domain.Part, error) {
+	if partID == "" {
+		return nil, domain.NewValidationError("partID is required", nil)
+	}
+
+	var partDTO PartDTO
+	err := executor(ctx, r.client).GetContext(ctx, &partDTO, query, partID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no part found with this id", map[string]any{"part_id": partID})
+		}
+		return nil, err
+	}
+
+	part := mapPartDTOToPart(partDTO)
+
+	return &part, nil
+}
+
+func (r *partRepository) Search(ctx context.Context, filters domain.PartFilters) (domain.PagingResult[domain.Part], error) {
+	whereQuery := []string{"1=1"}
+	whereArgs := make([]any, 0)
+
+	whereQuery, whereArgs = prepareInQuery(filters.PartID, whereQuery, whereArgs, "part_id")
+	whereQuery, whereArgs = prepareInQuery(filters.SKU, whereQuery, whereArgs, "sku")
+	whereQuery, whereArgs = prepareInQuery(filters.Brand, whereQuery, whereArgs, "brand")
+	whereQuery, whereArgs = prepareInQuery(filters.Model, whereQuery, whereArgs, "model")
+	if filters.Name != "" {
+		whereQuery, whereArgs = prepareLikeQuery([]string{filters.Name}, whereQuery, whereArgs, "name")
+	}
+	if filters.LowStock {
+		whereQuery = append(whereQuery, "stock_quantity - reserved_quantity <= minimum_stock")
+	}
+	if filters.Active != nil {
+		whereQuery = append(whereQuery, fmt.Sprintf("active = $%d", len(whereArgs)+1))
+		whereArgs = append(whereArgs, strconv.FormatBool(*filters.Active))
+	}
+
+	limitArgs := append([]any{}, whereArgs...)
+	limitArgs = append(limitArgs, filters.Limit, filters.Offset)
+	limitQuery := fmt.Sprintf("LIMIT $%d OFFSET $%d", len(whereArgs)+1, len(whereArgs)+2)
+
+	query := fmt.Sprintf("SELECT * FROM parts WHERE %s ORDER BY name ASC %s", strings.Join(whereQuery, " AND "), limitQuery)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM parts WHERE %s", strings.Join(whereQuery, " AND "))
+
+	var foundParts []PartDTO
+	err := executor(ctx, r.client).SelectContext(ctx, &foundParts, query, limitArgs...)
+	if err != nil {
+		return domain.PagingResult[domain.Part]{}, err
+	}
+
+	var countResult int
+	err = executor(ctx, r.client).GetContext(ctx, &countResult, countQuery, whereArgs...)
+	if err != nil {
+		return domain.PagingResult[domain.Part]{}, err
+	}
+
+	result := domain.PagingResult[domain.Part]{
+		Result: mapPartDTOsToParts(foundParts),
+		Paging: domain.Paging{
+			Total:  countResult,
+			Limit:  filters.Limit,
+			Offset: filters.Offset,
+		},
+	}
+
+	return result, nil
+}
+
+func (r *partRepository) Update(ctx context.Context, part domain.Part) error {
+	partDTO := mapPartToPartDTO(part)
+
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"UPDATE parts SET "+
+			"name = :name, "+
+			"description = :description, "+
+			"brand = :brand, "+
+			"model = :model, "+
+			"unit_cost = :unit_cost, "+
+			"stock_quantity = :stock_quantity, "+
+			"reserved_quantity = :reserved_quantity, "+
+			"minimum_stock = :minimum_stock, "+
+			"active = :active, "+
+			"updated_at = :updated_at, "+
+			"updated_by = :updated_by "+
+			"WHERE part_id = :part_id",
+		partDTO,
+	)
+
+	return err
+}
+
+func (r *partRepository) CreateMovement(ctx context.Context, movement domain.StockMovement) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO part_movements "+
+			"(movement_id, part_id, case_id, product_id, type, quantity, unit_cost, transaction_id, created_at, created_by) "+
+			"VALUES "+
+			"(:movement_id, :part_id, :case_id, :product_id, :type, :quantity, :unit_cost, :transaction_id, :created_at, :created_by)",
+		mapStockMovementToDTO(movement),
+	)
+
+	return err
+}
+
+func (r *partRepository) GetMovementsByCaseID(ctx context.Context, caseID string) ([]domain.StockMovement, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	var movementDTOs []StockMovementDTO
+	err := executor(ctx, r.client).SelectContext(ctx, &movementDTOs, "SELECT * FROM part_movements WHERE case_id = $1 ORDER BY created_at ASC", caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStockMovementDTOsToMovements(movementDTOs), nil
+}
@@ -11,6 +11,19 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+var partnerBulkInsert = bulkInsert{
+	table: "partners",
+	columns: []string{
+		"partner_id", "first_name", "last_name", "company_name", "legal_name", "partner_type",
+		"document", "document_type", "shipping_address", "shipping_city", "shipping_state",
+		"shipping_zip_code", "shipping_country", "billing_address", "billing_city",
+		"billing_state", "billing_zip_code", "billing_country", "personal_phone",
+		"business_phone", "personal_email", "business_email", "created_at", "created_by",
+		"updated_at", "updated_by", "active", "description", "payment_key",
+		"payment_key_option", "payment_type", "payment_owner", "payment_is_same_from_owner",
+	},
+}
+
 type partnerRepository struct {
 	client *sqlx.DB
 }
@@ -126,7 +139,7 @@ func (db *partnerRepository) Search(ctx context.Context, filters domain.PartnerF
 func (db *partnerRepository) Update(ctx context.Context, partner domain.Partner) error {
 	partnerDTO := mapPartnerToPartnerDTO(partner)
 
-	_, err := db.client.NamedExecContext(
+	_, err := executor(ctx, db.client).NamedExecContext(
 		ctx,
 		`UPDATE partners
 			SET first_name = :first_name,
@@ -169,38 +182,39 @@ func (db *partnerRepository) Update(ctx context.Context, partner domain.Partner)
 	return nil
 }
 
+// CreateBatch inserts the partners, all or none of them, joining the caller's
+// transaction when there is one.
 func (db *partnerRepository) CreateBatch(ctx context.Context, partners []domain.Partner) ([]string, error) {
-	chunks := createChunks(partners, 100)
-	tx := db.client.MustBegin()
-
-	insertedIDs := make([]string, 0, len(partners))
-	for _, chunk := range chunks {
-		partnerDTOs := mapPartnersToPartnerDTOs(chunk)
-
-		query := `INSERT INTO partners
-		(partner_id, first_name, last_name, company_name, legal_name, partner_type, document, document_type, shipping_address, shipping_city, shipping_state, shipping_zip_code, shipping_country, billing_address, billing_city, billing_state, billing_zip_code, billing_country, personal_phone, business_phone, personal_email, business_email, created_at, created_by, updated_at, updated_by, active, description, payment_key, payment_key_option, payment_type, payment_owner, payment_is_same_from_owner)
-		VALUES
-		(:partner_id, :first_name, :last_name, :company_name, :legal_name, :partner_type, :document, :document_type, :shipping_address, :shipping_city, :shipping_state, :shipping_zip_code, :shipping_country, :billing_address, :billing_city, :billing_state, :billing_zip_code, :billing_country, :personal_phone, :business_phone, :personal_email, :business_email, :created_at, :created_by, :updated_at, :updated_by, :active, :description, :payment_key, :payment_key_option, :payment_type, :payment_owner, :payment_is_same_from_owner)
-		ON CONFLICT DO NOTHING`
-
-		_, err := tx.NamedExecContext(
-			ctx,
-			query,
-			partnerDTOs,
-		)
-		if err != nil {
-			return nil, err
+	return insertBatch(ctx, db.client, partnerBulkInsert, mapPartnersToPartnerDTOs(partners))
+}
+
+// GetByDocuments returns the partners whose document matches one of the given
+// ones. Documents are compared by their digits only, since partners created
+// through the API may have them stored formatted.
+func (db *partnerRepository) GetByDocuments(ctx context.Context, documents []string) ([]domain.Partner, error) {
+	if len(documents) == 0 {
+		return []domain.Partner{}, nil
+	}
+
+	foundPartners := make([]domain.Partner, 0)
+	for _, chunk := range createChunks(documents, 1000) {
+		digits := make([]string, 0, len(chunk))
+		for _, document := range chunk {
+			digits = append(digits, domain.DocumentDigits(document))
 		}
 
-		for _, partner := range partnerDTOs {
-			insertedIDs = append(insertedIDs, partner.PartnerID)
+		whereQuery, whereArgs := prepareInQuery(digits, []string{"1=1"}, make([]any, 0), "regexp_replace(document, '[^0-9]', '', 'g')")
+
+		query := fmt.Sprintf("SELECT * FROM partners WHERE %s", strings.Join(whereQuery, " AND "))
+
+		var partnerDTOs []PartnerDTO
+		err := executor(ctx, db.client).SelectContext(ctx, &partnerDTOs, query, whereArgs...)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	err := tx.Commit()
-	if err != nil {
-		return nil, err
+		foundPartners = append(foundPartners, mapPartnerDTOsToPartners(partnerDTOs)...)
 	}
 
-	return insertedIDs, nil
+	return foundPartners, nil
 }
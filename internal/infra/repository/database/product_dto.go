@@ -20,6 +20,15 @@ type ProductDTO struct {
 	UpdatedBy    string    `db:"updated_by"`
 }
 
+func mapProductsToProductDTOs(products []domain.Product) []ProductDTO {
+	productDTOs := make([]ProductDTO, 0, len(products))
+	for _, product := range products {
+		productDTOs = append(productDTOs, mapProductToProductDTO(product))
+	}
+
+	return productDTOs
+}
+
 func mapProductToProductDTO(product domain.Product) ProductDTO {
 	return ProductDTO{
 		ProductID:    product.ProductID,
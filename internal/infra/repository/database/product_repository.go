@@ -9,6 +9,14 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+var productBulkInsert = bulkInsert{
+	table: "products",
+	columns: []string{
+		"product_id", "name", "description", "brand", "model", "value", "serial_number",
+		"created_at", "updated_at", "created_by", "updated_by",
+	},
+}
+
 type productRepository struct {
 	client *sqlx.DB
 }
@@ -37,6 +45,12 @@ func (r *productRepository) CreateProduct(ctx context.Context, product domain.Pr
 	return product.ProductID, nil
 }
 
+// CreateBatch inserts the products, all or none of them, joining the
+// caller's transaction when there is one.
+func (r *productRepository) CreateBatch(ctx context.Context, products []domain.Product) ([]string, error) {
+	return insertBatch(ctx, r.client, productBulkInsert, mapProductsToProductDTOs(products))
+}
+
 func (r *productRepository) GetProductByID(ctx context.Context, productID string) (*domain.Product, error) {
 	if productID == "" {
 		return nil, domain.NewValidationError("product_id is required", nil)
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type QuoteDTO struct {
+	QuoteID         string     `db:"quote_id"`
+	CaseID          string     `db:"case_id"`
+	Origin          string     `db:"origin"`
+	Status          string     `db:"status"`
+	Notes           *string    `db:"notes"`
+	RejectionReason *string    `db:"rejection_reason"`
+	ReviewedBy      *string    `db:"reviewed_by"`
+	ReviewedAt      *time.Time `db:"reviewed_at"`
+	CreatedBy       string     `db:"created_by"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedBy       string     `db:"updated_by"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+type QuoteItemDTO struct {
+	QuoteItemID string  `db:"quote_item_id"`
+	QuoteID     string  `db:"quote_id"`
+	Type        string  `db:"type"`
+	Description string  `db:"description"`
+	Quantity    float64 `db:"quantity"`
+	UnitValue   float64 `db:"unit_value"`
+}
+
+func mapQuoteToQuoteDTO(quote domain.Quote) QuoteDTO {
+	var reviewedBy *string
+	if quote.ReviewedBy != "" {
+		reviewedBy = &quote.ReviewedBy
+	}
+
+	return QuoteDTO{
+		QuoteID:         quote.QuoteID,
+		CaseID:          quote.CaseID,
+		Origin:          string(quote.Origin),
+		Status:          string(quote.Status),
+		Notes:           &quote.Notes,
+		RejectionReason: &quote.RejectionReason,
+		ReviewedBy:      reviewedBy,
+		ReviewedAt:      quote.ReviewedAt,
+		CreatedBy:       quote.CreatedBy,
+		CreatedAt:       quote.CreatedAt,
+		UpdatedBy:       quote.UpdatedBy,
+		UpdatedAt:       quote.UpdatedAt,
+	}
+}
+
+func mapQuoteDTOToQuote(quoteDTO QuoteDTO, items []QuoteItemDTO) domain.Quote {
+	var notes string
+	if quoteDTO.Notes != nil {
+		notes = *quoteDTO.Notes
+	}
+
+	var rejectionReason string
+	if quoteDTO.RejectionReason != nil {
+		rejectionReason = *quoteDTO.RejectionReason
+	}
+
+	var reviewedBy string
+	if quoteDTO.ReviewedBy != nil {
+		reviewedBy = *quoteDTO.ReviewedBy
+	}
+
+	return domain.Quote{
+		QuoteID:         quoteDTO.QuoteID,
+		CaseID:          quoteDTO.CaseID,
+		Origin:          domain.QuoteOrigin(quoteDTO.Origin),
+		Status:          domain.QuoteStatus(quoteDTO.Status),
+		Items:           mapQuoteItemDTOsToQuoteItems(items),
+		Notes:           notes,
+		RejectionReason: rejectionReason,
+		ReviewedBy:      reviewedBy,
+		ReviewedAt:      quoteDTO.ReviewedAt,
+		CreatedBy:       quoteDTO.CreatedBy,
+		CreatedAt:       quoteDTO.CreatedAt,
+		UpdatedBy:       quoteDTO.UpdatedBy,
+		UpdatedAt:       quoteDTO.UpdatedAt,
+	}
+}
+
+func mapQuoteItemsToQuoteItemDTOs(items []domain.QuoteItem) []QuoteItemDTO {
+	itemDTOs := make([]QuoteItemDTO, 0, len(items))
+	for _, item := range items {
+		itemDTOs = append(itemDTOs, QuoteItemDTO{
+			QuoteItemID: item.QuoteItemID,
+			QuoteID:     item.QuoteID,
+			Type:        string(item.Type),
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitValue:   item.UnitValue,
+		})
+	}
+
+	return itemDTOs
+}
+
+func mapQuoteItemDTOsToQuoteItems(itemDTOs []QuoteItemDTO) []domain.QuoteItem {
+	items := make([]domain.QuoteItem, 0, len(itemDTOs))
+	for _, itemDTO := range itemDTOs {
+		items = append(items, domain.QuoteItem{
+			QuoteItemID: itemDTO.QuoteItemID,
+			QuoteID:     itemDTO.QuoteID,
+			Type:        domain.QuoteItemType(itemDTO.Type),
+			Description: itemDTO.Description,
+			Quantity:    itemDTO.Quantity,
+			UnitValue:   itemDTO.UnitValue,
+		})
+	}
+
+	return items
+}
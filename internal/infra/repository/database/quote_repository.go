@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type quoteRepository struct {
+	client *sqlx.DB
+}
+
+func NewQuoteRepository(client *sqlx.DB) domain.QuoteRepository {
+	return &quoteRepository{
+		client: client,
+	}
+}
+
+func (r *quoteRepository) Create(ctx context.Context, quote domain.Quote) (string, error) {
+	quoteDTO := mapQuoteToQuoteDTO(quote)
+
+	err := NewTransactionManager(r.client).WithinTransaction(ctx, func(txCtx context.Context) error {
+		_, err := executor(txCtx, r.client).NamedExecContext(
+			txCtx,
+			"INSERT INTO quotes "+
+				"(quote_id, case_id, origin, status, notes, rejection_reason, reviewed_by, reviewed_at, created_at, created_by, updated_at, updated_by) "+
+				"VALUES "+
+				"(:quote_id, :case_id, :origin, :status, :notes, :rejection_reason, :reviewed_by, :reviewed_at, :created_at, :created_by, :updated_at, :updated_by)",
+			quoteDTO,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = executor(txCtx, r.client).NamedExecContext(
+			txCtx,
+			"INSERT INTO quote_items "+
+				"(quote_item_id, quote_id, type, description, quantity, unit_value) "+
+				"VALUES "+
+				"(:quote_item_id, :quote_id, :type, :description, :quantity, :unit_value)",
+			mapQuoteItemsToQuoteItemDTOs(quote.Items),
+		)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return quote.QuoteID, nil
+}
+
+func (r *quoteRepository) GetByID(ctx context.Context, quoteID string) (*domain.Quote, error) {
+	if quoteID == "" {
+		return nil, domain.NewValidationError("quoteID is required", nil)
+	}
+
+	var quoteDTO QuoteDTO
+	err := executor(ctx, r.client).GetContext(ctx, &quoteDTO, "SELECT * FROM quotes WHERE quote_id = $1", quoteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no quote found with this id", map[string]any{"quote_id": quoteID})
+		}
+		return nil, err
+	}
+
+	items, err := r.getItems(ctx, []string{quoteID})
+	if err != nil {
+		return nil, err
+	}
+
+	quote := mapQuoteDTOToQuote(quoteDTO, items[quoteID])
+
+	return &quote, nil
+}
+
+func (r *quoteRepository) GetByCaseID(ctx context.Context, caseID string) ([]domain.Quote, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	var quoteDTOs []QuoteDTO
+	err := executor(ctx, r.client).SelectContext(ctx, &quoteDTOs, "SELECT * FROM quotes WHERE case_id = $1 ORDER BY created_at ASC", caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteIDs := make([]string, 0, len(quoteDTOs))
+	for _, quoteDTO := range quoteDTOs {
+		quoteIDs = append(quoteIDs, quoteDTO.QuoteID)
+	}
+
+	items, err := r.getItems(ctx, quoteIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]domain.Quote, 0, len(quoteDTOs))
+	for _, quoteDTO := range quoteDTOs {
+		quotes = append(quotes, mapQuoteDTOToQuote(quoteDTO, items[quoteDTO.QuoteID]))
+	}
+
+	return quotes, nil
+}
+
+// Update stores the review of a quote that is still pending. A quote reviewed
+// by someone else in the meantime is left as it is and a ConflictError is
+// returned, so a quote is never approved twice.
+func (r *quoteRepository) Update(ctx context.Context, quote domain.Quote) error {
+	quoteDTO := mapQuoteToQuoteDTO(quote)
+
+	result, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"UPDATE quotes SET "+
+			"status = :status, "+
+			"notes = :notes, "+
+			"rejection_reason = :rejection_reason, "+
+			"reviewed_by = :reviewed_by, "+
+			"reviewed_at = :reviewed_at, "+
+			"updated_at = :updated_at, "+
+			"updated_by = :updated_by "+
+			"WHERE quote_id = :quote_id AND status = '"+string(domain.QUOTE_PENDING)+"'",
+		quoteDTO,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.NewConflictError("quote was already reviewed", map[string]any{"quote_id": quote.QuoteID})
+	}
+
+	return nil
+}
+
+func (r *quoteRepository) getItems(ctx context.Context, quoteIDs []string) (map[string][]QuoteItemDTO, error) {
+	itemsByQuote := make(map[string][]QuoteItemDTO)
+	if len(quoteIDs) == 0 {
+		return itemsByQuote, nil
+	}
+
+	whereQuery, whereArgs := prepareInQuery(quoteIDs, []string{}, []any{}, "quote_id")
+	query := fmt.Sprintf("SELECT * FROM quote_items WHERE %s", strings.Join(whereQuery, " AND "))
+
+	var itemDTOs []QuoteItemDTO
+	err := executor(ctx, r.client).SelectContext(ctx, &itemDTOs, query, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, itemDTO := range itemDTOs {
+		itemsByQuote[itemDTO.QuoteID] = append(itemsByQuote[itemDTO.QuoteID], itemDTO)
+	}
+
+	return itemsByQuote, nil
+}
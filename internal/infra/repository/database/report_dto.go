@@ -0,0 +1,62 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportDTO struct {
+	ReportID       string    `db:"report_id"`
+	ContractorID   string    `db:"contractor_id"`
+	ReportName     string    `db:"report_name"`
+	ReportTemplate string    `db:"report_template"`
+	FileName       string    `db:"file_name"`
+	Version        int       `db:"version"`
+	Status         string    `db:"status"`
+	CreatedBy      string    `db:"created_by"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedBy      string    `db:"updated_by"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+func mapReportToReportDTO(report domain.Report) ReportDTO {
+	return ReportDTO{
+		ReportID:       report.ReportID,
+		ContractorID:   report.ContractorID,
+		ReportName:     report.ReportName,
+		ReportTemplate: report.ReportTemplate,
+		FileName:       report.FileName,
+		Version:        report.Version,
+		Status:         string(report.Status),
+		CreatedBy:      report.CreatedBy,
+		CreatedAt:      report.CreatedAt,
+		UpdatedBy:      report.UpdatedBy,
+		UpdatedAt:      report.UpdatedAt,
+	}
+}
+
+func mapReportDTOToReport(reportDTO ReportDTO) domain.Report {
+	return domain.Report{
+		ReportID:       reportDTO.ReportID,
+		ContractorID:   reportDTO.ContractorID,
+		ReportName:     reportDTO.ReportName,
+		ReportTemplate: reportDTO.ReportTemplate,
+		FileName:       reportDTO.FileName,
+		Version:        reportDTO.Version,
+		Status:         domain.ReportStatus(reportDTO.Status),
+		CreatedBy:      reportDTO.CreatedBy,
+		CreatedAt:      reportDTO.CreatedAt,
+		UpdatedBy:      reportDTO.UpdatedBy,
+		UpdatedAt:      reportDTO.UpdatedAt,
+	}
+}
+
+func mapReportDTOsToReports(reportDTOs []ReportDTO) []domain.Report {
+	reports := make([]domain.Report, 0, len(reportDTOs))
+	for _, reportDTO := range reportDTOs {
+		reports = append(reports, mapReportDTOToReport(reportDTO))
+	}
+
+	return reports
+}
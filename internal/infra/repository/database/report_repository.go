@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type reportRepository struct {
+	client *sqlx.DB
+}
+
+func NewReportRepository(client *sqlx.DB) domain.ReportRepository {
+	return &reportRepository{
+		client: client,
+	}
+}
+
+// Create stores the template as the next version of its contractor and
+// returns the version it got. It locks the contractor so concurrent uploads
+// wait for each other instead of taking the same version, which only holds
+// when it runs inside a transaction.
+func (r *reportRepository) Create(ctx context.Context, report domain.Report) (int, error) {
+	var contractorID string
+	err := executor(ctx, r.client).GetContext(ctx, &contractorID, "SELECT contractor_id FROM contractors WHERE contractor_id = $1 FOR UPDATE", report.ContractorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.NewNotFoundError("no contractor found with this id", map[string]any{"contractor_id": report.ContractorID})
+		}
+		return 0, err
+	}
+
+	query, args, err := r.client.BindNamed(
+		"INSERT INTO report_templates "+
+			"(report_id, contractor_id, report_name, report_template, file_name, version, status, created_at, created_by, updated_at, updated_by) "+
+			"SELECT "+
+			":report_id, :contractor_id, :report_name, :report_template, :file_name, COALESCE(MAX(version), 0) + 1, :status, :created_at, :created_by, :updated_at, :updated_by "+
+			"FROM report_templates WHERE contractor_id = :contractor_id "+
+			"RETURNING version",
+		mapReportToReportDTO(report),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int
+	if err := executor(ctx, r.client).GetContext(ctx, &version, query, args...); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (r *reportRepository) GetByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	if reportID == "" {
+		return nil, domain.NewValidationError("reportID is required", nil)
+	}
+
+	var reportDTO ReportDTO
+	err := executor(ctx, r.client).GetContext(ctx, &reportDTO, "SELECT * FROM report_templates WHERE report_id = $1", reportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no report template found with this id", map[string]any{"report_id": reportID})
+		}
+		return nil, err
+	}
+
+	report := mapReportDTOToReport(reportDTO)
+
+	return &report, nil
+}
+
+func (r *reportRepository) GetActiveByContractor(ctx context.Context, contractorID string) (*domain.Report, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID is required", nil)
+	}
+
+	var reportDTO ReportDTO
+	err := executor(ctx, r.client).GetContext(
+		ctx,
+		&reportDTO,
+		"SELECT * FROM report_templates WHERE contractor_id = $1 AND status = $2",
+		contractorID,
+		string(domain.REPORT_ACTIVE),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no active report template found for this contractor", map[string]any{"contractor_id": contractorID})
+		}
+		return nil, err
+	}
+
+	report := mapReportDTOToReport(reportDTO)
+
+	return &report, nil
+}
+
+func (r *reportRepository) GetByContractor(ctx context.Context, contractorID string) ([]domain.Report, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID is required", nil)
+	}
+
+	var reportDTOs []ReportDTO
+	err := executor(ctx, r.client).SelectContext(
+		ctx,
+		&reportDTOs,
+		"SELECT * FROM report_templates WHERE contractor_id = $1 ORDER BY version DESC",
+		contractorID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReportDTOsToReports(reportDTOs), nil
+}
+
+func (r *reportRepository) Update(ctx context.Context, report domain.Report) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"UPDATE report_templates SET "+
+			"report_name = :report_name, "+
+			"status = :status, "+
+			"updated_at = :updated_at, "+
+			"updated_by = :updated_by "+
+			"WHERE report_id = :report_id",
+		mapReportToReportDTO(report),
+	)
+
+	return err
+}
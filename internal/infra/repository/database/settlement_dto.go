@@ -0,0 +1,86 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type SettlementPolicyDTO struct {
+	ContractorID        string    `db:"contractor_id"`
+	TotalLossThreshold  float64   `db:"total_loss_threshold"`
+	TotalLossSettlement string    `db:"total_loss_settlement"`
+	UpdatedBy           string    `db:"updated_by"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+type SettlementDecisionDTO struct {
+	DecisionID   string    `db:"decision_id"`
+	CaseID       string    `db:"case_id"`
+	Type         string    `db:"type"`
+	RepairCost   float64   `db:"repair_cost"`
+	ProductValue float64   `db:"product_value"`
+	LossRatio    float64   `db:"loss_ratio"`
+	Threshold    float64   `db:"threshold"`
+	Overridden   bool      `db:"overridden"`
+	Reason       *string   `db:"reason"`
+	DecidedBy    string    `db:"decided_by"`
+	DecidedAt    time.Time `db:"decided_at"`
+}
+
+func mapSettlementPolicyToDTO(policy domain.SettlementPolicy) SettlementPolicyDTO {
+	return SettlementPolicyDTO{
+		ContractorID:        policy.ContractorID,
+		TotalLossThreshold:  policy.TotalLossThreshold,
+		TotalLossSettlement: string(policy.TotalLossSettlement),
+		UpdatedBy:           policy.UpdatedBy,
+		UpdatedAt:           policy.UpdatedAt,
+	}
+}
+
+func mapSettlementPolicyDTOToPolicy(policyDTO SettlementPolicyDTO) domain.SettlementPolicy {
+	return domain.SettlementPolicy{
+		ContractorID:        policyDTO.ContractorID,
+		TotalLossThreshold:  policyDTO.TotalLossThreshold,
+		TotalLossSettlement: domain.SettlementType(policyDTO.TotalLossSettlement),
+		UpdatedBy:           policyDTO.UpdatedBy,
+		UpdatedAt:           policyDTO.UpdatedAt,
+	}
+}
+
+func mapSettlementDecisionToDTO(decision domain.SettlementDecision) SettlementDecisionDTO {
+	return SettlementDecisionDTO{
+		DecisionID:   decision.DecisionID,
+		CaseID:       decision.CaseID,
+		Type:         string(decision.Type),
+		RepairCost:   decision.RepairCost,
+		ProductValue: decision.ProductValue,
+		LossRatio:    decision.LossRatio,
+		Threshold:    decision.Threshold,
+		Overridden:   decision.Overridden,
+		Reason:       &decision.Reason,
+		DecidedBy:    decision.DecidedBy,
+		DecidedAt:    decision.DecidedAt,
+	}
+}
+
+func mapSettlementDecisionDTOToDecision(decisionDTO SettlementDecisionDTO) domain.SettlementDecision {
+	var reason string
+	if decisionDTO.Reason != nil {
+		reason = *decisionDTO.Reason
+	}
+
+	return domain.SettlementDecision{
+		DecisionID:   decisionDTO.DecisionID,
+		CaseID:       decisionDTO.CaseID,
+		Type:         domain.SettlementType(decisionDTO.Type),
+		RepairCost:   decisionDTO.RepairCost,
+		ProductValue: decisionDTO.ProductValue,
+		LossRatio:    decisionDTO.LossRatio,
+		Threshold:    decisionDTO.Threshold,
+		Overridden:   decisionDTO.Overridden,
+		Reason:       reason,
+		DecidedBy:    decisionDTO.DecidedBy,
+		DecidedAt:    decisionDTO.DecidedAt,
+	}
+}
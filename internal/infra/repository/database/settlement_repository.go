@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type settlementRepository struct {
+	client *sqlx.DB
+}
+
+func NewSettlementRepository(client *sqlx.DB) domain.SettlementRepository {
+	return &settlementRepository{
+		client: client,
+	}
+}
+
+func (r *settlementRepository) GetPolicy(ctx context.Context, contractorID string) (*domain.SettlementPolicy, error) {
+	if contractorID == "" {
+		return nil, domain.NewValidationError("contractorID is required", nil)
+	}
+
+	var policyDTO SettlementPolicyDTO
+	err := executor(ctx, r.client).GetContext(ctx, &policyDTO, "SELECT * FROM settlement_policies WHERE contractor_id = $1", contractorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no settlement policy found for this contractor", map[string]any{"contractor_id": contractorID})
+		}
+		return nil, err
+	}
+
+	policy := mapSettlementPolicyDTOToPolicy(policyDTO)
+
+	return &policy, nil
+}
+
+func (r *settlementRepository) SavePolicy(ctx context.Context, policy domain.SettlementPolicy) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO settlement_policies "+
+			"(contractor_id, total_loss_threshold, total_loss_settlement, updated_at, updated_by) "+
+			"VALUES "+
+			"(:contractor_id, :total_loss_threshold, :total_loss_settlement, :updated_at, :updated_by) "+
+			"ON CONFLICT (contractor_id) DO UPDATE SET "+
+			"total_loss_threshold = EXCLUDED.total_loss_threshold, "+
+			"total_loss_settlement = EXCLUDED.total_loss_settlement, "+
+			"updated_at = EXCLUDED.updated_at, "+
+			"updated_by = EXCLUDED.updated_by",
+		mapSettlementPolicyToDTO(policy),
+	)
+
+	return err
+}
+
+func (r *settlementRepository) GetDecision(ctx context.Context, caseID string) (*domain.SettlementDecision, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	var decisionDTO SettlementDecisionDTO
+	err := executor(ctx, r.client).GetContext(ctx, &decisionDTO, "SELECT * FROM case_settlements WHERE case_id = $1", caseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no settlement decision found for this case", map[string]any{"case_id": caseID})
+		}
+		return nil, err
+	}
+
+	decision := mapSettlementDecisionDTOToDecision(decisionDTO)
+
+	return &decision, nil
+}
+
+func (r *settlementRepository) SaveDecision(ctx context.Context, decision domain.SettlementDecision) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO case_settlements "+
+			"(case_id, decision_id, type, repair_cost, product_value, loss_ratio, threshold, overridden, reason, decided_by, decided_at) "+
+			"VALUES "+
+			"(:case_id, :decision_id, :type, :repair_cost, :product_value, :loss_ratio, :threshold, :overridden, :reason, :decided_by, :decided_at) "+
+			"ON CONFLICT (case_id) DO UPDATE SET "+
+			"decision_id = EXCLUDED.decision_id, "+
+			"type = EXCLUDED.type, "+
+			"repair_cost = EXCLUDED.repair_cost, "+
+			"product_value = EXCLUDED.product_value, "+
+			"loss_ratio = EXCLUDED.loss_ratio, "+
+			"threshold = EXCLUDED.threshold, "+
+			"overridden = EXCLUDED.overridden, "+
+			"reason = EXCLUDED.reason, "+
+			"decided_by = EXCLUDED.decided_by, "+
+			"decided_at = EXCLUDED.decided_at",
+		mapSettlementDecisionToDTO(decision),
+	)
+
+	return err
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/pkg/ptr"
+)
+
+type ShipmentDTO struct {
+	ShipmentID         string    `db:"shipment_id"`
+	CaseID             string    `db:"case_id"`
+	Direction          string    `db:"direction"`
+	Carrier            string    `db:"carrier"`
+	TrackingCode       string    `db:"tracking_code"`
+	Status             string    `db:"status"`
+	OriginAddress      *string   `db:"origin_address"`
+	OriginCity         *string   `db:"origin_city"`
+	OriginState        *string   `db:"origin_state"`
+	OriginZipCode      *string   `db:"origin_zip_code"`
+	OriginCountry      *string   `db:"origin_country"`
+	DestinationAddress *string   `db:"destination_address"`
+	DestinationCity    *string   `db:"destination_city"`
+	DestinationState   *string   `db:"destination_state"`
+	DestinationZipCode *string   `db:"destination_zip_code"`
+	DestinationCountry *string   `db:"destination_country"`
+	CreatedBy          string    `db:"created_by"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedBy          string    `db:"updated_by"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+type ShipmentEventDTO struct {
+	EventID     string    `db:"event_id"`
+	ShipmentID  string    `db:"shipment_id"`
+	Status      string    `db:"status"`
+	Description *string   `db:"description"`
+	Location    *string   `db:"location"`
+	OccurredAt  time.Time `db:"occurred_at"`
+}
+
+func mapShipmentToShipmentDTO(shipment domain.Shipment) ShipmentDTO {
+	return ShipmentDTO{
+		ShipmentID:         shipment.ShipmentID,
+		CaseID:             shipment.CaseID,
+		Direction:          string(shipment.Direction),
+		Carrier:            shipment.Carrier,
+		TrackingCode:       shipment.TrackingCode,
+		Status:             string(shipment.Status),
+		OriginAddress:      &shipment.Origin.Address,
+		OriginCity:         &shipment.Origin.City,
+		OriginState:        &shipment.Origin.State,
+		OriginZipCode:      &shipment.Origin.ZipCode,
+		OriginCountry:      &shipment.Origin.Country,
+		DestinationAddress: &shipment.Destination.Address,
+		DestinationCity:    &shipment.Destination.City,
+		DestinationState:   &shipment.Destination.State,
+		DestinationZipCode: &shipment.Destination.ZipCode,
+		DestinationCountry: &shipment.Destination.Country,
+		CreatedBy:          shipment.CreatedBy,
+		CreatedAt:          shipment.CreatedAt,
+		UpdatedBy:          shipment.UpdatedBy,
+		UpdatedAt:          shipment.UpdatedAt,
+	}
+}
+
+func mapShipmentDTOToShipment(shipmentDTO ShipmentDTO, eventDTOs []ShipmentEventDTO) domain.Shipment {
+	return domain.Shipment{
+		ShipmentID:   shipmentDTO.ShipmentID,
+		CaseID:       shipmentDTO.CaseID,
+		Direction:    domain.ShipmentDirection(shipmentDTO.Direction),
+		Carrier:      shipmentDTO.Carrier,
+		TrackingCode: shipmentDTO.TrackingCode,
+		Status:       domain.ShipmentStatus(shipmentDTO.Status),
+		Origin: domain.Address{
+			Address: ptr.ToString(shipmentDTO.OriginAddress),
+			City:    ptr.ToString(shipmentDTO.OriginCity),
+			State:   ptr.ToString(shipmentDTO.OriginState),
+			ZipCode: ptr.ToString(shipmentDTO.OriginZipCode),
+			Country: ptr.ToString(shipmentDTO.OriginCountry),
+		},
+		Destination: domain.Address{
+			Address: ptr.ToString(shipmentDTO.DestinationAddress),
+			City:    ptr.ToString(shipmentDTO.DestinationCity),
+			State:   ptr.ToString(shipmentDTO.DestinationState),
+			ZipCode: ptr.ToString(shipmentDTO.DestinationZipCode),
+			Country: ptr.ToString(shipmentDTO.DestinationCountry),
+		},
+		Events:    mapShipmentEventDTOsToEvents(eventDTOs),
+		CreatedBy: shipmentDTO.CreatedBy,
+		CreatedAt: shipmentDTO.CreatedAt,
+		UpdatedBy: shipmentDTO.UpdatedBy,
+		UpdatedAt: shipmentDTO.UpdatedAt,
+	}
+}
+
+func mapShipmentEventsToDTOs(events []domain.ShipmentEvent) []ShipmentEventDTO {
+	eventDTOs := make([]ShipmentEventDTO, 0, len(events))
+	for _, event := range events {
+		eventDTOs = append(eventDTOs, ShipmentEventDTO{
+			EventID:     event.EventID,
+			ShipmentID:  event.ShipmentID,
+			Status:      string(event.Status),
+			Description: &event.Description,
+			Location:    &event.Location,
+			OccurredAt:  event.OccurredAt,
+		})
+	}
+
+	return eventDTOs
+}
+
+func mapShipmentEventDTOsToEvents(eventDTOs []ShipmentEventDTO) []domain.ShipmentEvent {
+	events := make([]domain.ShipmentEvent, 0, len(eventDTOs))
+	for _, eventDTO := range eventDTOs {
+		events = append(events, domain.ShipmentEvent{
+			EventID:     eventDTO.EventID,
+			ShipmentID:  eventDTO.ShipmentID,
+			Status:      domain.ShipmentStatus(eventDTO.Status),
+			Description: ptr.ToString(eventDTO.Description),
+			Location:    ptr.ToString(eventDTO.Location),
+			OccurredAt:  eventDTO.OccurredAt,
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type shipmentRepository struct {
+	client *sqlx.DB
+}
+
+func NewShipmentRepository(client *sqlx.DB) domain.ShipmentRepository {
+	return &shipmentRepository{
+		client: client,
+	}
+}
+
+func (r *shipmentRepository) Create(ctx context.Context, shipment domain.Shipment) (string, error) {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO shipments "+
+			"(shipment_id, case_id, direction, carrier, tracking_code, status, "+
+			"origin_address, origin_city, origin_state, origin_zip_code, origin_country, "+
+			"destination_address, destination_city, destination_state, destination_zip_code, destination_country, "+
+			"created_at, created_by, updated_at, updated_by) "+
+			"VALUES "+
+			"(:shipment_id, :case_id, :direction, :carrier, :tracking_code, :status, "+
+			":origin_address, :origin_city, :origin_state, :origin_zip_code, :origin_country, "+
+			":destination_address, :destination_city, :destination_state, :destination_zip_code, :destination_country, "+
+			":created_at, :created_by, :updated_at, :updated_by)",
+		mapShipmentToShipmentDTO(shipment),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return shipment.ShipmentID, nil
+}
+
+func (r *shipmentRepository) GetByID(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	if shipmentID == "" {
+		return nil, domain.NewValidationError("shipmentID is required", nil)
+	}
+
+	var shipmentDTO ShipmentDTO
+	err := executor(ctx, r.client).GetContext(ctx, &shipmentDTO, "SELECT * FROM shipments WHERE shipment_id = $1", shipmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewNotFoundError("no shipment found with this id", map[string]any{"shipment_id": shipmentID})
+		}
+		return nil, err
+	}
+
+	events, err := r.getEvents(ctx, []string{shipmentID})
+	if err != nil {
+		return nil, err
+	}
+
+	shipment := mapShipmentDTOToShipment(shipmentDTO, events[shipmentID])
+
+	return &shipment, nil
+}
+
+func (r *shipmentRepository) GetByCaseID(ctx context.Context, caseID string) ([]domain.Shipment, error) {
+	if caseID == "" {
+		return nil, domain.NewValidationError("caseID is required", nil)
+	}
+
+	var shipmentDTOs []ShipmentDTO
+	err := executor(ctx, r.client).SelectContext(ctx, &shipmentDTOs, "SELECT * FROM shipments WHERE case_id = $1 ORDER BY created_at ASC", caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentIDs := make([]string, 0, len(shipmentDTOs))
+	for _, shipmentDTO := range shipmentDTOs {
+		shipmentIDs = append(shipmentIDs, shipmentDTO.ShipmentID)
+	}
+
+	events, err := r.getEvents(ctx, shipmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments := make([]domain.Shipment, 0, len(shipmentDTOs))
+	for _, shipmentDTO := range shipmentDTOs {
+		shipments = append(shipments, mapShipmentDTOToShipment(shipmentDTO, events[shipmentDTO.ShipmentID]))
+	}
+
+	return shipments, nil
+}
+
+func (r *shipmentRepository) Update(ctx context.Context, shipment domain.Shipment) error {
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"UPDATE shipments SET "+
+			"status = :status, "+
+			"updated_at = :updated_at, "+
+			"updated_by = :updated_by "+
+			"WHERE shipment_id = :shipment_id",
+		mapShipmentToShipmentDTO(shipment),
+	)
+
+	return err
+}
+
+func (r *shipmentRepository) CreateEvents(ctx context.Context, events []domain.ShipmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := executor(ctx, r.client).NamedExecContext(
+		ctx,
+		"INSERT INTO shipment_events "+
+			"(event_id, shipment_id, status, description, location, occurred_at) "+
+			"VALUES "+
+			"(:event_id, :shipment_id, :status, :description, :location, :occurred_at)",
+		mapShipmentEventsToDTOs(events),
+	)
+
+	return err
+}
+
+func (r *shipmentRepository) getEvents(ctx context.Context, shipmentIDs []string) (map[string][]ShipmentEventDTO, error) {
+	eventsByShipment := make(map[string][]ShipmentEventDTO)
+	if len(shipmentIDs) == 0 {
+		return eventsByShipment, nil
+	}
+
+	whereQuery, whereArgs := prepareInQuery(shipmentIDs, []string{}, []any{}, "shipment_id")
+	query := fmt.Sprintf("SELECT * FROM shipment_events WHERE %s ORDER BY occurred_at ASC", strings.Join(whereQuery, " AND "))
+
+	var eventDTOs []ShipmentEventDTO
+	err := executor(ctx, r.client).SelectContext(ctx, &eventDTOs, query, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, eventDTO := range eventDTOs {
+		eventsByShipment[eventDTO.ShipmentID] = append(eventsByShipment[eventDTO.ShipmentID], eventDTO)
+	}
+
+	return eventsByShipment, nil
+}
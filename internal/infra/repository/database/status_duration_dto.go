@@ -0,0 +1,63 @@
+package database
+
+import "github.com/icrxz/crm-api-core/internal/domain"
+
+type DurationStatsDTO struct {
+	Cases        int     `db:"cases"`
+	AverageHours float64 `db:"average_hours"`
+	P50Hours     float64 `db:"p50_hours"`
+	P90Hours     float64 `db:"p90_hours"`
+	P95Hours     float64 `db:"p95_hours"`
+	MaxHours     float64 `db:"max_hours"`
+}
+
+type StatusDurationStatsDTO struct {
+	GroupKey   string `db:"group_key"`
+	GroupLabel string `db:"group_label"`
+	Status     string `db:"status"`
+	DurationStatsDTO
+}
+
+type CycleTimeStatsDTO struct {
+	GroupKey   string `db:"group_key"`
+	GroupLabel string `db:"group_label"`
+	DurationStatsDTO
+}
+
+func mapDurationStatsDTOToStats(statsDTO DurationStatsDTO) domain.DurationStats {
+	return domain.DurationStats{
+		Cases:        statsDTO.Cases,
+		AverageHours: statsDTO.AverageHours,
+		P50Hours:     statsDTO.P50Hours,
+		P90Hours:     statsDTO.P90Hours,
+		P95Hours:     statsDTO.P95Hours,
+		MaxHours:     statsDTO.MaxHours,
+	}
+}
+
+func mapStatusDurationStatsDTOsToStats(statsDTOs []StatusDurationStatsDTO) []domain.StatusDurationStats {
+	stats := make([]domain.StatusDurationStats, 0, len(statsDTOs))
+	for _, statsDTO := range statsDTOs {
+		stats = append(stats, domain.StatusDurationStats{
+			GroupKey:      statsDTO.GroupKey,
+			GroupLabel:    statsDTO.GroupLabel,
+			Status:        domain.CaseStatus(statsDTO.Status),
+			DurationStats: mapDurationStatsDTOToStats(statsDTO.DurationStatsDTO),
+		})
+	}
+
+	return stats
+}
+
+func mapCycleTimeStatsDTOsToStats(statsDTOs []CycleTimeStatsDTO) []domain.CycleTimeStats {
+	stats := make([]domain.CycleTimeStats, 0, len(statsDTOs))
+	for _, statsDTO := range statsDTOs {
+		stats = append(stats, domain.CycleTimeStats{
+			GroupKey:      statsDTO.GroupKey,
+			GroupLabel:    statsDTO.GroupLabel,
+			DurationStats: mapDurationStatsDTOToStats(statsDTO.DurationStatsDTO),
+		})
+	}
+
+	return stats
+}
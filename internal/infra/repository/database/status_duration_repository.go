@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// statusPeriodsQuery replays the status changes of the selected cases, read
+// from the domain.CaseStatusEvents only, into the periods they spent in each
+// status, following domain.NewCaseStatusDurations:
+// a case is in the status it was created with until its first change, or in
+// its current status since creation when no change was recorded, and the
+// period it is in now runs until now unless the status is final.
+const statusPeriodsQuery = `WITH selected AS (
+		SELECT ca.case_id, ca.status, ca.created_at
+		FROM cases AS ca
+		LEFT JOIN customers AS cu ON ca.customer_id = cu.customer_id
+		WHERE %s
+	),
+	changes AS (
+		SELECT
+			ch.case_id,
+			ch.created_at AS started_at,
+			ch.new_values->>'status' AS status,
+			ch.old_values->>'status' AS previous_status,
+			ROW_NUMBER() OVER (PARTITION BY ch.case_id ORDER BY ch.created_at) AS position
+		FROM case_history AS ch
+		INNER JOIN selected AS se ON ch.case_id = se.case_id
+		WHERE ch.event_name IN (%s) AND COALESCE(ch.new_values->>'status', '') <> ''
+	),
+	timeline AS (
+		SELECT case_id, started_at, status FROM changes
+		UNION ALL
+		SELECT se.case_id, se.created_at, ch.previous_status
+		FROM changes AS ch
+		INNER JOIN selected AS se ON ch.case_id = se.case_id
+		WHERE ch.position = 1 AND COALESCE(ch.previous_status, '') <> ''
+		UNION ALL
+		SELECT se.case_id, se.created_at, se.status
+		FROM selected AS se
+		WHERE NOT EXISTS (SELECT 1 FROM changes AS ch WHERE ch.case_id = se.case_id)
+	),
+	periods AS (
+		SELECT
+			case_id,
+			status,
+			started_at,
+			LEAD(started_at) OVER (PARTITION BY case_id ORDER BY started_at) AS ended_at
+		FROM timeline
+	)`
+
+type statusDurationRepository struct {
+	client *sqlx.DB
+}
+
+func NewStatusDurationRepository(client *sqlx.DB) domain.StatusDurationRepository {
+	return &statusDurationRepository{
+		client: client,
+	}
+}
+
+func (r *statusDurationRepository) SummarizeStatusDurations(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) ([]domain.StatusDurationStats, error) {
+	whereQuery, whereArgs := caseFullWhere(filters)
+	groupKey, groupLabel, err := statusDurationGroupColumns(groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(statusPeriodsQuery+`,
+	case_durations AS (
+		SELECT
+			case_id,
+			status,
+			SUM(EXTRACT(EPOCH FROM COALESCE(ended_at, now() AT TIME ZONE 'UTC') - started_at) / 3600) AS hours
+		FROM periods
+		WHERE ended_at IS NOT NULL OR status NOT IN (%s)
+		GROUP BY 1, 2
+	)
+	SELECT
+		%s AS group_key,
+		%s AS group_label,
+		cd.status,
+		%s
+		FROM case_durations AS cd
+		INNER JOIN cases AS ca ON cd.case_id = ca.case_id
+		LEFT JOIN contractors AS co ON ca.contractor_id = co.contractor_id
+		LEFT JOIN queues AS qu ON ca.queue_id = qu.queue_id
+		LEFT JOIN users AS us ON ca.owner_id = us.user_id
+		GROUP BY 1, 2, 3
+		ORDER BY average_hours DESC`,
+		strings.Join(whereQuery, " AND "), sqlList(domain.CaseStatusEvents), sqlList(domain.FinalCaseStatuses), groupKey, groupLabel, durationStatsColumns("cd.hours"))
+
+	var statsDTOs []StatusDurationStatsDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &statsDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapStatusDurationStatsDTOsToStats(statsDTOs), nil
+}
+
+func (r *statusDurationRepository) SummarizeCycleTimes(ctx context.Context, filters domain.CaseFilters, groupBy *domain.CaseStatsDimension) ([]domain.CycleTimeStats, error) {
+	whereQuery, whereArgs := caseFullWhere(filters)
+	groupKey, groupLabel, err := statusDurationGroupColumns(groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(statusPeriodsQuery+`,
+	milestones AS (
+		SELECT
+			pe.case_id,
+			MIN(pe.started_at) FILTER (WHERE pe.status = '%s') AS new_at,
+			MIN(pe.started_at) FILTER (WHERE pe.status = '%s') AS closed_at
+		FROM periods AS pe
+		GROUP BY 1
+	),
+	cycle_times AS (
+		SELECT case_id, EXTRACT(EPOCH FROM closed_at - new_at) / 3600 AS hours
+		FROM milestones
+		WHERE closed_at >= new_at
+	)
+	SELECT
+		%s AS group_key,
+		%s AS group_label,
+		%s
+		FROM cycle_times AS ct
+		INNER JOIN cases AS ca ON ct.case_id = ca.case_id
+		LEFT JOIN contractors AS co ON ca.contractor_id = co.contractor_id
+		LEFT JOIN queues AS qu ON ca.queue_id = qu.queue_id
+		LEFT JOIN users AS us ON ca.owner_id = us.user_id
+		GROUP BY 1, 2
+		ORDER BY average_hours DESC`,
+		strings.Join(whereQuery, " AND "), sqlList(domain.CaseStatusEvents), domain.NEW, domain.CLOSED, groupKey, groupLabel, durationStatsColumns("ct.hours"))
+
+	var statsDTOs []CycleTimeStatsDTO
+	if err := executor(ctx, r.client).SelectContext(ctx, &statsDTOs, query, whereArgs...); err != nil {
+		return nil, err
+	}
+
+	return mapCycleTimeStatsDTOsToStats(statsDTOs), nil
+}
+
+// statusDurationGroupColumns picks the key and label columns of the case
+// stats dimension the durations are broken down by, or a single group for
+// every case when there is none.
+func statusDurationGroupColumns(groupBy *domain.CaseStatsDimension) (string, string, error) {
+	if groupBy == nil {
+		return "''", "''", nil
+	}
+
+	column, ok := caseStatsColumns[*groupBy]
+	if !ok {
+		return "", "", domain.NewValidationError("invalid status durations group", map[string]any{"group_by": *groupBy})
+	}
+
+	return fmt.Sprintf("COALESCE(%s, '')", column.key), fmt.Sprintf("COALESCE(%s, '')", column.label), nil
+}
+
+// sqlList quotes constant values into a SQL list.
+func sqlList[S ~string](values []S) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", value))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func durationStatsColumns(hours string) string {
+	return fmt.Sprintf(`COUNT(*) AS cases,
+		AVG(%[1]s) AS average_hours,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY %[1]s) AS p50_hours,
+		percentile_cont(0.9) WITHIN GROUP (ORDER BY %[1]s) AS p90_hours,
+		percentile_cont(0.95) WITHIN GROUP (ORDER BY %[1]s) AS p95_hours,
+		MAX(%[1]s) AS max_hours`, hours)
+}
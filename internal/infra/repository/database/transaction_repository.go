@@ -102,32 +102,33 @@ func (r *transactionRepository) SearchTransactions(ctx context.Context, filters
 
 func (r *transactionRepository) CreateTransactionBatch(ctx context.Context, transactions []domain.Transaction) ([]string, error) {
 	chunks := createChunks(transactions, 100)
-	tx := r.client.MustBegin()
 
 	insertedIDs := make([]string, 0, len(transactions))
-	for _, chunk := range chunks {
-		transactionDTOs := mapTransactionsToTransactionsDTOs(chunk)
-
-		query := "INSERT INTO transactions " +
-			"(transaction_id, case_id, type, amount, status, attachment_id, created_at, updated_at, created_by, updated_by, description) " +
-			"VALUES " +
-			"(:transaction_id, :case_id, :type, :amount, :status, :attachment_id, :created_at, :updated_at, :created_by, :updated_by, :description)"
-
-		_, err := tx.NamedExecContext(
-			ctx,
-			query,
-			transactionDTOs,
-		)
-		if err != nil {
-			return nil, err
+	err := NewTransactionManager(r.client).WithinTransaction(ctx, func(txCtx context.Context) error {
+		for _, chunk := range chunks {
+			transactionDTOs := mapTransactionsToTransactionsDTOs(chunk)
+
+			query := "INSERT INTO transactions " +
+				"(transaction_id, case_id, type, amount, status, attachment_id, created_at, updated_at, created_by, updated_by, description) " +
+				"VALUES " +
+				"(:transaction_id, :case_id, :type, :amount, :status, :attachment_id, :created_at, :updated_at, :created_by, :updated_by, :description)"
+
+			_, err := executor(txCtx, r.client).NamedExecContext(
+				txCtx,
+				query,
+				transactionDTOs,
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, transaction := range transactionDTOs {
+				insertedIDs = append(insertedIDs, transaction.TransactionID)
+			}
 		}
 
-		for _, transaction := range transactionDTOs {
-			insertedIDs = append(insertedIDs, transaction.TransactionID)
-		}
-	}
-
-	err := tx.Commit()
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2,19 +2,25 @@ package infra
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
 	"github.com/icrxz/crm-api-core/internal/infra/config"
 	"github.com/icrxz/crm-api-core/internal/infra/entrypoint"
 	"github.com/icrxz/crm-api-core/internal/infra/entrypoint/middleware"
 	"github.com/icrxz/crm-api-core/internal/infra/entrypoint/rest"
 	"github.com/icrxz/crm-api-core/internal/infra/repository/bucket"
+	"github.com/icrxz/crm-api-core/internal/infra/repository/carrier"
 	"github.com/icrxz/crm-api-core/internal/infra/repository/database"
+	"github.com/icrxz/crm-api-core/resources"
 )
 
 func RunApp() error {
-	_ = context.Background()
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
 
 	appConfig, err := config.Load()
 	if err != nil {
@@ -39,6 +45,9 @@ func RunApp() error {
 
 	attachmentBucket := bucket.NewAttachmentBucket(s3Client, appConfig.AttachmentsBucket.Name)
 
+	// carriers
+	manualCarrier := carrier.NewManualCarrier()
+
 	// repositories
 	userRepository := database.NewUserRepository(sqlDB)
 	partnerRepository := database.NewPartnerRepository(sqlDB)
@@ -52,6 +61,17 @@ func RunApp() error {
 	transactionRepository := database.NewTransactionRepository(sqlDB)
 	attachmentRepository := database.NewAttachmentRepository(sqlDB)
 	queueRepository := database.NewQueueRepository(sqlDB)
+	quoteRepository := database.NewQuoteRepository(sqlDB)
+	settlementRepository := database.NewSettlementRepository(sqlDB)
+	partRepository := database.NewPartRepository(sqlDB)
+	shipmentRepository := database.NewShipmentRepository(sqlDB)
+	fraudRepository := database.NewFraudRepository(sqlDB)
+	importJobRepository := database.NewImportJobRepository(sqlDB)
+	reportRepository := database.NewReportRepository(sqlDB)
+	caseReportRepository := database.NewCaseReportRepository(sqlDB)
+	operationalReportRepository := database.NewOperationalReportRepository(sqlDB)
+	statusDurationRepository := database.NewStatusDurationRepository(sqlDB)
+	backlogSnapshotRepository := database.NewBacklogSnapshotRepository(sqlDB)
 
 	// services
 	userService := application.NewUserService(userRepository)
@@ -60,10 +80,17 @@ func RunApp() error {
 	contractorService := application.NewContractorService(contractorRepository)
 	authService := application.NewAuthService(userRepository, appConfig.SecretKey())
 	productService := application.NewProductService(productRepository)
-	batchCaseService := application.NewBatchCaseService(customerService, productService, contractorService, caseRepository)
+	fraudService := application.NewFraudService(fraudRepository, customerService, productService, caseHistoryRepository, transactionManager)
+	batchCaseService := application.NewBatchCaseService(customerService, productService, contractorService, caseRepository, fraudService, transactionManager)
+	batchPartnerService := application.NewBatchPartnerService(partnerRepository, transactionManager)
+	batchCustomerService := application.NewBatchCustomerService(customerRepository, transactionManager)
 	commentService := application.NewCommentService(commentRepository, attachmentRepository, attachmentBucket, transactionManager)
 	transactionService := application.NewTransactionService(transactionRepository, caseRepository)
 	queueService := application.NewQueueService(queueRepository)
+	quoteService := application.NewQuoteService(quoteRepository, caseRepository, caseHistoryRepository, transactionRepository, userRepository, transactionManager)
+	settlementService := application.NewSettlementService(settlementRepository, caseRepository, productService, caseHistoryRepository, quoteService, transactionManager)
+	partService := application.NewPartService(partRepository, caseRepository, transactionRepository, transactionManager)
+	shipmentService := application.NewShipmentService(shipmentRepository, caseRepository, caseHistoryRepository, customerService, partnerService, transactionManager, manualCarrier)
 	caseService := application.NewCaseService(
 		customerService,
 		caseRepository,
@@ -76,34 +103,65 @@ func RunApp() error {
 		partnerService,
 		contractorService,
 		queueService,
+		fraudService,
 	)
+	reportTemplateService := application.NewReportTemplateService(reportRepository, contractorService, attachmentBucket, transactionManager)
 	reportService := application.NewReportService(
-		appConfig.ReportFolder,
 		caseService,
 		productService,
 		customerService,
 		commentService,
 		partnerService,
 		contractorService,
+		settlementService,
+		transactionService,
+		reportTemplateService,
 		attachmentBucket,
+		caseReportRepository,
+		caseHistoryRepository,
+		transactionManager,
 	)
 	attachmentService := application.NewAttachmentService(attachmentRepository, attachmentBucket)
-	caseActionService := application.NewCaseActionService(caseRepository, caseHistoryRepository, transactionManager, commentService, reportService, attachmentService, transactionService)
+	caseActionService := application.NewCaseActionService(caseRepository, caseHistoryRepository, transactionManager, commentService, reportService, attachmentService, transactionService, quoteService)
+	batchReportService := application.NewBatchReportService(caseService, caseActionService, attachmentBucket)
+	operationalReportService := application.NewOperationalReportService(operationalReportRepository)
+	analyticsService := application.NewAnalyticsService(caseService, statusDurationRepository)
+	backlogService := application.NewBacklogService(caseRepository, backlogSnapshotRepository, appConfig.BacklogSnapshot.Interval)
+	importJobService := application.NewImportJobService(
+		importJobRepository,
+		transactionManager,
+		appConfig.ImportWorker.PollInterval,
+		appConfig.ImportWorker.StaleAfter,
+		map[domain.ImportJobType]application.ImportProcessor{
+			domain.IMPORT_JOB_CASES:     batchCaseService,
+			domain.IMPORT_JOB_PARTNERS:  batchPartnerService,
+			domain.IMPORT_JOB_CUSTOMERS: batchCustomerService,
+			domain.IMPORT_JOB_REPORTS:   batchReportService,
+		},
+	)
 
 	// controllers
 	pingController := rest.NewPingController()
 	userController := rest.NewUserController(userService)
-	partnerController := rest.NewPartnerController(partnerService)
-	customerController := rest.NewCustomerController(customerService)
+	partnerController := rest.NewPartnerController(partnerService, batchPartnerService, importJobService)
+	customerController := rest.NewCustomerController(customerService, batchCustomerService, importJobService)
 	contractorController := rest.NewContractorController(contractorService)
 	webMessageController := rest.NewWebMessageController()
 	authController := rest.NewAuthController(authService)
-	caseController := rest.NewCaseController(caseService, batchCaseService)
+	caseController := rest.NewCaseController(caseService, batchCaseService, importJobService)
 	productController := rest.NewProductController(productService)
 	commentController := rest.NewCommentController(commentService)
 	transactionController := rest.NewTransactionController(transactionService)
 	caseActionController := rest.NewCaseActionController(caseActionService)
 	queueController := rest.NewQueueController(queueService)
+	quoteController := rest.NewQuoteController(quoteService)
+	settlementController := rest.NewSettlementController(settlementService)
+	partController := rest.NewPartController(partService)
+	shipmentController := rest.NewShipmentController(shipmentService)
+	fraudController := rest.NewFraudController(fraudService)
+	importJobController := rest.NewImportJobController(importJobService)
+	reportController := rest.NewReportController(reportTemplateService, reportService, batchReportService, importJobService, operationalReportService)
+	analyticsController := rest.NewAnalyticsController(analyticsService, backlogService)
 
 	// middlewares
 	authMiddleware := middleware.NewAuthenticationMiddleware(authService)
@@ -133,7 +191,28 @@ func RunApp() error {
 		transactionController,
 		caseActionController,
 		queueController,
+		quoteController,
+		settlementController,
+		partController,
+		shipmentController,
+		fraudController,
+		importJobController,
+		reportController,
+		analyticsController,
 	)
 
+	// shipped report templates
+	shippedTemplates, err := fs.Sub(resources.Reports, "reports")
+	if err != nil {
+		return err
+	}
+	if err := reportTemplateService.ImportShipped(context.Background(), shippedTemplates); err != nil {
+		fmt.Printf("error importing shipped report templates: %v\n", err.Error())
+	}
+
+	// workers
+	go importJobService.Run(workerCtx)
+	go backlogService.Run(workerCtx)
+
 	return router.Run()
 }
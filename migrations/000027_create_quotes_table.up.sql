@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS quotes (
+    quote_id TEXT PRIMARY KEY,
+    case_id TEXT NOT NULL REFERENCES cases(case_id),
+    origin TEXT NOT NULL,
+    status TEXT NOT NULL,
+    notes TEXT,
+    rejection_reason TEXT,
+    reviewed_by TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_quotes_case_id ON quotes (case_id);
+
+CREATE TABLE IF NOT EXISTS quote_items (
+    quote_item_id TEXT PRIMARY KEY,
+    quote_id TEXT NOT NULL REFERENCES quotes(quote_id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    description TEXT,
+    quantity DECIMAL(10, 2) NOT NULL,
+    unit_value DECIMAL(10, 2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_quote_items_quote_id ON quote_items (quote_id);
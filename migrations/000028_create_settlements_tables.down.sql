@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS case_settlements;
+DROP TABLE IF EXISTS settlement_policies;
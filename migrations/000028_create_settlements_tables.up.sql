@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS settlement_policies (
+    contractor_id TEXT PRIMARY KEY REFERENCES contractors(contractor_id),
+    total_loss_threshold DECIMAL(5, 4) NOT NULL,
+    total_loss_settlement TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS case_settlements (
+    case_id TEXT PRIMARY KEY REFERENCES cases(case_id),
+    decision_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    repair_cost DECIMAL(10, 2) NOT NULL,
+    product_value DECIMAL(10, 2) NOT NULL,
+    loss_ratio DECIMAL(10, 4) NOT NULL,
+    threshold DECIMAL(5, 4) NOT NULL,
+    overridden BOOLEAN NOT NULL DEFAULT FALSE,
+    reason TEXT,
+    decided_by TEXT NOT NULL,
+    decided_at TIMESTAMP NOT NULL DEFAULT now()
+);
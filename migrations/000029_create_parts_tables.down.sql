@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS part_movements;
+DROP TABLE IF EXISTS parts;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS parts (
+    part_id TEXT PRIMARY KEY,
+    sku TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT,
+    brand TEXT,
+    model TEXT,
+    unit_cost DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    stock_quantity INTEGER NOT NULL DEFAULT 0 CHECK (stock_quantity >= 0),
+    reserved_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reserved_quantity >= 0),
+    minimum_stock INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS part_movements (
+    movement_id TEXT PRIMARY KEY,
+    part_id TEXT NOT NULL REFERENCES parts(part_id),
+    case_id TEXT REFERENCES cases(case_id),
+    product_id TEXT,
+    type TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    unit_cost DECIMAL(10, 2) NOT NULL,
+    transaction_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_part_movements_case_id ON part_movements (case_id);
+CREATE INDEX IF NOT EXISTS idx_part_movements_part_id ON part_movements (part_id);
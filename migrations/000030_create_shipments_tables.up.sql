@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS shipments (
+    shipment_id TEXT PRIMARY KEY,
+    case_id TEXT NOT NULL REFERENCES cases(case_id),
+    direction TEXT NOT NULL,
+    carrier TEXT NOT NULL,
+    tracking_code TEXT NOT NULL,
+    status TEXT NOT NULL,
+    origin_address TEXT,
+    origin_city TEXT,
+    origin_state TEXT,
+    origin_zip_code TEXT,
+    origin_country TEXT,
+    destination_address TEXT,
+    destination_city TEXT,
+    destination_state TEXT,
+    destination_zip_code TEXT,
+    destination_country TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_case_id ON shipments (case_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipments_carrier_tracking_code ON shipments (carrier, tracking_code);
+
+CREATE TABLE IF NOT EXISTS shipment_events (
+    event_id TEXT PRIMARY KEY,
+    shipment_id TEXT NOT NULL REFERENCES shipments(shipment_id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    description TEXT,
+    location TEXT,
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipment_events_shipment_id ON shipment_events (shipment_id);
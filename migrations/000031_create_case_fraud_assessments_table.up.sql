@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS case_fraud_assessments (
+    case_id TEXT PRIMARY KEY REFERENCES cases(case_id),
+    score INTEGER NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    review_status TEXT NOT NULL,
+    review_notes TEXT,
+    reviewed_by TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_fraud_assessments_flagged ON case_fraud_assessments (flagged) WHERE flagged;
@@ -0,0 +1 @@
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS row_errors JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS skipped_ids;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS updated_ids;
@@ -0,0 +1,2 @@
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS updated_ids TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS skipped_ids TEXT[] NOT NULL DEFAULT '{}';
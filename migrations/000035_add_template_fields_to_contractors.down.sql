@@ -0,0 +1 @@
+ALTER TABLE contractors DROP COLUMN IF EXISTS template_fields;
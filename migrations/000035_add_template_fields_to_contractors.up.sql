@@ -0,0 +1 @@
+ALTER TABLE contractors ADD COLUMN IF NOT EXISTS template_fields JSONB NOT NULL DEFAULT '{}';
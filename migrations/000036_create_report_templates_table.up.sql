@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS report_templates (
+    report_id TEXT PRIMARY KEY,
+    contractor_id TEXT NOT NULL REFERENCES contractors(contractor_id),
+    report_name TEXT NOT NULL,
+    report_template TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL,
+    UNIQUE (contractor_id, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_templates_active ON report_templates (contractor_id) WHERE status = 'active';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS case_reports (
+    case_report_id TEXT PRIMARY KEY,
+    case_id TEXT NOT NULL REFERENCES cases(case_id),
+    comment_id TEXT NOT NULL,
+    attachment_id TEXT NOT NULL,
+    report_id TEXT NOT NULL REFERENCES report_templates(report_id),
+    template_version INTEGER NOT NULL,
+    format TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    key TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_reports_case_id ON case_reports (case_id, created_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS backlog_snapshots (
+    snapshot_id TEXT PRIMARY KEY,
+    snapshot_date DATE NOT NULL,
+    queue_id TEXT NOT NULL DEFAULT '',
+    owner_id TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    up_to_2_days INTEGER NOT NULL DEFAULT 0,
+    up_to_5_days INTEGER NOT NULL DEFAULT 0,
+    up_to_10_days INTEGER NOT NULL DEFAULT 0,
+    over_10_days INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_backlog_snapshots_day_group ON backlog_snapshots (snapshot_date, queue_id, owner_id, status);
@@ -0,0 +1,2 @@
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS heartbeat_at;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS lease_id;
@@ -0,0 +1,2 @@
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS lease_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS heartbeat_at TIMESTAMP;
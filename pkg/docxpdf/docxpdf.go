@@ -0,0 +1,28 @@
+// Package docxpdf renders Word documents (.docx) to PDF without any external
+// converter. It covers what report templates use: paragraphs with alignment,
+// indents and spacing, bold, italic and underlined runs, tables with borders,
+// inline images, page breaks and the default header and footer. Text is set
+// in Helvetica, limited to the Windows-1252 characters. Styles other than the
+// document defaults, floating positions, numbering and fields are ignored.
+package docxpdf
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+)
+
+// Convert writes the PDF rendering of the docx file to w.
+func Convert(docx []byte, w io.Writer) error {
+	archive, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	if err != nil {
+		return err
+	}
+
+	doc, err := parseDocument(archive)
+	if err != nil {
+		return err
+	}
+
+	return newRenderer(doc).render(w)
+}
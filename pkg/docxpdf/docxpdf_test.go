@@ -0,0 +1,166 @@
+package docxpdf
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDocumentXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing"
+	xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+	xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture"><w:body>
+<w:p><w:pPr><w:jc w:val="center"/><w:spacing w:after="200"/><w:ind w:left="720"/></w:pPr>
+	<w:r><w:rPr><w:b/><w:sz w:val="28"/></w:rPr><w:t>Laudo técnico</w:t></w:r>
+	<w:r><w:tab/><w:t xml:space="preserve"> nº 1</w:t></w:r></w:p>
+<w:tbl><w:tblPr><w:tblBorders><w:top w:val="single"/><w:insideH w:val="nil"/></w:tblBorders></w:tblPr>
+	<w:tblGrid><w:gridCol w:w="4000"/><w:gridCol w:w="5000"/></w:tblGrid>
+	<w:tr><w:trPr><w:trHeight w:val="400"/></w:trPr>
+		<w:tc><w:tcPr><w:tcBorders><w:top w:val="nil"/></w:tcBorders></w:tcPr><w:p><w:r><w:t>Cliente</w:t></w:r></w:p></w:tc>
+		<w:tc><w:p><w:r><w:t>$client</w:t></w:r></w:p></w:tc></w:tr>
+	<w:tr><w:tc><w:tcPr><w:gridSpan w:val="2"/></w:tcPr><w:p><w:r><w:t>Resumo</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+<w:p><w:r><w:br w:type="page"/></w:r><w:r><w:drawing><wp:inline><wp:extent cx="1270000" cy="635000"/>
+	<a:graphic><a:graphicData><pic:pic><pic:blipFill><a:blip r:embed="rId1"/></pic:blipFill></pic:pic></a:graphicData></a:graphic>
+	</wp:inline></w:drawing></w:r></w:p>
+<w:sectPr><w:headerReference w:type="default" r:id="rId2"/><w:pgSz w:w="11906" w:h="16838"/>
+	<w:pgMar w:top="1440" w:right="1200" w:bottom="-1440" w:left="1200" w:header="700" w:footer="700"/></w:sectPr>
+</w:body></w:document>`
+
+const testHeaderXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:p><w:r><w:t>Assistência Técnica</w:t></w:r></w:p></w:hdr>`
+
+const testRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>
+	<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/header" Target="header1.xml"/>
+	<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com" TargetMode="External"/>
+</Relationships>`
+
+const testStylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+	<w:docDefaults><w:rPrDefault><w:rPr><w:sz w:val="22"/></w:rPr></w:rPrDefault>
+	<w:pPrDefault><w:pPr><w:spacing w:line="276" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>
+	<w:style w:type="paragraph" w:styleId="Title"><w:pPr><w:jc w:val="right"/></w:pPr></w:style>
+</w:styles>`
+
+func newTestDocx(t *testing.T) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	var imgData bytes.Buffer
+	require.NoError(t, png.Encode(&imgData, img))
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range map[string][]byte{
+		"word/document.xml":            []byte(testDocumentXML),
+		"word/_rels/document.xml.rels": []byte(testRelsXML),
+		"word/header1.xml":             []byte(testHeaderXML),
+		"word/styles.xml":              []byte(testStylesXML),
+		"word/media/image1.png":        imgData.Bytes(),
+	} {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return buffer.Bytes()
+}
+
+func TestParseDocument(t *testing.T) {
+	data := newTestDocx(t)
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	doc, err := parseDocument(archive)
+	require.NoError(t, err)
+
+	assert.Equal(t, pageLayout{
+		width: 595.3, height: 841.9, top: 72, right: 60, bottom: 72, left: 60, headerOffset: 35, footerOffset: 35,
+	}, doc.page)
+
+	require.Len(t, doc.body, 3)
+
+	title := doc.body[0].(paragraph)
+	assert.Equal(t, "center", title.align)
+	assert.Equal(t, 36.0, title.indentLeft)
+	assert.Equal(t, 10.0, title.spaceAfter)
+	assert.InDelta(t, 1.15, title.lineMultiple, 0.001)
+	assert.Equal(t, []run{
+		{style: runStyle{bold: true, size: 14}, text: "Laudo técnico"},
+		{style: runStyle{size: 11}, tab: true},
+		{style: runStyle{size: 11}, text: " nº 1"},
+	}, title.runs)
+
+	tbl := doc.body[1].(table)
+	assert.Equal(t, []float64{200, 250}, tbl.columns)
+	assert.True(t, tbl.borders)
+	require.Len(t, tbl.rows, 2)
+	assert.Equal(t, 20.0, tbl.rows[0].height)
+	require.Len(t, tbl.rows[0].cells, 2)
+	assert.False(t, *tbl.rows[0].cells[0].borders)
+	assert.Nil(t, tbl.rows[0].cells[1].borders)
+	assert.Equal(t, "$client", tbl.rows[0].cells[1].blocks[0].(paragraph).runs[0].text)
+	assert.Equal(t, 2, tbl.rows[1].cells[0].span)
+
+	photo := doc.body[2].(paragraph)
+	require.Len(t, photo.runs, 2)
+	assert.True(t, photo.runs[0].pageBreak)
+	assert.Equal(t, &inlineImage{name: "word/media/image1.png", width: 100, height: 50}, photo.runs[1].image)
+
+	require.Len(t, doc.header, 1)
+	assert.Equal(t, "Assistência Técnica", doc.header[0].(paragraph).runs[0].text)
+}
+
+func TestConvert(t *testing.T) {
+	var output bytes.Buffer
+	require.NoError(t, Convert(newTestDocx(t), &output))
+
+	assert.True(t, bytes.HasPrefix(output.Bytes(), []byte("%PDF-")))
+	assert.Equal(t, 2, countPages(output.Bytes()))
+
+	assert.Error(t, Convert([]byte("not a docx"), &output))
+}
+
+// TestConvert_ReportTemplates renders the report templates kept in the
+// repository, which exercise floating tables, headers and photo pages.
+func TestConvert_ReportTemplates(t *testing.T) {
+	templates, err := filepath.Glob("../../resources/reports/*.docx")
+	require.NoError(t, err)
+
+	for _, template := range templates {
+		t.Run(filepath.Base(template), func(t *testing.T) {
+			data, err := os.ReadFile(template)
+			require.NoError(t, err)
+
+			var output bytes.Buffer
+			require.NoError(t, Convert(data, &output))
+			assert.Positive(t, countPages(output.Bytes()))
+		})
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	assert.Equal(t, []string{"Prezados,", " ", "informamos", " ", " ", "que"}, splitWords("Prezados, informamos  que"))
+	assert.Equal(t, []string{" ", "nº", " "}, splitWords(" nº "))
+}
+
+var pageObject = regexp.MustCompile(`/Type /Page\b[^s]`)
+
+func countPages(pdf []byte) int {
+	return len(pageObject.FindAll(pdf, -1))
+}
@@ -0,0 +1,725 @@
+package docxpdf
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// block is a paragraph or a table of the document body, a header or a
+// table cell.
+type block interface{}
+
+type paragraph struct {
+	align           string
+	indentLeft      float64
+	indentRight     float64
+	firstLine       float64
+	spaceBefore     float64
+	spaceAfter      float64
+	lineMultiple    float64
+	lineExact       float64
+	pageBreakBefore bool
+	mark            runStyle
+	runs            []run
+}
+
+type runStyle struct {
+	bold      bool
+	italic    bool
+	underline bool
+	size      float64
+	color     [3]int
+}
+
+// run is a piece of a paragraph: text in one style, an image or a tab, line
+// or page break.
+type run struct {
+	style     runStyle
+	text      string
+	image     *inlineImage
+	tab       bool
+	lineBreak bool
+	pageBreak bool
+}
+
+type inlineImage struct {
+	name   string
+	width  float64
+	height float64
+}
+
+type table struct {
+	columns []float64
+	borders bool
+	rows    []tableRow
+}
+
+type tableRow struct {
+	height float64
+	cells  []tableCell
+}
+
+type tableCell struct {
+	width   float64
+	span    int
+	borders *bool
+	blocks  []block
+}
+
+type pageLayout struct {
+	width        float64
+	height       float64
+	top          float64
+	right        float64
+	bottom       float64
+	left         float64
+	headerOffset float64
+	footerOffset float64
+}
+
+type document struct {
+	page   pageLayout
+	body   []block
+	header []block
+	footer []block
+	files  map[string]*zip.File
+}
+
+func (doc *document) readFile(name string) ([]byte, error) {
+	file, ok := doc.files[name]
+	if !ok {
+		return nil, fmt.Errorf("docxpdf: %s not found", name)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// defaultPage is A4 with 1 inch margins, used when the document has no
+// section properties.
+var defaultPage = pageLayout{
+	width:        595.3,
+	height:       841.9,
+	top:          72,
+	right:        72,
+	bottom:       72,
+	left:         72,
+	headerOffset: 36,
+	footerOffset: 36,
+}
+
+// parser reads the parts of a docx archive. Sizes are converted to points as
+// they are read: twentieths of a point for page and paragraph measures, half
+// points for fonts and EMUs for drawings.
+type parser struct {
+	files     map[string]*zip.File
+	paragraph paragraph
+}
+
+func parseDocument(archive *zip.Reader) (*document, error) {
+	p := &parser{
+		files:     make(map[string]*zip.File, len(archive.File)),
+		paragraph: paragraph{lineMultiple: 1, mark: runStyle{size: 10}},
+	}
+	for _, file := range archive.File {
+		p.files[file.Name] = file
+	}
+
+	if err := p.parseStyles("word/styles.xml"); err != nil {
+		return nil, err
+	}
+
+	doc := &document{page: defaultPage, files: p.files}
+	var headerID, footerID string
+
+	err := p.parsePart("word/document.xml", func(d *xml.Decoder, rels map[string]string) error {
+		return walk(d, func(se xml.StartElement) error {
+			switch se.Name.Local {
+			case "document":
+				return errDescend
+			case "body":
+				blocks, err := p.parseBlocks(d, rels, func(se xml.StartElement) error {
+					if se.Name.Local != "sectPr" {
+						return d.Skip()
+					}
+
+					var err error
+					doc.page, headerID, footerID, err = parseSection(d, rels)
+					return err
+				})
+				doc.body = blocks
+				return err
+			default:
+				return d.Skip()
+			}
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.header, err = p.parseHeaderFooter(headerID); err != nil {
+		return nil, err
+	}
+
+	if doc.footer, err = p.parseHeaderFooter(footerID); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (p *parser) parseHeaderFooter(partName string) ([]block, error) {
+	if partName == "" {
+		return nil, nil
+	}
+
+	var blocks []block
+	err := p.parsePart(partName, func(d *xml.Decoder, rels map[string]string) error {
+		return walk(d, func(se xml.StartElement) error {
+			var err error
+			blocks, err = p.parseBlocks(d, rels, nil)
+			return err
+		})
+	})
+
+	return blocks, err
+}
+
+// parsePart opens an archive part along with its relationships, resolved to
+// archive paths by id.
+func (p *parser) parsePart(name string, parse func(d *xml.Decoder, rels map[string]string) error) error {
+	file, ok := p.files[name]
+	if !ok {
+		return fmt.Errorf("docxpdf: %s not found", name)
+	}
+
+	rels, err := p.parseRelationships(name)
+	if err != nil {
+		return err
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return parse(xml.NewDecoder(reader), rels)
+}
+
+func (p *parser) parseRelationships(partName string) (map[string]string, error) {
+	rels := make(map[string]string)
+
+	dir, name := path.Split(partName)
+	file, ok := p.files[dir+"_rels/"+name+".rels"]
+	if !ok {
+		return rels, nil
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var relationships struct {
+		Relationship []struct {
+			ID         string `xml:"Id,attr"`
+			Target     string `xml:"Target,attr"`
+			TargetMode string `xml:"TargetMode,attr"`
+		}
+	}
+	if err := xml.NewDecoder(reader).Decode(&relationships); err != nil {
+		return nil, err
+	}
+
+	for _, relationship := range relationships.Relationship {
+		if relationship.TargetMode == "External" {
+			continue
+		}
+		rels[relationship.ID] = path.Join(dir, relationship.Target)
+	}
+
+	return rels, nil
+}
+
+// parseStyles reads the document defaults and the default paragraph style,
+// which every paragraph starts from.
+func (p *parser) parseStyles(name string) error {
+	file, ok := p.files[name]
+	if !ok {
+		return nil
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	d := xml.NewDecoder(reader)
+	return walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "styles", "docDefaults", "rPrDefault", "pPrDefault":
+			return errDescend
+		case "rPr":
+			return parseRunProps(d, &p.paragraph.mark)
+		case "pPr":
+			return parseParagraphProps(d, &p.paragraph)
+		case "style":
+			if attr(se, "type") != "paragraph" || !onOff(attr(se, "default"), false) {
+				return d.Skip()
+			}
+			return errDescend
+		default:
+			return d.Skip()
+		}
+	})
+}
+
+// parseBlocks reads the paragraphs and tables up to the end of the current
+// element, descending into content controls. Any other element is handed to
+// other, or skipped when it is nil.
+func (p *parser) parseBlocks(d *xml.Decoder, rels map[string]string, other func(se xml.StartElement) error) ([]block, error) {
+	var blocks []block
+	err := walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "p":
+			paragraph, err := p.parseParagraph(d, rels)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, paragraph)
+		case "tbl":
+			table, err := p.parseTable(d, rels)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, table)
+		case "sdt", "sdtContent", "customXml":
+			nested, err := p.parseBlocks(d, rels, other)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, nested...)
+		default:
+			if other != nil {
+				return other(se)
+			}
+			return d.Skip()
+		}
+		return nil
+	})
+
+	return blocks, err
+}
+
+func (p *parser) parseParagraph(d *xml.Decoder, rels map[string]string) (paragraph, error) {
+	para := p.paragraph
+	para.runs = nil
+
+	var parseContent func() error
+	parseContent = func() error {
+		return walk(d, func(se xml.StartElement) error {
+			switch se.Name.Local {
+			case "pPr":
+				return parseParagraphProps(d, &para)
+			case "r":
+				runs, err := parseRun(d, rels, para.mark)
+				para.runs = append(para.runs, runs...)
+				return err
+			case "hyperlink", "ins", "smartTag", "sdt", "sdtContent", "fldSimple", "customXml":
+				return parseContent()
+			default:
+				return d.Skip()
+			}
+		})
+	}
+
+	return para, parseContent()
+}
+
+func parseParagraphProps(d *xml.Decoder, para *paragraph) error {
+	return walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "jc":
+			para.align = attr(se, "val")
+		case "ind":
+			para.indentLeft = twips(firstAttr(se, "left", "start"), para.indentLeft)
+			para.indentRight = twips(firstAttr(se, "right", "end"), para.indentRight)
+			if hanging := attr(se, "hanging"); hanging != "" {
+				para.firstLine = -twips(hanging, 0)
+			} else {
+				para.firstLine = twips(attr(se, "firstLine"), para.firstLine)
+			}
+		case "spacing":
+			para.spaceBefore = twips(attr(se, "before"), para.spaceBefore)
+			para.spaceAfter = twips(attr(se, "after"), para.spaceAfter)
+			if line := attr(se, "line"); line != "" {
+				switch attr(se, "lineRule") {
+				case "exact", "atLeast":
+					para.lineExact = twips(line, 0)
+					para.lineMultiple = 1
+				default:
+					para.lineExact = 0
+					para.lineMultiple = number(line, 240) / 240
+				}
+			}
+		case "pageBreakBefore":
+			para.pageBreakBefore = onOff(attr(se, "val"), true)
+		case "rPr":
+			return parseRunProps(d, &para.mark)
+		}
+
+		return d.Skip()
+	})
+}
+
+func parseRun(d *xml.Decoder, rels map[string]string, style runStyle) ([]run, error) {
+	var runs []run
+	err := walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "rPr":
+			return parseRunProps(d, &style)
+		case "t":
+			text, err := readText(d)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run{style: style, text: text})
+			return nil
+		case "tab", "ptab":
+			runs = append(runs, run{style: style, tab: true})
+		case "br":
+			if attr(se, "type") == "page" {
+				runs = append(runs, run{style: style, pageBreak: true})
+			} else {
+				runs = append(runs, run{style: style, lineBreak: true})
+			}
+		case "cr":
+			runs = append(runs, run{style: style, lineBreak: true})
+		case "noBreakHyphen":
+			runs = append(runs, run{style: style, text: "-"})
+		case "drawing":
+			image, err := parseDrawing(d, rels)
+			if err != nil {
+				return err
+			}
+			if image != nil {
+				runs = append(runs, run{style: style, image: image})
+			}
+			return nil
+		}
+
+		return d.Skip()
+	})
+
+	return runs, err
+}
+
+func parseRunProps(d *xml.Decoder, style *runStyle) error {
+	return walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "b":
+			style.bold = onOff(attr(se, "val"), true)
+		case "i":
+			style.italic = onOff(attr(se, "val"), true)
+		case "u":
+			value := attr(se, "val")
+			style.underline = value != "" && value != "none"
+		case "sz":
+			style.size = number(attr(se, "val"), style.size*2) / 2
+		case "color":
+			style.color = parseColor(attr(se, "val"))
+		}
+
+		return d.Skip()
+	})
+}
+
+// parseDrawing reads the size and the picture of an inline or anchored
+// drawing. Shapes without a picture are left out.
+func parseDrawing(d *xml.Decoder, rels map[string]string) (*inlineImage, error) {
+	var width, height float64
+	var target string
+
+	err := walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "extent":
+			width = emus(attr(se, "cx"))
+			height = emus(attr(se, "cy"))
+		case "blip":
+			target = rels[attr(se, "embed")]
+		}
+
+		return errDescend
+	})
+	if err != nil || target == "" || width <= 0 || height <= 0 {
+		return nil, err
+	}
+
+	return &inlineImage{name: target, width: width, height: height}, nil
+}
+
+func (p *parser) parseTable(d *xml.Decoder, rels map[string]string) (table, error) {
+	var tbl table
+	err := walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "tblPr":
+			return walk(d, func(se xml.StartElement) error {
+				if se.Name.Local != "tblBorders" {
+					return d.Skip()
+				}
+
+				var err error
+				tbl.borders, err = parseBorders(d)
+				return err
+			})
+		case "tblGrid":
+			return walk(d, func(se xml.StartElement) error {
+				if se.Name.Local == "gridCol" {
+					tbl.columns = append(tbl.columns, twips(attr(se, "w"), 0))
+				}
+				return d.Skip()
+			})
+		case "tr":
+			row, err := p.parseTableRow(d, rels)
+			tbl.rows = append(tbl.rows, row)
+			return err
+		default:
+			return d.Skip()
+		}
+	})
+
+	return tbl, err
+}
+
+func (p *parser) parseTableRow(d *xml.Decoder, rels map[string]string) (tableRow, error) {
+	var row tableRow
+	err := walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "trPr":
+			return walk(d, func(se xml.StartElement) error {
+				if se.Name.Local == "trHeight" {
+					row.height = twips(attr(se, "val"), 0)
+				}
+				return d.Skip()
+			})
+		case "tc":
+			cell, err := p.parseTableCell(d, rels)
+			row.cells = append(row.cells, cell)
+			return err
+		default:
+			return d.Skip()
+		}
+	})
+
+	return row, err
+}
+
+func (p *parser) parseTableCell(d *xml.Decoder, rels map[string]string) (tableCell, error) {
+	cell := tableCell{span: 1}
+
+	blocks, err := p.parseBlocks(d, rels, func(se xml.StartElement) error {
+		if se.Name.Local != "tcPr" {
+			return d.Skip()
+		}
+
+		return walk(d, func(se xml.StartElement) error {
+			switch se.Name.Local {
+			case "tcW":
+				if attr(se, "type") == "dxa" || attr(se, "type") == "" {
+					cell.width = twips(attr(se, "w"), 0)
+				}
+			case "gridSpan":
+				cell.span = max(int(number(attr(se, "val"), 1)), 1)
+			case "tcBorders":
+				borders, err := parseBorders(d)
+				cell.borders = &borders
+				return err
+			}
+			return d.Skip()
+		})
+	})
+	cell.blocks = blocks
+
+	return cell, err
+}
+
+// parseBorders tells whether any of the borders is drawn. Borders are drawn
+// as thin black lines whatever their style.
+func parseBorders(d *xml.Decoder) (bool, error) {
+	visible := false
+	err := walk(d, func(se xml.StartElement) error {
+		if value := attr(se, "val"); value != "" && value != "nil" && value != "none" {
+			visible = true
+		}
+		return d.Skip()
+	})
+
+	return visible, err
+}
+
+func parseSection(d *xml.Decoder, rels map[string]string) (pageLayout, string, string, error) {
+	page := defaultPage
+	var headerID, footerID string
+
+	err := walk(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "pgSz":
+			page.width = twips(attr(se, "w"), page.width)
+			page.height = twips(attr(se, "h"), page.height)
+		case "pgMar":
+			page.top = twips(attr(se, "top"), page.top)
+			page.right = twips(attr(se, "right"), page.right)
+			page.bottom = twips(attr(se, "bottom"), page.bottom)
+			page.left = twips(attr(se, "left"), page.left)
+			page.headerOffset = twips(attr(se, "header"), page.headerOffset)
+			page.footerOffset = twips(attr(se, "footer"), page.footerOffset)
+		case "headerReference":
+			if attr(se, "type") == "default" {
+				headerID = rels[attr(se, "id")]
+			}
+		case "footerReference":
+			if attr(se, "type") == "default" {
+				footerID = rels[attr(se, "id")]
+			}
+		}
+
+		return d.Skip()
+	})
+
+	// negative margins let the text run over the header or footer
+	page.top = max(page.top, -page.top)
+	page.bottom = max(page.bottom, -page.bottom)
+
+	return page, headerID, footerID, err
+}
+
+// errDescend is returned by walk callbacks to go on with the children of the
+// element as if they were its siblings.
+var errDescend = errors.New("docxpdf: descend")
+
+// walk calls fn for every child element of the current one and returns once
+// it ends. fn must consume the element it gets, either by parsing it to its
+// end or calling d.Skip, or return errDescend.
+func walk(d *xml.Decoder, fn func(se xml.StartElement) error) error {
+	depth := 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if err := fn(element); err == errDescend {
+				depth++
+			} else if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+func readText(d *xml.Decoder) (string, error) {
+	var text strings.Builder
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+
+		switch element := token.(type) {
+		case xml.CharData:
+			text.Write(element)
+		case xml.StartElement:
+			if err := d.Skip(); err != nil {
+				return "", err
+			}
+		case xml.EndElement:
+			return text.String(), nil
+		}
+	}
+}
+
+func attr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+func firstAttr(se xml.StartElement, names ...string) string {
+	for _, name := range names {
+		if value := attr(se, name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func onOff(value string, empty bool) bool {
+	switch value {
+	case "":
+		return empty
+	case "0", "false", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+func number(value string, fallback float64) float64 {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback
+	}
+
+	return parsed
+}
+
+func twips(value string, fallback float64) float64 {
+	if value == "" {
+		return fallback
+	}
+
+	return number(value, fallback*20) / 20
+}
+
+func emus(value string) float64 {
+	return number(value, 0) / 12700
+}
+
+func parseColor(value string) [3]int {
+	parsed, err := strconv.ParseUint(value, 16, 32)
+	if err != nil || len(value) != 6 {
+		return [3]int{}
+	}
+
+	return [3]int{int(parsed >> 16 & 0xff), int(parsed >> 8 & 0xff), int(parsed & 0xff)}
+}
@@ -0,0 +1,469 @@
+package docxpdf
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	fontFamily = "Helvetica"
+	// lineHeightRatio and ascentRatio approximate the single line height and
+	// the ascent of Arial, the font templates are usually written in.
+	lineHeightRatio = 1.15
+	ascentRatio     = 0.905
+	// defaultTabStop is where tabs stop when the paragraph sets none.
+	defaultTabStop = 36
+	// cellPadding is Word's default left and right table cell margin.
+	cellPadding = 5.4
+)
+
+// frame is the horizontal band blocks are laid out in. When draw is false
+// blocks are only measured, and when paginate is false they never break to a
+// new page, as in headers and table cells.
+type frame struct {
+	x        float64
+	width    float64
+	draw     bool
+	paginate bool
+}
+
+type renderer struct {
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+	doc       *document
+	images    map[string]*fpdf.ImageInfoType
+	// contentTop and contentBottom bound the body on every page, between
+	// the header and the footer.
+	contentTop    float64
+	contentBottom float64
+}
+
+func newRenderer(doc *document) *renderer {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		UnitStr: "pt",
+		Size:    fpdf.SizeType{Wd: doc.page.width, Ht: doc.page.height},
+	})
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetMargins(doc.page.left, doc.page.top, doc.page.right)
+	pdf.SetLineWidth(0.5)
+
+	return &renderer{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+		doc:       doc,
+		images:    make(map[string]*fpdf.ImageInfoType),
+	}
+}
+
+func (r *renderer) render(w io.Writer) error {
+	page := r.doc.page
+	bodyFrame := frame{x: page.left, width: page.width - page.left - page.right, draw: true, paginate: true}
+
+	headerHeight := r.blocks(r.doc.header, frame{x: bodyFrame.x, width: bodyFrame.width}, 0)
+	footerHeight := r.blocks(r.doc.footer, frame{x: bodyFrame.x, width: bodyFrame.width}, 0)
+	r.contentTop = max(page.top, page.headerOffset+headerHeight)
+	r.contentBottom = min(page.height-page.bottom, page.height-page.footerOffset-footerHeight)
+
+	y := r.newPage()
+	r.blocks(r.doc.body, bodyFrame, y)
+
+	return r.pdf.Output(w)
+}
+
+// newPage starts a page with the header and footer drawn and returns where
+// the body starts.
+func (r *renderer) newPage() float64 {
+	page := r.doc.page
+	r.pdf.AddPage()
+
+	headerFrame := frame{x: page.left, width: page.width - page.left - page.right, draw: true}
+	r.blocks(r.doc.header, headerFrame, page.headerOffset)
+
+	footerHeight := r.blocks(r.doc.footer, frame{x: headerFrame.x, width: headerFrame.width}, 0)
+	r.blocks(r.doc.footer, headerFrame, page.height-page.footerOffset-footerHeight)
+
+	return r.contentTop
+}
+
+// blocks lays out the blocks from y and returns where they end, which is on
+// another page when they were paginated. Laid out from 0, that is their
+// height.
+func (r *renderer) blocks(blocks []block, f frame, y float64) float64 {
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case paragraph:
+			y = r.paragraph(b, f, y)
+		case table:
+			y = r.table(b, f, y)
+		}
+	}
+
+	return y
+}
+
+// item is a word, space, image or tab laid out on a line.
+type item struct {
+	run   run
+	text  string
+	width float64
+	space bool
+}
+
+// line is a wrapped line of a paragraph. Its width counts the indent it
+// starts at, the first line indent on the first line.
+type line struct {
+	items  []item
+	indent float64
+	width  float64
+	ascent float64
+	height float64
+	last   bool
+}
+
+func (r *renderer) paragraph(p paragraph, f frame, y float64) float64 {
+	if f.paginate && p.pageBreakBefore && y > r.contentTop {
+		y = r.newPage()
+	}
+
+	y += p.spaceBefore
+	left := f.x + p.indentLeft
+	width := f.width - p.indentLeft - p.indentRight
+
+	for _, segment := range r.splitPageBreaks(p.runs) {
+		if segment.pageBreak && f.paginate {
+			y = r.newPage()
+		}
+
+		lines := r.wrap(p, segment.runs, width)
+		for _, l := range lines {
+			if f.paginate && y+l.height > r.contentBottom && y > r.contentTop {
+				y = r.newPage()
+			}
+
+			if f.draw {
+				r.drawLine(p, l, left, width, y)
+			}
+			y += l.height
+		}
+	}
+
+	return y + p.spaceAfter
+}
+
+type runSegment struct {
+	runs      []run
+	pageBreak bool
+}
+
+// splitPageBreaks cuts the runs of a paragraph at its page breaks.
+func (r *renderer) splitPageBreaks(runs []run) []runSegment {
+	segments := []runSegment{{}}
+	for _, rn := range runs {
+		if rn.pageBreak {
+			segments = append(segments, runSegment{pageBreak: true})
+			continue
+		}
+		segments[len(segments)-1].runs = append(segments[len(segments)-1].runs, rn)
+	}
+
+	return segments
+}
+
+// wrap breaks the runs into lines no wider than width, the first one
+// shortened by the first line indent.
+func (r *renderer) wrap(p paragraph, runs []run, width float64) []line {
+	var lines []line
+	current := line{indent: p.firstLine, width: p.firstLine}
+
+	finish := func(last bool) {
+		// trailing spaces do not count for alignment
+		for len(current.items) > 0 && current.items[len(current.items)-1].space {
+			current.width -= current.items[len(current.items)-1].width
+			current.items = current.items[:len(current.items)-1]
+		}
+		if current.height == 0 {
+			current.ascent, current.height = r.textMetrics(p, p.mark)
+		}
+		current.last = last
+		lines = append(lines, current)
+		current = line{}
+	}
+
+	place := func(it item, ascent, height float64) {
+		if current.width+it.width > width && len(current.items) > 0 {
+			if it.space {
+				return
+			}
+			finish(false)
+		}
+		current.items = append(current.items, it)
+		current.width += it.width
+		current.ascent = max(current.ascent, ascent)
+		current.height = max(current.height, height)
+	}
+
+	for _, rn := range runs {
+		switch {
+		case rn.lineBreak:
+			finish(true)
+		case rn.tab:
+			stop := (float64(int(current.width/defaultTabStop)) + 1) * defaultTabStop
+			ascent, height := r.textMetrics(p, rn.style)
+			place(item{run: rn, width: stop - current.width, space: true}, ascent, height)
+		case rn.image != nil:
+			imgWidth, imgHeight := r.fitImage(rn.image, width)
+			place(item{run: rn, width: imgWidth}, imgHeight, imgHeight)
+		default:
+			ascent, height := r.textMetrics(p, rn.style)
+			r.setFont(rn.style)
+			for _, word := range splitWords(rn.text) {
+				wordWidth := r.pdf.GetStringWidth(r.translate(word))
+				if wordWidth > width && word != " " {
+					for _, piece := range r.breakWord(word, width) {
+						place(item{run: rn, text: piece, width: r.pdf.GetStringWidth(r.translate(piece))}, ascent, height)
+					}
+					continue
+				}
+				place(item{run: rn, text: word, width: wordWidth, space: word == " "}, ascent, height)
+			}
+		}
+	}
+	finish(true)
+
+	return lines
+}
+
+func (r *renderer) drawLine(p paragraph, l line, left, width, y float64) {
+	x := left + l.indent
+	available := width - l.indent
+	free := max(available-(l.width-l.indent), 0)
+
+	extraPerSpace := 0.0
+	switch p.align {
+	case "center":
+		x += free / 2
+	case "right", "end":
+		x += free
+	case "both", "distribute":
+		spaces := 0
+		for _, it := range l.items {
+			if it.space && it.text != "" {
+				spaces++
+			}
+		}
+		if spaces > 0 && !l.last {
+			extraPerSpace = free / float64(spaces)
+		}
+	}
+
+	baseline := y + l.ascent
+	for _, it := range l.items {
+		switch {
+		case it.run.image != nil:
+			_, imgHeight := r.fitImage(it.run.image, width)
+			r.drawImage(it.run.image, x, baseline-imgHeight, it.width, imgHeight)
+		case it.text != "":
+			r.setFont(it.run.style)
+			color := it.run.style.color
+			r.pdf.SetTextColor(color[0], color[1], color[2])
+			r.pdf.Text(x, baseline, r.translate(it.text))
+		}
+
+		x += it.width
+		if it.space && it.text != "" {
+			x += extraPerSpace
+		}
+	}
+}
+
+func (r *renderer) textMetrics(p paragraph, style runStyle) (float64, float64) {
+	if p.lineExact > 0 {
+		return min(style.size*ascentRatio, p.lineExact), p.lineExact
+	}
+
+	height := style.size * lineHeightRatio * p.lineMultiple
+	return height - style.size*(lineHeightRatio-ascentRatio), height
+}
+
+func (r *renderer) setFont(style runStyle) {
+	fontStyle := ""
+	if style.bold {
+		fontStyle += "B"
+	}
+	if style.italic {
+		fontStyle += "I"
+	}
+	if style.underline {
+		fontStyle += "U"
+	}
+
+	r.pdf.SetFont(fontFamily, fontStyle, style.size)
+}
+
+// breakWord cuts a word wider than the line into pieces that fit.
+func (r *renderer) breakWord(word string, width float64) []string {
+	var pieces []string
+	var piece []rune
+	for _, char := range word {
+		if len(piece) > 0 && r.pdf.GetStringWidth(r.translate(string(append(piece, char)))) > width {
+			pieces = append(pieces, string(piece))
+			piece = nil
+		}
+		piece = append(piece, char)
+	}
+
+	return append(pieces, string(piece))
+}
+
+// splitWords cuts text into words and the spaces between them.
+func splitWords(text string) []string {
+	var words []string
+	start := 0
+	for i, char := range text {
+		if unicode.IsSpace(char) {
+			if start < i {
+				words = append(words, text[start:i])
+			}
+			words = append(words, " ")
+			start = i + len(string(char))
+		}
+	}
+
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+
+	return words
+}
+
+// fitImage scales an image down to fit the line width and the page body.
+func (r *renderer) fitImage(img *inlineImage, width float64) (float64, float64) {
+	scale := 1.0
+	if img.width > width {
+		scale = width / img.width
+	}
+
+	if maxHeight := r.contentBottom - r.contentTop; maxHeight > 0 && img.height*scale > maxHeight {
+		scale = maxHeight / img.height
+	}
+
+	return img.width * scale, img.height * scale
+}
+
+// drawImage places an image, leaving its space blank when it is in a format
+// that cannot be decoded, such as the vector images Word also embeds.
+func (r *renderer) drawImage(img *inlineImage, x, y, width, height float64) {
+	info, ok := r.images[img.name]
+	if !ok {
+		info = r.registerImage(img.name)
+		r.images[img.name] = info
+	}
+
+	if info == nil {
+		return
+	}
+
+	r.pdf.ImageOptions(img.name, x, y, width, height, false, fpdf.ImageOptions{}, 0, "")
+}
+
+// registerImage hands an archive image to the PDF. JPEGs are embedded as
+// they are; anything else is decoded and written as an 8 bit PNG, the only
+// kind the PDF writer reads reliably.
+func (r *renderer) registerImage(name string) *fpdf.ImageInfoType {
+	data, err := r.doc.readFile(name)
+	if err != nil {
+		return nil
+	}
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	if format == "jpeg" {
+		return r.pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "JPG"}, bytes.NewReader(data))
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	normalized := image.NewNRGBA(decoded.Bounds())
+	draw.Draw(normalized, normalized.Bounds(), decoded, decoded.Bounds().Min, draw.Src)
+
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, normalized); err != nil {
+		return nil
+	}
+
+	return r.pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, &encoded)
+}
+
+func (r *renderer) table(t table, f frame, y float64) float64 {
+	columns := t.columns
+	total := 0.0
+	for _, column := range columns {
+		total += column
+	}
+
+	// tables wider than the page, as floating ones often are, are shrunk to it
+	scale := 1.0
+	if total > f.width {
+		scale = f.width / total
+	}
+
+	for _, row := range t.rows {
+		widths := make([]float64, len(row.cells))
+		column := 0
+		for i, cell := range row.cells {
+			width := 0.0
+			for span := 0; span < cell.span && column < len(columns); span++ {
+				width += columns[column]
+				column++
+			}
+			if width == 0 {
+				width = cell.width
+			}
+			widths[i] = width * scale
+		}
+
+		height := row.height
+		for i, cell := range row.cells {
+			cellFrame := frame{x: 0, width: max(widths[i]-2*cellPadding, 1)}
+			height = max(height, r.blocks(cell.blocks, cellFrame, 0))
+		}
+
+		if f.paginate && y+height > r.contentBottom && y > r.contentTop {
+			y = r.newPage()
+		}
+
+		if f.draw {
+			x := f.x
+			for i, cell := range row.cells {
+				cellFrame := frame{x: x + cellPadding, width: max(widths[i]-2*cellPadding, 1), draw: true}
+				r.blocks(cell.blocks, cellFrame, y)
+
+				borders := t.borders
+				if cell.borders != nil {
+					borders = *cell.borders
+				}
+				if borders {
+					r.pdf.SetDrawColor(0, 0, 0)
+					r.pdf.Rect(x, y, widths[i], height, "D")
+				}
+				x += widths[i]
+			}
+		}
+
+		y += height
+	}
+
+	return y
+}
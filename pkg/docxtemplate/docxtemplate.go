@@ -0,0 +1,367 @@
+// Package docxtemplate fills docx templates written with placeholders typed
+// as plain text in the document body, headers and footers:
+//
+//	{{customer.name}}                           prints a field
+//	{{brl settlement.repair_cost}}              prints a field through a helper
+//	{{#if settlement}}...{{else}}...{{/if}}     shows one of two parts
+//	{{#each comments as comment}}...{{/each}}   repeats a part for each item
+//
+// Inside a loop the item is read through its name, {{comment.content}}, and
+// {{@number}} counts the items from 1. Block tags typed alone in their
+// paragraphs repeat or hide whole paragraphs, and blocks opened and closed in
+// different cells of a table row repeat or hide the row.
+//
+// The data is a tree of map[string]any holding strings, numbers, booleans,
+// dates and lists of maps. Values of type Image print pictures.
+package docxtemplate
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var templatePart = regexp.MustCompile(`^word/(document|header\d*|footer\d*)\.xml$`)
+
+const contentTypesName = "[Content_Types].xml"
+
+const (
+	breakXML = `</w:t><w:br/><w:t xml:space="preserve">`
+	tabXML   = `</w:t><w:tab/><w:t xml:space="preserve">`
+)
+
+var textEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+	"\r\n", breakXML,
+	"\n", breakXML,
+	"\r", breakXML,
+	"\t", tabXML,
+)
+
+type Template struct {
+	files []*zip.File
+	parts map[string][]node
+	funcs Funcs
+}
+
+// Parse reads the placeholders of a docx file. Helpers are looked up in funcs
+// before the builtin ones.
+func Parse(docx []byte, funcs Funcs) (*Template, error) {
+	archive, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Template{
+		files: archive.File,
+		parts: make(map[string][]node),
+		funcs: funcs,
+	}
+
+	for _, file := range archive.File {
+		if !templatePart.MatchString(file.Name) {
+			continue
+		}
+
+		content, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := parsePart(string(content), funcs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		t.parts[file.Name] = nodes
+	}
+
+	return t, nil
+}
+
+// Execute writes the docx filled with data. Fields missing from data and
+// unknown helpers are errors, Check finds them beforehand.
+func (t *Template) Execute(w io.Writer, data map[string]any) error {
+	e := &execution{Template: t, data: data, media: make(map[string][]media)}
+
+	rendered := make(map[string]string)
+	for _, file := range t.files {
+		nodes, ok := t.parts[file.Name]
+		if !ok {
+			continue
+		}
+
+		e.part = file.Name
+		var part strings.Builder
+		if err := e.render(&part, nodes, nil); err != nil {
+			return fmt.Errorf("%s: %w", file.Name, err)
+		}
+		rendered[file.Name] = part.String()
+	}
+
+	if err := e.linkMedia(rendered); err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range t.files {
+		content, ok := rendered[file.Name]
+		if !ok {
+			if err := archive.Copy(file); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := writeFile(archive, file.Name, file.Modified, []byte(content)); err != nil {
+			return err
+		}
+		delete(rendered, file.Name)
+	}
+
+	// relationships of parts that had none
+	for _, name := range slices.Sorted(maps.Keys(rendered)) {
+		if err := writeFile(archive, name, time.Time{}, []byte(rendered[name])); err != nil {
+			return err
+		}
+	}
+
+	for _, part := range slices.Sorted(maps.Keys(e.media)) {
+		for _, m := range e.media[part] {
+			if err := writeFile(archive, path.Join(path.Dir(part), "media", m.name), time.Time{}, m.data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return archive.Close()
+}
+
+// execution is the state of an Execute call.
+type execution struct {
+	*Template
+	data   map[string]any
+	part   string
+	media  map[string][]media
+	images int
+}
+
+func (e *execution) render(b *strings.Builder, nodes []node, s *scope) error {
+	for _, n := range nodes {
+		if n.kind == textNode {
+			b.WriteString(n.text)
+			continue
+		}
+
+		value, err := e.eval(n.expr, e.data, s)
+		if err != nil {
+			return fmt.Errorf("{{%s}}: %w", n.text, err)
+		}
+
+		switch n.kind {
+		case valueNode:
+			if img, ok := value.(Image); ok {
+				b.WriteString(e.drawing(img))
+				continue
+			}
+			b.WriteString(textEscaper.Replace(format(value)))
+		case ifNode:
+			branch := n.alt
+			if truthy(value) {
+				branch = n.body
+			}
+			if err := e.render(b, branch, s); err != nil {
+				return err
+			}
+		case eachNode:
+			items, ok := toItems(value)
+			if !ok && value != nil {
+				return fmt.Errorf("{{%s}}: not a list", n.text)
+			}
+
+			if len(items) == 0 {
+				if err := e.render(b, n.alt, s); err != nil {
+					return err
+				}
+			}
+
+			for i, item := range items {
+				if err := e.render(b, n.body, &scope{name: n.name, value: item, number: i + 1, parent: s}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// linkMedia adds the relationships and content types of the inserted images
+// to the rendered files.
+func (e *execution) linkMedia(rendered map[string]string) error {
+	if len(e.media) == 0 {
+		return nil
+	}
+
+	files := make(map[string]*zip.File, len(e.files))
+	for _, file := range e.files {
+		files[file.Name] = file
+	}
+
+	for part, images := range e.media {
+		name := relationshipsName(part)
+
+		var relationships []byte
+		if file, ok := files[name]; ok {
+			content, err := readFile(file)
+			if err != nil {
+				return err
+			}
+			relationships = content
+		}
+		rendered[name] = addRelationships(string(relationships), images)
+	}
+
+	file, ok := files[contentTypesName]
+	if !ok {
+		return fmt.Errorf("%s not found", contentTypesName)
+	}
+
+	contentTypes, err := readFile(file)
+	if err != nil {
+		return err
+	}
+	rendered[contentTypesName] = addContentTypes(string(contentTypes), e.media)
+
+	return nil
+}
+
+// Check lists the placeholders that cannot be filled from data: unknown
+// fields and helpers, loops over values that are not lists and helpers given
+// the wrong arguments. Both branches of every block are checked, and loops
+// over their first item, so data should hold an item in every list.
+func (t *Template) Check(data map[string]any) []string {
+	problems := make([]string, 0)
+	seen := make(map[string]bool)
+	report := func(n node) {
+		if !seen[n.text] {
+			seen[n.text] = true
+			problems = append(problems, n.text)
+		}
+	}
+
+	for _, file := range t.files {
+		if nodes, ok := t.parts[file.Name]; ok {
+			t.check(nodes, data, nil, report)
+		}
+	}
+
+	return problems
+}
+
+func (t *Template) check(nodes []node, data map[string]any, s *scope, report func(node)) {
+	for _, n := range nodes {
+		if n.kind == textNode {
+			continue
+		}
+
+		value, err := t.eval(n.expr, data, s)
+		if err != nil {
+			report(n)
+		}
+
+		switch n.kind {
+		case ifNode:
+			t.check(n.body, data, s, report)
+			t.check(n.alt, data, s, report)
+		case eachNode:
+			items, ok := toItems(value)
+			if !ok && value != nil {
+				report(n)
+			}
+
+			var item any
+			if len(items) > 0 {
+				item = items[0]
+			}
+			t.check(n.body, data, &scope{name: n.name, value: item, number: 1, parent: s}, report)
+			t.check(n.alt, data, s, report)
+		}
+	}
+}
+
+// Uses reports whether any placeholder reads the given field of the data, as
+// {{#each photos as photo}} does with photos.
+func (t *Template) Uses(field string) bool {
+	for _, nodes := range t.parts {
+		if usesField(nodes, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func usesField(nodes []node, field string) bool {
+	for _, n := range nodes {
+		if n.kind != textNode && exprUses(n.expr, field) || usesField(n.body, field) || usesField(n.alt, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func exprUses(e expr, field string) bool {
+	switch e.kind {
+	case pathExpr:
+		return e.path[0] == field
+	case callExpr:
+		for _, arg := range e.args {
+			if exprUses(arg, field) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func writeFile(archive *zip.Writer, name string, modified time.Time, content []byte) error {
+	writer, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(content)
+	return err
+}
+
+func readFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,242 @@
+package docxtemplate
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFuncs = Funcs{
+	"upper": func(args ...any) (any, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("upper takes 1 argument, got %d", len(args))
+		}
+		return strings.ToUpper(format(args[0])), nil
+	},
+}
+
+func newTestDocx(t *testing.T, body string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			body + `</w:body></w:document>`,
+		"word/header1.xml":    `<w:hdr><w:p><w:r><w:t>{{case.claim}}</w:t></w:r></w:p></w:hdr>`,
+		"word/styles.xml":     `<w:styles>{{not a placeholder}}</w:styles>`,
+		"word/media/a.png":    "png",
+		"[Content_Types].xml": `<Types></Types>`,
+	} {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return buffer.Bytes()
+}
+
+func render(t *testing.T, body string, data map[string]any) string {
+	t.Helper()
+
+	template, err := Parse(newTestDocx(t, body), testFuncs)
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	require.NoError(t, template.Execute(&output, data))
+
+	return readPart(t, output.Bytes(), "word/document.xml")
+}
+
+func readPart(t *testing.T, docx []byte, name string) string {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	require.NoError(t, err)
+
+	file, err := archive.Open(name)
+	require.NoError(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	return strings.TrimSuffix(strings.TrimPrefix(string(content),
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`), `</w:body></w:document>`)
+}
+
+func paragraph(text string) string {
+	return `<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+}
+
+func TestExecute(t *testing.T) {
+	data := map[string]any{
+		"case":       map[string]any{"claim": "SIN-1", "closed": false},
+		"customer":   map[string]any{"name": "Ana & Filhos", "notes": "first\nsecond"},
+		"settlement": nil,
+		"comments": []map[string]any{
+			{"content": "Visita"},
+			{"content": "Laudo"},
+		},
+	}
+
+	t.Run("prints fields escaped for xml", func(t *testing.T) {
+		output := render(t, paragraph(`Cliente: {{ customer.name }}`)+paragraph(`{{customer.notes}}`), data)
+
+		assert.Equal(t, `<w:p><w:r><w:t xml:space="preserve">Cliente: Ana &amp; Filhos</w:t></w:r></w:p>`+
+			`<w:p><w:r><w:t xml:space="preserve">first</w:t><w:br/><w:t xml:space="preserve">second</w:t></w:r></w:p>`, output)
+	})
+
+	t.Run("reads tags split across runs", func(t *testing.T) {
+		output := render(t, `<w:p><w:r><w:t>Sinistro {</w:t></w:r><w:proofErr w:type="spellStart"/>`+
+			`<w:r><w:rPr><w:b/></w:rPr><w:t>{case.</w:t></w:r><w:r><w:t>claim}} aberto</w:t></w:r></w:p>`, data)
+
+		assert.Equal(t, `<w:p><w:r><w:t xml:space="preserve">Sinistro SIN-1</w:t></w:r><w:proofErr w:type="spellStart"/>`+
+			`<w:r><w:rPr><w:b/></w:rPr><w:t></w:t></w:r><w:r><w:t> aberto</w:t></w:r></w:p>`, output)
+	})
+
+	t.Run("calls helpers with nested expressions", func(t *testing.T) {
+		output := render(t, paragraph(`{{upper (default settlement.label “sem acordo”)}}`), data)
+
+		assert.Equal(t, `<w:p><w:r><w:t xml:space="preserve">SEM ACORDO</w:t></w:r></w:p>`, output)
+	})
+
+	t.Run("repeats paragraphs holding only block tags", func(t *testing.T) {
+		output := render(t, paragraph(`{{#each comments as comment}}`)+
+			paragraph(`{{@number}}. {{comment.content}}`)+
+			paragraph(`{{/each}}`), data)
+
+		assert.Equal(t, `<w:p><w:r><w:t xml:space="preserve">1. Visita</w:t></w:r></w:p>`+
+			`<w:p><w:r><w:t xml:space="preserve">2. Laudo</w:t></w:r></w:p>`, output)
+	})
+
+	t.Run("repeats table rows of blocks spanning cells", func(t *testing.T) {
+		row := `<w:tr><w:tc><w:p><w:r><w:t>{{#each comments as comment}}{{@number}}</w:t></w:r></w:p></w:tc>` +
+			`<w:tc><w:p><w:r><w:t>{{comment.content}}{{/each}}</w:t></w:r></w:p></w:tc></w:tr>`
+
+		output := render(t, `<w:tbl><w:tr><w:tc><w:p/></w:tc></w:tr>`+row+`</w:tbl>`, data)
+
+		assert.Equal(t, `<w:tbl><w:tr><w:tc><w:p/></w:tc></w:tr>`+
+			`<w:tr><w:tc><w:p><w:r><w:t xml:space="preserve">1</w:t></w:r></w:p></w:tc>`+
+			`<w:tc><w:p><w:r><w:t xml:space="preserve">Visita</w:t></w:r></w:p></w:tc></w:tr>`+
+			`<w:tr><w:tc><w:p><w:r><w:t xml:space="preserve">2</w:t></w:r></w:p></w:tc>`+
+			`<w:tc><w:p><w:r><w:t xml:space="preserve">Laudo</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`, output)
+	})
+
+	t.Run("shows one branch of conditionals", func(t *testing.T) {
+		body := paragraph(`{{#if settlement}}`) + paragraph(`Acordo`) + paragraph(`{{else}}`) + paragraph(`Sem acordo`) + paragraph(`{{/if}}`) +
+			paragraph(`Status: {{#if eq case.closed false}}aberto{{else}}fechado{{/if}}.`)
+
+		output := render(t, body, data)
+
+		assert.Equal(t, paragraph(`Sem acordo`)+`<w:p><w:r><w:t xml:space="preserve">Status: aberto.</w:t></w:r></w:p>`, output)
+	})
+
+	t.Run("fills headers and copies other parts", func(t *testing.T) {
+		template, err := Parse(newTestDocx(t, paragraph(`x`)), testFuncs)
+		require.NoError(t, err)
+
+		var output bytes.Buffer
+		require.NoError(t, template.Execute(&output, data))
+
+		assert.Equal(t, `<w:hdr><w:p><w:r><w:t xml:space="preserve">SIN-1</w:t></w:r></w:p></w:hdr>`, readPart(t, output.Bytes(), "word/header1.xml"))
+		assert.Equal(t, `<w:styles>{{not a placeholder}}</w:styles>`, readPart(t, output.Bytes(), "word/styles.xml"))
+	})
+
+	t.Run("inserts images with their relationships", func(t *testing.T) {
+		template, err := Parse(newTestDocx(t, paragraph(`{{#each photos as photo}}Foto {{@number}}: {{photo}}{{/each}}`)), testFuncs)
+		require.NoError(t, err)
+
+		var output bytes.Buffer
+		require.NoError(t, template.Execute(&output, map[string]any{
+			"case":   data["case"],
+			"photos": []any{Image{Data: []byte("\x89PNG"), Width: 2, Height: 1}, Image{}},
+		}))
+
+		document := readPart(t, output.Bytes(), "word/document.xml")
+		assert.Contains(t, document, `Foto 1: </w:t><w:drawing>`)
+		assert.Contains(t, document, `<wp:extent cx="720000" cy="360000"/>`)
+		assert.Contains(t, document, `r:embed="rIdTemplateImage1"`)
+		assert.Contains(t, document, `</w:drawing><w:t xml:space="preserve">Foto 2: </w:t>`)
+		assert.Equal(t, 1, strings.Count(document, "<w:drawing>"))
+
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+
+			`<Relationship Id="rIdTemplateImage1" Type="`+imageRelationship+`" Target="media/template_image1.png"/></Relationships>`,
+			readPart(t, output.Bytes(), "word/_rels/document.xml.rels"))
+		assert.Equal(t, `<Types><Default Extension="png" ContentType="image/png"/></Types>`, readPart(t, output.Bytes(), "[Content_Types].xml"))
+		assert.Equal(t, "\x89PNG", readPart(t, output.Bytes(), "word/media/template_image1.png"))
+	})
+
+	t.Run("fails on unknown fields", func(t *testing.T) {
+		template, err := Parse(newTestDocx(t, paragraph(`{{customer.nickname}}`)), testFuncs)
+		require.NoError(t, err)
+
+		err = template.Execute(io.Discard, data)
+
+		assert.ErrorIs(t, err, errUnknownField)
+	})
+}
+
+func TestParse_Errors(t *testing.T) {
+	for body, message := range map[string]string{
+		paragraph(`{{#if settlement}}`):                          "{{#if settlement}} is not closed",
+		paragraph(`{{#each comments}}`) + paragraph(`{{/each}}`): "loops name their item",
+		paragraph(`{{#if a}}{{/each}}`):                          "{{/each}} closes {{#if a}}",
+		paragraph(`{{/if}}`):                                     "{{/if}} without a block to close",
+		paragraph(`{{else}}`):                                    "{{else}} outside of a block",
+		paragraph(`{{#unless a}}{{/unless}}`):                    "unknown block {{#unless a}}",
+		paragraph(`{{customer.name`) + paragraph(`}}`):           `placeholder "{{customer.name" is not closed`,
+		paragraph(`{{upper (customer.name}}`):                    "unclosed parenthesis",
+	} {
+		_, err := Parse(newTestDocx(t, body), testFuncs)
+
+		require.Error(t, err, body)
+		assert.Contains(t, err.Error(), message, body)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	body := paragraph(`{{customer.name}} {{customer.nickname}} {{brl case.value}}`) +
+		paragraph(`{{#each comments as comment}}`) +
+		paragraph(`{{comment.content}} {{comment.author}} {{upper comment.content comment.content}}`) +
+		paragraph(`{{/each}}`) +
+		paragraph(`{{#each customer as item}}{{/each}}{{#if settlement}}{{settlement.reason}}{{/if}}`)
+
+	template, err := Parse(newTestDocx(t, body), testFuncs)
+	require.NoError(t, err)
+
+	unknown := template.Check(map[string]any{
+		"case":       map[string]any{"claim": "", "value": 0.0},
+		"customer":   map[string]any{"name": ""},
+		"comments":   []map[string]any{{"content": ""}},
+		"settlement": map[string]any{"reason": ""},
+	})
+
+	assert.Equal(t, []string{
+		"customer.nickname",
+		"brl case.value",
+		"comment.author",
+		"upper comment.content comment.content",
+		"#each customer as item",
+	}, unknown)
+}
+
+func TestTruthy(t *testing.T) {
+	for value, expected := range map[any]bool{
+		nil: false, "": false, "a": true, 0.0: false, 1.5: true, 0: false, true: true, false: false,
+	} {
+		assert.Equal(t, expected, truthy(value), value)
+	}
+
+	assert.False(t, truthy([]map[string]any{}))
+	assert.True(t, truthy(map[string]any{}))
+}
@@ -0,0 +1,408 @@
+package docxtemplate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Funcs maps helper names to the functions called with the values of their
+// arguments, as in {{brl product.value}}.
+type Funcs map[string]func(args ...any) (any, error)
+
+// builtins are the helpers every template can use, mostly to write the
+// conditions of {{#if}} blocks.
+var builtins = Funcs{
+	"eq": func(args ...any) (any, error) {
+		if err := arity("eq", args, 2); err != nil {
+			return nil, err
+		}
+		return equal(args[0], args[1]), nil
+	},
+	"ne": func(args ...any) (any, error) {
+		if err := arity("ne", args, 2); err != nil {
+			return nil, err
+		}
+		return !equal(args[0], args[1]), nil
+	},
+	"gt": func(args ...any) (any, error) {
+		a, b, err := numbers("gt", args)
+		return a > b, err
+	},
+	"lt": func(args ...any) (any, error) {
+		a, b, err := numbers("lt", args)
+		return a < b, err
+	},
+	"not": func(args ...any) (any, error) {
+		if err := arity("not", args, 1); err != nil {
+			return nil, err
+		}
+		return !truthy(args[0]), nil
+	},
+	"and": func(args ...any) (any, error) {
+		for _, arg := range args {
+			if !truthy(arg) {
+				return false, nil
+			}
+		}
+		return len(args) > 0, nil
+	},
+	"or": func(args ...any) (any, error) {
+		for _, arg := range args {
+			if truthy(arg) {
+				return true, nil
+			}
+		}
+		return false, nil
+	},
+	"default": func(args ...any) (any, error) {
+		if err := arity("default", args, 2); err != nil {
+			return nil, err
+		}
+		if truthy(args[0]) {
+			return args[0], nil
+		}
+		return args[1], nil
+	},
+}
+
+type exprKind int
+
+const (
+	pathExpr exprKind = iota
+	literalExpr
+	callExpr
+)
+
+// expr is a field path, a literal or a helper call.
+type expr struct {
+	kind  exprKind
+	path  []string
+	value any
+	fn    string
+	args  []expr
+}
+
+var (
+	errUnknownField  = errors.New("unknown field")
+	errUnknownHelper = errors.New("unknown helper")
+)
+
+// parseExpr reads the expression of a tag. A word naming a helper calls it
+// when it comes first, and so does any first word followed by arguments.
+func parseExpr(source string, funcs Funcs) (expr, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return expr{}, err
+	}
+
+	if len(tokens) == 0 {
+		return expr{}, errors.New("empty expression")
+	}
+
+	parsed, rest, err := parseCall(tokens, funcs)
+	if err != nil {
+		return expr{}, err
+	}
+
+	if len(rest) > 0 {
+		return expr{}, fmt.Errorf("unexpected %q", rest[0])
+	}
+
+	return parsed, nil
+}
+
+func parseCall(tokens []string, funcs Funcs) (expr, []string, error) {
+	head := tokens[0]
+	_, isHelper := funcs[head]
+	if !isHelper {
+		_, isHelper = builtins[head]
+	}
+
+	hasArgs := len(tokens) > 1 && tokens[1] != ")"
+	if !isWord(head) || !isHelper && !hasArgs {
+		return parseOperand(tokens, funcs)
+	}
+
+	args := make([]expr, 0)
+	rest := tokens[1:]
+	for len(rest) > 0 && rest[0] != ")" {
+		arg, remaining, err := parseOperand(rest, funcs)
+		if err != nil {
+			return expr{}, nil, err
+		}
+		args = append(args, arg)
+		rest = remaining
+	}
+
+	return expr{kind: callExpr, fn: head, args: args}, rest, nil
+}
+
+func parseOperand(tokens []string, funcs Funcs) (expr, []string, error) {
+	token := tokens[0]
+
+	switch {
+	case token == "(":
+		if len(tokens) < 2 || tokens[1] == ")" {
+			return expr{}, nil, errors.New("empty parentheses")
+		}
+		inner, rest, err := parseCall(tokens[1:], funcs)
+		if err != nil {
+			return expr{}, nil, err
+		}
+		if len(rest) == 0 {
+			return expr{}, nil, errors.New("unclosed parenthesis")
+		}
+		return inner, rest[1:], nil
+	case token == ")":
+		return expr{}, nil, errors.New("unexpected )")
+	case strings.HasPrefix(token, `"`):
+		return expr{kind: literalExpr, value: strings.Trim(token, `"`)}, tokens[1:], nil
+	case token == "true" || token == "false":
+		return expr{kind: literalExpr, value: token == "true"}, tokens[1:], nil
+	}
+
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return expr{kind: literalExpr, value: number}, tokens[1:], nil
+	}
+
+	path := strings.Split(token, ".")
+	for _, segment := range path {
+		if segment == "" {
+			return expr{}, nil, fmt.Errorf("invalid field %q", token)
+		}
+	}
+
+	return expr{kind: pathExpr, path: path}, tokens[1:], nil
+}
+
+// lex splits an expression into words, quoted strings and parentheses. Word
+// turns straight quotes typed in a document into curly ones, so both are read.
+func lex(source string) ([]string, error) {
+	source = strings.NewReplacer("“", `"`, "”", `"`).Replace(source)
+
+	tokens := make([]string, 0)
+	for i := 0; i < len(source); {
+		char, size := utf8.DecodeRuneInString(source[i:])
+		switch {
+		case unicode.IsSpace(char):
+			i += size
+		case char == '(' || char == ')':
+			tokens = append(tokens, string(char))
+			i++
+		case char == '"':
+			end := strings.IndexByte(source[i+1:], '"')
+			if end < 0 {
+				return nil, errors.New("unclosed string")
+			}
+			tokens = append(tokens, source[i:i+end+2])
+			i += end + 2
+		default:
+			end := strings.IndexFunc(source[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+			})
+			if end < 0 {
+				end = len(source) - i
+			}
+			tokens = append(tokens, source[i:i+end])
+			i += end
+		}
+	}
+
+	return tokens, nil
+}
+
+func isWord(token string) bool {
+	return token != "(" && token != ")" && !strings.HasPrefix(token, `"`)
+}
+
+// scope holds the variables of the loops around a tag, the innermost first.
+type scope struct {
+	name   string
+	value  any
+	number int
+	parent *scope
+}
+
+func (s *scope) lookup(name string) (any, bool) {
+	for current := s; current != nil; current = current.parent {
+		if name == "@number" && current.name != "" {
+			return current.number, true
+		}
+		if current.name == name {
+			return current.value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (t *Template) eval(e expr, data map[string]any, s *scope) (any, error) {
+	switch e.kind {
+	case literalExpr:
+		return e.value, nil
+	case callExpr:
+		fn, ok := t.funcs[e.fn]
+		if !ok {
+			fn, ok = builtins[e.fn]
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w %s", errUnknownHelper, e.fn)
+		}
+
+		args := make([]any, 0, len(e.args))
+		for _, arg := range e.args {
+			value, err := t.eval(arg, data, s)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, value)
+		}
+
+		return fn(args...)
+	default:
+		return resolve(e.path, data, s)
+	}
+}
+
+// resolve walks a field path from a loop variable or the template data. Fields
+// of empty values are empty, so {{settlement.reason}} prints nothing for cases
+// without a settlement.
+func resolve(path []string, data map[string]any, s *scope) (any, error) {
+	value, ok := s.lookup(path[0])
+	if !ok {
+		value, ok = data[path[0]]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w %s", errUnknownField, strings.Join(path, "."))
+	}
+
+	for i, field := range path[1:] {
+		if value == nil {
+			return nil, nil
+		}
+
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w %s: %s has no fields", errUnknownField, strings.Join(path, "."), strings.Join(path[:i+1], "."))
+		}
+
+		if value, ok = fields[field]; !ok {
+			return nil, fmt.Errorf("%w %s", errUnknownField, strings.Join(path, "."))
+		}
+	}
+
+	return value, nil
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case time.Time:
+		return !v.IsZero()
+	}
+
+	if number, ok := toNumber(value); ok {
+		return number != 0
+	}
+
+	if items, ok := toItems(value); ok {
+		return len(items) > 0
+	}
+
+	return true
+}
+
+func toNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// toItems reads the list looped by {{#each}}.
+func toItems(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []map[string]any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, item)
+		}
+		return items, true
+	case []string:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, item)
+		}
+		return items, true
+	default:
+		return nil, false
+	}
+}
+
+func equal(a, b any) bool {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return x == y
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func numbers(name string, args []any) (float64, float64, error) {
+	if err := arity(name, args, 2); err != nil {
+		return 0, 0, err
+	}
+
+	a, okA := toNumber(args[0])
+	b, okB := toNumber(args[1])
+	if !okA || !okB {
+		return 0, 0, fmt.Errorf("%s compares numbers", name)
+	}
+
+	return a, b, nil
+}
+
+func arity(name string, args []any, expected int) error {
+	if len(args) != expected {
+		return fmt.Errorf("%s takes %d arguments, got %d", name, expected, len(args))
+	}
+
+	return nil
+}
+
+// format prints a value. Dates and amounts are printed through helpers, which
+// know the locale of the document.
+func format(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,123 @@
+package docxtemplate
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Image is a picture printed by a placeholder in the run it is typed in,
+// sized in centimetres. Images without data print nothing.
+type Image struct {
+	Data   []byte
+	Width  float64
+	Height float64
+}
+
+const (
+	emusPerCentimetre = 360000
+	// docPrIDBase keeps the ids of inserted drawings clear of the ones Word
+	// numbers the template's own drawings with
+	docPrIDBase = 100000
+
+	imageRelationship = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+)
+
+var imageContentTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// media is an image inserted in a part, stored as word/media/<name>.
+type media struct {
+	name       string
+	relationID string
+	data       []byte
+}
+
+func imageExtension(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG")):
+		return "png"
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return "gif"
+	default:
+		return "jpeg"
+	}
+}
+
+// drawing registers the image in the part being rendered and returns the
+// inline drawing showing it, closing the text element the placeholder was in
+// and opening another after it.
+func (e *execution) drawing(img Image) string {
+	if len(img.Data) == 0 || img.Width <= 0 || img.Height <= 0 {
+		return ""
+	}
+
+	e.images++
+	m := media{
+		name:       fmt.Sprintf("template_image%d.%s", e.images, imageExtension(img.Data)),
+		relationID: fmt.Sprintf("rIdTemplateImage%d", e.images),
+		data:       img.Data,
+	}
+	e.media[e.part] = append(e.media[e.part], m)
+
+	cx, cy := int64(img.Width*emusPerCentimetre), int64(img.Height*emusPerCentimetre)
+	id := docPrIDBase + e.images
+
+	return fmt.Sprintf(`</w:t><w:drawing>`+
+		`<wp:inline xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" distT="0" distB="0" distL="0" distR="0">`+
+		`<wp:extent cx="%[1]d" cy="%[2]d"/><wp:docPr id="%[3]d" name="%[4]s"/>`+
+		`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">`+
+		`<a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:nvPicPr><pic:cNvPr id="%[3]d" name="%[4]s"/><pic:cNvPicPr/></pic:nvPicPr>`+
+		`<pic:blipFill><a:blip xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:embed="%[5]s"/>`+
+		`<a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+		`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%[1]d" cy="%[2]d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr>`+
+		`</pic:pic></a:graphicData></a:graphic></wp:inline></w:drawing><w:t xml:space="preserve">`,
+		cx, cy, id, m.name, m.relationID)
+}
+
+// relationshipsName is the file holding the relationships of a part, as
+// word/_rels/document.xml.rels for word/document.xml.
+func relationshipsName(part string) string {
+	return path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+}
+
+// addRelationships links the images inserted in a part to their files,
+// creating the relationships file of parts that had none.
+func addRelationships(relationships string, images []media) string {
+	if relationships == "" {
+		relationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"></Relationships>`
+	}
+
+	var added strings.Builder
+	for _, m := range images {
+		fmt.Fprintf(&added, `<Relationship Id="%s" Type="%s" Target="media/%s"/>`, m.relationID, imageRelationship, m.name)
+	}
+
+	return strings.Replace(relationships, "</Relationships>", added.String()+"</Relationships>", 1)
+}
+
+// addContentTypes declares the formats of the inserted images the template
+// did not use yet.
+func addContentTypes(contentTypes string, images map[string][]media) string {
+	declared := make(map[string]bool)
+	var added strings.Builder
+	for _, partImages := range images {
+		for _, m := range partImages {
+			extension := strings.TrimPrefix(path.Ext(m.name), ".")
+			if declared[extension] || strings.Contains(strings.ToLower(contentTypes), fmt.Sprintf(`extension="%s"`, extension)) {
+				continue
+			}
+			declared[extension] = true
+			fmt.Fprintf(&added, `<Default Extension="%s" ContentType="%s"/>`, extension, imageContentTypes[extension])
+		}
+	}
+
+	return strings.Replace(contentTypes, "</Types>", added.String()+"</Types>", 1)
+}
@@ -0,0 +1,480 @@
+package docxtemplate
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type nodeKind int
+
+const (
+	textNode nodeKind = iota
+	valueNode
+	ifNode
+	eachNode
+)
+
+// node is a piece of a part: raw xml, a printed value or a block holding
+// other nodes.
+type node struct {
+	kind nodeKind
+	text string // the xml of text nodes, the tag of the others
+	expr expr
+	name string // variable of each blocks
+	body []node
+	alt  []node // else branch, taken by each blocks over empty lists
+}
+
+type tagKind int
+
+const (
+	valueTag tagKind = iota
+	ifTag
+	eachTag
+	elseTag
+	endIfTag
+	endEachTag
+)
+
+// tag is a {{placeholder}} found in a part. It is cut from the part along
+// with cutStart:cutEnd and placed back at at, which moves block tags to the
+// bounds of the paragraphs and rows they repeat.
+type tag struct {
+	kind     tagKind
+	source   string
+	start    int
+	end      int
+	at       int
+	cutStart int
+	cutEnd   int
+	moved    bool
+}
+
+type element struct {
+	start int
+	end   int
+}
+
+var (
+	textElement   = regexp.MustCompile(`(<w:t(?:\s[^>]*[^/>])?>)([^<]*)</w:t>`)
+	structureTag  = regexp.MustCompile(`<(/?)w:(p|tc|tr)[\s>/]`)
+	nonTextMarkup = []string{"<w:drawing", "<w:pict", "<w:object"}
+)
+
+// parsePart reads the placeholders of a document part into the nodes it is
+// rendered from.
+func parsePart(part string, funcs Funcs) ([]node, error) {
+	part = mergeSplitTags(part)
+
+	tags, err := findTags(part)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return []node{{kind: textNode, text: part}}, nil
+	}
+
+	placeTags(part, tags, scanElements(part))
+
+	return buildNodes(part, tags, funcs)
+}
+
+// mergeSplitTags moves each placeholder into the text element it starts in.
+// Word splits the text of a paragraph into runs at every change of spelling
+// state or formatting, so a typed {{customer.name}} is often stored as
+// "{{", "customer", ".name}}" in separate runs. Tags spanning paragraphs are
+// left alone and reported as unclosed.
+func mergeSplitTags(part string) string {
+	matches := textElement.FindAllStringSubmatchIndex(part, -1)
+	if len(matches) == 0 {
+		return part
+	}
+
+	var joined strings.Builder
+	owners := make([]int, 0, len(part)/4)
+	for i, match := range matches {
+		joined.WriteString(part[match[4]:match[5]])
+		for range match[5] - match[4] {
+			owners = append(owners, i)
+		}
+	}
+
+	text := joined.String()
+	preserve := make([]bool, len(matches))
+	for pos := 0; ; {
+		start := strings.Index(text[pos:], "{{")
+		if start < 0 {
+			break
+		}
+		start += pos
+
+		end := strings.Index(text[start+2:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 4
+
+		// an unclosed tag before this one, start over from the inner one
+		if inner := strings.Index(text[start+2:end-2], "{{"); inner >= 0 {
+			pos = start + 2 + inner
+			continue
+		}
+
+		first, last := owners[start], owners[end-1]
+		if first == last || !strings.Contains(part[matches[first][1]:matches[last][0]], "</w:p>") {
+			for k := start; k < end; k++ {
+				owners[k] = first
+			}
+			preserve[first] = true
+		}
+		pos = end
+	}
+
+	texts := make([]strings.Builder, len(matches))
+	for k := range len(text) {
+		texts[owners[k]].WriteByte(text[k])
+	}
+
+	var merged strings.Builder
+	merged.Grow(len(part))
+	last := 0
+	for i, match := range matches {
+		merged.WriteString(part[last:match[0]])
+
+		openTag := part[match[2]:match[3]]
+		if preserve[i] && !strings.Contains(openTag, "xml:space") {
+			// printed values may start or end with spaces
+			openTag = strings.Replace(openTag, "<w:t", `<w:t xml:space="preserve"`, 1)
+		}
+
+		merged.WriteString(openTag)
+		merged.WriteString(texts[i].String())
+		merged.WriteString("</w:t>")
+		last = match[1]
+	}
+	merged.WriteString(part[last:])
+
+	return merged.String()
+}
+
+func findTags(part string) ([]tag, error) {
+	tags := make([]tag, 0)
+	for pos := 0; ; {
+		start := strings.Index(part[pos:], "{{")
+		if start < 0 {
+			return tags, nil
+		}
+		start += pos
+
+		length := strings.Index(part[start:], "}}")
+		if length < 0 || strings.ContainsAny(part[start:start+length], "<>") {
+			excerpt := part[start:]
+			if markup := strings.IndexAny(excerpt, "<>"); markup >= 0 {
+				excerpt = excerpt[:markup]
+			}
+			return nil, fmt.Errorf("placeholder %q is not closed in its paragraph", html.UnescapeString(excerpt))
+		}
+		end := start + length + 2
+
+		source := strings.TrimSpace(html.UnescapeString(part[start+2 : end-2]))
+		kind, err := classifyTag(source)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag{
+			kind:     kind,
+			source:   source,
+			start:    start,
+			end:      end,
+			at:       start,
+			cutStart: start,
+			cutEnd:   end,
+		})
+		pos = end
+	}
+}
+
+func classifyTag(source string) (tagKind, error) {
+	switch {
+	case source == "else":
+		return elseTag, nil
+	case source == "/if":
+		return endIfTag, nil
+	case source == "/each":
+		return endEachTag, nil
+	case strings.HasPrefix(source, "#if "):
+		return ifTag, nil
+	case strings.HasPrefix(source, "#each "):
+		return eachTag, nil
+	case strings.HasPrefix(source, "#"), strings.HasPrefix(source, "/"):
+		return 0, fmt.Errorf("unknown block {{%s}}, blocks are #if and #each", source)
+	default:
+		return valueTag, nil
+	}
+}
+
+// scanElements finds the paragraphs, table cells and table rows of a part.
+func scanElements(part string) map[string][]element {
+	stacks := make(map[string][]int)
+	elements := make(map[string][]element)
+
+	for _, match := range structureTag.FindAllStringSubmatchIndex(part, -1) {
+		name := part[match[4]:match[5]]
+		tagEnd := match[0] + strings.IndexByte(part[match[0]:], '>') + 1
+
+		if match[3] > match[2] {
+			stack := stacks[name]
+			if len(stack) == 0 {
+				continue
+			}
+			elements[name] = append(elements[name], element{start: stack[len(stack)-1], end: tagEnd})
+			stacks[name] = stack[:len(stack)-1]
+			continue
+		}
+
+		if part[tagEnd-2] == '/' {
+			continue
+		}
+		stacks[name] = append(stacks[name], match[0])
+	}
+
+	return elements
+}
+
+// innermost returns the index of the smallest element holding pos, -1 when
+// none does.
+func innermost(elements []element, pos int) int {
+	found := -1
+	for i, e := range elements {
+		if e.start <= pos && pos < e.end && (found < 0 || e.start > elements[found].start) {
+			found = i
+		}
+	}
+
+	return found
+}
+
+// placeTags moves block tags so blocks repeat or hide whole rows and
+// paragraphs:
+//   - a block opened and closed in different cells of the same table row
+//     wraps the row;
+//   - block tags alone in a paragraph outside tables take the paragraph
+//     with them, so they leave no blank lines behind.
+//
+// Other tags are rendered where they are typed.
+func placeTags(part string, tags []tag, elements map[string][]element) {
+	cells, rows := elements["tc"], elements["tr"]
+
+	opened := make([]int, 0)
+	for i := range tags {
+		switch tags[i].kind {
+		case ifTag, eachTag:
+			opened = append(opened, i)
+		case endIfTag, endEachTag:
+			if len(opened) == 0 {
+				continue
+			}
+			open := opened[len(opened)-1]
+			opened = opened[:len(opened)-1]
+
+			openCell, closeCell := innermost(cells, tags[open].start), innermost(cells, tags[i].start)
+			if openCell < 0 || closeCell < 0 || openCell == closeCell {
+				continue
+			}
+
+			row := innermost(rows, tags[open].start)
+			if row < 0 || row != innermost(rows, tags[i].start) {
+				continue
+			}
+
+			tags[open].at, tags[open].moved = rows[row].start, true
+			tags[i].at, tags[i].moved = rows[row].end, true
+		}
+	}
+
+	paragraphs := elements["p"]
+	byParagraph := make(map[int][]int)
+	for i, t := range tags {
+		if paragraph := innermost(paragraphs, t.start); paragraph >= 0 {
+			byParagraph[paragraph] = append(byParagraph[paragraph], i)
+		}
+	}
+
+	for paragraph, indexes := range byParagraph {
+		bounds := paragraphs[paragraph]
+		if !holdsOnlyBlockTags(part, bounds, tags, indexes) || innermost(cells, bounds.start) >= 0 {
+			continue
+		}
+
+		for _, i := range indexes {
+			tags[i].at, tags[i].cutStart, tags[i].cutEnd = bounds.start, bounds.start, bounds.end
+		}
+	}
+}
+
+func holdsOnlyBlockTags(part string, bounds element, tags []tag, indexes []int) bool {
+	var rest strings.Builder
+	last := bounds.start
+	for _, i := range indexes {
+		if tags[i].kind == valueTag || tags[i].moved {
+			return false
+		}
+		rest.WriteString(part[last:tags[i].start])
+		last = tags[i].end
+	}
+	rest.WriteString(part[last:bounds.end])
+
+	paragraph := rest.String()
+	for _, markup := range nonTextMarkup {
+		if strings.Contains(paragraph, markup) {
+			return false
+		}
+	}
+
+	for _, match := range textElement.FindAllStringSubmatch(paragraph, -1) {
+		if strings.TrimSpace(html.UnescapeString(match[2])) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// buildNodes cuts the tags out of the part and nests the xml between them in
+// the blocks they open.
+func buildNodes(part string, tags []tag, funcs Funcs) ([]node, error) {
+	order := make([]int, len(tags))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return tags[a].at - tags[b].at })
+
+	cuts := make([]element, 0, len(tags))
+	for _, t := range tags {
+		cut := element{start: t.cutStart, end: t.cutEnd}
+		if !slices.Contains(cuts, cut) {
+			cuts = append(cuts, cut)
+		}
+	}
+	slices.SortFunc(cuts, func(a, b element) int { return a.start - b.start })
+
+	b := &nodeBuilder{funcs: funcs}
+	cursor := 0
+	for _, i := range order {
+		if tags[i].at > cursor {
+			b.text(part, cursor, tags[i].at, cuts)
+			cursor = tags[i].at
+		}
+		if err := b.tag(tags[i]); err != nil {
+			return nil, err
+		}
+	}
+	b.text(part, cursor, len(part), cuts)
+
+	if len(b.open) > 0 {
+		return nil, fmt.Errorf("{{%s}} is not closed", b.open[len(b.open)-1].text)
+	}
+
+	return b.root, nil
+}
+
+type nodeBuilder struct {
+	funcs  Funcs
+	root   []node
+	open   []*node
+	inElse []bool
+}
+
+func (b *nodeBuilder) append(n node) {
+	target := &b.root
+	if depth := len(b.open); depth > 0 {
+		target = &b.open[depth-1].body
+		if b.inElse[depth-1] {
+			target = &b.open[depth-1].alt
+		}
+	}
+
+	if last := len(*target) - 1; n.kind == textNode && last >= 0 && (*target)[last].kind == textNode {
+		(*target)[last].text += n.text
+		return
+	}
+
+	*target = append(*target, n)
+}
+
+// text appends part[from:to], leaving out what was cut with the tags.
+func (b *nodeBuilder) text(part string, from, to int, cuts []element) {
+	for _, cut := range cuts {
+		if cut.end <= from || cut.start >= to {
+			continue
+		}
+		if cut.start > from {
+			b.append(node{kind: textNode, text: part[from:cut.start]})
+		}
+		from = max(from, cut.end)
+	}
+
+	if from < to {
+		b.append(node{kind: textNode, text: part[from:to]})
+	}
+}
+
+func (b *nodeBuilder) tag(t tag) error {
+	switch t.kind {
+	case valueTag:
+		e, err := parseExpr(t.source, b.funcs)
+		if err != nil {
+			return fmt.Errorf("{{%s}}: %w", t.source, err)
+		}
+		b.append(node{kind: valueNode, text: t.source, expr: e})
+	case ifTag:
+		e, err := parseExpr(strings.TrimPrefix(t.source, "#if "), b.funcs)
+		if err != nil {
+			return fmt.Errorf("{{%s}}: %w", t.source, err)
+		}
+		b.push(node{kind: ifNode, text: t.source, expr: e})
+	case eachTag:
+		list, name, found := strings.Cut(strings.TrimPrefix(t.source, "#each "), " as ")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, ". ()\"") {
+			return fmt.Errorf("{{%s}}: loops name their item, as in {{#each comments as comment}}", t.source)
+		}
+		e, err := parseExpr(list, b.funcs)
+		if err != nil {
+			return fmt.Errorf("{{%s}}: %w", t.source, err)
+		}
+		b.push(node{kind: eachNode, text: t.source, expr: e, name: name})
+	case elseTag:
+		depth := len(b.open)
+		if depth == 0 || b.inElse[depth-1] {
+			return errors.New("{{else}} outside of a block")
+		}
+		b.inElse[depth-1] = true
+	case endIfTag, endEachTag:
+		depth := len(b.open)
+		if depth == 0 {
+			return fmt.Errorf("{{%s}} without a block to close", t.source)
+		}
+
+		block := b.open[depth-1]
+		if (block.kind == ifNode) != (t.kind == endIfTag) {
+			return fmt.Errorf("{{%s}} closes {{%s}}", t.source, block.text)
+		}
+
+		b.open, b.inElse = b.open[:depth-1], b.inElse[:depth-1]
+		b.append(*block)
+	}
+
+	return nil
+}
+
+func (b *nodeBuilder) push(n node) {
+	b.open = append(b.open, &n)
+	b.inElse = append(b.inElse, false)
+}
@@ -0,0 +1,85 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJPEG(t *testing.T, width, height, orientation int) []byte {
+	t.Helper()
+
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, width, height)), nil))
+	if orientation == 0 {
+		return encoded.Bytes()
+	}
+
+	// a little endian TIFF header and an IFD holding only the orientation
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00\x12\x01\x03\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint16(tiff[18:], uint16(orientation))
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	data := append([]byte{0xFF, 0xD8}, app1...)
+	data = append(data, segment...)
+	return append(data, encoded.Bytes()[2:]...)
+}
+
+func TestOrientation(t *testing.T) {
+	assert.Equal(t, 6, Orientation(newTestJPEG(t, 4, 2, 6)))
+	assert.Equal(t, 1, Orientation(newTestJPEG(t, 4, 2, 0)))
+	assert.Equal(t, 1, Orientation([]byte("\x89PNG")))
+	assert.Equal(t, 1, Orientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}))
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("turns rotated pictures upright", func(t *testing.T) {
+		_, size, err := Normalize(newTestJPEG(t, 40, 20, 6), 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(20, 40), size)
+	})
+
+	t.Run("shrinks the longest side", func(t *testing.T) {
+		data, size, err := Normalize(newTestJPEG(t, 400, 100, 0), 200)
+
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(200, 50), size)
+
+		decoded, err := jpeg.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 200, 50), decoded.Bounds())
+	})
+
+	t.Run("prints transparent areas white", func(t *testing.T) {
+		var encoded bytes.Buffer
+		require.NoError(t, png.Encode(&encoded, image.NewNRGBA(image.Rect(0, 0, 8, 8))))
+
+		data, _, err := Normalize(encoded.Bytes(), 0)
+		require.NoError(t, err)
+
+		decoded, err := jpeg.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		r, g, b, _ := decoded.At(4, 4).RGBA()
+		white, _, _, _ := color.White.RGBA()
+		assert.InDelta(t, white, r, 0x400)
+		assert.InDelta(t, white, g, 0x400)
+		assert.InDelta(t, white, b, 0x400)
+	})
+
+	t.Run("fails on unknown formats", func(t *testing.T) {
+		_, _, err := Normalize([]byte("%PDF-1.7"), 0)
+
+		assert.ErrorIs(t, err, image.ErrFormat)
+	})
+}
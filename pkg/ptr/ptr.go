@@ -7,3 +7,11 @@ func ToString(s *string) string {
 	}
 	return *s
 }
+
+// FromString returns a pointer to s, or nil if s is empty.
+func FromString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,228 @@
+package xls
+
+import (
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+)
+
+// Compound File Binary is the container legacy Office files are stored in: a
+// small FAT file system whose streams hold the actual documents.
+
+var cfbSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+const (
+	cfbEndOfChain  = 0xFFFFFFFE
+	cfbFreeSect    = 0xFFFFFFFF
+	cfbHeaderSize  = 512
+	cfbDirEntry    = 128
+	cfbHeaderDIFAT = 109
+
+	cfbTypeStream = 2
+	cfbTypeRoot   = 5
+)
+
+type cfbEntry struct {
+	name  string
+	kind  byte
+	start uint32
+	size  uint64
+}
+
+type cfbFile struct {
+	data           []byte
+	sectorSize     int
+	miniSectorSize int
+	miniCutoff     uint64
+	fat            []uint32
+	miniFAT        []uint32
+	miniStream     []byte
+	entries        []cfbEntry
+}
+
+func openCFB(data []byte) (*cfbFile, error) {
+	if len(data) < cfbHeaderSize || string(data[:8]) != string(cfbSignature) {
+		return nil, ErrNotXLS
+	}
+
+	sectorShift := binary.LittleEndian.Uint16(data[0x1E:])
+	miniSectorShift := binary.LittleEndian.Uint16(data[0x20:])
+	if sectorShift < 7 || sectorShift > 16 || miniSectorShift > sectorShift {
+		return nil, errors.New("xls: invalid sector size")
+	}
+
+	f := &cfbFile{
+		data:           data,
+		sectorSize:     1 << sectorShift,
+		miniSectorSize: 1 << miniSectorShift,
+		miniCutoff:     uint64(binary.LittleEndian.Uint32(data[0x38:])),
+	}
+
+	if err := f.readFAT(); err != nil {
+		return nil, err
+	}
+
+	dir, err := f.chain(binary.LittleEndian.Uint32(data[0x30:]))
+	if err != nil {
+		return nil, err
+	}
+	for offset := 0; offset+cfbDirEntry <= len(dir); offset += cfbDirEntry {
+		f.entries = append(f.entries, parseCFBEntry(dir[offset:offset+cfbDirEntry]))
+	}
+
+	if len(f.entries) == 0 || f.entries[0].kind != cfbTypeRoot {
+		return nil, errors.New("xls: missing root directory entry")
+	}
+
+	// the root entry owns the mini stream where streams under the cutoff live
+	if f.miniStream, err = f.chain(f.entries[0].start); err != nil {
+		return nil, err
+	}
+
+	miniFAT, err := f.chain(binary.LittleEndian.Uint32(data[0x3C:]))
+	if err != nil {
+		return nil, err
+	}
+	f.miniFAT = sectorIDs(miniFAT)
+
+	return f, nil
+}
+
+// readFAT collects the FAT sectors listed in the header and in the DIFAT
+// sector chain, then loads the allocation table they hold.
+func (f *cfbFile) readFAT() error {
+	fatSectors := make([]uint32, 0, cfbHeaderDIFAT)
+	fatSectors = append(fatSectors, sectorIDs(f.data[0x4C:0x4C+cfbHeaderDIFAT*4])...)
+
+	next := binary.LittleEndian.Uint32(f.data[0x44:])
+	for visited := 0; next != cfbEndOfChain && next != cfbFreeSect; visited++ {
+		sector, err := f.sector(next)
+		if err != nil || visited > len(f.data)/f.sectorSize {
+			return errors.New("xls: broken DIFAT chain")
+		}
+
+		ids := sectorIDs(sector)
+		fatSectors = append(fatSectors, ids[:len(ids)-1]...)
+		next = ids[len(ids)-1]
+	}
+
+	for _, id := range fatSectors {
+		if id == cfbFreeSect || id == cfbEndOfChain {
+			continue
+		}
+
+		sector, err := f.sector(id)
+		if err != nil {
+			return err
+		}
+		f.fat = append(f.fat, sectorIDs(sector)...)
+	}
+
+	return nil
+}
+
+func (f *cfbFile) sector(id uint32) ([]byte, error) {
+	start := (int(id) + 1) * f.sectorSize
+	if id >= cfbFreeSect-5 || start+f.sectorSize > len(f.data) {
+		return nil, errors.New("xls: sector out of range")
+	}
+
+	return f.data[start : start+f.sectorSize], nil
+}
+
+// chain reads a whole sector chain, failing on loops instead of spinning.
+func (f *cfbFile) chain(start uint32) ([]byte, error) {
+	var stream []byte
+	for id, visited := start, 0; id != cfbEndOfChain && id != cfbFreeSect; visited++ {
+		if int(id) >= len(f.fat) || visited > len(f.fat) {
+			return nil, errors.New("xls: broken sector chain")
+		}
+
+		sector, err := f.sector(id)
+		if err != nil {
+			return nil, err
+		}
+		stream = append(stream, sector...)
+		id = f.fat[id]
+	}
+
+	return stream, nil
+}
+
+func (f *cfbFile) miniChain(start uint32) ([]byte, error) {
+	var stream []byte
+	for id, visited := start, 0; id != cfbEndOfChain && id != cfbFreeSect; visited++ {
+		offset := int(id) * f.miniSectorSize
+		if int(id) >= len(f.miniFAT) || visited > len(f.miniFAT) || offset+f.miniSectorSize > len(f.miniStream) {
+			return nil, errors.New("xls: broken mini sector chain")
+		}
+
+		stream = append(stream, f.miniStream[offset:offset+f.miniSectorSize]...)
+		id = f.miniFAT[id]
+	}
+
+	return stream, nil
+}
+
+// stream returns the content of the first stream named after one of names.
+func (f *cfbFile) stream(names ...string) ([]byte, error) {
+	for _, name := range names {
+		for _, entry := range f.entries {
+			if entry.kind != cfbTypeStream || entry.name != name {
+				continue
+			}
+
+			var content []byte
+			var err error
+			if entry.size < f.miniCutoff {
+				content, err = f.miniChain(entry.start)
+			} else {
+				content, err = f.chain(entry.start)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if uint64(len(content)) < entry.size {
+				return nil, errors.New("xls: stream is truncated")
+			}
+
+			return content[:entry.size], nil
+		}
+	}
+
+	return nil, ErrNotXLS
+}
+
+func parseCFBEntry(raw []byte) cfbEntry {
+	nameSize := int(binary.LittleEndian.Uint16(raw[0x40:]))
+	if nameSize > 64 {
+		nameSize = 64
+	}
+
+	units := make([]uint16, 0, nameSize/2)
+	for i := 0; i+1 < nameSize; i += 2 {
+		unit := binary.LittleEndian.Uint16(raw[i:])
+		if unit == 0 {
+			break
+		}
+		units = append(units, unit)
+	}
+
+	return cfbEntry{
+		name:  string(utf16.Decode(units)),
+		kind:  raw[0x42],
+		start: binary.LittleEndian.Uint32(raw[0x74:]),
+		// version 3 files may leave garbage in the high half of the size
+		size: uint64(binary.LittleEndian.Uint32(raw[0x78:])),
+	}
+}
+
+func sectorIDs(raw []byte) []uint32 {
+	ids := make([]uint32, len(raw)/4)
+	for i := range ids {
+		ids[i] = binary.LittleEndian.Uint32(raw[i*4:])
+	}
+
+	return ids
+}
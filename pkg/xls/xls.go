@@ -0,0 +1,504 @@
+// Package xls reads the cell values of legacy Excel 97-2003 (.xls, BIFF8)
+// workbooks. Only what imports need is supported: cell text, numbers, booleans
+// and cached formula results, with date cells rendered as RFC 3339 like the
+// .xlsx reader does. Styles, merged cells and charts are ignored.
+package xls
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+var (
+	ErrNotXLS      = errors.New("xls: not an Excel 97-2003 workbook")
+	ErrUnsupported = errors.New("xls: only Excel 97-2003 (BIFF8) workbooks are supported")
+)
+
+type Workbook struct {
+	Sheets []Sheet
+}
+
+// Sheet holds the cells of a worksheet indexed by row and column, so empty
+// rows and cells keep their position.
+type Sheet struct {
+	Name string
+	Rows [][]string
+}
+
+const (
+	recordBOF        = 0x0809
+	recordEOF        = 0x000A
+	recordBoundSheet = 0x0085
+	recordSST        = 0x00FC
+	recordContinue   = 0x003C
+	recordFormat     = 0x041E
+	recordXF         = 0x00E0
+	recordDateMode   = 0x0022
+	recordLabelSST   = 0x00FD
+	recordLabel      = 0x0204
+	recordNumber     = 0x0203
+	recordRK         = 0x027E
+	recordMulRK      = 0x00BD
+	recordBoolErr    = 0x0205
+	recordFormula    = 0x0006
+	recordString     = 0x0207
+
+	biff8Version   = 0x0600
+	maxColumns     = 256
+	sheetWorksheet = 0
+)
+
+type record struct {
+	id   uint16
+	body []byte
+}
+
+type globals struct {
+	sheets  []boundSheet
+	strings []string
+	formats map[uint16]string
+	xfs     []uint16
+	use1904 bool
+}
+
+type boundSheet struct {
+	name   string
+	offset int
+	kind   byte
+}
+
+// Read parses an .xls file held in memory.
+func Read(data []byte) (*Workbook, error) {
+	container, err := openCFB(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := container.stream("Workbook", "Book")
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := readGlobals(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	workbook := &Workbook{Sheets: make([]Sheet, 0, len(g.sheets))}
+	for _, bs := range g.sheets {
+		if bs.kind != sheetWorksheet {
+			continue
+		}
+
+		rows, err := g.readSheet(stream, bs.offset)
+		if err != nil {
+			return nil, err
+		}
+		workbook.Sheets = append(workbook.Sheets, Sheet{Name: bs.name, Rows: rows})
+	}
+
+	return workbook, nil
+}
+
+func readRecord(stream []byte, offset int) (record, int, bool) {
+	if offset+4 > len(stream) {
+		return record{}, offset, false
+	}
+
+	id := binary.LittleEndian.Uint16(stream[offset:])
+	size := int(binary.LittleEndian.Uint16(stream[offset+2:]))
+	end := offset + 4 + size
+	if end > len(stream) {
+		return record{}, offset, false
+	}
+
+	return record{id: id, body: stream[offset+4 : end]}, end, true
+}
+
+func readGlobals(stream []byte) (*globals, error) {
+	bof, offset, ok := readRecord(stream, 0)
+	if !ok || bof.id != recordBOF || len(bof.body) < 2 {
+		return nil, ErrUnsupported
+	}
+	if binary.LittleEndian.Uint16(bof.body) != biff8Version {
+		return nil, ErrUnsupported
+	}
+
+	g := &globals{formats: make(map[uint16]string)}
+	for {
+		rec, next, ok := readRecord(stream, offset)
+		if !ok {
+			return nil, errors.New("xls: workbook globals are truncated")
+		}
+		offset = next
+
+		switch rec.id {
+		case recordEOF:
+			return g, nil
+		case recordBoundSheet:
+			if len(rec.body) < 8 {
+				continue
+			}
+			name, _ := readChars(rec.body[8:], int(rec.body[6]), rec.body[7]&0x01 != 0)
+			g.sheets = append(g.sheets, boundSheet{
+				name:   name,
+				offset: int(binary.LittleEndian.Uint32(rec.body)),
+				kind:   rec.body[5],
+			})
+		case recordSST:
+			segments := [][]byte{rec.body}
+			for {
+				cont, afterCont, ok := readRecord(stream, offset)
+				if !ok || cont.id != recordContinue {
+					break
+				}
+				segments = append(segments, cont.body)
+				offset = afterCont
+			}
+			g.strings = readSST(segments)
+		case recordFormat:
+			if len(rec.body) < 5 {
+				continue
+			}
+			code, _ := readChars(rec.body[5:], int(binary.LittleEndian.Uint16(rec.body[2:])), rec.body[4]&0x01 != 0)
+			g.formats[binary.LittleEndian.Uint16(rec.body)] = code
+		case recordXF:
+			if len(rec.body) < 4 {
+				continue
+			}
+			g.xfs = append(g.xfs, binary.LittleEndian.Uint16(rec.body[2:]))
+		case recordDateMode:
+			if len(rec.body) >= 2 {
+				g.use1904 = binary.LittleEndian.Uint16(rec.body) == 1
+			}
+		}
+	}
+}
+
+func (g *globals) readSheet(stream []byte, offset int) ([][]string, error) {
+	bof, offset, ok := readRecord(stream, offset)
+	if !ok || bof.id != recordBOF {
+		return nil, errors.New("xls: worksheet does not start with a BOF record")
+	}
+
+	var grid [][]string
+	set := func(row, col int, value string) {
+		if col >= maxColumns {
+			return
+		}
+		for len(grid) <= row {
+			grid = append(grid, nil)
+		}
+		for len(grid[row]) <= col {
+			grid[row] = append(grid[row], "")
+		}
+		grid[row][col] = value
+	}
+
+	// charts embedded in the sheet come as nested BOF/EOF blocks
+	depth := 1
+	pendingRow, pendingCol := -1, -1
+	for depth > 0 {
+		rec, next, ok := readRecord(stream, offset)
+		if !ok {
+			return nil, errors.New("xls: worksheet is truncated")
+		}
+		offset = next
+
+		switch rec.id {
+		case recordBOF:
+			depth++
+			continue
+		case recordEOF:
+			depth--
+			continue
+		}
+
+		if depth > 1 {
+			continue
+		}
+
+		if rec.id == recordString {
+			if pendingRow >= 0 && len(rec.body) >= 3 {
+				text, _ := readChars(rec.body[3:], int(binary.LittleEndian.Uint16(rec.body)), rec.body[2]&0x01 != 0)
+				set(pendingRow, pendingCol, text)
+			}
+			pendingRow, pendingCol = -1, -1
+			continue
+		}
+
+		if len(rec.body) < 6 {
+			continue
+		}
+
+		row := int(binary.LittleEndian.Uint16(rec.body))
+		col := int(binary.LittleEndian.Uint16(rec.body[2:]))
+		xf := binary.LittleEndian.Uint16(rec.body[4:])
+
+		switch rec.id {
+		case recordLabelSST:
+			if len(rec.body) < 10 {
+				continue
+			}
+			if idx := int(binary.LittleEndian.Uint32(rec.body[6:])); idx < len(g.strings) {
+				set(row, col, g.strings[idx])
+			}
+		case recordLabel:
+			if len(rec.body) < 9 {
+				continue
+			}
+			text, _ := readChars(rec.body[9:], int(binary.LittleEndian.Uint16(rec.body[6:])), rec.body[8]&0x01 != 0)
+			set(row, col, text)
+		case recordNumber:
+			if len(rec.body) < 14 {
+				continue
+			}
+			set(row, col, g.formatNumber(math.Float64frombits(binary.LittleEndian.Uint64(rec.body[6:])), xf))
+		case recordRK:
+			if len(rec.body) < 10 {
+				continue
+			}
+			set(row, col, g.formatNumber(decodeRK(binary.LittleEndian.Uint32(rec.body[6:])), xf))
+		case recordMulRK:
+			// row, first column, then xf/rk pairs and the last column
+			for i, pos := 0, 4; pos+6 <= len(rec.body)-2; i, pos = i+1, pos+6 {
+				cellXF := binary.LittleEndian.Uint16(rec.body[pos:])
+				set(row, col+i, g.formatNumber(decodeRK(binary.LittleEndian.Uint32(rec.body[pos+2:])), cellXF))
+			}
+		case recordBoolErr:
+			if len(rec.body) < 8 {
+				continue
+			}
+			if rec.body[7] == 0 {
+				set(row, col, formatBool(rec.body[6] != 0))
+			}
+		case recordFormula:
+			if len(rec.body) < 14 {
+				continue
+			}
+			result := rec.body[6:14]
+			if result[6] != 0xFF || result[7] != 0xFF {
+				set(row, col, g.formatNumber(math.Float64frombits(binary.LittleEndian.Uint64(result)), xf))
+				continue
+			}
+
+			switch result[0] {
+			case 0: // the text comes in the STRING record that follows
+				pendingRow, pendingCol = row, col
+			case 1:
+				set(row, col, formatBool(result[2] != 0))
+			}
+		}
+
+	}
+
+	return grid, nil
+}
+
+// formatNumber renders a numeric cell, turning it into a date when its style
+// uses a date format.
+func (g *globals) formatNumber(value float64, xf uint16) string {
+	if int(xf) < len(g.xfs) && g.isDateFormat(g.xfs[xf]) {
+		epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		if g.use1904 {
+			epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		return epoch.Add(time.Duration(value * float64(24*time.Hour))).Round(time.Second).Format(time.RFC3339)
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (g *globals) isDateFormat(formatID uint16) bool {
+	switch {
+	case formatID >= 14 && formatID <= 22,
+		formatID >= 27 && formatID <= 36,
+		formatID >= 45 && formatID <= 47,
+		formatID >= 50 && formatID <= 58:
+		return true
+	}
+
+	code, found := g.formats[formatID]
+	return found && isDateFormatCode(code)
+}
+
+// isDateFormatCode looks for date or time tokens in a custom format, ignoring
+// quoted literals, escaped characters and bracketed sections like [Red].
+func isDateFormatCode(code string) bool {
+	inQuotes, inBrackets, escaped := false, false, false
+	for _, char := range strings.ToLower(code) {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case char == '[':
+			inBrackets = true
+		case char == ']':
+			inBrackets = false
+		case inBrackets:
+		case strings.ContainsRune("dmyhs", char):
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeRK(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+
+	return value
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "TRUE"
+	}
+
+	return "FALSE"
+}
+
+// readChars reads count characters stored either as UTF-16 or as compressed
+// Latin-1 bytes, stopping early when the data runs out.
+func readChars(data []byte, count int, highByte bool) (string, int) {
+	if !highByte {
+		count = min(count, len(data))
+		runes := make([]rune, count)
+		for i := range runes {
+			runes[i] = rune(data[i])
+		}
+		return string(runes), count
+	}
+
+	count = min(count, len(data)/2)
+	units := make([]uint16, count)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+
+	return string(utf16.Decode(units)), count * 2
+}
+
+// sstReader walks the shared string table across its CONTINUE records.
+// Strings may be split between records, and each continuation of a string
+// starts with a flag byte telling how the remaining characters are stored.
+type sstReader struct {
+	segments [][]byte
+	segment  int
+	pos      int
+}
+
+func (r *sstReader) available() int {
+	for r.segment < len(r.segments) && r.pos >= len(r.segments[r.segment]) {
+		r.pos -= len(r.segments[r.segment])
+		r.segment++
+	}
+	if r.segment >= len(r.segments) {
+		return 0
+	}
+
+	return len(r.segments[r.segment]) - r.pos
+}
+
+func (r *sstReader) bytes(n int) ([]byte, bool) {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		if r.available() == 0 {
+			return nil, false
+		}
+		out = append(out, r.segments[r.segment][r.pos])
+		r.pos++
+	}
+
+	return out, true
+}
+
+func (r *sstReader) skip(n int) {
+	r.pos += n
+}
+
+func readSST(segments [][]byte) []string {
+	r := &sstReader{segments: segments}
+	header, ok := r.bytes(8)
+	if !ok {
+		return nil
+	}
+
+	unique := int(binary.LittleEndian.Uint32(header[4:]))
+	// the count comes from the file, never trust it for the allocation
+	sst := make([]string, 0, min(unique, 1<<16))
+	for range unique {
+		stringHeader, ok := r.bytes(3)
+		if !ok {
+			break
+		}
+
+		remaining := int(binary.LittleEndian.Uint16(stringHeader))
+		flags := stringHeader[2]
+
+		richRuns, extSize := 0, 0
+		if flags&0x08 != 0 {
+			runs, ok := r.bytes(2)
+			if !ok {
+				break
+			}
+			richRuns = int(binary.LittleEndian.Uint16(runs))
+		}
+		if flags&0x04 != 0 {
+			size, ok := r.bytes(4)
+			if !ok {
+				break
+			}
+			extSize = int(binary.LittleEndian.Uint32(size))
+		}
+
+		var text strings.Builder
+		highByte := flags&0x01 != 0
+		for remaining > 0 {
+			segment := r.segment
+			if r.available() == 0 {
+				break
+			}
+			if r.segment != segment {
+				highByte = r.segments[r.segment][r.pos]&0x01 != 0
+				r.pos++
+				continue
+			}
+
+			chars, used := readChars(r.segments[r.segment][r.pos:], remaining, highByte)
+			if used == 0 {
+				break
+			}
+			text.WriteString(chars)
+			r.pos += used
+			if highByte {
+				used /= 2
+			}
+			remaining -= used
+		}
+
+		sst = append(sst, text.String())
+		r.skip(richRuns*4 + extSize)
+	}
+
+	return sst
+}
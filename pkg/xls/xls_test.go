@@ -0,0 +1,223 @@
+package xls
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("should read every worksheet with positioned cells", func(t *testing.T) {
+		workbook, err := Read(buildWorkbook(t))
+
+		require.NoError(t, err)
+		require.Len(t, workbook.Sheets, 2)
+
+		assert.Equal(t, "Capa", workbook.Sheets[0].Name)
+		assert.Equal(t, [][]string{{"Relatório de sinistros"}}, workbook.Sheets[0].Rows)
+
+		casos := workbook.Sheets[1]
+		assert.Equal(t, "Casos", casos.Name)
+		require.Len(t, casos.Rows, 4)
+		assert.Equal(t, []string{"Sinistro", "Cidade", "Valor", "Data", "Abertura", "Parcelas", "Cobertura", "Ativo"}, casos.Rows[0])
+		assert.Equal(t, []string{"123456", "São Paulo", "1299.9", "2025-01-31T00:00:00Z", "2025-02-01T12:00:00Z", "12", "Garantia estendida", "TRUE"}, casos.Rows[1])
+		assert.Nil(t, casos.Rows[2])
+		assert.Equal(t, []string{"", "Curitiba", "3", "4.5"}, casos.Rows[3])
+	})
+
+	t.Run("should fail when the file is not a compound document", func(t *testing.T) {
+		_, err := Read([]byte("Sinistro;Cidade\n123;Curitiba\n"))
+
+		assert.ErrorIs(t, err, ErrNotXLS)
+	})
+
+	t.Run("should fail on workbooks older than Excel 97", func(t *testing.T) {
+		stream := biffRecord(recordBOF, u16(0x0500), u16(0x0005))
+		stream = append(stream, biffRecord(recordEOF)...)
+
+		_, err := Read(compoundFile(stream))
+
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+}
+
+func TestIsDateFormatCode(t *testing.T) {
+	assert.True(t, isDateFormatCode("dd/mm/yyyy"))
+	assert.True(t, isDateFormatCode("[$-416]d-mmm-yy;@"))
+	assert.False(t, isDateFormatCode(`"R$" #,##0.00`))
+	assert.False(t, isDateFormatCode("[Red]#,##0"))
+	assert.False(t, isDateFormatCode("General"))
+}
+
+func buildWorkbook(t *testing.T) []byte {
+	t.Helper()
+
+	boundSheet := func(name string) []byte {
+		return biffRecord(recordBoundSheet, u32(0), []byte{0, sheetWorksheet, byte(len(name)), 0}, []byte(name))
+	}
+
+	// the last shared string is split between the SST and its CONTINUE
+	// record, the continuation switching to UTF-16
+	sst := append(u32(6), u32(5)...)
+	for _, text := range []string{"Sinistro", "Cidade", "Valor", "Data"} {
+		sst = append(sst, compressedString(text)...)
+	}
+	sst = append(sst, u16(len("Relatório de sinistros"))...)
+	sst = append(sst, 0, 'R', 'e', 'l', 'a', 't')
+
+	globals := biffRecord(recordBOF, u16(biff8Version), u16(0x0005))
+	globals = append(globals, biffRecord(recordFormat, u16(164), u16(10), []byte{0}, []byte("dd/mm/yyyy"))...)
+	for _, formatID := range []int{0, 14, 164, 4} {
+		globals = append(globals, biffRecord(recordXF, u16(0), u16(formatID), make([]byte, 16))...)
+	}
+	capaOffset := len(globals) + 4
+	globals = append(globals, boundSheet("Capa")...)
+	casosOffset := len(globals) + 4
+	globals = append(globals, boundSheet("Casos")...)
+	globals = append(globals, biffRecord(recordSST, sst)...)
+	globals = append(globals, biffRecord(recordContinue, []byte{1}, utf16LE("ório de sinistros"))...)
+	globals = append(globals, biffRecord(recordEOF)...)
+
+	capa := biffRecord(recordBOF, u16(biff8Version), u16(0x0010))
+	capa = append(capa, biffRecord(recordLabelSST, cell(0, 0, 0), u32(4))...)
+	capa = append(capa, biffRecord(recordEOF)...)
+
+	casos := biffRecord(recordBOF, u16(biff8Version), u16(0x0010))
+	for col := range 4 {
+		casos = append(casos, biffRecord(recordLabelSST, cell(0, col, 0), u32(uint32(col)))...)
+	}
+	casos = append(casos, biffRecord(recordLabel, cell(0, 4, 0), u16(8), []byte{0}, []byte("Abertura"))...)
+	casos = append(casos, biffRecord(recordLabel, cell(0, 5, 0), u16(8), []byte{0}, []byte("Parcelas"))...)
+	casos = append(casos, biffRecord(recordLabel, cell(0, 6, 0), u16(9), []byte{0}, []byte("Cobertura"))...)
+	casos = append(casos, biffRecord(recordLabel, cell(0, 7, 0), u16(5), []byte{0}, []byte("Ativo"))...)
+
+	casos = append(casos, biffRecord(recordRK, cell(1, 0, 0), u32(123456<<2|0x02))...)
+	casos = append(casos, biffRecord(recordLabel, cell(1, 1, 0), u16(9), []byte{1}, utf16LE("São Paulo"))...)
+	casos = append(casos, biffRecord(recordNumber, cell(1, 2, 3), f64(1299.9))...)
+	casos = append(casos, biffRecord(recordNumber, cell(1, 3, 1), f64(45688))...)
+	casos = append(casos, biffRecord(recordNumber, cell(1, 4, 2), f64(45689.5))...)
+	casos = append(casos, biffRecord(recordFormula, cell(1, 5, 0), f64(12), make([]byte, 6))...)
+	casos = append(casos, biffRecord(recordFormula, cell(1, 6, 0), []byte{0, 0, 0, 0, 0, 0, 0xFF, 0xFF}, make([]byte, 6))...)
+	casos = append(casos, biffRecord(recordString, u16(18), []byte{0}, []byte("Garantia estendida"))...)
+	casos = append(casos, biffRecord(recordBoolErr, cell(1, 7, 0), []byte{1, 0})...)
+
+	// an embedded chart, its cells are not part of the sheet
+	casos = append(casos, biffRecord(recordBOF, u16(biff8Version), u16(0x0020))...)
+	casos = append(casos, biffRecord(recordLabel, cell(2, 0, 0), u16(5), []byte{0}, []byte("chart"))...)
+	casos = append(casos, biffRecord(recordEOF)...)
+
+	casos = append(casos, biffRecord(recordLabel, cell(3, 1, 0), u16(8), []byte{0}, []byte("Curitiba"))...)
+	casos = append(casos, biffRecord(recordMulRK, u16(3), u16(2), u16(0), u32(3<<2|0x02), u16(0), u32(450<<2|0x03), u16(3))...)
+	casos = append(casos, biffRecord(recordEOF)...)
+
+	stream := append(globals, capa...)
+	binary.LittleEndian.PutUint32(stream[capaOffset:], uint32(len(globals)))
+	binary.LittleEndian.PutUint32(stream[casosOffset:], uint32(len(stream)))
+	stream = append(stream, casos...)
+
+	return compoundFile(stream)
+}
+
+// compoundFile wraps a workbook stream in a version 3 compound file with one
+// FAT sector and one directory sector. The stream is padded past the mini
+// stream cutoff so it is stored in regular sectors.
+func compoundFile(stream []byte) []byte {
+	const sectorSize = 512
+
+	stream = append(stream, make([]byte, max(0, 4096-len(stream)))...)
+	streamSectors := (len(stream) + sectorSize - 1) / sectorSize
+
+	header := make([]byte, sectorSize)
+	copy(header, cfbSignature)
+	binary.LittleEndian.PutUint16(header[0x18:], 0x3E)
+	binary.LittleEndian.PutUint16(header[0x1A:], 3)
+	binary.LittleEndian.PutUint16(header[0x1C:], 0xFFFE)
+	binary.LittleEndian.PutUint16(header[0x1E:], 9)
+	binary.LittleEndian.PutUint16(header[0x20:], 6)
+	binary.LittleEndian.PutUint32(header[0x2C:], 1)
+	binary.LittleEndian.PutUint32(header[0x30:], 1)
+	binary.LittleEndian.PutUint32(header[0x38:], 4096)
+	binary.LittleEndian.PutUint32(header[0x3C:], cfbEndOfChain)
+	binary.LittleEndian.PutUint32(header[0x44:], cfbEndOfChain)
+	for i := range cfbHeaderDIFAT {
+		binary.LittleEndian.PutUint32(header[0x4C+i*4:], cfbFreeSect)
+	}
+	binary.LittleEndian.PutUint32(header[0x4C:], 0)
+
+	fat := make([]byte, sectorSize)
+	for i := range sectorSize / 4 {
+		binary.LittleEndian.PutUint32(fat[i*4:], cfbFreeSect)
+	}
+	binary.LittleEndian.PutUint32(fat[0:], 0xFFFFFFFD)
+	binary.LittleEndian.PutUint32(fat[4:], cfbEndOfChain)
+	for i := range streamSectors {
+		next := uint32(i + 3)
+		if i == streamSectors-1 {
+			next = cfbEndOfChain
+		}
+		binary.LittleEndian.PutUint32(fat[(i+2)*4:], next)
+	}
+
+	dir := make([]byte, sectorSize)
+	dirEntry(dir[0:], "Root Entry", cfbTypeRoot, cfbEndOfChain, 0)
+	dirEntry(dir[cfbDirEntry:], "Workbook", cfbTypeStream, 2, len(stream))
+
+	file := append(header, fat...)
+	file = append(file, dir...)
+	file = append(file, stream...)
+
+	return append(file, make([]byte, streamSectors*sectorSize-len(stream))...)
+}
+
+func dirEntry(raw []byte, name string, kind byte, start uint32, size int) {
+	units := utf16.Encode([]rune(name))
+	for i, unit := range units {
+		binary.LittleEndian.PutUint16(raw[i*2:], unit)
+	}
+	binary.LittleEndian.PutUint16(raw[0x40:], uint16((len(units)+1)*2))
+	raw[0x42] = kind
+	binary.LittleEndian.PutUint32(raw[0x74:], start)
+	binary.LittleEndian.PutUint32(raw[0x78:], uint32(size))
+}
+
+func biffRecord(id uint16, parts ...[]byte) []byte {
+	var body []byte
+	for _, part := range parts {
+		body = append(body, part...)
+	}
+
+	return append(append(u16(int(id)), u16(len(body))...), body...)
+}
+
+func cell(row, col, xf int) []byte {
+	return append(append(u16(row), u16(col)...), u16(xf)...)
+}
+
+func compressedString(text string) []byte {
+	return append(append(u16(len(text)), 0), text...)
+}
+
+func utf16LE(text string) []byte {
+	var out []byte
+	for _, unit := range utf16.Encode([]rune(text)) {
+		out = append(out, u16(int(unit))...)
+	}
+
+	return out
+}
+
+func u16(value int) []byte {
+	return binary.LittleEndian.AppendUint16(nil, uint16(value))
+}
+
+func u32(value uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, value)
+}
+
+func f64(value float64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(value))
+}
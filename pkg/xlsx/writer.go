@@ -0,0 +1,186 @@
+// Package xlsx writes single-sheet Excel workbooks (.xlsx) row by row, so
+// exports can be streamed without holding the whole sheet in memory. Cells are
+// written as inline strings, or as numbers when given one; styles, formulas
+// and multiple sheets are not supported.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrClosed = errors.New("xlsx: writer is closed")
+
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	stylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="1"><fill><patternFill patternType="none"/></fill></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs>` +
+		`</styleSheet>`
+	sheetHeaderXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetFooterXML = `</sheetData></worksheet>`
+)
+
+// Writer streams the rows of a single sheet into an .xlsx file. The sheet is
+// written first and the small workbook parts on Close, which must be called
+// for the file to be readable.
+type Writer struct {
+	archive   *zip.Writer
+	sheet     io.Writer
+	sheetName string
+	rows      int
+	closed    bool
+}
+
+// NewWriter starts a workbook holding one sheet with the given name.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	archive := zip.NewWriter(w)
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(sheet, sheetHeaderXML); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		archive:   archive,
+		sheet:     sheet,
+		sheetName: sheetName,
+	}, nil
+}
+
+// WriteRow appends a row of text cells to the sheet.
+func (w *Writer) WriteRow(values []string) error {
+	cells := make([]any, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+
+	return w.WriteCells(cells)
+}
+
+// WriteCells appends a row to the sheet. Integers and floats are written as
+// numbers, nil as an empty cell and anything else as its text.
+func (w *Writer) WriteCells(cells []any) error {
+	if w.closed {
+		return ErrClosed
+	}
+
+	w.rows++
+
+	var row strings.Builder
+	fmt.Fprintf(&row, `<row r="%d">`, w.rows)
+	for i, cell := range cells {
+		reference := columnName(i) + strconv.Itoa(w.rows)
+
+		switch value := cell.(type) {
+		case nil:
+			continue
+		case int:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, reference, value)
+		case int64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, reference, value)
+		case float64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%s</v></c>`, reference, strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			text := fmt.Sprint(value)
+			if text == "" {
+				continue
+			}
+
+			fmt.Fprintf(&row, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, reference)
+			if err := xml.EscapeText(&row, []byte(text)); err != nil {
+				return err
+			}
+			row.WriteString(`</t></is></c>`)
+		}
+	}
+	row.WriteString(`</row>`)
+
+	_, err := io.WriteString(w.sheet, row.String())
+	return err
+}
+
+// Close finishes the sheet and writes the rest of the workbook. It does not
+// close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+
+	if _, err := io.WriteString(w.sheet, sheetFooterXML); err != nil {
+		return err
+	}
+
+	var sheetName strings.Builder
+	if err := xml.EscapeText(&sheetName, []byte(w.sheetName)); err != nil {
+		return err
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: contentTypesXML},
+		{name: "_rels/.rels", content: rootRelsXML},
+		{name: "xl/workbook.xml", content: fmt.Sprintf(workbookXML, sheetName.String())},
+		{name: "xl/_rels/workbook.xml.rels", content: workbookRelsXML},
+		{name: "xl/styles.xml", content: stylesXML},
+	}
+	for _, part := range parts {
+		file, err := w.archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	return w.archive.Close()
+}
+
+// columnName turns a 0-based column index into its letters, e.g. 27 is AB.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
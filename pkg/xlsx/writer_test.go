@@ -0,0 +1,52 @@
+package xlsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thedatashed/xlsxreader"
+)
+
+func TestWriter(t *testing.T) {
+	var buffer bytes.Buffer
+
+	writer, err := NewWriter(&buffer, "Clientes")
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteRow([]string{"Nome", "Cidade", "Observação"}))
+	require.NoError(t, writer.WriteCells([]any{"João & Maria", nil, "<sem>"}))
+	require.NoError(t, writer.WriteCells([]any{"Total", 2, 1299.9}))
+	require.NoError(t, writer.Close())
+
+	workbook, err := xlsxreader.NewReader(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Clientes"}, workbook.Sheets)
+
+	var rows [][]string
+	for row := range workbook.ReadRows("Clientes") {
+		require.NoError(t, row.Error)
+
+		values := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			values = append(values, cell.Column+":"+cell.Value)
+		}
+		rows = append(rows, values)
+	}
+
+	assert.Equal(t, [][]string{
+		{"A:Nome", "B:Cidade", "C:Observação"},
+		{"A:João & Maria", "C:<sem>"},
+		{"A:Total", "B:2", "C:1299.9"},
+	}, rows)
+
+	assert.ErrorIs(t, writer.WriteRow([]string{"late"}), ErrClosed)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AB", columnName(27))
+	assert.Equal(t, "BA", columnName(52))
+}
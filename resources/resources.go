@@ -0,0 +1,11 @@
+// Package resources holds the files shipped inside the service binary.
+package resources
+
+import "embed"
+
+// Reports are the report templates the contractors used before templates
+// were managed through the API. They are imported on startup for contractors
+// that have no template yet.
+//
+//go:embed reports/*.docx
+var Reports embed.FS